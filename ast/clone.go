@@ -0,0 +1,139 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package ast
+
+import (
+	"bytes"
+)
+
+// Clone 深度复制 n 及其子节点，复制出的节点没有父节点和兄弟节点，节点 ID 保持不变。
+//
+// 复制出的节点被认为是新节点，所以不会保留源码位置 SourceSpan。
+func (n *Node) Clone() *Node {
+	clones := map[*Node]*Node{}
+	ret := n.clone(clones)
+
+	// 脚注引用指向的节点在子树内时改为指向复制出的节点
+	for _, c := range clones {
+		for i, ref := range c.FootnotesRefs {
+			if cloned := clones[ref]; nil != cloned {
+				c.FootnotesRefs[i] = cloned
+			}
+		}
+	}
+	return ret
+}
+
+// CloneWithNewIDs 深度复制 n 及其子节点，并为复制出的节点重新生成 ID，内联属性列表中的 id 和 updated 也会随之更新。
+func (n *Node) CloneWithNewIDs() *Node {
+	ret := n.Clone()
+	ids := map[string]string{}
+	Walk(ret, func(c *Node, entering bool) WalkStatus {
+		if !entering || "" == c.ID {
+			return WalkContinue
+		}
+
+		id := NewNodeID()
+		ids[c.ID] = id
+		c.ID = id
+		if "" != c.IALAttr("id") {
+			c.SetIALAttr("id", id)
+		}
+		if "" != c.IALAttr("updated") {
+			c.SetIALAttr("updated", id[:14])
+		}
+		return WalkContinue
+	})
+
+	// 块级内联属性列表节点的 Tokens 中也包含了 ID
+	Walk(ret, func(c *Node, entering bool) WalkStatus {
+		if entering && NodeKramdownBlockIAL == c.Type {
+			for oldID, newID := range ids {
+				c.Tokens = bytes.ReplaceAll(c.Tokens, []byte(oldID), []byte(newID))
+			}
+			if prev := c.Previous; nil != prev && "" != prev.IALAttr("updated") {
+				c.Tokens = replaceIALAttr(c.Tokens, "updated", prev.IALAttr("updated"))
+			}
+		}
+		return WalkContinue
+	})
+	return ret
+}
+
+// replaceIALAttr 替换内联属性列表 tokens 中属性 name 的值。
+func replaceIALAttr(tokens []byte, name, value string) []byte {
+	prefix := []byte(" " + name + "=\"")
+	start := bytes.Index(tokens, prefix)
+	if 0 > start {
+		return tokens
+	}
+	start += len(prefix)
+	end := bytes.IndexByte(tokens[start:], '"')
+	if 0 > end {
+		return tokens
+	}
+	ret := make([]byte, 0, len(tokens))
+	ret = append(ret, tokens[:start]...)
+	ret = append(ret, value...)
+	return append(ret, tokens[start+end:]...)
+}
+
+func (n *Node) clone(clones map[*Node]*Node) *Node {
+	ret := &Node{}
+	*ret = *n
+	clones[n] = ret
+	ret.Parent, ret.Previous, ret.Next, ret.FirstChild, ret.LastChild, ret.Children = nil, nil, nil, nil, nil, nil
+	ret.SourceSpan = nil
+
+	ret.Tokens = cloneBytes(n.Tokens)
+	ret.CodeBlockOpenFence = cloneBytes(n.CodeBlockOpenFence)
+	ret.CodeBlockInfo = cloneBytes(n.CodeBlockInfo)
+	ret.CodeBlockInfoMeta = cloneBytes(n.CodeBlockInfoMeta)
+	ret.CodeBlockCloseFence = cloneBytes(n.CodeBlockCloseFence)
+	ret.LinkRefLabel = cloneBytes(n.LinkRefLabel)
+	ret.FootnotesRefLabel = cloneBytes(n.FootnotesRefLabel)
+	ret.HtmlEntityTokens = cloneBytes(n.HtmlEntityTokens)
+	if nil != n.ListData {
+		listData := *n.ListData
+		listData.Marker = cloneBytes(n.ListData.Marker)
+		ret.ListData = &listData
+	}
+	if nil != n.TableAligns {
+		ret.TableAligns = append([]int{}, n.TableAligns...)
+	}
+	if nil != n.FootnotesRefs {
+		ret.FootnotesRefs = append([]*Node{}, n.FootnotesRefs...)
+	}
+	if nil != n.KramdownIAL {
+		ret.KramdownIAL = make([][]string, 0, len(n.KramdownIAL))
+		for _, kv := range n.KramdownIAL {
+			ret.KramdownIAL = append(ret.KramdownIAL, append([]string{}, kv...))
+		}
+	}
+	if nil != n.Properties {
+		ret.Properties = make(map[string]string, len(n.Properties))
+		for k, v := range n.Properties {
+			ret.Properties[k] = v
+		}
+	}
+
+	for c := n.FirstChild; nil != c; c = c.Next {
+		ret.AppendChild(c.clone(clones))
+	}
+	return ret
+}
+
+func cloneBytes(b []byte) []byte {
+	if nil == b {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
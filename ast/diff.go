@@ -0,0 +1,141 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NodeDiff 描述了两棵子树间的一处结构差异。
+type NodeDiff struct {
+	Path  string // 差异节点位置，由子节点下标组成，比如 /0/2 表示第一个子节点的第三个子节点，根节点为 /
+	Field string // 差异字段，Children 表示子节点个数不同
+	A, B  string // 差异字段在两边的值
+}
+
+func (d *NodeDiff) String() string {
+	return fmt.Sprintf("%s %s: %q != %q", d.Path, d.Field, d.A, d.B)
+}
+
+// Equal 判断 a 和 b 两棵子树的结构是否相同，比较时忽略节点 ID，详见 Diff。
+func Equal(a, b *Node) bool {
+	return nil == diff(a, b, "/", true)
+}
+
+// Diff 比较 a 和 b 两棵子树的结构，返回所有差异。
+//
+// 比较时忽略节点 ID（包括内联属性列表中的 id 和 updated）、解析过程标识、源码位置以及渲染时计算的表格宽度等字段，
+// 有子节点的节点 Tokens 是解析过程中的中间结果，也不参与比较。
+func Diff(a, b *Node) []*NodeDiff {
+	return diff(a, b, "/", false)
+}
+
+func diff(a, b *Node, path string, first bool) (ret []*NodeDiff) {
+	for _, field := range nodeDiffFields {
+		if va, vb := field.value(a), field.value(b); va != vb {
+			ret = append(ret, &NodeDiff{Path: path, Field: field.name, A: va, B: vb})
+			if first {
+				return
+			}
+		}
+	}
+
+	ca, cb := a.FirstChild, b.FirstChild
+	for i := 0; nil != ca && nil != cb; i++ {
+		if diffs := diff(ca, cb, strings.TrimSuffix(path, "/")+"/"+strconv.Itoa(i), first); 0 < len(diffs) {
+			ret = append(ret, diffs...)
+			if first {
+				return
+			}
+		}
+		ca, cb = ca.Next, cb.Next
+	}
+	if nil != ca || nil != cb {
+		ret = append(ret, &NodeDiff{Path: path, Field: "Children", A: strconv.Itoa(childCount(a)), B: strconv.Itoa(childCount(b))})
+	}
+	return
+}
+
+func childCount(n *Node) (ret int) {
+	for c := n.FirstChild; nil != c; c = c.Next {
+		ret++
+	}
+	return
+}
+
+var ialIDAttr = regexp.MustCompile(` (id|updated)="[^"]*"`)
+
+type nodeDiffField struct {
+	name  string
+	value func(n *Node) string
+}
+
+var nodeDiffFields = []*nodeDiffField{
+	{"Type", func(n *Node) string { return n.Type.String() }},
+	{"Tokens", func(n *Node) string {
+		if nil != n.FirstChild {
+			return ""
+		}
+		if NodeKramdownBlockIAL == n.Type || NodeKramdownSpanIAL == n.Type {
+			return ialIDAttr.ReplaceAllString(string(n.Tokens), "")
+		}
+		return string(n.Tokens)
+	}},
+	{"CodeMarkerLen", func(n *Node) string { return strconv.Itoa(n.CodeMarkerLen) }},
+	{"IsFencedCodeBlock", func(n *Node) string { return strconv.FormatBool(n.IsFencedCodeBlock) }},
+	{"CodeBlockFenceChar", func(n *Node) string { return string(n.CodeBlockFenceChar) }},
+	{"CodeBlockFenceLen", func(n *Node) string { return strconv.Itoa(n.CodeBlockFenceLen) }},
+	{"CodeBlockInfo", func(n *Node) string { return string(n.CodeBlockInfo) }},
+	{"CodeBlockInfoMeta", func(n *Node) string { return string(n.CodeBlockInfoMeta) }},
+	{"GitConflictSeparatorOffset", func(n *Node) string { return strconv.Itoa(n.GitConflictSeparatorOffset) }},
+	{"HtmlBlockType", func(n *Node) string { return strconv.Itoa(n.HtmlBlockType) }},
+	{"ListData", func(n *Node) string {
+		if nil == n.ListData {
+			return ""
+		}
+		d := n.ListData
+		return fmt.Sprintf("%d %v %q %d %q %v %q %d", d.Typ, d.Tight, d.BulletChar, d.Start, d.Delimiter, d.Checked, d.Marker, d.Num)
+	}},
+	{"TaskListItemChecked", func(n *Node) string { return strconv.FormatBool(n.TaskListItemChecked) }},
+	{"TableAligns", func(n *Node) string { return fmt.Sprint(n.TableAligns) }},
+	{"TableCellAlign", func(n *Node) string { return strconv.Itoa(n.TableCellAlign) }},
+	{"LinkType", func(n *Node) string { return strconv.Itoa(n.LinkType) }},
+	{"LinkRefLabel", func(n *Node) string { return string(n.LinkRefLabel) }},
+	{"HeadingLevel", func(n *Node) string { return strconv.Itoa(n.HeadingLevel) }},
+	{"HeadingSetext", func(n *Node) string { return strconv.FormatBool(n.HeadingSetext) }},
+	{"FootnotesRefLabel", func(n *Node) string { return string(n.FootnotesRefLabel) }},
+	{"HtmlEntityTokens", func(n *Node) string { return string(n.HtmlEntityTokens) }},
+	{"KramdownIAL", func(n *Node) string {
+		var attrs []string
+		for _, kv := range n.KramdownIAL {
+			if "id" != kv[0] && "updated" != kv[0] {
+				attrs = append(attrs, strings.Join(kv, "="))
+			}
+		}
+		return strings.Join(attrs, " ")
+	}},
+	{"Properties", func(n *Node) string {
+		var props []string
+		for k, v := range n.Properties {
+			props = append(props, k+"="+v)
+		}
+		sort.Strings(props)
+		return strings.Join(props, " ")
+	}},
+	{"TextMark", func(n *Node) string {
+		return strings.Join([]string{n.TextMarkType, n.TextMarkAHref, n.TextMarkATitle, n.TextMarkInlineMathContent, n.TextMarkInlineMemoContent,
+			n.TextMarkBlockRefID, n.TextMarkBlockRefSubtype, n.TextMarkFileAnnotationRefID, n.TextMarkTextContent}, "\x00")
+	}},
+}
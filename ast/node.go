@@ -67,6 +67,7 @@ type Node struct {
 	CodeBlockFenceOffset int    `json:",omitempty"`
 	CodeBlockOpenFence   []byte `json:",omitempty"`
 	CodeBlockInfo        []byte `json:",omitempty"`
+	CodeBlockInfoMeta    []byte `json:",omitempty"` // 信息字符串中语言之后的部分，比如 ```go {3} title="main.go" 中的 {3} title="main.go"
 	CodeBlockCloseFence  []byte `json:",omitempty"`
 
 	// HTML 块
@@ -85,7 +86,7 @@ type Node struct {
 
 	TableAligns              []int `json:",omitempty"` // 从左到右每个表格节点的对齐方式，0：默认对齐，1：左对齐，2：居中对齐，3：右对齐
 	TableCellAlign           int   `json:",omitempty"` // 表的单元格对齐方式
-	TableCellContentWidth    int   `json:",omitempty"` // 表的单元格内容宽度（字节数）
+	TableCellContentWidth    int   `json:",omitempty"` // 表的单元格内容显示宽度
 	TableCellContentMaxWidth int   `json:",omitempty"` // 表的单元格内容最大宽度
 
 	// 链接
@@ -103,6 +104,10 @@ type Node struct {
 
 	MathBlockDollarOffset int `json:",omitempty"`
 
+	// Git 冲突标记
+
+	GitConflictSeparatorOffset int `json:",omitempty"` // 冲突内容中 ======= 分隔行的起始偏移，之前为 ours，之后为 theirs
+
 	// 脚注
 
 	FootnotesRefLabel []byte  `json:",omitempty"` // 脚注引用 label，[^label]
@@ -128,6 +133,10 @@ type Node struct {
 	TextMarkBlockRefSubtype     string `json:",omitempty"` // 文本标记块引用子类型（静态/动态锚文本） data-subtype 属性
 	TextMarkFileAnnotationRefID string `json:",omitempty"` // 文本标记文件注解引用 ID data-id 属性
 	TextMarkTextContent         string `json:",omitempty"` // 文本标记文本内容
+
+	// 源码位置
+
+	SourceSpan *SourceSpan `json:"-"` // 块级节点在源码中的位置，仅在打开解析选项 SourceSpan 时记录
 }
 
 // ListData 用于记录列表或列表项节点的附加信息。
@@ -456,6 +465,18 @@ func (n *Node) TokenLen() (ret int) {
 	return
 }
 
+// DisplayWidth 返回 n 及其子节点 tokens 拼接后在等宽字体下的显示宽度，用于对齐表格等纯文本排版。
+func (n *Node) DisplayWidth() int {
+	buf := &bytes.Buffer{}
+	Walk(n, func(n *Node, entering bool) WalkStatus {
+		if entering {
+			buf.Write(n.Tokens)
+		}
+		return WalkContinue
+	})
+	return util.BytesDisplayWidth(buf.Bytes())
+}
+
 // DocChild 返回 n 的父节点，该该父节点是 doc 的直接子节点。
 func (n *Node) DocChild() (ret *Node) {
 	ret = n
@@ -0,0 +1,120 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package ast
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"strings"
+)
+
+// SourceSpan 描述了块级节点在源码中的位置。
+type SourceSpan struct {
+	Start           int    // 起始字节下标（行首）
+	End             int    // 结束字节下标（不包含），不包含节点后面的空行
+	ContentStart    int    // 首行内容的起始字节下标，即首行中引述块标记符、列表项标记符等容器块标记符之后的位置
+	Fingerprint     uint64 // 解析完成时节点子树的指纹，用于判断节点是否被修改过
+	NodeFingerprint uint64 // 解析完成时节点自身（不包含子节点）的指纹，用于判断容器块自身是否被修改过
+}
+
+// SourceModified 判断 n 在解析完成后是否被修改过，没有记录源码位置的节点（比如新插入的节点）也认为是修改过的。
+func (n *Node) SourceModified() bool {
+	return nil == n.SourceSpan || n.SourceSpan.Fingerprint != n.Fingerprint()
+}
+
+// SourceNodeModified 判断 n 自身（不包含子节点）在解析完成后是否被修改过，没有记录源码位置的节点也认为是修改过的。
+func (n *Node) SourceNodeModified() bool {
+	return nil == n.SourceSpan || n.SourceSpan.NodeFingerprint != n.NodeFingerprint()
+}
+
+// Fingerprint 计算 n 及其子节点的指纹，指纹覆盖了节点类型、Tokens 以及会影响 Markdown 输出的节点属性。
+func (n *Node) Fingerprint() uint64 {
+	h := fnv.New64a()
+	Walk(n, func(n *Node, entering bool) WalkStatus {
+		if !entering {
+			writeFingerprintInt(h, -1) // 子节点结束，区分兄弟节点和子节点
+			return WalkContinue
+		}
+
+		n.writeFingerprint(h)
+		return WalkContinue
+	})
+	return h.Sum64()
+}
+
+// NodeFingerprint 计算 n 自身（不包含子节点）的指纹。
+func (n *Node) NodeFingerprint() uint64 {
+	h := fnv.New64a()
+	n.writeFingerprint(h)
+	return h.Sum64()
+}
+
+func writeFingerprintInt(h hash.Hash64, i int) {
+	var num [8]byte
+	binary.LittleEndian.PutUint64(num[:], uint64(i))
+	h.Write(num[:])
+}
+
+// writeFingerprint 将 n 自身的类型、Tokens 以及会影响 Markdown 输出的节点属性写入 h。
+func (n *Node) writeFingerprint(h hash.Hash64) {
+	writeInt := func(i int) {
+		writeFingerprintInt(h, i)
+	}
+	writeBytes := func(b []byte) {
+		writeInt(len(b))
+		h.Write(b)
+	}
+	writeBool := func(b bool) {
+		if b {
+			writeInt(1)
+		} else {
+			writeInt(0)
+		}
+	}
+
+	writeInt(int(n.Type))
+	writeBytes(n.Tokens)
+	writeBytes([]byte(n.ID))
+	writeInt(n.CodeMarkerLen)
+	writeBool(n.IsFencedCodeBlock)
+	writeInt(int(n.CodeBlockFenceChar))
+	writeInt(n.CodeBlockFenceLen)
+	writeBytes(n.CodeBlockInfo)
+	writeBytes(n.CodeBlockInfoMeta)
+	writeInt(n.GitConflictSeparatorOffset)
+	writeBool(n.TaskListItemChecked)
+	for _, align := range n.TableAligns {
+		writeInt(align)
+	}
+	writeInt(n.TableCellAlign)
+	writeInt(n.LinkType)
+	writeBytes(n.LinkRefLabel)
+	writeInt(n.HeadingLevel)
+	writeBool(n.HeadingSetext)
+	writeBytes(n.HtmlEntityTokens)
+	if nil != n.ListData {
+		writeInt(n.ListData.Typ)
+		writeBool(n.ListData.Tight)
+		writeInt(int(n.ListData.BulletChar))
+		writeInt(n.ListData.Start)
+		writeInt(int(n.ListData.Delimiter))
+		writeBool(n.ListData.Checked)
+		writeBytes(n.ListData.Marker)
+		writeInt(n.ListData.Num)
+	}
+	for _, kv := range n.KramdownIAL {
+		writeBytes([]byte(strings.Join(kv, "\x00")))
+	}
+	writeBytes([]byte(n.TextMarkType + "\x00" + n.TextMarkAHref + "\x00" + n.TextMarkATitle + "\x00" + n.TextMarkInlineMathContent + "\x00" +
+		n.TextMarkInlineMemoContent + "\x00" + n.TextMarkBlockRefID + "\x00" + n.TextMarkBlockRefSubtype + "\x00" +
+		n.TextMarkFileAnnotationRefID + "\x00" + n.TextMarkTextContent))
+}
@@ -0,0 +1,461 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package blockop 提供了直接作用于语法树的块级操作，包括 Protyle 块 DOM 操作（取消列表、块类型转换等）对应的语法树版本，
+// 以及列表项缩进、段落拆分合并、块移动、标题章节升降级和包裹为引述块或者超级块等操作。
+//
+// 块移动时会连同其块级内联属性列表节点一起移动，已有块的 ID 和属性保持不变。新建的块会生成新的 ID，
+// 如果参照块带有块级内联属性列表，新建的块也会带有包含 id 和 updated 的块级内联属性列表。
+package blockop
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/util"
+)
+
+// CancelSuperBlock 取消超级块 sb，将其子块移动到超级块所在的位置。
+func CancelSuperBlock(sb *ast.Node) bool {
+	if ast.NodeSuperBlock != sb.Type {
+		return false
+	}
+	unwrap(sb)
+	return true
+}
+
+// CancelBlockquote 取消引述块 bq，将其子块移动到引述块所在的位置。
+func CancelBlockquote(bq *ast.Node) bool {
+	if ast.NodeBlockquote != bq.Type {
+		return false
+	}
+	unwrap(bq)
+	return true
+}
+
+// CancelList 取消列表 list，将所有列表项的子块移动到列表所在的位置，任务列表项标记符会被移除。
+func CancelList(list *ast.Node) bool {
+	if ast.NodeList != list.Type {
+		return false
+	}
+
+	for _, li := range children(list) {
+		removeTaskListItemMarker(li)
+		unwrap(li)
+	}
+	unwrap(list)
+	return true
+}
+
+// Blocks2Ps 将标题块转换为段落块。
+func Blocks2Ps(nodes ...*ast.Node) {
+	for _, n := range nodes {
+		if ast.NodeHeading != n.Type {
+			continue
+		}
+		n.Type = ast.NodeParagraph
+		n.HeadingSetext = false
+		if marker := n.ChildByType(ast.NodeHeadingC8hMarker); nil != marker {
+			marker.Unlink()
+		}
+	}
+}
+
+// Blocks2Hs 将段落块和标题块转换为 level 级标题块。
+func Blocks2Hs(level int, nodes ...*ast.Node) {
+	level = clampLevel(level)
+	for _, n := range nodes {
+		if ast.NodeParagraph != n.Type && ast.NodeHeading != n.Type {
+			continue
+		}
+		setHeadingLevel(n, level)
+	}
+}
+
+// ConvertList 将列表 list 转换为 typ 类型的列表，typ 为 0 时转换为无序列表，为 1 时转换为有序列表，为 3 时转换为任务列表。
+//
+// 转换为任务列表时为列表项添加未勾选的任务列表项标记符，从任务列表转换为其他列表时移除任务列表项标记符。
+func ConvertList(list *ast.Node, typ int) bool {
+	if ast.NodeList != list.Type || (0 != typ && 1 != typ && 3 != typ) {
+		return false
+	}
+
+	list.ListData.Typ = typ
+	if 0 == typ && 0 == list.ListData.BulletChar {
+		list.ListData.BulletChar = '*'
+	} else if 1 == typ {
+		list.ListData.BulletChar = 0
+		if 0 == list.ListData.Delimiter {
+			list.ListData.Delimiter = '.'
+		}
+	}
+	for _, li := range children(list) {
+		if ast.NodeListItem != li.Type {
+			continue
+		}
+
+		li.ListData.Typ = typ
+		li.ListData.BulletChar = list.ListData.BulletChar
+		li.ListData.Delimiter = list.ListData.Delimiter
+		marker := taskListItemMarker(li)
+		if 3 == typ && nil == marker {
+			li.PrependChild(&ast.Node{Type: ast.NodeTaskListItemMarker, Tokens: []byte("[ ]")})
+		} else if 3 != typ && nil != marker {
+			removeTaskListItemMarker(li)
+			li.ListData.Checked = false
+		}
+	}
+	renumber(list)
+	return true
+}
+
+// WrapBlockquote 将连续的兄弟块 nodes 包裹到一个新的引述块中，返回新的引述块，nodes 为列表项时返回 nil。
+func WrapBlockquote(nodes ...*ast.Node) *ast.Node {
+	if !consecutive(nodes) {
+		return nil
+	}
+
+	bq := newBlock(ast.NodeBlockquote, nodes[0])
+	bq.AppendChild(&ast.Node{Type: ast.NodeBlockquoteMarker, Tokens: []byte(">")})
+	wrap(bq, nodes)
+	return bq
+}
+
+// WrapSuperBlock 将连续的兄弟块 nodes 包裹到一个新的超级块中，layout 为 row 或者 col，返回新的超级块，nodes 为列表项时返回 nil。
+func WrapSuperBlock(layout string, nodes ...*ast.Node) *ast.Node {
+	if !consecutive(nodes) || ("row" != layout && "col" != layout) {
+		return nil
+	}
+
+	sb := newBlock(ast.NodeSuperBlock, nodes[0])
+	sb.AppendChild(&ast.Node{Type: ast.NodeSuperBlockOpenMarker})
+	sb.AppendChild(&ast.Node{Type: ast.NodeSuperBlockLayoutMarker, Tokens: []byte(layout)})
+	wrap(sb, nodes)
+	sb.AppendChild(&ast.Node{Type: ast.NodeSuperBlockCloseMarker})
+	return sb
+}
+
+// MoveUp 将块 n 与前一个兄弟块交换位置。
+func MoveUp(n *ast.Node) bool {
+	previous := previousBlock(n)
+	if nil == previous {
+		return false
+	}
+	moveBefore(previous, n)
+	if ast.NodeListItem == n.Type {
+		renumber(n.Parent)
+	}
+	return true
+}
+
+// MoveDown 将块 n 与后一个兄弟块交换位置。
+func MoveDown(n *ast.Node) bool {
+	next := nextBlock(n)
+	if nil == next {
+		return false
+	}
+	moveBefore(n, next)
+	if ast.NodeListItem == n.Type {
+		renumber(n.Parent)
+	}
+	return true
+}
+
+// PromoteSection 将标题 heading 及其章节内的所有子标题提升一级，章节内有一级标题时返回 false。
+func PromoteSection(heading *ast.Node) bool {
+	return shiftSection(heading, -1)
+}
+
+// DemoteSection 将标题 heading 及其章节内的所有子标题降低一级，章节内有六级标题时返回 false。
+func DemoteSection(heading *ast.Node) bool {
+	return shiftSection(heading, 1)
+}
+
+// Section 返回标题 heading 的章节，即 heading 以及其后直到下一个同级或者更高级标题之前的所有兄弟块。
+func Section(heading *ast.Node) (ret []*ast.Node) {
+	if ast.NodeHeading != heading.Type {
+		return
+	}
+
+	ret = append(ret, heading)
+	for n := nextBlock(heading); nil != n; n = nextBlock(n) {
+		if ast.NodeHeading == n.Type && n.HeadingLevel <= heading.HeadingLevel {
+			break
+		}
+		ret = append(ret, n)
+	}
+	return
+}
+
+func shiftSection(heading *ast.Node, delta int) bool {
+	var headings []*ast.Node
+	for _, n := range Section(heading) {
+		if ast.NodeHeading != n.Type {
+			continue
+		}
+		if level := n.HeadingLevel + delta; 1 > level || 6 < level {
+			return false
+		}
+		headings = append(headings, n)
+	}
+	if 1 > len(headings) {
+		return false
+	}
+
+	for _, h := range headings {
+		setHeadingLevel(h, h.HeadingLevel+delta)
+	}
+	return true
+}
+
+func setHeadingLevel(n *ast.Node, level int) {
+	n.Type = ast.NodeHeading
+	n.HeadingLevel = level
+	if 2 < level {
+		n.HeadingSetext = false
+	}
+	if marker := n.ChildByType(ast.NodeHeadingC8hMarker); nil != marker {
+		marker.Tokens = []byte(headingMarker(level))
+	}
+}
+
+func headingMarker(level int) (ret string) {
+	for i := 0; i < level; i++ {
+		ret += "#"
+	}
+	return ret + " "
+}
+
+func clampLevel(level int) int {
+	if 1 > level {
+		return 1
+	}
+	if 6 < level {
+		return 6
+	}
+	return level
+}
+
+// renumber 重新计算有序列表 list 中列表项的序号。
+func renumber(list *ast.Node) {
+	if nil == list || ast.NodeList != list.Type || nil == list.ListData {
+		return
+	}
+
+	num := list.ListData.Start
+	if 1 > num {
+		num = 1
+	}
+	for _, li := range children(list) {
+		if ast.NodeListItem != li.Type || nil == li.ListData {
+			continue
+		}
+		if 0 == li.ListData.BulletChar {
+			if 0 == li.ListData.Delimiter {
+				li.ListData.Delimiter = '.'
+			}
+			marker := []byte(strconv.Itoa(num))
+			if bytes.HasSuffix(li.ListData.Marker, []byte{li.ListData.Delimiter}) {
+				// Protyle 列表项的标识符包含分隔符
+				marker = append(marker, li.ListData.Delimiter)
+			}
+			li.ListData.Num = num
+			li.ListData.Marker = marker
+			num++
+		} else {
+			li.ListData.Marker = []byte{li.ListData.BulletChar}
+		}
+	}
+}
+
+func taskListItemMarker(li *ast.Node) *ast.Node {
+	if marker := li.ChildByType(ast.NodeTaskListItemMarker); nil != marker {
+		return marker
+	}
+	if p := li.FirstChild; nil != p && ast.NodeParagraph == p.Type {
+		return p.ChildByType(ast.NodeTaskListItemMarker)
+	}
+	return nil
+}
+
+// removeTaskListItemMarker 移除列表项 li 的任务列表项标记符，以及段落中标记符后的空格。
+func removeTaskListItemMarker(li *ast.Node) {
+	marker := taskListItemMarker(li)
+	if nil == marker {
+		return
+	}
+	if next := marker.Next; nil != next && ast.NodeText == next.Type {
+		next.Tokens = bytes.TrimLeft(next.Tokens, " ")
+	}
+	marker.Unlink()
+}
+
+// newBlock 创建一个 typ 类型的新块，ref 带有块级内联属性列表时新块也会带有块级内联属性列表。
+func newBlock(typ ast.NodeType, ref *ast.Node) (ret *ast.Node) {
+	ret = &ast.Node{Type: typ, ID: ast.NewNodeID()}
+	if nil != ial(ref) {
+		ret.KramdownIAL = [][]string{{"id", ret.ID}, {"updated", ret.ID[:14]}}
+	}
+	return
+}
+
+// insertNewBlock 将新块 n 插入到 ref 之前，n 带有属性时同时插入其块级内联属性列表节点。
+func insertNewBlock(ref, n *ast.Node) {
+	ref.InsertBefore(n)
+	if 0 < len(n.KramdownIAL) {
+		n.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(n.KramdownIAL)})
+	}
+}
+
+// wrap 将 nodes 移动到新的容器块 container 中，container 放置在 nodes 原来的位置。
+func wrap(container *ast.Node, nodes []*ast.Node) {
+	insertNewBlock(nodes[0], container)
+	for _, n := range nodes {
+		appendBlock(container, n)
+	}
+}
+
+// unwrap 将容器块 container 的子块移动到容器块所在的位置，然后移除容器块。
+func unwrap(container *ast.Node) {
+	for _, c := range children(container) {
+		moveBefore(container, c)
+	}
+	unlink(container)
+}
+
+// appendBlock 将块 n 及其内联属性列表移动到 parent 的子块末尾，超级块的子块放置在结束标记符之前，文档的子块放置在文档块级内联属性列表之前。
+func appendBlock(parent, n *ast.Node) {
+	nIAL := ial(n)
+	if last := parent.LastChild; nil != last && (ast.NodeSuperBlockCloseMarker == last.Type || isDocIAL(last)) {
+		last.InsertBefore(n)
+	} else {
+		parent.AppendChild(n)
+	}
+	if nil != nIAL {
+		n.InsertAfter(nIAL)
+	}
+}
+
+// moveBefore 将块 n 及其内联属性列表移动到 ref 之前。
+func moveBefore(ref, n *ast.Node) {
+	nIAL := ial(n)
+	ref.InsertBefore(n)
+	if nil != nIAL {
+		n.InsertAfter(nIAL)
+	}
+}
+
+// moveAfter 将块 n 及其内联属性列表移动到 ref 及其内联属性列表之后。
+func moveAfter(ref, n *ast.Node) {
+	nIAL := ial(n)
+	if refIAL := ial(ref); nil != refIAL {
+		ref = refIAL
+	}
+	ref.InsertAfter(n)
+	if nil != nIAL {
+		n.InsertAfter(nIAL)
+	}
+}
+
+func unlink(n *ast.Node) {
+	if nIAL := ial(n); nil != nIAL {
+		nIAL.Unlink()
+	}
+	n.Unlink()
+}
+
+// ial 返回块 n 的块级内联属性列表节点，文档块级内联属性列表不属于最后一个块。
+func ial(n *ast.Node) *ast.Node {
+	if nil != n.Next && ast.NodeKramdownBlockIAL == n.Next.Type && !isDocIAL(n.Next) {
+		return n.Next
+	}
+	return nil
+}
+
+// isDocIAL 判断 n 是否是位于文档末尾的文档块级内联属性列表节点。
+func isDocIAL(n *ast.Node) bool {
+	return ast.NodeKramdownBlockIAL == n.Type && nil == n.Next && util.IsDocIAL(n.Tokens)
+}
+
+// children 返回容器块 n 的子块，不包括标记符和块级内联属性列表节点。
+func children(n *ast.Node) (ret []*ast.Node) {
+	for c := n.FirstChild; nil != c; c = c.Next {
+		if isBlock(c) {
+			ret = append(ret, c)
+		}
+	}
+	return
+}
+
+func isBlock(n *ast.Node) bool {
+	return n.IsBlock() && ast.NodeKramdownBlockIAL != n.Type
+}
+
+func previousBlock(n *ast.Node) *ast.Node {
+	for c := n.Previous; nil != c; c = c.Previous {
+		if isBlock(c) {
+			return c
+		}
+		if !c.IsMarker() && ast.NodeKramdownBlockIAL != c.Type {
+			return nil
+		}
+	}
+	return nil
+}
+
+func nextBlock(n *ast.Node) *ast.Node {
+	for c := n.Next; nil != c; c = c.Next {
+		if isBlock(c) {
+			return c
+		}
+		if !c.IsMarker() && ast.NodeKramdownBlockIAL != c.Type {
+			return nil
+		}
+	}
+	return nil
+}
+
+// consecutive 判断 nodes 是否为可以包裹的连续兄弟块，列表项只能位于列表中，所以不能被包裹。
+func consecutive(nodes []*ast.Node) bool {
+	if 1 > len(nodes) {
+		return false
+	}
+	for i := 1; i < len(nodes); i++ {
+		if nodes[i] != nextBlock(nodes[i-1]) {
+			return false
+		}
+	}
+	return isBlock(nodes[0]) && ast.NodeDocument != nodes[0].Type && ast.NodeListItem != nodes[0].Type
+}
+
+// insertNewBlockAfter 将新块 n 插入到 ref 及其内联属性列表之后，n 带有属性时同时插入其块级内联属性列表节点。
+func insertNewBlockAfter(ref, n *ast.Node) {
+	if refIAL := ial(ref); nil != refIAL {
+		ref = refIAL
+	}
+	ref.InsertAfter(n)
+	if 0 < len(n.KramdownIAL) {
+		n.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(n.KramdownIAL)})
+	}
+}
+
+// appendNewBlock 将新块 n 添加到 parent 的子块末尾，n 带有属性时同时添加其块级内联属性列表节点。
+func appendNewBlock(parent, n *ast.Node) {
+	if last := parent.LastChild; nil != last && isDocIAL(last) {
+		last.InsertBefore(n)
+	} else {
+		parent.AppendChild(n)
+	}
+	if 0 < len(n.KramdownIAL) {
+		n.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(n.KramdownIAL)})
+	}
+}
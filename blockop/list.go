@@ -0,0 +1,134 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package blockop
+
+import (
+	"github.com/88250/lute/ast"
+)
+
+// Indent 缩进列表项 li，li 会被移动到前一个列表项的子列表末尾，前一个列表项没有子列表时会新建子列表。
+func Indent(li *ast.Node) bool {
+	if ast.NodeListItem != li.Type {
+		return false
+	}
+	previous := previousBlock(li)
+	if nil == previous || ast.NodeListItem != previous.Type {
+		return false
+	}
+
+	list := li.Parent
+	var sub *ast.Node
+	if blocks := children(previous); 0 < len(blocks) && ast.NodeList == blocks[len(blocks)-1].Type {
+		sub = blocks[len(blocks)-1]
+	} else {
+		sub = newList(list, li)
+		appendNewBlock(previous, sub)
+	}
+	appendBlock(sub, li)
+	adoptListStyle(li, sub)
+	renumber(list)
+	renumber(sub)
+	return true
+}
+
+// Outdent 反缩进列表项 li。
+//
+// 嵌套列表中的 li 会被移动到父列表项之后，li 后面的兄弟列表项成为 li 的子列表项；
+// 顶层列表中的 li 会被取消，其子块移动到列表之后，li 后面的兄弟列表项拆分为一个新列表。
+func Outdent(li *ast.Node) bool {
+	if ast.NodeListItem != li.Type || nil == li.Parent || ast.NodeList != li.Parent.Type {
+		return false
+	}
+
+	list := li.Parent
+	var following []*ast.Node
+	for n := nextBlock(li); nil != n; n = nextBlock(n) {
+		following = append(following, n)
+	}
+
+	if parentLi := list.Parent; nil != parentLi && ast.NodeListItem == parentLi.Type {
+		if 0 < len(following) {
+			var sub *ast.Node
+			if blocks := children(li); 0 < len(blocks) && ast.NodeList == blocks[len(blocks)-1].Type {
+				sub = blocks[len(blocks)-1]
+			} else {
+				sub = newList(list, li)
+				appendNewBlock(li, sub)
+			}
+			for _, n := range following {
+				appendBlock(sub, n)
+				adoptListStyle(n, sub)
+			}
+			renumber(sub)
+		}
+
+		moveAfter(parentLi, li)
+		adoptListStyle(li, parentLi.Parent)
+		renumber(parentLi.Parent)
+	} else {
+		removeTaskListItemMarker(li)
+		anchor := list
+		for _, c := range children(li) {
+			moveAfter(anchor, c)
+			anchor = c
+		}
+		if 0 < len(following) {
+			rest := newList(list, following[0])
+			insertNewBlockAfter(anchor, rest)
+			for _, n := range following {
+				appendBlock(rest, n)
+			}
+			renumber(rest)
+		}
+		unlink(li)
+	}
+
+	if 1 > len(children(list)) {
+		unlink(list)
+	} else {
+		renumber(list)
+	}
+	return true
+}
+
+// newList 创建一个与 list 样式相同的新列表，ref 为参照块。
+func newList(list, ref *ast.Node) (ret *ast.Node) {
+	ret = newBlock(ast.NodeList, ref)
+	listData := *list.ListData
+	listData.Start = 1
+	ret.ListData = &listData
+	return
+}
+
+// adoptListStyle 使用列表 list 的列表标识符样式更新列表项 li。
+//
+// 样式优先取自 list 中的其他列表项，因为 Protyle 列表节点上没有列表标识符，只有列表项上有；没有其他列表项时取自 list，
+// list 上也没有列表标识符时 li 保留原样式。
+func adoptListStyle(li, list *ast.Node) {
+	if nil == li.ListData {
+		return
+	}
+	style := list.ListData
+	for _, sibling := range children(list) {
+		if sibling != li && ast.NodeListItem == sibling.Type && nil != sibling.ListData {
+			style = sibling.ListData
+			break
+		}
+	}
+	if nil == style || (0 == style.BulletChar && 0 == style.Delimiter) {
+		return
+	}
+	li.ListData.BulletChar = style.BulletChar
+	li.ListData.Delimiter = style.Delimiter
+	if 3 != li.ListData.Typ {
+		li.ListData.Typ = style.Typ
+	}
+}
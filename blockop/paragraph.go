@@ -0,0 +1,86 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package blockop
+
+import (
+	"github.com/88250/lute/ast"
+)
+
+// SplitParagraph 在 offset 处将段落 p 拆分为两个段落，返回新的后一个段落。
+//
+// offset 为 p.Text() 中的字节偏移，拆分点位于文本节点中间时拆分该文本节点，位于其他行级节点中间时在该节点之后拆分。
+// offset 不在段落文本中间时返回 nil。
+func SplitParagraph(p *ast.Node, offset int) *ast.Node {
+	if ast.NodeParagraph != p.Type || 0 >= offset {
+		return nil
+	}
+
+	var split *ast.Node // 新段落的第一个子节点
+	pos := 0
+	for c := p.FirstChild; nil != c; c = c.Next {
+		length := len(c.Text())
+		if ast.NodeText == c.Type {
+			length = len(c.Tokens)
+		}
+		if pos+length <= offset {
+			pos += length
+			continue
+		}
+
+		if ast.NodeText == c.Type && offset > pos {
+			i := offset - pos
+			right := &ast.Node{Type: ast.NodeText, Tokens: c.Tokens[i:]}
+			c.Tokens = c.Tokens[:i:i] // 限制容量，避免后续追加覆盖新段落的文本
+			c.InsertAfter(right)
+			split = right
+		} else if offset == pos {
+			split = c
+		} else {
+			split = c.Next
+		}
+		break
+	}
+	if nil == split {
+		return nil
+	}
+
+	ret := newBlock(ast.NodeParagraph, p)
+	var moves []*ast.Node
+	for c := split; nil != c; c = c.Next {
+		moves = append(moves, c)
+	}
+	for _, c := range moves {
+		ret.AppendChild(c)
+	}
+	insertNewBlockAfter(p, ret)
+	return ret
+}
+
+// MergeParagraphs 将段落 next 的行级内容合并到其前一个兄弟段落 p 的末尾，然后移除 next。
+func MergeParagraphs(p, next *ast.Node) bool {
+	if ast.NodeParagraph != p.Type || ast.NodeParagraph != next.Type || next != nextBlock(p) {
+		return false
+	}
+
+	var moves []*ast.Node
+	for c := next.FirstChild; nil != c; c = c.Next {
+		moves = append(moves, c)
+	}
+	for _, c := range moves {
+		if last := p.LastChild; nil != last && ast.NodeText == last.Type && ast.NodeText == c.Type {
+			last.Tokens = append(last.Tokens, c.Tokens...)
+			continue
+		}
+		p.AppendChild(c)
+	}
+	unlink(next)
+	return true
+}
@@ -0,0 +1,458 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package builder 提供了构造语法树节点的函数，构造出的节点结构（包括各种标记符子节点）和解析器生成的一致，可以直接交给各个渲染器渲染。
+//
+// 参数中的文本都是纯文本，不会作为 Markdown 解析。文本节点加入父节点时会把其中的 Markdown 标记符拆分为反斜杠转义节点，
+// 链接地址包含空格或者括号时会使用 <> 包裹，所以构造出的语法树格式化为 Markdown 后再次解析可以得到相同的结构。
+package builder
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/html"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/parse"
+)
+
+// Tree 使用解析选项 options 构造一棵以 children 为顶层块的语法树，options 为 nil 时使用默认解析选项。
+//
+// 语法树使用的是 children 的副本，所以调用方持有的 children 不会被修改，可以用来构造多棵语法树。
+func Tree(name string, options *parse.Options, children ...*ast.Node) *parse.Tree {
+	if nil == options {
+		options = parse.NewOptions()
+	}
+	clones := make([]*ast.Node, 0, len(children))
+	for _, child := range children {
+		clones = append(clones, child.Clone())
+	}
+	ret := &parse.Tree{Name: name, Root: Document(clones...), Context: &parse.Context{ParseOption: options}}
+	ret.Context.Tree = ret
+	if !options.VditorWYSIWYG && !options.VditorIR && !options.VditorSV && !options.ProtyleWYSIWYG {
+		// 和解析器一致，非编辑器模式下去掉链接标题中的转义并对链接地址进行编码，编码后不再需要的 <> 也一并去掉
+		var dests, titles []*ast.Node
+		ast.Walk(ret.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if entering {
+				switch n.Type {
+				case ast.NodeLinkDest:
+					dests = append(dests, n)
+				case ast.NodeLinkTitle:
+					titles = append(titles, n)
+				}
+			}
+			return ast.WalkContinue
+		})
+		for _, title := range titles {
+			title.Tokens = html.UnescapeBytes(title.Tokens)
+		}
+		for _, dest := range dests {
+			dest.Tokens = html.EncodeDestination(dest.Tokens)
+			if less, greater := dest.Previous, dest.Next; nil != less && ast.NodeLess == less.Type && nil != greater && ast.NodeGreater == greater.Type && balancedParens(dest.Tokens) {
+				less.Unlink()
+				greater.Unlink()
+			}
+		}
+	}
+	return ret
+}
+
+// Document 构造文档节点。
+func Document(children ...*ast.Node) *ast.Node {
+	return appendChildren(&ast.Node{Type: ast.NodeDocument}, children)
+}
+
+// Paragraph 构造段落节点，children 为行级节点。
+func Paragraph(children ...*ast.Node) *ast.Node {
+	return appendChildren(&ast.Node{Type: ast.NodeParagraph}, children)
+}
+
+// Heading 构造 ATX 标题节点，level 为 1 到 6，children 为行级节点。
+func Heading(level int, children ...*ast.Node) *ast.Node {
+	if 1 > level {
+		level = 1
+	} else if 6 < level {
+		level = 6
+	}
+	ret := &ast.Node{Type: ast.NodeHeading, HeadingLevel: level}
+	ret.AppendChild(&ast.Node{Type: ast.NodeHeadingC8hMarker, Tokens: []byte(strings.Repeat("#", level) + " ")})
+	return appendChildren(ret, children)
+}
+
+// Blockquote 构造引述节点，children 为块级节点。
+func Blockquote(children ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeBlockquote}
+	ret.AppendChild(&ast.Node{Type: ast.NodeBlockquoteMarker, Tokens: []byte("> ")})
+	return appendChildren(ret, children)
+}
+
+// BulletList 构造使用 - 作为标记符的无序列表节点，items 为 ListItem 或者 TaskListItem 构造的列表项。
+func BulletList(items ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeList}
+	for _, item := range items {
+		item.ListData = &ast.ListData{Typ: listType(item, 0), Tight: true, BulletChar: '-', Padding: 2, Checked: item.ListData.Checked, Marker: []byte("-"), Num: -1}
+		ret.AppendChild(item)
+	}
+	return setListData(ret)
+}
+
+// OrderedList 构造从 start 开始编号的有序列表节点，items 为 ListItem 或者 TaskListItem 构造的列表项。
+func OrderedList(start int, items ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeList}
+	for i, item := range items {
+		num := start + i
+		marker := []byte(strconv.Itoa(num))
+		item.ListData = &ast.ListData{Typ: listType(item, 1), Tight: true, Start: num, Delimiter: '.', Padding: len(marker) + 2, Checked: item.ListData.Checked, Marker: marker, Num: num}
+		ret.AppendChild(item)
+	}
+	return setListData(ret)
+}
+
+// ListItem 构造列表项节点，children 为块级节点。列表项需要通过 BulletList 或者 OrderedList 加入列表。
+func ListItem(children ...*ast.Node) *ast.Node {
+	return appendChildren(&ast.Node{Type: ast.NodeListItem, ListData: &ast.ListData{}}, children)
+}
+
+// TaskListItem 构造任务列表项节点，children 为块级节点，第一个子节点不是段落时会插入一个空段落用于放置任务列表项标记符。
+func TaskListItem(checked bool, children ...*ast.Node) *ast.Node {
+	if 1 > len(children) || ast.NodeParagraph != children[0].Type {
+		children = append([]*ast.Node{Paragraph()}, children...)
+	}
+	p := children[0]
+	if text := p.FirstChild; nil != text && ast.NodeText == text.Type {
+		text.Tokens = append([]byte(" "), text.Tokens...)
+	} else {
+		p.PrependChild(Text(" "))
+	}
+	marker := &ast.Node{Type: ast.NodeTaskListItemMarker, Tokens: []byte("[ ]"), TaskListItemChecked: checked}
+	if checked {
+		marker.Tokens = []byte("[x]")
+	}
+	p.PrependChild(marker)
+
+	ret := ListItem(children...)
+	ret.ListData.Typ = 3
+	ret.ListData.Checked = checked
+	return ret
+}
+
+func listType(item *ast.Node, typ int) int {
+	if 3 == item.ListData.Typ {
+		return 3
+	}
+	return typ
+}
+
+// setListData 使用第一个列表项的 ListData 作为列表的 ListData，和解析器保持一致。
+func setListData(list *ast.Node) *ast.Node {
+	if nil == list.FirstChild {
+		list.ListData = &ast.ListData{Tight: true, BulletChar: '-', Padding: 2, Marker: []byte("-"), Num: -1}
+		return list
+	}
+	listData := *list.FirstChild.ListData
+	list.ListData = &listData
+	return list
+}
+
+// CodeBlock 构造围栏代码块节点，lang 为语言（信息字符串），围栏长度会根据代码内容自动调整。
+func CodeBlock(lang, code string) *ast.Node {
+	if "" != code && !strings.HasSuffix(code, "\n") {
+		code += "\n"
+	}
+	fenceLen := 3
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimLeft(line, " ")
+		run := len(line) - len(strings.TrimLeft(line, "`"))
+		if fenceLen <= run {
+			fenceLen = run + 1
+		}
+	}
+	fence := bytes.Repeat([]byte{lex.ItemBacktick}, fenceLen)
+
+	ret := &ast.Node{Type: ast.NodeCodeBlock, IsFencedCodeBlock: true, CodeBlockFenceChar: lex.ItemBacktick, CodeBlockFenceLen: fenceLen,
+		CodeBlockOpenFence: fence, CodeBlockCloseFence: fence, CodeBlockInfo: []byte(lang)}
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeBlockFenceOpenMarker, Tokens: fence, CodeBlockFenceLen: fenceLen})
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeBlockFenceInfoMarker, CodeBlockInfo: []byte(lang)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeBlockCode, Tokens: []byte(code)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeBlockFenceCloseMarker, Tokens: fence, CodeBlockFenceLen: fenceLen})
+	return ret
+}
+
+// MathBlock 构造数学公式块节点。
+func MathBlock(math string) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeMathBlock}
+	ret.AppendChild(&ast.Node{Type: ast.NodeMathBlockOpenMarker})
+	ret.AppendChild(&ast.Node{Type: ast.NodeMathBlockContent, Tokens: []byte(math)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeMathBlockCloseMarker})
+	return ret
+}
+
+// ThematicBreak 构造分隔线节点。
+func ThematicBreak() *ast.Node {
+	return &ast.Node{Type: ast.NodeThematicBreak}
+}
+
+// Table 构造表格节点，rows 的第一行为表头，每个单元格为一个行级节点（nil 表示空单元格），
+// aligns 为每列的对齐方式，0：默认对齐，1：左对齐，2：居中对齐，3：右对齐，列数以 aligns 为准。
+func Table(rows [][]*ast.Node, aligns []int) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeTable, TableAligns: aligns}
+	for i, row := range rows {
+		tr := &ast.Node{Type: ast.NodeTableRow}
+		if 0 < i {
+			tr.TableAligns = aligns
+		}
+		for j, align := range aligns {
+			td := &ast.Node{Type: ast.NodeTableCell, TableCellAlign: align}
+			if j < len(row) {
+				appendChildren(td, row[j:j+1])
+			}
+			tr.AppendChild(td)
+		}
+
+		if 0 == i {
+			head := &ast.Node{Type: ast.NodeTableHead}
+			head.AppendChild(tr)
+			ret.AppendChild(head)
+		} else {
+			ret.AppendChild(tr)
+		}
+	}
+	return ret
+}
+
+// TextTable 构造单元格内容均为纯文本的表格节点，参数说明见 Table。
+func TextTable(rows [][]string, aligns []int) *ast.Node {
+	var nodeRows [][]*ast.Node
+	for _, row := range rows {
+		var nodeRow []*ast.Node
+		for _, cell := range row {
+			var node *ast.Node
+			if "" != cell {
+				node = Text(cell)
+			}
+			nodeRow = append(nodeRow, node)
+		}
+		nodeRows = append(nodeRows, nodeRow)
+	}
+	return Table(nodeRows, aligns)
+}
+
+// Text 构造文本节点。文本节点通过本包的构造函数加入父节点时会对其中的 Markdown 标记符进行转义。
+func Text(text string) *ast.Node {
+	return &ast.Node{Type: ast.NodeText, Tokens: []byte(text)}
+}
+
+// Emphasis 构造使用 * 作为标记符的强调节点。
+func Emphasis(children ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeEmphasis}
+	ret.AppendChild(&ast.Node{Type: ast.NodeEmA6kOpenMarker, Tokens: []byte("*")})
+	appendChildren(ret, children)
+	ret.AppendChild(&ast.Node{Type: ast.NodeEmA6kCloseMarker, Tokens: []byte("*")})
+	return ret
+}
+
+// Strong 构造使用 ** 作为标记符的加粗节点。
+func Strong(children ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeStrong}
+	ret.AppendChild(&ast.Node{Type: ast.NodeStrongA6kOpenMarker, Tokens: []byte("**")})
+	appendChildren(ret, children)
+	ret.AppendChild(&ast.Node{Type: ast.NodeStrongA6kCloseMarker, Tokens: []byte("**")})
+	return ret
+}
+
+// Strikethrough 构造使用 ~~ 作为标记符的删除线节点。
+func Strikethrough(children ...*ast.Node) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeStrikethrough}
+	ret.AppendChild(&ast.Node{Type: ast.NodeStrikethrough2OpenMarker, Tokens: []byte("~~")})
+	appendChildren(ret, children)
+	ret.AppendChild(&ast.Node{Type: ast.NodeStrikethrough2CloseMarker, Tokens: []byte("~~")})
+	return ret
+}
+
+// CodeSpan 构造代码节点，标记符的反引号比代码中最长的连续反引号多一个。
+func CodeSpan(code string) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeCodeSpan, CodeMarkerLen: 1}
+	run := 0
+	for _, c := range code {
+		if '`' != c {
+			run = 0
+			continue
+		}
+		if run++; ret.CodeMarkerLen <= run {
+			ret.CodeMarkerLen = run + 1
+		}
+	}
+	marker := []byte(strings.Repeat("`", ret.CodeMarkerLen))
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeSpanOpenMarker, Tokens: marker})
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeSpanContent, Tokens: []byte(code)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeCodeSpanCloseMarker, Tokens: marker})
+	return ret
+}
+
+// InlineMath 构造行级数学公式节点。
+func InlineMath(math string) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeInlineMath}
+	ret.AppendChild(&ast.Node{Type: ast.NodeInlineMathOpenMarker})
+	ret.AppendChild(&ast.Node{Type: ast.NodeInlineMathContent, Tokens: []byte(math)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeInlineMathCloseMarker})
+	return ret
+}
+
+// Link 构造内联链接节点，title 为空时不输出链接标题。dest 包含空格或者括号时使用 <> 包裹。
+func Link(text, dest, title string) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeLink}
+	ret.AppendChild(&ast.Node{Type: ast.NodeOpenBracket, Tokens: []byte("[")})
+	appendEscaped(ret, ast.NodeLinkText, []byte(text), false)
+	appendLinkTail(ret, dest, title)
+	return ret
+}
+
+// Image 构造图片节点，title 为空时不输出图片标题。dest 包含空格或者括号时使用 <> 包裹。
+func Image(alt, dest, title string) *ast.Node {
+	ret := &ast.Node{Type: ast.NodeImage}
+	ret.AppendChild(&ast.Node{Type: ast.NodeBang, Tokens: []byte("!")})
+	ret.AppendChild(&ast.Node{Type: ast.NodeOpenBracket, Tokens: []byte("[")})
+	appendEscaped(ret, ast.NodeLinkText, []byte(alt), false)
+	appendLinkTail(ret, dest, title)
+	return ret
+}
+
+func appendLinkTail(link *ast.Node, dest, title string) {
+	link.AppendChild(&ast.Node{Type: ast.NodeCloseBracket, Tokens: []byte("]")})
+	link.AppendChild(&ast.Node{Type: ast.NodeOpenParen, Tokens: []byte("(")})
+	dest = strings.NewReplacer("<", "%3C", ">", "%3E", "\n", "%0A").Replace(dest)
+	pointy := strings.ContainsAny(dest, " ()")
+	if pointy {
+		link.AppendChild(&ast.Node{Type: ast.NodeLess, Tokens: []byte("<")})
+	}
+	link.AppendChild(&ast.Node{Type: ast.NodeLinkDest, Tokens: []byte(dest)})
+	if pointy {
+		link.AppendChild(&ast.Node{Type: ast.NodeGreater, Tokens: []byte(">")})
+	}
+	if "" != title {
+		link.AppendChild(&ast.Node{Type: ast.NodeLinkSpace, Tokens: []byte(" ")})
+		title = strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(title)
+		link.AppendChild(&ast.Node{Type: ast.NodeLinkTitle, Tokens: []byte(title)})
+	}
+	link.AppendChild(&ast.Node{Type: ast.NodeCloseParen, Tokens: []byte(")")})
+}
+
+// balancedParens 判断链接地址 dest 中的括号是否配对，配对时不使用 <> 包裹也能被正确解析。
+func balancedParens(dest []byte) bool {
+	depth := 0
+	for _, c := range dest {
+		switch c {
+		case lex.ItemOpenParen:
+			depth++
+		case lex.ItemCloseParen:
+			if depth--; 0 > depth {
+				return false
+			}
+		}
+	}
+	return 0 == depth
+}
+
+// HardBreak 构造硬换行节点。
+func HardBreak() *ast.Node {
+	return &ast.Node{Type: ast.NodeHardBreak, Tokens: []byte("\n")}
+}
+
+// SoftBreak 构造软换行节点。
+func SoftBreak() *ast.Node {
+	return &ast.Node{Type: ast.NodeSoftBreak, Tokens: []byte("\n")}
+}
+
+// appendChildren 将 children 加入 n，其中的文本节点会拆分为文本节点和反斜杠转义节点。
+func appendChildren(n *ast.Node, children []*ast.Node) *ast.Node {
+	for _, c := range children {
+		if nil == c {
+			continue
+		}
+		if ast.NodeText != c.Type {
+			n.AppendChild(c)
+			continue
+		}
+
+		lineStart := ast.NodeParagraph == n.Type && (nil == n.LastChild || ast.NodeSoftBreak == n.LastChild.Type || ast.NodeHardBreak == n.LastChild.Type)
+		appendEscaped(n, ast.NodeText, c.Tokens, lineStart)
+	}
+	return n
+}
+
+// appendEscaped 将文本 text 拆分为 typ 类型的文本节点和反斜杠转义节点加入 n，和解析器解析转义后的结构一致。
+// lineStart 表示 text 位于段落行首，此时还需要转义会被识别为块级标记符的字符。
+func appendEscaped(n *ast.Node, typ ast.NodeType, text []byte, lineStart bool) {
+	start, digits := 0, 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if '\n' == c {
+			lineStart, digits = true, 0
+			continue
+		}
+		escape := needEscape(text, i, lineStart, 0 < digits && 9 >= digits)
+		if lex.IsDigit(c) && (lineStart || 0 < digits) {
+			digits++
+		} else {
+			digits = 0
+		}
+		if ' ' != c && '\t' != c {
+			lineStart = false
+		}
+		if !escape {
+			continue
+		}
+
+		if start < i {
+			n.AppendChild(&ast.Node{Type: typ, Tokens: text[start:i]})
+		}
+		backslash := &ast.Node{Type: ast.NodeBackslash}
+		backslash.AppendChild(&ast.Node{Type: ast.NodeBackslashContent, Tokens: []byte{text[i]}})
+		n.AppendChild(backslash)
+		start = i + 1
+	}
+	if start < len(text) || 0 == len(text) {
+		n.AppendChild(&ast.Node{Type: typ, Tokens: text[start:]})
+	}
+}
+
+// needEscape 判断 text[i] 是否需要使用反斜杠转义，ordered 表示 text[i] 前面是行首的数字，即可能是有序列表标记符。
+func needEscape(text []byte, i int, lineStart, ordered bool) bool {
+	switch text[i] {
+	case '\\', '`', '*', '_', '[', ']', '<', '~', '$', '|', '{', '#':
+		return true
+	case '&':
+		// 实体引用
+		return i+1 < len(text) && ('#' == text[i+1] || lex.IsASCIILetter(text[i+1]))
+	case '=':
+		// 标记 ==
+		return lineStart || (i+1 < len(text) && '=' == text[i+1]) || (0 < i && '=' == text[i-1])
+	case ':':
+		// 表情别名 :alias:
+		for j := i + 1; j < len(text); j++ {
+			if ':' == text[j] {
+				return j > i+1
+			}
+			if !lex.IsASCIILetterNum(text[j]) && '_' != text[j] && '+' != text[j] && '-' != text[j] {
+				return false
+			}
+		}
+		return false
+	case '>', '-', '+':
+		// 引述、列表、分隔线和 Setext 标题
+		return lineStart
+	case '.', ')':
+		// 有序列表
+		return ordered
+	}
+	return false
+}
@@ -0,0 +1,449 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package diff 提供了两个 Markdown 文档之间块级的语义差异比较。
+//
+// 比较的单位是同一个父块的子块，块带有内联属性列表 id 时按 id 匹配，否则按内容匹配：先匹配内容相同的块，再匹配内容相似的同类型块。
+// 匹配上的块如果相对顺序发生了变化则认为是移动，内容不同则认为是修改，修改的段落和标题会进一步给出单词级的差异，
+// 修改的容器块（列表、列表项、引述块等）会递归比较其子块。
+package diff
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// Op 描述了差异操作类型。
+type Op string
+
+const (
+	OpEqual  Op = "equal"  // 未修改
+	OpInsert Op = "insert" // 插入
+	OpDelete Op = "delete" // 删除
+	OpUpdate Op = "update" // 修改
+	OpMove   Op = "move"   // 移动，内容也可能同时被修改
+)
+
+// similarityThreshold 是内容相似的块的最小相似度。
+const similarityThreshold = 0.5
+
+// Change 描述了一个块的差异。
+type Change struct {
+	Op       Op      `json:"op"`              // 操作类型
+	ID       string  `json:"id,omitempty"`    // 块的内联属性列表 id
+	Type     string  `json:"type"`            // 块的节点类型
+	OldIndex int     `json:"oldIndex"`        // 块在旧父块中的位置，插入时为 -1
+	NewIndex int     `json:"newIndex"`        // 块在新父块中的位置，删除时为 -1
+	Old      string  `json:"old,omitempty"`   // 旧块的 Markdown
+	New      string  `json:"new,omitempty"`   // 新块的 Markdown
+	Words    []*Word `json:"words,omitempty"` // 修改的段落和标题的单词级差异
+
+	Children []*Change `json:"children,omitempty"` // 修改的容器块的子块差异，容器块本身的属性被修改或者子块都没有修改时为 nil
+
+	OldNode *ast.Node `json:"-"` // 旧块节点
+	NewNode *ast.Node `json:"-"` // 新块节点
+}
+
+// Modified 判断块的内容是否被修改。
+func (change *Change) Modified() bool {
+	return nil != change.OldNode && nil != change.NewNode && change.Old != change.New
+}
+
+// Word 描述了单词级的差异片段。
+type Word struct {
+	Op   Op     `json:"op"`   // 操作类型，只会是 OpEqual、OpInsert 或者 OpDelete
+	Text string `json:"text"` // 片段文本
+}
+
+// block 描述了参与比较的块。
+type block struct {
+	node     *ast.Node
+	id       string
+	markdown string
+	words    []string
+	match    int // 匹配的另一个文档中的块位置，未匹配时为 -1
+}
+
+// Diff 比较 oldTree 和 newTree 的顶层块，按新文档的顺序返回差异，删除的块位于它在旧文档中的前一个块之后。
+// 修改的容器块的子块差异按照同样的规则记录在 Change.Children 中。
+func Diff(oldTree, newTree *parse.Tree) (ret []*Change) {
+	return diffChildren(options(oldTree), options(newTree), oldTree.Root, newTree.Root)
+}
+
+// diffChildren 比较 oldParent 和 newParent 的子块。
+func diffChildren(oldOptions, newOptions *parse.Options, oldParent, newParent *ast.Node) (ret []*Change) {
+	olds, news := blocks(oldParent, oldOptions), blocks(newParent, newOptions)
+	matchByID(olds, news)
+	matchByContent(olds, news)
+	matchBySimilarity(olds, news)
+	stable := stableMatches(news)
+
+	emitted := 0
+	emitDeletes := func(end int) {
+		for ; emitted < end; emitted++ {
+			if old := olds[emitted]; -1 == old.match {
+				ret = append(ret, &Change{Op: OpDelete, ID: old.id, Type: old.node.Type.String(), OldIndex: emitted, NewIndex: -1, Old: old.markdown, OldNode: old.node})
+			}
+		}
+	}
+
+	// 插入的块之前先输出同一位置上删除的块，即下一个位置没有变化的块之前删除的块
+	nextStable := make([]int, len(news))
+	next := len(olds)
+	for i := len(news) - 1; 0 <= i; i-- {
+		if stable[i] {
+			next = news[i].match
+		}
+		nextStable[i] = next
+	}
+
+	for i, b := range news {
+		if -1 == b.match {
+			emitDeletes(nextStable[i])
+			ret = append(ret, &Change{Op: OpInsert, ID: b.id, Type: b.node.Type.String(), OldIndex: -1, NewIndex: i, New: b.markdown, NewNode: b.node})
+			continue
+		}
+
+		old := olds[b.match]
+		change := &Change{Op: OpEqual, ID: b.id, Type: b.node.Type.String(), OldIndex: b.match, NewIndex: i, Old: old.markdown, New: b.markdown, OldNode: old.node, NewNode: b.node}
+		if "" == change.ID {
+			change.ID = old.id
+		}
+		if stable[i] {
+			emitDeletes(b.match + 1)
+			if change.Modified() {
+				change.Op = OpUpdate
+			}
+		} else {
+			change.Op = OpMove
+		}
+		if change.Modified() && old.node.Type == b.node.Type {
+			if ast.NodeParagraph == b.node.Type || ast.NodeHeading == b.node.Type {
+				change.Words = diffWords(old.words, b.words)
+			} else if b.node.IsContainerBlock() && signature(old.node) == signature(b.node) {
+				if children := diffChildren(oldOptions, newOptions, old.node, b.node); changed(children) {
+					change.Children = children
+				}
+			}
+		}
+		ret = append(ret, change)
+	}
+	emitDeletes(len(olds))
+	return
+}
+
+// JSON 将差异列表序列化为 JSON。
+func JSON(changes []*Change) ([]byte, error) {
+	if nil == changes {
+		changes = []*Change{}
+	}
+	return json.Marshal(changes)
+}
+
+func changed(changes []*Change) bool {
+	for _, change := range changes {
+		if OpEqual != change.Op {
+			return true
+		}
+	}
+	return false
+}
+
+func options(tree *parse.Tree) *parse.Options {
+	if nil != tree.Context && nil != tree.Context.ParseOption {
+		return tree.Context.ParseOption
+	}
+	return parse.NewOptions()
+}
+
+// blocks 返回 parent 中参与比较的子块，块级内联属性列表和标记符不参与比较。
+func blocks(parent *ast.Node, options *parse.Options) (ret []*block) {
+	for n := parent.FirstChild; nil != n; n = n.Next {
+		if !n.IsBlock() || ast.NodeKramdownBlockIAL == n.Type {
+			continue
+		}
+		markdown := formatBlock(n, options)
+		ret = append(ret, &block{node: n, id: n.IALAttr("id"), markdown: markdown, words: splitWords(inlineMarkdown(n, markdown)), match: -1})
+	}
+	return
+}
+
+// formatBlock 返回块 n 格式化后的 Markdown，块级内联属性列表不参与比较。
+func formatBlock(n *ast.Node, options *parse.Options) string {
+	renderer := render.NewFormatRenderer(detached(n, options), render.NewOptions())
+	return strings.TrimSpace(string(renderer.Render()))
+}
+
+// detached 返回只包含块 n 副本的语法树，列表项会被包裹在只包含它的列表中。
+func detached(n *ast.Node, options *parse.Options) (ret *parse.Tree) {
+	ret = &parse.Tree{Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: options}}
+	ret.Context.Tree = ret
+
+	parent := ret.Root
+	if ast.NodeListItem == n.Type && nil != n.Parent && ast.NodeList == n.Parent.Type {
+		listData := *n.Parent.ListData
+		parent = &ast.Node{Type: ast.NodeList, ListData: &listData}
+		ret.Root.AppendChild(parent)
+	}
+	parent.AppendChild(n.Clone())
+	return
+}
+
+// signature 返回容器块本身的签名，不包括子块。有序列表项的序号会随着位置变化，不作为签名的一部分。
+func signature(n *ast.Node) string {
+	buf := &strings.Builder{}
+	buf.WriteString(n.Type.String())
+	if nil != n.ListData {
+		buf.WriteString(" " + strconv.Itoa(n.ListData.Typ) + " " + strconv.FormatBool(n.ListData.Tight) + " " + strconv.FormatBool(n.ListData.Checked))
+		buf.WriteByte(n.ListData.BulletChar)
+		buf.WriteByte(n.ListData.Delimiter)
+	}
+	for c := n.FirstChild; nil != c; c = c.Next {
+		if !c.IsBlock() {
+			buf.WriteString(" " + string(c.Tokens))
+		}
+	}
+	return buf.String()
+}
+
+// inlineMarkdown 返回段落和标题的行级内容 Markdown，用于单词级比较，列表项去掉列表项标记符。
+func inlineMarkdown(n *ast.Node, markdown string) string {
+	if ast.NodeListItem == n.Type {
+		if idx := strings.IndexAny(markdown, " \t\n"); 0 <= idx {
+			return markdown[idx+1:]
+		}
+		return ""
+	}
+	if ast.NodeHeading != n.Type {
+		return markdown
+	}
+	if strings.HasPrefix(markdown, "#") {
+		return strings.TrimPrefix(strings.TrimLeft(markdown, "#"), " ")
+	}
+	// Setext 标题
+	if idx := strings.Index(markdown, "\n"); 0 < idx {
+		return markdown[:idx]
+	}
+	return markdown
+}
+
+func matchByID(olds, news []*block) {
+	ids := map[string]int{}
+	for i, old := range olds {
+		if "" != old.id {
+			ids[old.id] = i
+		}
+	}
+	for i, b := range news {
+		if "" == b.id {
+			continue
+		}
+		if j, ok := ids[b.id]; ok && -1 == olds[j].match {
+			link(olds, news, j, i)
+		}
+	}
+}
+
+func matchByContent(olds, news []*block) {
+	contents := map[string][]int{}
+	for i, old := range olds {
+		if -1 == old.match && "" == old.id {
+			contents[old.markdown] = append(contents[old.markdown], i)
+		}
+	}
+	for i, b := range news {
+		if -1 != b.match || "" != b.id {
+			continue
+		}
+		if candidates := contents[b.markdown]; 0 < len(candidates) {
+			link(olds, news, candidates[0], i)
+			contents[b.markdown] = candidates[1:]
+		}
+	}
+}
+
+func matchBySimilarity(olds, news []*block) {
+	for i, b := range news {
+		if -1 != b.match || "" != b.id {
+			continue
+		}
+
+		best, bestScore := -1, similarityThreshold
+		for j, old := range olds {
+			if -1 != old.match || "" != old.id || old.node.Type != b.node.Type {
+				continue
+			}
+			if score := similarity(old.words, b.words); score >= bestScore && (-1 == best || score > bestScore) {
+				best, bestScore = j, score
+			}
+		}
+		if -1 != best {
+			link(olds, news, best, i)
+		}
+	}
+}
+
+func link(olds, news []*block, oldIndex, newIndex int) {
+	olds[oldIndex].match = newIndex
+	news[newIndex].match = oldIndex
+}
+
+// stableMatches 返回相对顺序没有变化的匹配块，即新文档中旧位置的最长递增子序列，其余匹配块被认为是移动。
+func stableMatches(news []*block) (ret map[int]bool) {
+	var indexes []int // 匹配块在新文档中的位置
+	for i, b := range news {
+		if -1 != b.match {
+			indexes = append(indexes, i)
+		}
+	}
+
+	// tails[k] 为长度 k+1 的递增子序列的末尾元素在 indexes 中的位置
+	var tails []int
+	prev := make([]int, len(indexes))
+	for k, i := range indexes {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if news[indexes[tails[mid]]].match < news[i].match {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[k] = -1
+		if 0 < lo {
+			prev[k] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, k)
+		} else {
+			tails[lo] = k
+		}
+	}
+
+	ret = map[int]bool{}
+	if 0 < len(tails) {
+		for k := tails[len(tails)-1]; -1 != k; k = prev[k] {
+			ret[indexes[k]] = true
+		}
+	}
+	return
+}
+
+// similarity 返回两个单词序列的相似度，取值 0 到 1。
+func similarity(a, b []string) float64 {
+	if 0 == len(a) && 0 == len(b) {
+		return 1
+	}
+	return 2 * float64(lcsLen(a, b)) / float64(len(a)+len(b))
+}
+
+func lcsLen(a, b []string) int {
+	row := make([]int, len(b)+1)
+	for i := range a {
+		diagonal := 0
+		for j := range b {
+			up := row[j+1]
+			if a[i] == b[j] {
+				row[j+1] = diagonal + 1
+			} else if row[j] > row[j+1] {
+				row[j+1] = row[j]
+			}
+			diagonal = up
+		}
+	}
+	return row[len(b)]
+}
+
+// diffWords 基于最长公共子序列比较两个单词序列，相邻的同类片段会被合并。
+func diffWords(a, b []string) (ret []*Word) {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; 0 <= i; i-- {
+		for j := len(b) - 1; 0 <= j; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	appendWord := func(op Op, text string) {
+		if last := len(ret) - 1; 0 <= last && op == ret[last].Op {
+			ret[last].Text += text
+			return
+		}
+		ret = append(ret, &Word{Op: op, Text: text})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			appendWord(OpEqual, a[i])
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			appendWord(OpDelete, a[i])
+			i++
+		} else {
+			appendWord(OpInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		appendWord(OpDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		appendWord(OpInsert, b[j])
+	}
+	return
+}
+
+// splitWords 将文本切分为单词，连续的字母数字和连续的空白各为一个单词，其他字符（包括中日韩文字）每个字符为一个单词。
+func splitWords(text string) (ret []string) {
+	kind := func(r rune) int {
+		if unicode.IsSpace(r) {
+			return 1
+		}
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || '_' == r) {
+			return 2
+		}
+		if unicode.IsLetter(r) && !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return 2
+		}
+		return 0
+	}
+
+	start, last := 0, -1
+	for i, r := range text {
+		k := kind(r)
+		if i > start && (0 == k || k != last) {
+			ret = append(ret, text[start:i])
+			start = i
+		}
+		last = k
+	}
+	if start < len(text) {
+		ret = append(ret, text[start:])
+	}
+	return
+}
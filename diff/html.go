@@ -0,0 +1,238 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package diff
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// HTML 将差异列表渲染为 HTML，options 为 nil 时使用默认渲染选项。
+//
+// 插入的块使用 <ins> 包裹，删除的块使用 <del> 包裹，移动的块使用 <ins class="diff-move" data-old-index="旧位置"> 包裹。
+// 修改的段落和标题在渲染后的 HTML 中使用 <ins> 和 <del> 标记单词级差异，修改的容器块在新容器块中渲染子块的差异，
+// 其他修改的块依次输出删除的旧块和插入的新块。
+func HTML(changes []*Change, options *render.Options) []byte {
+	if nil == options {
+		options = render.NewOptions()
+	}
+
+	buf := &bytes.Buffer{}
+	writeChanges(buf, changes, options)
+	return buf.Bytes()
+}
+
+func writeChanges(buf *bytes.Buffer, changes []*Change, options *render.Options) {
+	for _, change := range changes {
+		switch change.Op {
+		case OpEqual:
+			buf.Write(blockHTML(change.NewNode, options))
+		case OpInsert:
+			writeWrapped(buf, "<ins>", blockHTML(change.NewNode, options), "</ins>")
+		case OpDelete:
+			writeWrapped(buf, "<del>", blockHTML(change.OldNode, options), "</del>")
+		case OpUpdate:
+			if nil != change.Words || nil != change.Children {
+				buf.Write(updatedHTML(change, options))
+				continue
+			}
+			writeWrapped(buf, "<del>", blockHTML(change.OldNode, options), "</del>")
+			writeWrapped(buf, "<ins>", blockHTML(change.NewNode, options), "</ins>")
+		case OpMove:
+			content := blockHTML(change.NewNode, options)
+			if nil != change.Words || nil != change.Children {
+				content = updatedHTML(change, options)
+			}
+			writeWrapped(buf, "<ins class=\"diff-move\" data-old-index=\""+strconv.Itoa(change.OldIndex)+"\">", content, "</ins>")
+		}
+	}
+}
+
+// writeWrapped 使用 open 和 close 包裹 content，列表项在 <li> 内部包裹。
+func writeWrapped(buf *bytes.Buffer, open string, content []byte, close string) {
+	content = bytes.TrimRight(content, "\n")
+	if bytes.HasPrefix(content, []byte("<li")) && bytes.HasSuffix(content, []byte("</li>")) {
+		start := bytes.IndexByte(content, '>') + 1
+		buf.Write(content[:start])
+		buf.WriteString(open)
+		buf.Write(content[start : len(content)-len("</li>")])
+		buf.WriteString(close)
+		buf.WriteString("</li>\n")
+		return
+	}
+	buf.WriteString(open)
+	buf.Write(content)
+	buf.WriteString(close)
+	buf.WriteByte('\n')
+}
+
+// updatedHTML 渲染修改的块：段落和标题标记单词级差异，容器块渲染子块的差异。
+func updatedHTML(change *Change, options *render.Options) []byte {
+	if nil != change.Children {
+		open, close := containerHTML(change.NewNode, options)
+		buf := &bytes.Buffer{}
+		buf.WriteString(open)
+		writeChanges(buf, change.Children, options)
+		if ast.NodeListItem == change.NewNode.Type && change.NewNode.Parent.ListData.Tight {
+			// 紧凑列表项的内容不换行
+			buf.Truncate(len(bytes.TrimRight(buf.Bytes(), "\n")))
+		}
+		buf.WriteString(close)
+		return buf.Bytes()
+	}
+	return wordsHTML(change, options)
+}
+
+// blockHTML 渲染单个块，紧凑列表中的段落不输出 <p>，列表项只输出 <li>。
+func blockHTML(n *ast.Node, options *render.Options) []byte {
+	ret := render.NewHtmlRenderer(detached(n, parse.NewOptions()), options).Render()
+	if ast.NodeListItem == n.Type && nil != n.Parent && ast.NodeList == n.Parent.Type {
+		// 去掉包裹列表项的列表标签
+		ret = ret[bytes.IndexByte(ret, '\n')+1 : bytes.LastIndex(ret, []byte("</"))]
+	} else if ast.NodeParagraph == n.Type && inTightList(n) {
+		ret = bytes.TrimSuffix(bytes.TrimSuffix(ret, []byte("\n")), []byte("</p>"))
+		ret = ret[bytes.IndexByte(ret, '>')+1:]
+	}
+	return ret
+}
+
+func inTightList(n *ast.Node) bool {
+	return nil != n.Parent && nil != n.Parent.Parent && ast.NodeList == n.Parent.Parent.Type && n.Parent.Parent.ListData.Tight
+}
+
+const childrenPlaceholder = "LuteDiffChildrenPlaceholder"
+
+// containerHTML 返回容器块 n 在子块前后的 HTML。
+func containerHTML(n *ast.Node, options *render.Options) (open, close string) {
+	shell := &ast.Node{Type: n.Type, ListData: n.ListData, KramdownIAL: n.KramdownIAL, Parent: n.Parent}
+	if ast.NodeListItem == n.Type {
+		// 列表项只取开始标签，任务列表项需要保留任务列表项标记符以便渲染任务列表项样式
+		if first := n.FirstChild; nil != first && nil != first.FirstChild && ast.NodeTaskListItemMarker == first.FirstChild.Type {
+			paragraph := &ast.Node{Type: first.Type}
+			paragraph.AppendChild(first.FirstChild.Clone())
+			shell.AppendChild(paragraph)
+		}
+		html := string(blockHTML(shell, options))
+		open, close = html[:strings.IndexByte(html, '>')+1], "</li>\n"
+		if !n.Parent.ListData.Tight {
+			open += "\n"
+		}
+		return
+	}
+
+	for c := n.FirstChild; nil != c; c = c.Next {
+		if !c.IsBlock() {
+			shell.AppendChild(c.Clone())
+		}
+	}
+	placeholder := &ast.Node{Type: ast.NodeHTMLBlock, Tokens: []byte(childrenPlaceholder)}
+	if closeMarker := shell.ChildByType(ast.NodeSuperBlockCloseMarker); nil != closeMarker {
+		closeMarker.InsertBefore(placeholder)
+	} else {
+		shell.AppendChild(placeholder)
+	}
+
+	html := string(blockHTML(shell, options))
+	idx := strings.Index(html, childrenPlaceholder)
+	open, close = html[:idx], strings.TrimPrefix(html[idx+len(childrenPlaceholder):], "\n")
+	return
+}
+
+// wordsHTML 渲染段落和标题的单词级差异：分别渲染新旧块的行级内容，以 HTML 标签、字符实体和单词为单位比较并标记差异。
+//
+// 差异片段中的标签只保留新块的，所以插入的片段中的标签原样输出，删除的片段中的标签被去掉，文本分别使用 <ins> 和 <del> 包裹。
+func wordsHTML(change *Change, options *render.Options) []byte {
+	_, oldInner, _ := splitOuterTag(change.OldNode, blockHTML(change.OldNode, options))
+	open, newInner, close := splitOuterTag(change.NewNode, blockHTML(change.NewNode, options))
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(open)
+	for _, word := range diffWords(splitHTML(oldInner), splitHTML(newInner)) {
+		switch word.Op {
+		case OpInsert:
+			writeMarked(buf, "ins", word.Text, true)
+		case OpDelete:
+			writeMarked(buf, "del", word.Text, false)
+		default:
+			buf.WriteString(word.Text)
+		}
+	}
+	buf.WriteString(close)
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// splitOuterTag 将段落或者标题 n 渲染后的 HTML 切分为外层开始标签、行级内容和外层结束标签，紧凑列表中的段落没有外层标签。
+func splitOuterTag(n *ast.Node, html []byte) (open, inner, close string) {
+	inner = strings.TrimSuffix(string(html), "\n")
+	if ast.NodeParagraph == n.Type && inTightList(n) {
+		return
+	}
+	start, end := strings.IndexByte(inner, '>')+1, strings.LastIndex(inner, "</")
+	if 0 >= start || end < start {
+		return
+	}
+	return inner[:start], inner[start:end], inner[end:]
+}
+
+// writeMarked 使用 tag 包裹差异片段 text 中的文本，keepTags 为 true 时保留其中的 HTML 标签，否则去掉。
+func writeMarked(buf *bytes.Buffer, tag, text string, keepTags bool) {
+	var pending strings.Builder
+	flush := func() {
+		if 0 < pending.Len() {
+			buf.WriteString("<" + tag + ">" + pending.String() + "</" + tag + ">")
+			pending.Reset()
+		}
+	}
+	for _, token := range splitHTML(text) {
+		if strings.HasPrefix(token, "<") {
+			flush()
+			if keepTags {
+				buf.WriteString(token)
+			}
+			continue
+		}
+		pending.WriteString(token)
+	}
+	flush()
+}
+
+// splitHTML 将行级 HTML 切分为标签、字符实体和单词。
+func splitHTML(s string) (ret []string) {
+	text := 0 // 尚未切分的文本起始位置
+	for i := 0; i < len(s); {
+		var end int
+		switch s[i] {
+		case '<':
+			end = strings.IndexByte(s[i:], '>')
+		case '&':
+			end = strings.IndexByte(s[i:], ';')
+		default:
+			i++
+			continue
+		}
+		if 0 > end {
+			i++
+			continue
+		}
+		ret = append(ret, splitWords(s[text:i])...)
+		ret = append(ret, s[i:i+end+1])
+		i += end + 1
+		text = i
+	}
+	ret = append(ret, splitWords(s[text:])...)
+	return
+}
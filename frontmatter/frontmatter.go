@@ -0,0 +1,235 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package frontmatter 实现了 Front Matter 元数据的解析和序列化，支持 YAML、TOML 和 JSON 三种格式。
+//
+// 解析结果为 map[string]interface{}，值的类型包括 string、int64、float64、bool、time.Time、nil、[]interface{} 和 map[string]interface{}。
+// 序列化时按照键排序输出，所以同样的元数据总是得到同样的文本，便于判断元数据是否被修改过。
+//
+// 为了避免引入第三方依赖，YAML 仅支持 Front Matter 中常用的子集：块映射、块序列、流式映射和序列、引号字符串以及 | 和 > 块标量，不支持锚点、别名和标签。
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Front Matter 格式。
+const (
+	YAML = "yaml"
+	TOML = "toml"
+	JSON = "json"
+)
+
+// Unmarshal 将 format 格式的 data 解析为元数据。
+func Unmarshal(format string, data []byte) (ret map[string]interface{}, err error) {
+	switch format {
+	case YAML:
+		return unmarshalYAML(data)
+	case TOML:
+		return unmarshalTOML(data)
+	case JSON:
+		return unmarshalJSON(data)
+	}
+	return nil, errors.New("unsupported front matter format [" + format + "]")
+}
+
+// Marshal 将元数据 m 按照 format 格式规范化输出，键按照字典序排列。
+func Marshal(format string, m map[string]interface{}) (ret []byte, err error) {
+	buf := &bytes.Buffer{}
+	switch format {
+	case YAML:
+		err = marshalYAML(buf, m, 0)
+	case TOML:
+		err = marshalTOML(buf, m, nil)
+	case JSON:
+		return marshalJSON(m)
+	default:
+		err = errors.New("unsupported front matter format [" + format + "]")
+	}
+	if nil != err {
+		return
+	}
+	ret = buf.Bytes()
+	return
+}
+
+func sortedKeys(m map[string]interface{}) (ret []string) {
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return
+}
+
+// quote 返回 s 的双引号字符串形式，仅使用 YAML 和 TOML 都支持的转义。
+func quote(s string) string {
+	buf := &strings.Builder{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString("\\\"")
+		case '\\':
+			buf.WriteString("\\\\")
+		case '\b':
+			buf.WriteString("\\b")
+		case '\t':
+			buf.WriteString("\\t")
+		case '\n':
+			buf.WriteString("\\n")
+		case '\f':
+			buf.WriteString("\\f")
+		case '\r':
+			buf.WriteString("\\r")
+		default:
+			if r < 0x20 || 0x7F == r || utf8.RuneError == r {
+				fmt.Fprintf(buf, "\\u%04X", r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// unquote 解析双引号字符串内容 s 中的转义。
+func unquote(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	buf := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if '\\' != s[i] {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", errors.New("invalid escape at end of string")
+		}
+		switch s[i] {
+		case 'b':
+			buf.WriteByte('\b')
+		case 't':
+			buf.WriteByte('\t')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'r':
+			buf.WriteByte('\r')
+		case '0':
+			buf.WriteByte(0)
+		case 'e':
+			buf.WriteByte(0x1B)
+		case ' ', '/', '"', '\\':
+			buf.WriteByte(s[i])
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[i]]
+			if i+size >= len(s) {
+				return "", errors.New("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if nil != err {
+				return "", errors.New("invalid unicode escape")
+			}
+			buf.WriteRune(rune(code))
+			i += size
+		default:
+			return "", fmt.Errorf("invalid escape [\\%c]", s[i])
+		}
+	}
+	return buf.String(), nil
+}
+
+// 日期时间格式，按照从长到短的顺序匹配。
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseTime 解析日期时间 s，没有时区的日期时间按照 UTC 处理。
+func parseTime(s string) (ret time.Time, ok bool) {
+	if 10 > len(s) || '-' != s[4] || '-' != s[7] {
+		return
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); nil == err {
+			return t, true
+		}
+	}
+	return
+}
+
+// formatTime 格式化日期时间 t，UTC 零点输出为日期。
+func formatTime(t time.Time) string {
+	if time.UTC == t.Location() && 0 == t.Hour() && 0 == t.Minute() && 0 == t.Second() && 0 == t.Nanosecond() {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// formatFloat 格式化浮点数 f，整数值也会带上小数点，避免再次解析时变为整数。
+func formatFloat(f float64, inf, nan string) string {
+	switch {
+	case math.IsInf(f, 1):
+		return inf
+	case math.IsInf(f, -1):
+		return "-" + inf
+	case math.IsNaN(f):
+		return nan
+	}
+	ret := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(ret, ".eE") {
+		ret += ".0"
+	}
+	return ret
+}
+
+// String 返回元数据值 v 的文本形式，列表的元素使用 ", " 连接，空值返回空字符串。
+func String(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return formatFloat(val, "Infinity", "NaN")
+	case time.Time:
+		return formatTime(val)
+	case []interface{}:
+		var items []string
+		for _, e := range val {
+			items = append(items, String(e))
+		}
+		return strings.Join(items, ", ")
+	case map[string]interface{}:
+		var items []string
+		for _, k := range sortedKeys(val) {
+			items = append(items, k+": "+String(val[k]))
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,94 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package frontmatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+func unmarshalJSON(data []byte) (ret map[string]interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&ret); nil != err {
+		return
+	}
+	if _, err = decoder.Token(); io.EOF != err {
+		return nil, errors.New("unexpected content after JSON object")
+	}
+	err = nil
+	ret = jsonNumbers(ret).(map[string]interface{})
+	return
+}
+
+// jsonNumbers 将 v 中的 json.Number 转换为 int64 或者 float64。
+func jsonNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(val.String(), 10, 64); nil == err {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range val {
+			val[k] = jsonNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range val {
+			val[i] = jsonNumbers(e)
+		}
+	}
+	return v
+}
+
+func marshalJSON(m map[string]interface{}) (ret []byte, err error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(jsonValues(m)); nil != err {
+		return
+	}
+	ret = buf.Bytes()
+	return
+}
+
+// jsonValues 将 v 中的 time.Time 转换为与 YAML、TOML 一致的字符串，浮点数转换为带小数点的数字，避免重新解析时变为整数。
+func jsonValues(v interface{}) interface{} {
+	switch val := v.(type) {
+	case time.Time:
+		return formatTime(val)
+	case float64:
+		if math.IsInf(val, 0) || math.IsNaN(val) {
+			return val // JSON 不支持，交给编码器报错
+		}
+		return json.Number(formatFloat(val, "", ""))
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			ret[k] = jsonValues(e)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(val))
+		for i, e := range val {
+			ret[i] = jsonValues(e)
+		}
+		return ret
+	}
+	return v
+}
@@ -0,0 +1,578 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type tomlParser struct {
+	s    string
+	i    int
+	line int
+	root map[string]interface{}
+	cur  map[string]interface{}
+
+	defined map[string]bool // 已经通过 [table] 定义过的表
+}
+
+func unmarshalTOML(data []byte) (ret map[string]interface{}, err error) {
+	p := &tomlParser{s: strings.ReplaceAll(string(data), "\r\n", "\n"), line: 1, root: map[string]interface{}{}, defined: map[string]bool{}}
+	p.cur = p.root
+	if err = p.parse(); nil != err {
+		return nil, fmt.Errorf("line %d: %s", p.line, err)
+	}
+	return p.root, nil
+}
+
+func (p *tomlParser) parse() error {
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			return nil
+		}
+		switch c := p.s[p.i]; {
+		case '#' == c:
+			p.skipComment()
+			continue
+		case '\n' == c:
+			p.i++
+			p.line++
+			continue
+		case '[' == c:
+			if err := p.parseTableHeader(); nil != err {
+				return err
+			}
+		default:
+			if err := p.parseKeyValue(p.cur); nil != err {
+				return err
+			}
+		}
+		if err := p.expectLineEnd(); nil != err {
+			return err
+		}
+	}
+}
+
+func (p *tomlParser) skipSpace() {
+	for ; p.i < len(p.s) && (' ' == p.s[p.i] || '\t' == p.s[p.i]); p.i++ {
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	for ; p.i < len(p.s) && '\n' != p.s[p.i]; p.i++ {
+	}
+}
+
+// skipWhitespace 跳过空白、换行和注释，数组和多行内容中使用。
+func (p *tomlParser) skipWhitespace() {
+	for p.i < len(p.s) {
+		switch p.s[p.i] {
+		case ' ', '\t':
+			p.i++
+		case '\n':
+			p.i++
+			p.line++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) expectLineEnd() error {
+	p.skipSpace()
+	if p.i < len(p.s) && '#' == p.s[p.i] {
+		p.skipComment()
+	}
+	if p.i < len(p.s) && '\n' != p.s[p.i] {
+		return fmt.Errorf("unexpected [%s] at end of line", p.rest())
+	}
+	return nil
+}
+
+func (p *tomlParser) rest() string {
+	end := strings.IndexByte(p.s[p.i:], '\n')
+	if 0 > end {
+		return p.s[p.i:]
+	}
+	return p.s[p.i : p.i+end]
+}
+
+// parseTableHeader 解析 [table] 和 [[array]]。
+func (p *tomlParser) parseTableHeader() error {
+	array := strings.HasPrefix(p.s[p.i:], "[[")
+	if array {
+		p.i += 2
+	} else {
+		p.i++
+	}
+	p.skipSpace()
+	keys, err := p.parseKey()
+	if nil != err {
+		return err
+	}
+	p.skipSpace()
+	closer := "]"
+	if array {
+		closer = "]]"
+	}
+	if !strings.HasPrefix(p.s[p.i:], closer) {
+		return fmt.Errorf("expected [%s] after table name", closer)
+	}
+	p.i += len(closer)
+
+	parent, err := p.descend(p.root, keys[:len(keys)-1])
+	if nil != err {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if array {
+		var arr []interface{}
+		if existing, ok := parent[last]; ok {
+			if arr, ok = existing.([]interface{}); !ok {
+				return fmt.Errorf("key [%s] is not an array of tables", strings.Join(keys, "."))
+			}
+		}
+		p.cur = map[string]interface{}{}
+		parent[last] = append(arr, p.cur)
+		return nil
+	}
+
+	path := strings.Join(keys, "\x00")
+	if p.defined[path] {
+		return fmt.Errorf("table [%s] is defined twice", strings.Join(keys, "."))
+	}
+	p.defined[path] = true
+	if p.cur, err = p.descend(parent, []string{last}); nil != err {
+		return err
+	}
+	return nil
+}
+
+// descend 从 m 开始沿着 keys 找到或者创建子表，数组表取最后一个元素。
+func (p *tomlParser) descend(m map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case nil:
+			child := map[string]interface{}{}
+			m[k] = child
+			m = child
+		case map[string]interface{}:
+			m = v
+		case []interface{}:
+			if 0 == len(v) {
+				return nil, fmt.Errorf("key [%s] is not a table", k)
+			}
+			child, ok := v[len(v)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("key [%s] is not a table", k)
+			}
+			m = child
+		default:
+			return nil, fmt.Errorf("key [%s] is not a table", k)
+		}
+	}
+	return m, nil
+}
+
+func (p *tomlParser) parseKeyValue(m map[string]interface{}) error {
+	keys, err := p.parseKey()
+	if nil != err {
+		return err
+	}
+	p.skipSpace()
+	if p.i >= len(p.s) || '=' != p.s[p.i] {
+		return fmt.Errorf("expected [=] after key [%s]", strings.Join(keys, "."))
+	}
+	p.i++
+	p.skipSpace()
+	v, err := p.parseValue()
+	if nil != err {
+		return err
+	}
+
+	parent, err := p.descend(m, keys[:len(keys)-1])
+	if nil != err {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if _, exists := parent[last]; exists {
+		return fmt.Errorf("duplicate key [%s]", strings.Join(keys, "."))
+	}
+	parent[last] = v
+	return nil
+}
+
+// parseKey 解析裸键、引号键和点分键。
+func (p *tomlParser) parseKey() (ret []string, err error) {
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("expected a key")
+		}
+		var key string
+		switch p.s[p.i] {
+		case '"':
+			if key, err = p.parseBasicString(); nil != err {
+				return
+			}
+		case '\'':
+			if key, err = p.parseLiteralString(); nil != err {
+				return
+			}
+		default:
+			start := p.i
+			for ; p.i < len(p.s) && isTOMLBareKeyChar(p.s[p.i]); p.i++ {
+			}
+			if start == p.i {
+				return nil, fmt.Errorf("invalid key [%s]", p.rest())
+			}
+			key = p.s[start:p.i]
+		}
+		ret = append(ret, key)
+		p.skipSpace()
+		if p.i >= len(p.s) || '.' != p.s[p.i] {
+			return
+		}
+		p.i++
+	}
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return ('A' <= c && 'Z' >= c) || ('a' <= c && 'z' >= c) || ('0' <= c && '9' >= c) || '_' == c || '-' == c
+}
+
+func (p *tomlParser) parseValue() (interface{}, error) {
+	if p.i >= len(p.s) {
+		return nil, fmt.Errorf("expected a value")
+	}
+	switch p.s[p.i] {
+	case '"':
+		if strings.HasPrefix(p.s[p.i:], `"""`) {
+			return p.parseMultilineString(`"""`)
+		}
+		return p.parseBasicString()
+	case '\'':
+		if strings.HasPrefix(p.s[p.i:], "'''") {
+			return p.parseMultilineString("'''")
+		}
+		return p.parseLiteralString()
+	case '[':
+		return p.parseArray()
+	case '{':
+		return p.parseInlineTable()
+	}
+
+	start := p.i
+	for ; p.i < len(p.s) && !strings.ContainsRune(",]}#\n", rune(p.s[p.i])); p.i++ {
+	}
+	token := strings.TrimSpace(p.s[start:p.i])
+	p.i = start + len(token)
+	return resolveTOMLScalar(token)
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.i++
+	start := p.i
+	for ; p.i < len(p.s); p.i++ {
+		switch p.s[p.i] {
+		case '\\':
+			p.i++
+		case '\n':
+			return "", fmt.Errorf("unclosed string")
+		case '"':
+			p.i++
+			return unquote(p.s[start : p.i-1])
+		}
+	}
+	return "", fmt.Errorf("unclosed string")
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.i++
+	end := strings.IndexAny(p.s[p.i:], "'\n")
+	if 0 > end || '\n' == p.s[p.i+end] {
+		return "", fmt.Errorf("unclosed string")
+	}
+	ret := p.s[p.i : p.i+end]
+	p.i += end + 1
+	return ret, nil
+}
+
+// parseMultilineString 解析基本和字面量多行字符串，紧跟开始标记的换行会被去掉。
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	p.i += 3
+	end := p.i
+	for {
+		idx := strings.Index(p.s[end:], delim)
+		if 0 > idx {
+			return "", fmt.Errorf("unclosed multi-line string")
+		}
+		end += idx
+		if `"""` == delim && isEscaped(p.s, end) {
+			end++
+			continue
+		}
+		break
+	}
+	// 结束标记前最多还可以有两个引号
+	for n := 0; n < 2 && end+3 < len(p.s) && delim[0] == p.s[end+3]; n++ {
+		end++
+	}
+	content := p.s[p.i:end]
+	p.line += strings.Count(content, "\n")
+	p.i = end + 3
+	content = strings.TrimPrefix(content, "\n")
+	if "'''" == delim {
+		return content, nil
+	}
+
+	// 行尾反斜杠会去掉换行以及下一行开头的空白
+	content = tomlLineEndingBackslash.ReplaceAllString(content, "")
+	return unquote(content)
+}
+
+var tomlLineEndingBackslash = regexp.MustCompile(`\\[ \t]*\n[ \t\n]*`)
+
+// isEscaped 判断 s[i] 前面是否有奇数个反斜杠。
+func isEscaped(s string, i int) bool {
+	n := 0
+	for j := i - 1; 0 <= j && '\\' == s[j]; j-- {
+		n++
+	}
+	return 1 == n%2
+}
+
+func (p *tomlParser) parseArray() (interface{}, error) {
+	p.i++
+	ret := []interface{}{}
+	for {
+		p.skipWhitespace()
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("unclosed array")
+		}
+		if ']' == p.s[p.i] {
+			p.i++
+			return ret, nil
+		}
+		v, err := p.parseValue()
+		if nil != err {
+			return nil, err
+		}
+		ret = append(ret, v)
+		p.skipWhitespace()
+		if p.i < len(p.s) && ',' == p.s[p.i] {
+			p.i++
+		} else if p.i >= len(p.s) || ']' != p.s[p.i] {
+			return nil, fmt.Errorf("expected [,] or []] in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (interface{}, error) {
+	p.i++
+	ret := map[string]interface{}{}
+	p.skipSpace()
+	if p.i < len(p.s) && '}' == p.s[p.i] {
+		p.i++
+		return ret, nil
+	}
+	for {
+		if err := p.parseKeyValue(ret); nil != err {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("unclosed inline table")
+		}
+		switch p.s[p.i] {
+		case ',':
+			p.i++
+		case '}':
+			p.i++
+			return ret, nil
+		default:
+			return nil, fmt.Errorf("expected [,] or [}] in inline table")
+		}
+	}
+}
+
+// resolveTOMLScalar 解析布尔、数字和日期时间。
+func resolveTOMLScalar(s string) (interface{}, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if t, ok := parseTime(s); ok {
+		return t, nil
+	}
+
+	n := strings.ReplaceAll(s, "_", "")
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(n, prefix) {
+			if i, err := strconv.ParseInt(n[2:], base, 64); nil == err {
+				return i, nil
+			}
+			return nil, fmt.Errorf("invalid number [%s]", s)
+		}
+	}
+	if i, err := strconv.ParseInt(n, 10, 64); nil == err {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(n, 64); nil == err && !strings.ContainsAny(n, "xXpP") {
+		return f, nil
+	}
+	return nil, fmt.Errorf("invalid value [%s]", s)
+}
+
+// marshalTOML 输出表 m，path 为 m 的表名。先输出键值对，再输出子表和数组表。
+func marshalTOML(buf *bytes.Buffer, m map[string]interface{}, path []string) error {
+	var tables, arrays []string
+	for _, k := range sortedKeys(m) {
+		switch v := m[k].(type) {
+		case nil:
+			// TOML 没有空值
+			continue
+		case map[string]interface{}:
+			tables = append(tables, k)
+			continue
+		case []interface{}:
+			if isTOMLTableArray(v) {
+				arrays = append(arrays, k)
+				continue
+			}
+		}
+		value, err := tomlValue(m[k])
+		if nil != err {
+			return err
+		}
+		buf.WriteString(tomlKey(k) + " = " + value + "\n")
+	}
+
+	for _, k := range tables {
+		child := append(append([]string{}, path...), k)
+		writeTOMLHeader(buf, "["+tomlPath(child)+"]")
+		if err := marshalTOML(buf, m[k].(map[string]interface{}), child); nil != err {
+			return err
+		}
+	}
+	for _, k := range arrays {
+		child := append(append([]string{}, path...), k)
+		for _, e := range m[k].([]interface{}) {
+			writeTOMLHeader(buf, "[["+tomlPath(child)+"]]")
+			if err := marshalTOML(buf, e.(map[string]interface{}), child); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTOMLHeader(buf *bytes.Buffer, header string) {
+	if 0 < buf.Len() {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(header + "\n")
+}
+
+func isTOMLTableArray(arr []interface{}) bool {
+	if 0 == len(arr) {
+		return false
+	}
+	for _, e := range arr {
+		if _, ok := e.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlPath(keys []string) string {
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, tomlKey(k))
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlKey 返回键 k 在 TOML 中的表示，不能使用裸键时使用双引号。
+func tomlKey(k string) string {
+	if "" == k {
+		return quote(k)
+	}
+	for i := 0; i < len(k); i++ {
+		if !isTOMLBareKeyChar(k[i]) {
+			return quote(k)
+		}
+	}
+	return k
+}
+
+func tomlValue(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return quote(val), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return formatFloat(val, "inf", "nan"), nil
+	case time.Time:
+		return formatTime(val), nil
+	case []interface{}:
+		var items []string
+		for _, e := range val {
+			if nil == e {
+				return "", fmt.Errorf("null is not supported in TOML arrays")
+			}
+			item, err := tomlValue(e)
+			if nil != err {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		var items []string
+		for _, k := range sortedKeys(val) {
+			if nil == val[k] {
+				continue
+			}
+			item, err := tomlValue(val[k])
+			if nil != err {
+				return "", err
+			}
+			items = append(items, tomlKey(k)+" = "+item)
+		}
+		return "{" + strings.Join(items, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported front matter value type [%T]", v)
+}
@@ -0,0 +1,619 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// yamlLine 描述了 YAML 中的一行。
+type yamlLine struct {
+	num    int    // 行号，从 1 开始
+	indent int    // 缩进空格数
+	text   string // 去掉缩进和注释后的内容
+	raw    string // 原始内容，块标量使用
+}
+
+type yamlParser struct {
+	lines []*yamlLine
+	pos   int
+}
+
+func unmarshalYAML(data []byte) (ret map[string]interface{}, err error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(raw, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		text := strings.TrimLeft(raw, " ")
+		p.lines = append(p.lines, &yamlLine{num: i + 1, indent: len(raw) - len(text), text: strings.TrimSpace(stripYAMLComment(text)), raw: raw})
+	}
+
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return map[string]interface{}{}, nil
+	}
+	v, err := p.parseNode(p.lines[p.pos].indent)
+	if nil != err {
+		return
+	}
+	if p.skipBlank(); p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], "unexpected indentation")
+	}
+	ret, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("front matter is not a mapping")
+	}
+	return
+}
+
+func (p *yamlParser) skipBlank() {
+	for ; p.pos < len(p.lines) && "" == p.lines[p.pos].text; p.pos++ {
+	}
+}
+
+func (p *yamlParser) errorf(line *yamlLine, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", line.num, fmt.Sprintf(format, args...))
+}
+
+// parseNode 解析缩进为 indent 的块映射、块序列或者标量。
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	line := p.lines[p.pos]
+	if isYAMLSeqItem(line.text) {
+		return p.parseSeq(indent)
+	}
+	if _, _, ok := splitYAMLKey(line.text); ok {
+		return p.parseMap(indent)
+	}
+	p.pos++
+	return p.parseValue(line, line.text, indent-1)
+}
+
+func (p *yamlParser) parseMap(indent int) (interface{}, error) {
+	ret := map[string]interface{}{}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, p.errorf(line, "expected a mapping key")
+		}
+		if _, exists := ret[key]; exists {
+			return nil, p.errorf(line, "duplicate key [%s]", key)
+		}
+		p.pos++
+		v, err := p.parseValue(line, rest, indent)
+		if nil != err {
+			return nil, err
+		}
+		ret[key] = v
+	}
+	return ret, nil
+}
+
+func (p *yamlParser) parseSeq(indent int) (interface{}, error) {
+	ret := []interface{}{}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		line := p.lines[p.pos]
+		if line.indent < indent || !isYAMLSeqItem(line.text) {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+
+		rest := strings.TrimSpace(line.text[1:])
+		if _, _, ok := splitYAMLKey(rest); ok || isYAMLSeqItem(rest) {
+			// - key: value 和 - - item 将 - 之后的内容作为缩进更深的一行继续解析
+			offset := len(line.text) - len(strings.TrimLeft(line.text[1:], " "))
+			p.lines[p.pos] = &yamlLine{num: line.num, indent: indent + offset, text: rest, raw: line.raw}
+			v, err := p.parseNode(indent + offset)
+			if nil != err {
+				return nil, err
+			}
+			ret = append(ret, v)
+			continue
+		}
+		p.pos++
+		v, err := p.parseValue(line, rest, indent)
+		if nil != err {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+// parseValue 解析缩进为 indent 的键或者序列项之后的值 rest，值为空时解析下面缩进更深的块。
+func (p *yamlParser) parseValue(line *yamlLine, rest string, indent int) (interface{}, error) {
+	if "" == rest {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return nil, nil
+		}
+		next := p.lines[p.pos]
+		if next.indent > indent || (next.indent == indent && isYAMLSeqItem(next.text)) {
+			return p.parseNode(next.indent)
+		}
+		return nil, nil
+	}
+
+	switch rest[0] {
+	case '|', '>':
+		return p.parseBlockScalar(line, rest, indent)
+	case '[', '{':
+		// 流式集合可以跨越多行
+		text := rest
+		for !flowBalanced(text) && p.pos < len(p.lines) {
+			text += " " + p.lines[p.pos].text
+			p.pos++
+		}
+		f := &yamlFlow{s: text, line: line}
+		v, err := f.parse()
+		if nil != err {
+			return nil, err
+		}
+		if f.skipSpace(); f.i < len(f.s) {
+			return nil, p.errorf(line, "unexpected [%s] after flow collection", f.s[f.i:])
+		}
+		return v, nil
+	case '"', '\'':
+		f := &yamlFlow{s: rest, line: line}
+		v, err := f.parseQuoted()
+		if nil != err {
+			return nil, err
+		}
+		if f.skipSpace(); f.i < len(f.s) {
+			return nil, p.errorf(line, "unexpected [%s] after quoted string", f.s[f.i:])
+		}
+		return v, nil
+	case '&', '*', '!':
+		return nil, p.errorf(line, "anchors, aliases and tags are not supported")
+	}
+
+	// 多行纯量，后续缩进更深的行使用空格连接
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if "" == next.text || next.indent <= indent {
+			break
+		}
+		rest += " " + next.text
+		p.pos++
+	}
+	return resolveYAMLScalar(rest), nil
+}
+
+// parseBlockScalar 解析 | 和 > 块标量。
+func (p *yamlParser) parseBlockScalar(line *yamlLine, header string, indent int) (interface{}, error) {
+	folded := '>' == header[0]
+	chomp := byte(0)
+	for _, c := range header[1:] {
+		if '-' == c || '+' == c {
+			chomp = byte(c)
+		} else if ' ' != c {
+			return nil, p.errorf(line, "unsupported block scalar header [%s]", header)
+		}
+	}
+
+	var lines []string
+	contentIndent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		next := p.lines[p.pos]
+		if "" == strings.TrimSpace(next.raw) {
+			lines = append(lines, "")
+			continue
+		}
+		if next.indent <= indent {
+			break
+		}
+		if 0 > contentIndent {
+			contentIndent = next.indent
+		}
+		if next.indent < contentIndent {
+			break
+		}
+		lines = append(lines, next.raw[contentIndent:])
+	}
+
+	// 末尾空行由 chomp 决定
+	trailing := 0
+	for ; 0 < len(lines) && "" == lines[len(lines)-1]; lines = lines[:len(lines)-1] {
+		trailing++
+	}
+	var ret string
+	if folded {
+		buf := &strings.Builder{}
+		for i, l := range lines {
+			if 0 < i {
+				if "" == l || "" == lines[i-1] || strings.HasPrefix(l, " ") {
+					buf.WriteByte('\n')
+				} else {
+					buf.WriteByte(' ')
+				}
+			}
+			buf.WriteString(l)
+		}
+		ret = buf.String()
+	} else {
+		ret = strings.Join(lines, "\n")
+	}
+	switch chomp {
+	case '-':
+	case '+':
+		ret += "\n" + strings.Repeat("\n", trailing)
+	default:
+		if 0 < len(lines) {
+			ret += "\n"
+		}
+	}
+	return ret, nil
+}
+
+func isYAMLSeqItem(text string) bool {
+	return "-" == text || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey 将 key: value 形式的 text 拆分为键和值。
+func splitYAMLKey(text string) (key, value string, ok bool) {
+	if "" == text || strings.ContainsRune("[{#&*!|>%@`", rune(text[0])) || isYAMLSeqItem(text) {
+		return
+	}
+
+	if '"' == text[0] || '\'' == text[0] {
+		f := &yamlFlow{s: text}
+		k, err := f.parseQuoted()
+		if nil != err {
+			return
+		}
+		rest := text[f.i:]
+		if ":" != rest && !strings.HasPrefix(rest, ": ") {
+			return
+		}
+		return k.(string), strings.TrimSpace(rest[1:]), true
+	}
+
+	idx := strings.Index(text, ": ")
+	if 0 > idx {
+		if !strings.HasSuffix(text, ":") {
+			return
+		}
+		idx = len(text) - 1
+	}
+	if key = strings.TrimSpace(text[:idx]); "" == key {
+		return
+	}
+	return key, strings.TrimSpace(text[idx+1:]), true
+}
+
+// stripYAMLComment 去掉 text 中引号之外的 # 注释。
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case 0 != quote:
+			if '\\' == c && '"' == quote {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case '"' == c || '\'' == c:
+			if 0 == i || strings.ContainsRune(" [{,:", rune(text[i-1])) {
+				quote = c
+			}
+		case '#' == c:
+			if 0 == i || ' ' == text[i-1] || '\t' == text[i-1] {
+				return text[:i]
+			}
+		}
+	}
+	return text
+}
+
+// flowBalanced 判断流式集合 text 的括号是否已经闭合。
+func flowBalanced(text string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case 0 != quote:
+			if '\\' == c && '"' == quote {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case '"' == c || '\'' == c:
+			quote = c
+		case '[' == c || '{' == c:
+			depth++
+		case ']' == c || '}' == c:
+			depth--
+		}
+	}
+	return 0 >= depth
+}
+
+// yamlFlow 用于解析流式集合和引号字符串。
+type yamlFlow struct {
+	s    string
+	i    int
+	line *yamlLine
+}
+
+func (f *yamlFlow) errorf(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if nil != f.line {
+		return fmt.Errorf("line %d: %s", f.line.num, msg)
+	}
+	return errors.New(msg)
+}
+
+func (f *yamlFlow) skipSpace() {
+	for ; f.i < len(f.s) && (' ' == f.s[f.i] || '\t' == f.s[f.i]); f.i++ {
+	}
+}
+
+func (f *yamlFlow) parse() (interface{}, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, f.errorf("unexpected end of flow collection")
+	}
+	switch f.s[f.i] {
+	case '[':
+		return f.parseCollection(']')
+	case '{':
+		return f.parseCollection('}')
+	case '"', '\'':
+		return f.parseQuoted()
+	}
+
+	start := f.i
+	for ; f.i < len(f.s) && !strings.ContainsRune(",]}", rune(f.s[f.i])); f.i++ {
+		if ':' == f.s[f.i] && (f.i+1 == len(f.s) || ' ' == f.s[f.i+1]) {
+			break
+		}
+	}
+	return resolveYAMLScalar(strings.TrimSpace(f.s[start:f.i])), nil
+}
+
+func (f *yamlFlow) parseCollection(end byte) (interface{}, error) {
+	f.i++ // 跳过 [ 或者 {
+	seq := []interface{}{}
+	m := map[string]interface{}{}
+	for {
+		f.skipSpace()
+		if f.i >= len(f.s) {
+			return nil, f.errorf("unclosed flow collection")
+		}
+		if end == f.s[f.i] {
+			f.i++
+			break
+		}
+
+		v, err := f.parse()
+		if nil != err {
+			return nil, err
+		}
+		if f.skipSpace(); '}' == end {
+			if f.i >= len(f.s) || ':' != f.s[f.i] {
+				return nil, f.errorf("expected [:] in flow mapping")
+			}
+			f.i++
+			value, err := f.parse()
+			if nil != err {
+				return nil, err
+			}
+			m[fmt.Sprint(v)] = value
+		} else {
+			seq = append(seq, v)
+		}
+
+		if f.skipSpace(); f.i < len(f.s) && ',' == f.s[f.i] {
+			f.i++
+		} else if f.i >= len(f.s) || end != f.s[f.i] {
+			return nil, f.errorf("expected [,] or [%c] in flow collection", end)
+		}
+	}
+	if '}' == end {
+		return m, nil
+	}
+	return seq, nil
+}
+
+func (f *yamlFlow) parseQuoted() (interface{}, error) {
+	q := f.s[f.i]
+	f.i++
+	buf := &strings.Builder{}
+	for ; f.i < len(f.s); f.i++ {
+		c := f.s[f.i]
+		if '\'' == q && '\'' == c {
+			if f.i+1 < len(f.s) && '\'' == f.s[f.i+1] {
+				buf.WriteByte('\'')
+				f.i++
+				continue
+			}
+			f.i++
+			return buf.String(), nil
+		}
+		if '"' == q {
+			if '\\' == c && f.i+1 < len(f.s) {
+				buf.WriteByte(c)
+				buf.WriteByte(f.s[f.i+1])
+				f.i++
+				continue
+			}
+			if '"' == c {
+				f.i++
+				ret, err := unquote(buf.String())
+				if nil != err {
+					return nil, f.errorf("%s", err)
+				}
+				return ret, nil
+			}
+		}
+		buf.WriteByte(c)
+	}
+	return nil, f.errorf("unclosed quoted string")
+}
+
+var (
+	yamlIntRegexp   = regexp.MustCompile(`^[-+]?[0-9]+$`) // 与 YAML 1.2 核心模式一致，允许前导零
+	yamlFloatRegexp = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAMLScalar 将纯量 s 解析为对应类型的值。
+func resolveYAMLScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	if yamlIntRegexp.MatchString(s) {
+		if i, err := strconv.ParseInt(s, 10, 64); nil == err {
+			return i
+		}
+	}
+	if strings.HasPrefix(s, "0x") {
+		if i, err := strconv.ParseInt(s[2:], 16, 64); nil == err {
+			return i
+		}
+	}
+	if strings.HasPrefix(s, "0o") {
+		if i, err := strconv.ParseInt(s[2:], 8, 64); nil == err {
+			return i
+		}
+	}
+	if yamlFloatRegexp.MatchString(s) {
+		if f, err := strconv.ParseFloat(s, 64); nil == err {
+			return f
+		}
+	}
+	if t, ok := parseTime(s); ok {
+		return t
+	}
+	return s
+}
+
+func marshalYAML(buf *bytes.Buffer, m map[string]interface{}, indent int) error {
+	for _, k := range sortedKeys(m) {
+		buf.WriteString(strings.Repeat(" ", indent) + yamlString(k) + ":")
+		if err := marshalYAMLValue(buf, m[k], indent); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// marshalYAMLValue 输出键或者序列项之后的值 v，indent 为键或者序列项的缩进。
+func marshalYAMLValue(buf *bytes.Buffer, v interface{}, indent int) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if 0 == len(val) {
+			buf.WriteString(" {}\n")
+			return nil
+		}
+		buf.WriteByte('\n')
+		return marshalYAML(buf, val, indent+2)
+	case []interface{}:
+		if 0 == len(val) {
+			buf.WriteString(" []\n")
+			return nil
+		}
+		buf.WriteByte('\n')
+		for _, e := range val {
+			buf.WriteString(strings.Repeat(" ", indent+2) + "-")
+			if m, ok := e.(map[string]interface{}); ok && 0 < len(m) {
+				// - key: value，第一个键与 - 同行
+				item := &bytes.Buffer{}
+				if err := marshalYAML(item, m, indent+4); nil != err {
+					return err
+				}
+				buf.WriteString(" " + strings.TrimLeft(item.String(), " "))
+				continue
+			}
+			if err := marshalYAMLValue(buf, e, indent+2); nil != err {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s, err := yamlScalar(v)
+	if nil != err {
+		return err
+	}
+	buf.WriteString(" " + s + "\n")
+	return nil
+}
+
+func yamlScalar(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return yamlString(val), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case int:
+		return strconv.Itoa(val), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case float64:
+		return formatFloat(val, ".inf", ".nan"), nil
+	case time.Time:
+		return formatTime(val), nil
+	}
+	return "", fmt.Errorf("unsupported front matter value type [%T]", v)
+}
+
+// yamlString 返回字符串 s 在 YAML 中的表示，不能使用纯量表示时使用双引号。
+func yamlString(s string) string {
+	if "" == s || s != strings.TrimSpace(s) || strings.ContainsAny(s, "\n\t\"\\") || strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.HasSuffix(s, ":") || strings.ContainsRune("-?:,[]{}#&*!|>'%@`", rune(s[0])) {
+		return quote(s)
+	}
+	if resolved, ok := resolveYAMLScalar(s).(string); !ok || resolved != s {
+		return quote(s)
+	}
+	for _, r := range s {
+		if r < 0x20 || 0x7F == r {
+			return quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,623 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package graph 提供了跨文档的链接关系图，用于维护文档、标题和内容块之间的正向链接、反向链接、未解析链接以及孤立文档。
+package graph
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+	"github.com/88250/lute/util"
+)
+
+// NodeKind 描述了关系图节点类型。
+type NodeKind string
+
+const (
+	KindDoc        NodeKind = "doc"        // 文档
+	KindHeading    NodeKind = "heading"    // 标题
+	KindBlock      NodeKind = "block"      // 内容块
+	KindAnnotation NodeKind = "annotation" // 文件注解，仅在被引用时出现
+)
+
+// LinkKind 描述了链接类型。
+type LinkKind string
+
+const (
+	LinkWikilink          LinkKind = "wikilink"            // [[文档#标题]]
+	LinkBlockRef          LinkKind = "block-ref"           // ((id "锚文本"))
+	LinkFileAnnotationRef LinkKind = "file-annotation-ref" // <<file/annotation "锚文本">>
+	LinkMarkdown          LinkKind = "link"                // [text](other.md#heading)
+)
+
+// Node 描述了关系图中的节点。
+type Node struct {
+	ID    string   `json:"id"`              // 节点 ID，文档为文档 ID，标题和内容块优先使用块 ID
+	Kind  NodeKind `json:"kind"`            // 节点类型
+	Doc   string   `json:"doc,omitempty"`   // 所属文档 ID
+	Title string   `json:"title,omitempty"` // 文档标题或者标题文本
+	Level int      `json:"level,omitempty"` // 标题级别
+}
+
+// Link 描述了一条链接。
+type Link struct {
+	Kind      LinkKind `json:"kind"`             // 链接类型
+	Source    string   `json:"source"`           // 链接所在的节点 ID
+	SourceDoc string   `json:"sourceDoc"`        // 链接所在的文档 ID
+	Raw       string   `json:"raw"`              // 原始链接目标
+	Target    string   `json:"target,omitempty"` // 解析后的目标节点 ID，未解析时为空
+
+	seq int // 链接在文档中的次序，用于稳定排序
+}
+
+// Resolved 判断链接是否已经解析到关系图中的节点。
+func (link *Link) Resolved() bool {
+	return "" != link.Target
+}
+
+// Graph 描述了链接关系图。文档的增删改都是增量的，链接解析在查询时按需进行，并且只重新解析受变动文档影响的链接：
+// 变动文档中的链接、指向变动文档或者与变动文档同名、同路径的文档的链接，以及有文档加入时所有未解析的链接。
+type Graph struct {
+	docs  map[string]*doc  // 文档 ID -> 文档索引
+	nodes map[string]*Node // 节点 ID -> 节点
+
+	titles      map[string]map[string]bool // 规范化后的文档名 -> 文档 ID 集合
+	paths       map[string]string          // 文档路径 -> 文档 ID
+	annotations map[string]int             // 文件注解节点 ID -> 引用该文件注解的文档数
+	backlinks   map[string][]*Link         // 目标节点 ID -> 链接
+	unresolved  map[*Link]bool             // 未解析的链接
+
+	stale        map[*Link]bool  // 需要重新解析的链接
+	changedNames map[string]bool // 有文档加入或者移除的文档名
+	changedPaths map[string]bool // 有文档加入或者移除的文档路径
+	added        bool            // 标识是否有文档加入，这时所有未解析的链接都需要重新解析
+
+	options *render.Options // 渲染选项，用于按照其中的 Slugger 生成标题锚点
+
+	lock sync.Mutex
+}
+
+// doc 描述了单个文档的索引结果。
+type doc struct {
+	node     *Node
+	path     string
+	names    []string
+	anchors  map[string]string    // 标题文本或者锚点 -> 标题节点 ID
+	nodes    []*Node              // 文档下的标题和内容块
+	headings map[*ast.Node]string // 标题节点 -> 标题锚点，仅在索引时使用
+	links    []*Link
+	external map[string]*Node // 文件注解节点
+}
+
+// New 创建一个空的链接关系图。options 为渲染时使用的选项，标题锚点按照其中的 Slugger 生成，为 nil 时使用默认渲染选项。
+func New(options *render.Options) *Graph {
+	return &Graph{docs: map[string]*doc{}, nodes: map[string]*Node{}, options: options,
+		titles: map[string]map[string]bool{}, paths: map[string]string{}, annotations: map[string]int{}, backlinks: map[string][]*Link{}, unresolved: map[*Link]bool{},
+		stale: map[*Link]bool{}, changedNames: map[string]bool{}, changedPaths: map[string]bool{}}
+}
+
+// DocID 返回 tree 在关系图中使用的文档 ID，依次使用 tree.ID、tree.Path 和 tree.Name。
+func DocID(tree *parse.Tree) string {
+	if "" != tree.ID {
+		return tree.ID
+	}
+	if "" != tree.Path {
+		return tree.Path
+	}
+	return tree.Name
+}
+
+// Add 索引 tree，如果关系图中已经存在该文档则替换。
+func (g *Graph) Add(tree *parse.Tree) {
+	d := index(tree, g.options)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.remove(d.node.ID)
+	g.docs[d.node.ID] = d
+	g.nodes[d.node.ID] = d.node
+	for _, n := range d.nodes {
+		g.nodes[n.ID] = n
+	}
+	for _, name := range d.names {
+		if nil == g.titles[name] {
+			g.titles[name] = map[string]bool{}
+		}
+		g.titles[name][d.node.ID] = true
+		g.changedNames[name] = true
+	}
+	if "" != d.path {
+		g.paths[d.path] = d.node.ID
+		g.changedPaths[d.path] = true
+	}
+	for id, n := range d.external {
+		if g.annotations[id]++; 1 == g.annotations[id] {
+			g.nodes[id] = n
+		}
+	}
+	for _, link := range d.links {
+		g.stale[link] = true
+	}
+	g.added = true
+}
+
+// Remove 从关系图中移除 ID 为 docID 的文档。
+func (g *Graph) Remove(docID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.remove(docID)
+}
+
+func (g *Graph) remove(docID string) {
+	d := g.docs[docID]
+	if nil == d {
+		return
+	}
+
+	// 指向该文档的链接需要重新解析
+	for _, link := range g.backlinks[docID] {
+		g.stale[link] = true
+	}
+	for _, n := range d.nodes {
+		for _, link := range g.backlinks[n.ID] {
+			g.stale[link] = true
+		}
+	}
+
+	delete(g.nodes, docID)
+	for _, n := range d.nodes {
+		if g.nodes[n.ID] == n {
+			delete(g.nodes, n.ID)
+		}
+	}
+	for _, name := range d.names {
+		if delete(g.titles[name], docID); 0 == len(g.titles[name]) {
+			delete(g.titles, name)
+		}
+		g.changedNames[name] = true
+	}
+	if "" != d.path && docID == g.paths[d.path] {
+		delete(g.paths, d.path)
+		g.changedPaths[d.path] = true
+	}
+	for id := range d.external {
+		if g.annotations[id]--; 0 == g.annotations[id] {
+			delete(g.annotations, id)
+			delete(g.nodes, id)
+		}
+	}
+	for _, link := range d.links {
+		g.detach(link)
+		delete(g.stale, link)
+	}
+	delete(g.docs, docID)
+}
+
+// Node 返回 ID 为 id 的节点，不存在时返回 nil。
+func (g *Graph) Node(id string) *Node {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+	return g.nodes[id]
+}
+
+// Docs 返回所有文档节点，按 ID 排序。
+func (g *Graph) Docs() (ret []*Node) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, d := range g.docs {
+		ret = append(ret, d.node)
+	}
+	sortNodes(ret)
+	return
+}
+
+// Links 返回从 id 指定节点出发的链接。id 为文档 ID 时返回该文档中的所有链接。返回的链接是副本，修改它们不会影响关系图。
+func (g *Graph) Links(id string) (ret []*Link) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+
+	if d := g.docs[id]; nil != d {
+		return copyLinks(d.links)
+	}
+	if n := g.nodes[id]; nil != n {
+		if d := g.docs[n.Doc]; nil != d {
+			for _, link := range d.links {
+				if id == link.Source {
+					ret = append(ret, link)
+				}
+			}
+		}
+	}
+	return copyLinks(ret)
+}
+
+// Backlinks 返回指向 id 指定节点的链接。id 为文档 ID 时同时返回指向该文档中标题和内容块的链接。返回的链接是副本。
+func (g *Graph) Backlinks(id string) (ret []*Link) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+
+	ret = append(ret, g.backlinks[id]...)
+	if d := g.docs[id]; nil != d {
+		for _, n := range d.nodes {
+			if n.ID != id {
+				ret = append(ret, g.backlinks[n.ID]...)
+			}
+		}
+	}
+	ret = copyLinks(ret)
+	sortLinks(ret)
+	return
+}
+
+// Unresolved 返回所有未能解析到目标的链接。返回的链接是副本。
+func (g *Graph) Unresolved() (ret []*Link) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+
+	for link := range g.unresolved {
+		ret = append(ret, link)
+	}
+	ret = copyLinks(ret)
+	sortLinks(ret)
+	return
+}
+
+// Orphans 返回没有被其他文档链接到的文档。
+func (g *Graph) Orphans() (ret []*Node) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+
+	for id, d := range g.docs {
+		linked := false
+		for _, link := range g.backlinks[id] {
+			if id != link.SourceDoc {
+				linked = true
+				break
+			}
+		}
+		for i := 0; !linked && i < len(d.nodes); i++ {
+			for _, link := range g.backlinks[d.nodes[i].ID] {
+				if id != link.SourceDoc {
+					linked = true
+					break
+				}
+			}
+		}
+		if !linked {
+			ret = append(ret, d.node)
+		}
+	}
+	sortNodes(ret)
+	return
+}
+
+// MarshalJSON 将关系图导出为 {"nodes": [...], "links": [...]} 形式的 JSON。
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.resolve()
+
+	nodes := make([]*Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sortNodes(nodes)
+	links := []*Link{}
+	for _, d := range g.docs {
+		links = append(links, d.links...)
+	}
+	sortLinks(links)
+	return json.Marshal(map[string]interface{}{"nodes": nodes, "links": links})
+}
+
+// resolve 重新解析受变动文档影响的链接。
+func (g *Graph) resolve() {
+	// 与变动文档同名或者同路径的文档可能改变了文档名、路径的解析结果，指向这些文档的链接需要重新解析
+	affected := map[string]bool{}
+	for name := range g.changedNames {
+		for id := range g.titles[name] {
+			affected[id] = true
+		}
+	}
+	for p := range g.changedPaths {
+		if id, ok := g.paths[p]; ok {
+			affected[id] = true
+		}
+	}
+	for id := range affected {
+		d := g.docs[id]
+		for _, link := range g.backlinks[id] {
+			g.stale[link] = true
+		}
+		for _, n := range d.nodes {
+			for _, link := range g.backlinks[n.ID] {
+				g.stale[link] = true
+			}
+		}
+	}
+	if g.added {
+		for link := range g.unresolved {
+			g.stale[link] = true
+		}
+	}
+
+	for link := range g.stale {
+		g.detach(link)
+		link.Target = g.resolveLink(g.docs[link.SourceDoc], link)
+		if "" != link.Target {
+			g.backlinks[link.Target] = append(g.backlinks[link.Target], link)
+		} else {
+			g.unresolved[link] = true
+		}
+	}
+	g.stale, g.changedNames, g.changedPaths, g.added = map[*Link]bool{}, map[string]bool{}, map[string]bool{}, false
+}
+
+// detach 从反向链接和未解析链接中移除 link。
+func (g *Graph) detach(link *Link) {
+	delete(g.unresolved, link)
+	if "" == link.Target {
+		return
+	}
+
+	links := g.backlinks[link.Target]
+	for i, l := range links {
+		if l == link {
+			links = append(links[:i], links[i+1:]...)
+			break
+		}
+	}
+	if 0 == len(links) {
+		delete(g.backlinks, link.Target)
+	} else {
+		g.backlinks[link.Target] = links
+	}
+}
+
+// titleDoc 返回文档名为 name 的文档 ID，有多个同名文档时返回 ID 最小的。
+func (g *Graph) titleDoc(name string) (ret string) {
+	for id := range g.titles[name] {
+		if "" == ret || id < ret {
+			ret = id
+		}
+	}
+	return
+}
+
+func (g *Graph) resolveLink(source *doc, link *Link) string {
+	switch link.Kind {
+	case LinkBlockRef:
+		if _, ok := g.nodes[link.Raw]; ok {
+			return link.Raw
+		}
+		return ""
+	case LinkFileAnnotationRef:
+		return link.Raw
+	case LinkWikilink:
+		page, anchor := splitWikilink(link.Raw)
+		target := source
+		if "" != page {
+			target = g.docs[g.titleDoc(normalizeName(page))]
+		}
+		return resolveAnchor(target, anchor)
+	case LinkMarkdown:
+		dest, anchor := link.Raw, ""
+		if idx := strings.Index(dest, "#"); 0 <= idx {
+			dest, anchor = dest[:idx], dest[idx+1:]
+		}
+		target := source
+		if "" != dest {
+			p := path.Join(path.Dir(source.path), dest)
+			if unescaped, err := util.PathUnescape(p); nil == err {
+				p = unescaped
+			}
+			target = g.docs[g.paths[p]]
+		}
+		return resolveAnchor(target, anchor)
+	}
+	return ""
+}
+
+// resolveAnchor 在文档 d 中查找锚点 anchor，anchor 为空时返回文档 ID，以 ^ 开头时按块 ID 查找。
+func resolveAnchor(d *doc, anchor string) string {
+	if nil == d {
+		return ""
+	}
+	if "" == anchor {
+		return d.node.ID
+	}
+	if strings.HasPrefix(anchor, "^") {
+		id := anchor[1:]
+		for _, n := range d.nodes {
+			if id == n.ID {
+				return id
+			}
+		}
+		return ""
+	}
+	if id, ok := d.anchors[anchor]; ok {
+		return id
+	}
+	return d.anchors[strings.ToLower(anchor)]
+}
+
+// splitWikilink 将 [[文档#标题|别名]] 或者 [[文档^块 ID]] 的链接目标拆分为文档名和锚点。
+func splitWikilink(raw string) (page, anchor string) {
+	if idx := strings.Index(raw, "|"); 0 <= idx {
+		raw = raw[:idx]
+	}
+	if idx := strings.Index(raw, "#"); 0 <= idx {
+		return strings.TrimSpace(raw[:idx]), strings.TrimSpace(raw[idx+1:])
+	}
+	if idx := strings.Index(raw, "^"); 0 <= idx {
+		return strings.TrimSpace(raw[:idx]), strings.TrimSpace(raw[idx:])
+	}
+	return strings.TrimSpace(raw), ""
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// index 遍历 tree 收集文档中的标题、内容块和链接。
+func index(tree *parse.Tree, options *render.Options) (ret *doc) {
+	docID := DocID(tree)
+	title := tree.Root.IALAttr("title")
+	base := strings.TrimSuffix(path.Base(tree.Path), path.Ext(tree.Path))
+	if "" == title {
+		title = tree.Name
+	}
+	if "" == title && "." != base && "/" != base {
+		title = base
+	}
+
+	ret = &doc{
+		node:     &Node{ID: docID, Kind: KindDoc, Title: title},
+		path:     strings.TrimPrefix(tree.Path, "/"),
+		anchors:  map[string]string{},
+		external: map[string]*Node{},
+		headings: render.HeadingIDs(tree.Root, options),
+	}
+	for _, name := range []string{title, tree.Name, base, strings.TrimSuffix(ret.path, path.Ext(ret.path))} {
+		if name = normalizeName(name); "" != name && "." != name {
+			ret.names = append(ret.names, name)
+		}
+	}
+
+	var sources []*ast.Node // 链接来源块栈
+	var sourceIDs []string
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if n.IsBlock() && ast.NodeDocument != n.Type && ast.NodeKramdownBlockIAL != n.Type {
+			if entering {
+				if node := ret.indexBlock(n); nil != node {
+					sources = append(sources, n)
+					sourceIDs = append(sourceIDs, node.ID)
+				}
+			} else if last := len(sources) - 1; 0 <= last && n == sources[last] {
+				sources, sourceIDs = sources[:last], sourceIDs[:last]
+			}
+			return ast.WalkContinue
+		}
+		if !entering {
+			return ast.WalkContinue
+		}
+
+		source := docID
+		if 0 < len(sourceIDs) {
+			source = sourceIDs[len(sourceIDs)-1]
+		}
+		link := &Link{Source: source, SourceDoc: docID, seq: len(ret.links)}
+		switch n.Type {
+		case ast.NodeWikilink:
+			link.Kind, link.Raw = LinkWikilink, util.BytesToStr(n.LinkRefLabel)
+		case ast.NodeBlockRef:
+			if id := n.ChildByType(ast.NodeBlockRefID); nil != id {
+				link.Kind, link.Raw = LinkBlockRef, util.BytesToStr(id.Tokens)
+			}
+		case ast.NodeFileAnnotationRef:
+			if id := n.ChildByType(ast.NodeFileAnnotationRefID); nil != id {
+				link.Kind, link.Raw = LinkFileAnnotationRef, util.BytesToStr(id.Tokens)
+			}
+		case ast.NodeTextMark:
+			if n.IsTextMarkType("block-ref") {
+				link.Kind, link.Raw = LinkBlockRef, n.TextMarkBlockRefID
+			} else if n.IsTextMarkType("file-annotation-ref") {
+				link.Kind, link.Raw = LinkFileAnnotationRef, n.TextMarkFileAnnotationRefID
+			} else if n.IsTextMarkType("a") && isDocLink(n.TextMarkAHref) {
+				link.Kind, link.Raw = LinkMarkdown, n.TextMarkAHref
+			}
+		case ast.NodeLink:
+			if dest := n.ChildByType(ast.NodeLinkDest); nil != dest && isDocLink(util.BytesToStr(dest.Tokens)) {
+				link.Kind, link.Raw = LinkMarkdown, util.BytesToStr(dest.Tokens)
+			}
+		}
+		if "" != link.Kind && "" != link.Raw {
+			ret.links = append(ret.links, link)
+			if LinkFileAnnotationRef == link.Kind {
+				ret.external[link.Raw] = &Node{ID: link.Raw, Kind: KindAnnotation}
+			}
+		}
+		return ast.WalkContinue
+	})
+	ret.headings = nil // 不再引用语法树
+	return
+}
+
+// indexBlock 为块级节点 n 创建关系图节点，没有块 ID 的非标题块返回 nil。
+func (d *doc) indexBlock(n *ast.Node) (ret *Node) {
+	if ast.NodeHeading == n.Type {
+		anchor := d.headings[n]
+		id := n.ID
+		if "" == id {
+			id = d.node.ID + "#" + anchor
+		}
+		text := strings.TrimSpace(n.Text())
+		ret = &Node{ID: id, Kind: KindHeading, Doc: d.node.ID, Title: text, Level: n.HeadingLevel}
+		for _, key := range []string{anchor, text, strings.ToLower(anchor), strings.ToLower(text)} {
+			if _, ok := d.anchors[key]; !ok {
+				d.anchors[key] = id
+			}
+		}
+	} else if "" != n.ID {
+		ret = &Node{ID: n.ID, Kind: KindBlock, Doc: d.node.ID}
+	}
+	if nil != ret {
+		d.nodes = append(d.nodes, ret)
+	}
+	return
+}
+
+// isDocLink 判断 dest 是否为指向其他 Markdown 文档的相对链接。
+func isDocLink(dest string) bool {
+	if "" == dest || '#' == dest[0] || !util.IsRelativePath([]byte(dest)) || strings.Contains(dest, ":") {
+		return false
+	}
+	if idx := strings.Index(dest, "#"); 0 <= idx {
+		dest = dest[:idx]
+	}
+	return strings.EqualFold(".md", path.Ext(dest))
+}
+
+// copyLinks 返回 links 的副本，关系图中的链接在重新解析时会被修改，所以不能直接返回给调用方。
+func copyLinks(links []*Link) (ret []*Link) {
+	for _, link := range links {
+		copied := *link
+		ret = append(ret, &copied)
+	}
+	return
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+}
+
+func sortLinks(links []*Link) {
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].SourceDoc != links[j].SourceDoc {
+			return links[i].SourceDoc < links[j].SourceDoc
+		}
+		if links[i].Source != links[j].Source {
+			return links[i].Source < links[j].Source
+		}
+		return links[i].seq < links[j].seq
+	})
+}
@@ -30,6 +30,7 @@ func (lute *Lute) HTML2Markdown(htmlStr string) (markdown string, err error) {
 	//fmt.Println(htmlStr)
 	// 将字符串解析为 DOM 树
 	tree := lute.HTML2Tree(htmlStr)
+	lute.transform(tree)
 
 	// 将 AST 进行 Markdown 格式化渲染
 	var formatted []byte
@@ -51,6 +52,7 @@ func (lute *Lute) HTML2Tree(dom string) (ret *parse.Tree) {
 
 	// 调整 DOM 结构
 	lute.adjustVditorDOM(htmlRoot)
+	lute.adjustSuperBlockDOM(htmlRoot)
 
 	// 将 HTML 树转换为 Markdown AST
 	ret = &parse.Tree{Name: "", Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: lute.ParseOptions}}
@@ -141,6 +143,22 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 			break
 		}
 
+		if layout := lute.superBlockLayout(n); "" != layout {
+			// 还原渲染为布局容器的超级块
+			node.Type = ast.NodeSuperBlock
+			node.Tokens = nil
+			node.AppendChild(&ast.Node{Type: ast.NodeSuperBlockOpenMarker})
+			node.AppendChild(&ast.Node{Type: ast.NodeSuperBlockLayoutMarker, Tokens: []byte(layout)})
+			tree.Context.Tip.AppendChild(node)
+			tree.Context.Tip = node
+			for c := n.FirstChild; nil != c; c = c.NextSibling {
+				lute.genASTByDOM(c, tree)
+			}
+			node.AppendChild(&ast.Node{Type: ast.NodeSuperBlockCloseMarker})
+			tree.Context.ParentTip()
+			return
+		}
+
 		if atom.Div == n.DataAtom {
 			// 解析 GitHub 语法高亮代码块
 			class := util.DomAttrValue(n, "class")
@@ -587,6 +605,84 @@ func (lute *Lute) genASTByDOM(n *html.Node, tree *parse.Tree) {
 	}
 }
 
+// superBlockLayout 返回 DOM 节点 n 作为超级块布局容器时的布局，n 不是超级块布局容器时返回空字符串。
+//
+// 通过 data-sb-layout 属性或者 RenderOptions.SuperBlockClass 生成的 class 识别。
+func (lute *Lute) superBlockLayout(n *html.Node) string {
+	if atom.Div != n.DataAtom && atom.Table != n.DataAtom {
+		return ""
+	}
+
+	switch layout := util.DomAttrValue(n, "data-sb-layout"); layout {
+	case render.SuperBlockLayoutRow, render.SuperBlockLayoutCol:
+		return layout
+	}
+
+	if prefix := lute.RenderOptions.SuperBlockClass; "" != prefix {
+		for _, class := range strings.Fields(util.DomAttrValue(n, "class")) {
+			switch class {
+			case prefix + "--" + render.SuperBlockLayoutRow:
+				return render.SuperBlockLayoutRow
+			case prefix + "--" + render.SuperBlockLayoutCol:
+				return render.SuperBlockLayoutCol
+			}
+		}
+	}
+	return ""
+}
+
+// adjustSuperBlockDOM 将导出为一行多列表格的横向超级块还原为 div 容器。
+// 单元格中只有一个元素时直接提升该元素，有多个元素时将单元格作为纵向超级块，没有元素时将单元格作为段落。
+func (lute *Lute) adjustSuperBlockDOM(n *html.Node) {
+	for c := n.FirstChild; nil != c; c = c.NextSibling {
+		lute.adjustSuperBlockDOM(c)
+	}
+
+	if atom.Table != n.DataAtom || "" == lute.superBlockLayout(n) {
+		return
+	}
+
+	var cells []*html.Node
+	var collect func(*html.Node)
+	collect = func(p *html.Node) {
+		for c := p.FirstChild; nil != c; c = c.NextSibling {
+			switch c.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tr:
+				collect(c)
+			case atom.Td, atom.Th:
+				cells = append(cells, c)
+			}
+		}
+	}
+	collect(n)
+
+	for c := n.FirstChild; nil != c; c = n.FirstChild {
+		n.RemoveChild(c)
+	}
+	n.DataAtom, n.Data = atom.Div, "div"
+	for _, cell := range cells {
+		cell.Parent.RemoveChild(cell)
+		var elements []*html.Node
+		for c := cell.FirstChild; nil != c; c = c.NextSibling {
+			if html.ElementNode == c.Type {
+				elements = append(elements, c)
+			}
+		}
+		if 1 == len(elements) {
+			cell.RemoveChild(elements[0])
+			n.AppendChild(elements[0])
+			continue
+		}
+		if 1 > len(elements) {
+			cell.DataAtom, cell.Data, cell.Attr = atom.P, "p", nil
+		} else {
+			cell.DataAtom, cell.Data = atom.Div, "div"
+			cell.Attr = []*html.Attribute{{Key: "data-sb-layout", Val: render.SuperBlockLayoutCol}}
+		}
+		n.AppendChild(cell)
+	}
+}
+
 func appendSpace(n *html.Node, tree *parse.Tree, lute *Lute) {
 	if nil != n.NextSibling {
 		if nextText := util.DomText(n.NextSibling); "" != nextText {
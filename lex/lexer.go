@@ -31,6 +31,16 @@ func NewLexer(input []byte) (ret *Lexer) {
 	return
 }
 
+// Offset 返回当前读取字节位置。
+func (l *Lexer) Offset() int {
+	return l.offset
+}
+
+// Input 返回预处理（统一换行符等）后的输入文本字节数组。
+func (l *Lexer) Input() []byte {
+	return l.input[:l.length]
+}
+
 // NextLine 返回下一行。
 func (l *Lexer) NextLine() (ret []byte) {
 	if l.offset >= l.length {
@@ -0,0 +1,229 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package linkcheck 提供了离线的内部链接检查，包括页内锚点、脚注、链接引用定义以及相对路径文件链接。
+package linkcheck
+
+import (
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+	"github.com/88250/lute/util"
+)
+
+// Kind 描述了检查结果类型。
+type Kind string
+
+const (
+	BrokenAnchor         Kind = "broken-anchor"          // [x](#anchor) 找不到锚点
+	UndefinedFootnote    Kind = "undefined-footnote"     // [^label] 找不到脚注定义
+	UnusedFootnote       Kind = "unused-footnote"        // [^label]: 脚注定义没有被引用
+	UndefinedLinkRef     Kind = "undefined-link-ref"     // [text][label] 找不到链接引用定义
+	MissingFile          Kind = "missing-file"           // [x](path/to/file) 文件不存在
+	BrokenDocumentAnchor Kind = "broken-document-anchor" // [x](other.md#anchor) 其他文档中找不到锚点
+	UnresolvableLinkDest Kind = "unresolvable-link-dest" // 链接地址无法解码
+)
+
+// Diagnostic 描述了一条检查结果。
+type Diagnostic struct {
+	Kind    Kind      `json:"kind"`    // 类型
+	Doc     string    `json:"doc"`     // 所在文档路径，为空时使用文档名
+	Target  string    `json:"target"`  // 有问题的链接目标或者标签
+	Message string    `json:"message"` // 描述
+	Line    int       `json:"line"`    // 行号（从 1 开始），语法树解析时没有打开 SourceSpan 时为 0
+	Column  int       `json:"column"`  // 列号（从 1 开始，按字节计算），语法树解析时没有打开 SourceSpan 时为 0
+	Node    *ast.Node `json:"-"`       // 相关节点
+}
+
+// Check 检查 tree 中的内部链接，fsys 用于检查相对路径文件链接，为 nil 时不检查文件。
+// options 为渲染时使用的选项，标题锚点按照其中的 Slugger 生成，为 nil 时使用默认渲染选项。
+func Check(tree *parse.Tree, fsys fs.FS, options *render.Options) []*Diagnostic {
+	return CheckAll([]*parse.Tree{tree}, fsys, options)
+}
+
+// CheckAll 检查 trees 中的内部链接。指向 trees 中其他文档的链接（比如 other.md#anchor）会使用该文档的标题检查锚点，
+// 文档通过 tree.Path 定位。
+func CheckAll(trees []*parse.Tree, fsys fs.FS, options *render.Options) (ret []*Diagnostic) {
+	c := &checker{fsys: fsys, anchors: map[string]map[string]bool{}}
+	for _, tree := range trees {
+		c.anchors[docPath(tree)] = Anchors(tree, options)
+	}
+	for _, tree := range trees {
+		ret = append(ret, c.check(tree)...)
+	}
+	return
+}
+
+// Anchors 返回 tree 中所有可以作为页内锚点的 ID，包括使用 options 渲染时生成的标题 ID（见 render.HeadingIDs）以及块 ID。
+func Anchors(tree *parse.Tree, options *render.Options) (ret map[string]bool) {
+	ret = map[string]bool{}
+	for _, id := range render.HeadingIDs(tree.Root, options) {
+		ret[id] = true
+	}
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !n.IsBlock() {
+			return ast.WalkContinue
+		}
+		if "" != n.ID {
+			ret[n.ID] = true
+		}
+		if id := n.IALAttr("id"); "" != id {
+			ret[id] = true
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+type checker struct {
+	fsys    fs.FS
+	anchors map[string]map[string]bool // 文档路径 -> 锚点
+}
+
+var (
+	footnoteRefRegexp = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+	linkRefRegexp     = regexp.MustCompile(`\[([^\[\]]+)\]\[([^\[\]]*)\]`)
+)
+
+func (c *checker) check(tree *parse.Tree) (ret []*Diagnostic) {
+	doc := docPath(tree)
+	report := func(kind Kind, target, msg string, n *ast.Node) {
+		ret = append(ret, &Diagnostic{Kind: kind, Doc: doc, Target: target, Message: msg, Node: n})
+	}
+
+	footnoteRefs := map[string]bool{}
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+
+		switch n.Type {
+		case ast.NodeFootnotesRef:
+			footnoteRefs[strings.ToLower(util.BytesToStr(n.Tokens))] = true
+		case ast.NodeText:
+			// 找不到定义的脚注引用和链接引用不会被解析为节点，只能从文本中识别
+			text := util.BytesToStr(n.Tokens)
+			if tree.Context.ParseOption.Footnotes {
+				for _, m := range footnoteRefRegexp.FindAllStringSubmatchIndex(text, -1) {
+					if m[1] < len(text) && ':' == text[m[1]] {
+						continue // [^label]: 是脚注定义
+					}
+					label := "^" + text[m[2]:m[3]]
+					if _, def := tree.FindFootnotesDef([]byte(label)); nil == def {
+						report(UndefinedFootnote, label, "footnote ["+label+"] is not defined", n)
+					}
+				}
+			}
+			if tree.Context.ParseOption.LinkRef {
+				for _, m := range linkRefRegexp.FindAllStringSubmatch(text, -1) {
+					if tree.Context.ParseOption.Footnotes && (strings.HasPrefix(m[1], "^") || strings.HasPrefix(m[2], "^")) {
+						continue // 相邻的脚注引用 [^1][^2]
+					}
+					label := m[2]
+					if "" == label {
+						label = m[1]
+					}
+					if nil == tree.FindLinkRefDefLink([]byte(label)) {
+						report(UndefinedLinkRef, label, "link reference definition ["+label+"] is not found", n)
+					}
+				}
+			}
+		case ast.NodeLink, ast.NodeImage:
+			if 3 == n.LinkType {
+				return ast.WalkContinue // 链接引用 [foo] 的地址在链接引用定义上检查
+			}
+			dest := n.ChildByType(ast.NodeLinkDest)
+			if nil == dest {
+				return ast.WalkContinue
+			}
+			if d := c.checkDest(doc, util.BytesToStr(dest.Tokens), n); nil != d {
+				d.Doc = doc
+				ret = append(ret, d)
+			}
+		}
+		return ast.WalkContinue
+	})
+
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeFootnotesDef == n.Type {
+			if label := util.BytesToStr(n.Tokens); !footnoteRefs[strings.ToLower(label)] {
+				report(UnusedFootnote, label, "footnote ["+label+"] is defined but never referenced", n)
+			}
+		}
+		return ast.WalkContinue
+	})
+
+	for _, d := range ret {
+		d.Line, d.Column = tree.Position(d.Node, d.Target)
+	}
+	return
+}
+
+// checkDest 检查链接地址 dest，只检查页内锚点和相对路径，带协议的链接直接忽略。
+func (c *checker) checkDest(doc, dest string, n *ast.Node) *Diagnostic {
+	if "" == dest {
+		return nil
+	}
+
+	unescaped, err := util.PathUnescape(dest)
+	if nil != err {
+		return &Diagnostic{Kind: UnresolvableLinkDest, Target: dest, Message: "link destination [" + dest + "] can not be decoded", Node: n}
+	}
+
+	file, anchor := unescaped, ""
+	if idx := strings.Index(file, "#"); 0 <= idx {
+		file, anchor = file[:idx], file[idx+1:]
+	}
+	if idx := strings.Index(file, "?"); 0 <= idx {
+		file = file[:idx]
+	}
+
+	if "" == file {
+		if "" != anchor && !c.anchors[doc][anchor] {
+			return &Diagnostic{Kind: BrokenAnchor, Target: dest, Message: "anchor [#" + anchor + "] is not found", Node: n}
+		}
+		return nil
+	}
+
+	if !util.IsRelativePath([]byte(file)) || strings.Contains(file, ":") {
+		return nil
+	}
+
+	target := path.Join(path.Dir(doc), file)
+	if anchors, ok := c.anchors[target]; ok {
+		if "" != anchor && !anchors[anchor] {
+			return &Diagnostic{Kind: BrokenDocumentAnchor, Target: dest, Message: "anchor [#" + anchor + "] is not found in [" + target + "]", Node: n}
+		}
+		return nil
+	}
+
+	if nil == c.fsys {
+		return nil
+	}
+	if !fs.ValidPath(target) {
+		return &Diagnostic{Kind: MissingFile, Target: dest, Message: "file [" + file + "] is outside of the file system", Node: n}
+	}
+	if _, err = fs.Stat(c.fsys, target); nil != err {
+		return &Diagnostic{Kind: MissingFile, Target: dest, Message: "file [" + target + "] does not exist", Node: n}
+	}
+	return nil
+}
+
+func docPath(tree *parse.Tree) string {
+	if "" != tree.Path {
+		return strings.TrimPrefix(tree.Path, "/")
+	}
+	return tree.Name
+}
@@ -0,0 +1,148 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package lint 提供了基于语法树的 Markdown 检查，规则可以单独配置，支持自动修复的规则会直接改写语法树，
+// 修复后的语法树再通过 render.FormatRenderer 输出，这样检查修复和格式化共用同一套流程。
+package lint
+
+import (
+	"sort"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// Problem 描述了一个检查出的问题。
+type Problem struct {
+	Rule    string    `json:"rule"`    // 规则名
+	Message string    `json:"message"` // 描述
+	Line    int       `json:"line"`    // 行号（从 1 开始），语法树解析时没有打开 SourceSpan 时为 0
+	Column  int       `json:"column"`  // 列号（从 1 开始，按字节计算），语法树解析时没有打开 SourceSpan 时为 0
+	Fixable bool      `json:"fixable"` // 是否可以自动修复
+	Node    *ast.Node `json:"-"`       // 相关节点
+
+	fix func() // 自动修复函数，会改写语法树
+}
+
+// Reporter 用于规则报告问题，fix 为 nil 表示该问题不能自动修复。
+type Reporter func(n *ast.Node, message string, fix func())
+
+// Rule 描述了一条检查规则。
+type Rule struct {
+	Name        string                                                      // 规则名
+	Description string                                                      // 规则描述
+	Check       func(tree *parse.Tree, config *RuleConfig, report Reporter) // 检查函数
+}
+
+// RuleConfig 描述了单条规则的配置。
+type RuleConfig struct {
+	Disabled bool              `json:"disabled"` // 是否禁用该规则
+	Options  map[string]string `json:"options"`  // 规则选项
+}
+
+// Option 返回名为 name 的规则选项，未配置时返回 defaultValue。
+func (config *RuleConfig) Option(name, defaultValue string) string {
+	if nil == config || nil == config.Options {
+		return defaultValue
+	}
+	if value, ok := config.Options[name]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+// Config 描述了检查配置。
+type Config struct {
+	Rules map[string]*RuleConfig `json:"rules"` // 规则名 -> 规则配置，未配置的规则使用默认配置启用
+}
+
+// NewConfig 创建一个启用所有内置规则的默认配置。
+func NewConfig() *Config {
+	return &Config{Rules: map[string]*RuleConfig{}}
+}
+
+// Disable 禁用名为 names 的规则。
+func (config *Config) Disable(names ...string) *Config {
+	for _, name := range names {
+		config.rule(name).Disabled = true
+	}
+	return config
+}
+
+// Set 设置名为 rule 的规则选项。
+func (config *Config) Set(rule, option, value string) *Config {
+	ruleConfig := config.rule(rule)
+	if nil == ruleConfig.Options {
+		ruleConfig.Options = map[string]string{}
+	}
+	ruleConfig.Options[option] = value
+	return config
+}
+
+func (config *Config) rule(name string) (ret *RuleConfig) {
+	if nil == config.Rules {
+		config.Rules = map[string]*RuleConfig{}
+	}
+	if ret = config.Rules[name]; nil == ret {
+		ret = &RuleConfig{}
+		config.Rules[name] = ret
+	}
+	return
+}
+
+// Rules 为内置规则列表，可以追加自定义规则。
+var Rules = []*Rule{
+	HeadingIncrement,
+	NoDuplicateHeading,
+	ImageAlt,
+	NoBareURLs,
+	ListMarkerStyle,
+	NoTrailingSpaces,
+	NoEmphasisAsHeading,
+}
+
+// Lint 使用 config 检查 tree，config 为 nil 时使用默认配置。
+func Lint(tree *parse.Tree, config *Config) (ret []*Problem) {
+	if nil == config {
+		config = NewConfig()
+	}
+
+	for _, rule := range Rules {
+		ruleConfig := config.Rules[rule.Name]
+		if nil != ruleConfig && ruleConfig.Disabled {
+			continue
+		}
+
+		name := rule.Name
+		rule.Check(tree, ruleConfig, func(n *ast.Node, message string, fix func()) {
+			line, column := tree.Position(n, "")
+			ret = append(ret, &Problem{Rule: name, Message: message, Line: line, Column: column, Node: n, Fixable: nil != fix, fix: fix})
+		})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Line != ret[j].Line {
+			return ret[i].Line < ret[j].Line
+		}
+		return ret[i].Column < ret[j].Column
+	})
+	return
+}
+
+// Fix 检查 tree 并应用所有可以自动修复的问题，返回不能自动修复的问题。修复后的 tree 可以直接使用 render.FormatRenderer 输出。
+func Fix(tree *parse.Tree, config *Config) (ret []*Problem) {
+	for _, problem := range Lint(tree, config) {
+		if problem.Fixable {
+			problem.fix()
+			continue
+		}
+		ret = append(ret, problem)
+	}
+	return
+}
@@ -0,0 +1,448 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package lint
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/util"
+)
+
+// HeadingIncrement 检查标题级别是否逐级递增，比如 # 后面直接跟 ###。自动修复会将标题级别调整为父标题修复后的级别加一，
+// 其下级标题按照相同的幅度调整，这样修复后不会产生新的跳级。
+var HeadingIncrement = &Rule{
+	Name:        "heading-increment",
+	Description: "Heading levels should only increment by one level at a time",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		hs := headings(tree)
+		levels := fixedHeadingLevels(hs)
+		for i := 1; i < len(hs); i++ {
+			heading, previous := hs[i], hs[i-1]
+			if heading.HeadingLevel <= previous.HeadingLevel+1 {
+				continue
+			}
+
+			// 修复范围为该标题及其后续标题，直到遇到级别不低于其父标题的标题
+			parentLevel := 0
+			for j := i - 1; 0 <= j; j-- {
+				if hs[j].HeadingLevel < heading.HeadingLevel {
+					parentLevel = hs[j].HeadingLevel
+					break
+				}
+			}
+			end := i + 1
+			for ; end < len(hs) && hs[end].HeadingLevel > parentLevel; end++ {
+			}
+			section, sectionLevels := hs[i:end], levels[i:end]
+			report(heading, "heading level jumps from h"+strconv.Itoa(previous.HeadingLevel)+" to h"+strconv.Itoa(heading.HeadingLevel), func() {
+				for j, h := range section {
+					h.HeadingLevel = sectionLevels[j]
+				}
+			})
+		}
+	},
+}
+
+// fixedHeadingLevels 计算标题 hs 修复跳级后的级别：每个标题按照父标题修复时调整的幅度调整，并且不超过父标题修复后的级别加一。
+func fixedHeadingLevels(hs []*ast.Node) (ret []int) {
+	type level struct{ original, fixed int }
+	var parents []level // 标题层级栈
+	for _, heading := range hs {
+		for 0 < len(parents) && parents[len(parents)-1].original >= heading.HeadingLevel {
+			parents = parents[:len(parents)-1]
+		}
+		fixed := heading.HeadingLevel
+		if 0 < len(parents) {
+			parent := parents[len(parents)-1]
+			fixed += parent.fixed - parent.original
+			if fixed > parent.fixed+1 {
+				fixed = parent.fixed + 1
+			}
+		}
+		ret = append(ret, fixed)
+		parents = append(parents, level{heading.HeadingLevel, fixed})
+	}
+	return
+}
+
+// NoDuplicateHeading 检查是否存在内容相同的标题。选项 siblings_only 为 true 时仅检查同一父标题下的标题。
+var NoDuplicateHeading = &Rule{
+	Name:        "no-duplicate-heading",
+	Description: "Multiple headings should not have the same content",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		siblingsOnly := "true" == config.Option("siblings_only", "false")
+		seen := map[string]bool{}
+		var parents []*ast.Node // 标题层级栈
+		for _, heading := range headings(tree) {
+			for 0 < len(parents) && parents[len(parents)-1].HeadingLevel >= heading.HeadingLevel {
+				parents = parents[:len(parents)-1]
+			}
+			key := strings.TrimSpace(heading.Text())
+			if siblingsOnly {
+				scope := "0"
+				if 0 < len(parents) {
+					scope = strconv.Itoa(len(parents)) + "-" + strings.TrimSpace(parents[len(parents)-1].Text())
+				}
+				key = scope + "/" + strconv.Itoa(heading.HeadingLevel) + "/" + key
+			}
+			if seen[key] {
+				report(heading, "duplicate heading ["+strings.TrimSpace(heading.Text())+"]", nil)
+			}
+			seen[key] = true
+			parents = append(parents, heading)
+		}
+	},
+}
+
+// ImageAlt 检查图片是否缺少替代文本。
+var ImageAlt = &Rule{
+	Name:        "image-alt",
+	Description: "Images should have alternate text",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeImage != n.Type {
+				return ast.WalkContinue
+			}
+			if alt := n.ChildByType(ast.NodeLinkText); nil == alt || "" == strings.TrimSpace(n.Text()) {
+				dest := ""
+				if d := n.ChildByType(ast.NodeLinkDest); nil != d {
+					dest = util.BytesToStr(d.Tokens)
+				}
+				report(n, "image ["+dest+"] has no alternate text", nil)
+			}
+			return ast.WalkSkipChildren
+		})
+	},
+}
+
+var bareURLRegexp = regexp.MustCompile(`(?:https?|ftp)://[^\s<>"'\x60]+[^\s<>"'\x60.,;:!?)\]]`)
+
+// NoBareURLs 检查文本中的裸 URL，包括没有被解析为链接的 URL 和 GFM 自动链接（没有使用 <> 包裹的 URL）。自动修复会将其转换为链接。
+//
+// 判断自动链接是否使用了 <> 包裹需要源码位置，语法树解析时没有打开 SourceSpan 时不检查自动链接。
+var NoBareURLs = &Rule{
+	Name:        "no-bare-urls",
+	Description: "Bare URLs should be written as links",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		literals := literalAutoLinks(tree)
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering {
+				return ast.WalkContinue
+			}
+			if literals[n] {
+				link := n
+				report(n, "bare URL ["+util.BytesToStr(n.ChildByType(ast.NodeLinkText).Tokens)+"]", func() { link.LinkType = 0 })
+				return ast.WalkSkipChildren
+			}
+			if ast.NodeLink == n.Type || ast.NodeImage == n.Type || ast.NodeHeadingID == n.Type {
+				return ast.WalkSkipChildren
+			}
+			if ast.NodeText != n.Type {
+				return ast.WalkContinue
+			}
+			for _, url := range bareURLRegexp.FindAll(n.Tokens, -1) {
+				report(n, "bare URL ["+string(url)+"]", func() { linkBareURLs(n) })
+			}
+			return ast.WalkContinue
+		})
+	},
+}
+
+// literalAutoLinks 返回 tree 中的 GFM 自动链接，即源码中没有使用 <> 包裹的自动链接。
+//
+// 自动链接没有源码位置，所以在其所在块的源码中按顺序查找链接文本，找不到时按照使用了 <> 包裹处理。
+func literalAutoLinks(tree *parse.Tree) (ret map[*ast.Node]bool) {
+	ret = map[*ast.Node]bool{}
+	ast.Walk(tree.Root, func(block *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !block.IsBlock() || block.IsContainerBlock() || nil == block.SourceSpan {
+			return ast.WalkContinue
+		}
+
+		src := tree.Source[block.SourceSpan.Start:block.SourceSpan.End]
+		pos := 0
+		ast.Walk(block, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeLink != n.Type || 2 != n.LinkType {
+				return ast.WalkContinue
+			}
+			text := n.ChildByType(ast.NodeLinkText)
+			if nil == text {
+				return ast.WalkSkipChildren
+			}
+			i := bytes.Index(src[pos:], text.Tokens)
+			if 0 > i {
+				return ast.WalkSkipChildren
+			}
+			i += pos
+			if 1 > i || '<' != src[i-1] {
+				ret[n] = true
+			}
+			pos = i + len(text.Tokens)
+			return ast.WalkSkipChildren
+		})
+		return ast.WalkSkipChildren
+	})
+	return
+}
+
+// linkBareURLs 将文本节点 n 中的 URL 拆分为自动链接节点，多次调用是幂等的。
+func linkBareURLs(n *ast.Node) {
+	if nil == n.Parent {
+		return // 已经被拆分过了
+	}
+
+	tokens := n.Tokens
+	locs := bareURLRegexp.FindAllIndex(tokens, -1)
+	if 1 > len(locs) {
+		return
+	}
+
+	last := 0
+	for _, loc := range locs {
+		if last < loc[0] {
+			n.InsertBefore(&ast.Node{Type: ast.NodeText, Tokens: tokens[last:loc[0]]})
+		}
+		url := tokens[loc[0]:loc[1]]
+		link := &ast.Node{Type: ast.NodeLink, LinkType: 2}
+		link.AppendChild(&ast.Node{Type: ast.NodeOpenBracket})
+		link.AppendChild(&ast.Node{Type: ast.NodeLinkText, Tokens: url})
+		link.AppendChild(&ast.Node{Type: ast.NodeCloseBracket})
+		link.AppendChild(&ast.Node{Type: ast.NodeOpenParen})
+		link.AppendChild(&ast.Node{Type: ast.NodeLinkDest, Tokens: url})
+		link.AppendChild(&ast.Node{Type: ast.NodeCloseParen})
+		n.InsertBefore(link)
+		last = loc[1]
+	}
+	if last < len(tokens) {
+		n.InsertBefore(&ast.Node{Type: ast.NodeText, Tokens: tokens[last:]})
+	}
+	n.Unlink()
+}
+
+// ListMarkerStyle 检查无序列表标记符是否一致。选项 style 可以是 consistent（默认，和文档中第一个无序列表一致）、asterisk、dash 或者 plus。
+// 自动修复会改写列表和列表项上的 ListData.BulletChar。
+var ListMarkerStyle = &Rule{
+	Name:        "list-marker-style",
+	Description: "Unordered list markers should be consistent",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		var expected byte
+		switch config.Option("style", "consistent") {
+		case "asterisk":
+			expected = lex.ItemAsterisk
+		case "dash":
+			expected = lex.ItemHyphen
+		case "plus":
+			expected = lex.ItemPlus
+		}
+
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeList != n.Type || nil == n.ListData || 0 != n.ListData.Typ && 3 != n.ListData.Typ || 0 == n.ListData.BulletChar {
+				return ast.WalkContinue
+			}
+			if 0 == expected {
+				expected = n.ListData.BulletChar
+			}
+			if expected != n.ListData.BulletChar {
+				list, bullet := n, expected
+				report(n, "list marker ["+string(n.ListData.BulletChar)+"] should be ["+string(expected)+"]", func() {
+					setBulletChar(list, bullet)
+				})
+			}
+			return ast.WalkContinue
+		})
+	},
+}
+
+func setBulletChar(list *ast.Node, bullet byte) {
+	list.ListData.BulletChar = bullet
+	list.ListData.Marker = []byte{bullet}
+	for li := list.FirstChild; nil != li; li = li.Next {
+		if ast.NodeListItem != li.Type || nil == li.ListData {
+			continue
+		}
+		li.ListData.BulletChar = bullet
+		li.ListData.Marker = []byte{bullet}
+		li.Tokens = []byte{bullet}
+	}
+}
+
+// NoTrailingSpaces 检查行尾空白。语法树中段落行尾的空格在解析时已经被去掉，所以在有源码位置时按照段落、标题、表格、HTML 块和数学公式块的源码行检查，
+// 段落中用于硬换行的两个以上空格不算行尾空白；没有源码位置时检查 HTML 块、数学公式块以及保留了空白的文本。选项 code_blocks 为 true 时同时检查代码块。
+// 自动修复会去掉行尾空白。
+var NoTrailingSpaces = &Rule{
+	Name:        "no-trailing-spaces",
+	Description: "Lines should not end with whitespace",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		codeBlocks := "true" == config.Option("code_blocks", "false")
+		if nil != tree.Source {
+			checkSourceTrailingSpaces(tree, codeBlocks, report)
+			return
+		}
+
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering {
+				return ast.WalkContinue
+			}
+
+			switch n.Type {
+			case ast.NodeHTMLBlock, ast.NodeMathBlockContent:
+			case ast.NodeCodeBlockCode:
+				if !codeBlocks {
+					return ast.WalkContinue
+				}
+			case ast.NodeText:
+				if next := n.Next; nil != next && ast.NodeSoftBreak != next.Type && ast.NodeHardBreak != next.Type {
+					return ast.WalkContinue
+				}
+			default:
+				return ast.WalkContinue
+			}
+
+			node := n
+			for i, line := range bytes.Split(n.Tokens, []byte{lex.ItemNewline}) {
+				if trimmed := bytes.TrimRight(line, " \t"); len(trimmed) < len(line) {
+					report(n, "trailing whitespace at line ["+strconv.Itoa(i+1)+"] of "+n.Type.String(), func() { trimTrailingSpaces(node) })
+				}
+			}
+			return ast.WalkContinue
+		})
+	},
+}
+
+// checkSourceTrailingSpaces 按照叶子块的源码行检查行尾空白。
+func checkSourceTrailingSpaces(tree *parse.Tree, codeBlocks bool, report Reporter) {
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !n.IsBlock() {
+			return ast.WalkContinue
+		}
+
+		var content *ast.Node // 需要修复的内容节点，为 nil 时渲染已经会去掉行尾空白
+		switch n.Type {
+		case ast.NodeParagraph, ast.NodeHeading, ast.NodeTable:
+		case ast.NodeHTMLBlock:
+			content = n
+		case ast.NodeMathBlock:
+			content = n.ChildByType(ast.NodeMathBlockContent)
+		case ast.NodeCodeBlock:
+			if !codeBlocks {
+				return ast.WalkSkipChildren
+			}
+			content = n.ChildByType(ast.NodeCodeBlockCode)
+		default:
+			return ast.WalkContinue
+		}
+		if nil == n.SourceSpan {
+			return ast.WalkSkipChildren
+		}
+
+		// 源码保留了 \r\n 和 \r 换行符
+		src := bytes.ReplaceAll(tree.Source[n.SourceSpan.Start:n.SourceSpan.End], []byte("\r\n"), []byte{lex.ItemNewline})
+		src = bytes.ReplaceAll(src, []byte{lex.ItemCarriageReturn}, []byte{lex.ItemNewline})
+		lines := bytes.Split(bytes.TrimSuffix(src, []byte{lex.ItemNewline}), []byte{lex.ItemNewline})
+		for i, line := range lines {
+			trimmed := bytes.TrimRight(line, " \t")
+			if len(trimmed) == len(line) {
+				continue
+			}
+			if ast.NodeParagraph == n.Type && i < len(lines)-1 && 2 <= len(line)-len(bytes.TrimRight(line, " ")) {
+				continue // 硬换行
+			}
+
+			var fix func()
+			if nil != content {
+				node := content
+				fix = func() { trimTrailingSpaces(node) }
+			}
+			report(n, "trailing whitespace at line ["+strconv.Itoa(i+1)+"] of "+n.Type.String(), fix)
+		}
+		return ast.WalkSkipChildren
+	})
+}
+
+func trimTrailingSpaces(n *ast.Node) {
+	lines := bytes.Split(n.Tokens, []byte{lex.ItemNewline})
+	for i, line := range lines {
+		lines[i] = bytes.TrimRight(line, " \t")
+	}
+	n.Tokens = bytes.Join(lines, []byte{lex.ItemNewline})
+}
+
+// NoEmphasisAsHeading 检查是否使用整段强调或者加粗代替标题。选项 level 指定自动修复时转换的标题级别，默认为 2。
+var NoEmphasisAsHeading = &Rule{
+	Name:        "no-emphasis-as-heading",
+	Description: "Emphasis should not be used instead of a heading",
+	Check: func(tree *parse.Tree, config *RuleConfig, report Reporter) {
+		level, err := strconv.Atoi(config.Option("level", "2"))
+		if nil != err || 1 > level || 6 < level {
+			level = 2
+		}
+
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering {
+				return ast.WalkContinue
+			}
+			if ast.NodeParagraph != n.Type {
+				if n.IsContainerBlock() {
+					return ast.WalkContinue
+				}
+				return ast.WalkSkipChildren
+			}
+			if ast.NodeDocument != n.Parent.Type {
+				return ast.WalkSkipChildren
+			}
+
+			emphasis := n.FirstChild
+			if nil == emphasis || emphasis != n.LastChild || (ast.NodeEmphasis != emphasis.Type && ast.NodeStrong != emphasis.Type) {
+				return ast.WalkSkipChildren
+			}
+			text := strings.TrimSpace(emphasis.Text())
+			if "" == text || strings.Contains(text, "\n") || nil != emphasis.ChildByType(ast.NodeSoftBreak) {
+				return ast.WalkSkipChildren
+			}
+			if last, _ := utf8.DecodeLastRuneInString(text); strings.ContainsRune(".,;:!?。，；：！？", last) {
+				return ast.WalkSkipChildren
+			}
+
+			paragraph := n
+			report(n, "emphasis used instead of a heading ["+text+"]", func() {
+				paragraph.Type = ast.NodeHeading
+				paragraph.HeadingLevel = level
+				for c := emphasis.FirstChild; nil != c; {
+					next := c.Next
+					if !c.IsMarker() {
+						emphasis.InsertBefore(c)
+					}
+					c = next
+				}
+				emphasis.Unlink()
+			})
+			return ast.WalkSkipChildren
+		})
+	},
+}
+
+// headings 返回 tree 中的所有标题。
+func headings(tree *parse.Tree) (ret []*ast.Node) {
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeHeading == n.Type {
+			ret = append(ret, n)
+			return ast.WalkSkipChildren
+		}
+		return ast.WalkContinue
+	})
+	return
+}
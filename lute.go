@@ -14,11 +14,14 @@ package lute
 import (
 	"bytes"
 	"errors"
+	"io/fs"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/88250/lute/ast"
 	"github.com/88250/lute/lex"
+	"github.com/88250/lute/lint"
 	"github.com/88250/lute/parse"
 	"github.com/88250/lute/render"
 	"github.com/88250/lute/util"
@@ -42,6 +45,8 @@ type Lute struct {
 	Md2VditorIRDOMRendererFuncs   map[ast.NodeType]render.ExtRendererFunc // 用户自定义的 Md2VditorIRDOM 渲染器函数
 	Md2BlockDOMRendererFuncs      map[ast.NodeType]render.ExtRendererFunc // 用户自定义的 Md2BlockDOM 渲染器函数
 	Md2VditorSVDOMRendererFuncs   map[ast.NodeType]render.ExtRendererFunc // 用户自定义的 Md2VditorSVDOM 渲染器函数
+
+	transformers []*transformer // 语法树变换器，按优先级排序
 }
 
 // New 创建一个新的 Lute 引擎。
@@ -81,6 +86,7 @@ func New(opts ...ParseOption) (ret *Lute) {
 // Markdown 将 markdown 文本字节数组处理为相应的 html 字节数组。name 参数仅用于标识文本，比如可传入 id 或者标题，也可以传入 ""。
 func (lute *Lute) Markdown(name string, markdown []byte) (html []byte) {
 	tree := parse.Parse(name, markdown, lute.ParseOptions)
+	lute.transform(tree)
 	renderer := render.NewHtmlRenderer(tree, lute.RenderOptions)
 	for nodeType, rendererFunc := range lute.Md2HTMLRendererFuncs {
 		renderer.ExtRendererFuncs[nodeType] = rendererFunc
@@ -99,6 +105,7 @@ func (lute *Lute) MarkdownStr(name, markdown string) (html string) {
 // Format 将 markdown 文本字节数组进行格式化。
 func (lute *Lute) Format(name string, markdown []byte) (formatted []byte) {
 	tree := parse.Parse(name, markdown, lute.ParseOptions)
+	lute.transform(tree)
 	renderer := render.NewFormatRenderer(tree, lute.RenderOptions)
 	formatted = renderer.Render()
 	return
@@ -111,6 +118,60 @@ func (lute *Lute) FormatStr(name, markdown string) (formatted string) {
 	return
 }
 
+// Outline 返回 markdown 的大纲，即 [toc] 目录使用的标题树。
+func (lute *Lute) Outline(name string, markdown []byte) []*render.Heading {
+	tree := parse.Parse(name, markdown, lute.ParseOptions)
+	return render.Outline(tree, lute.RenderOptions)
+}
+
+// Lint 使用 config 检查 markdown，config 为 nil 时使用默认配置。
+func (lute *Lute) Lint(name string, markdown []byte, config *lint.Config) (problems []*lint.Problem) {
+	tree := parse.Parse(name, markdown, lute.lintParseOptions())
+	problems = lint.Lint(tree, config)
+	return
+}
+
+// LintFix 使用 config 检查 markdown 并自动修复，修复结果和 Format 一样通过 FormatRenderer 输出，problems 为不能自动修复的问题。
+func (lute *Lute) LintFix(name string, markdown []byte, config *lint.Config) (formatted []byte, problems []*lint.Problem) {
+	tree := parse.Parse(name, markdown, lute.lintParseOptions())
+	problems = lint.Fix(tree, config)
+	renderer := render.NewFormatRenderer(tree, lute.RenderOptions)
+	formatted = renderer.Render()
+	return
+}
+
+// lintParseOptions 返回检查时使用的解析选项，打开 SourceSpan 以便问题带有行列号。
+func (lute *Lute) lintParseOptions() *parse.Options {
+	options := lute.ParseOptions.Clone()
+	options.SourceSpan = true
+	return options
+}
+
+// transformer 描述了语法树变换器。
+type transformer struct {
+	priority  int                    // 优先级，数值小的先执行
+	transform func(tree *parse.Tree) // 变换函数
+}
+
+// AddTransformer 注册语法树变换器 transform，变换器会在 Markdown、Format、Md2BlockDOM、Md2VditorIRDOM 和 HTML2Markdown 中
+// 解析完成后、渲染之前按 priority 从小到大依次执行，priority 相同时按注册顺序执行。常用的变换器见 transform 包。
+func (lute *Lute) AddTransformer(priority int, transform func(tree *parse.Tree)) {
+	lute.transformers = append(lute.transformers, &transformer{priority: priority, transform: transform})
+	sort.SliceStable(lute.transformers, func(i, j int) bool {
+		return lute.transformers[i].priority < lute.transformers[j].priority
+	})
+}
+
+// transform 依次执行已注册的语法树变换器。
+func (lute *Lute) transform(tree *parse.Tree) {
+	if nil == tree {
+		return
+	}
+	for _, t := range lute.transformers {
+		t.transform(tree)
+	}
+}
+
 // TextBundle 将 markdown 文本字节数组进行 TextBundle 处理。
 func (lute *Lute) TextBundle(name string, markdown []byte, linkPrefixes []string) (textbundle []byte, originalLinks []string) {
 	tree := parse.Parse(name, markdown, lute.ParseOptions)
@@ -184,13 +245,7 @@ func (lute *Lute) GetEmojis() (ret map[string]string) {
 
 // PutEmojis 将指定的 emojiMap 合并覆盖已有的 Emoji 字典。
 func (lute *Lute) PutEmojis(emojiMap map[string]string) {
-	parse.EmojiLock.Lock()
-	defer parse.EmojiLock.Unlock()
-
-	for k, v := range emojiMap {
-		lute.ParseOptions.AliasEmoji[k] = v
-		lute.ParseOptions.EmojiAlias[v] = k
-	}
+	lute.ParseOptions.PutEmojis(emojiMap)
 }
 
 // RemoveEmoji 用于删除 str 中的 Emoji Unicode。
@@ -302,7 +357,16 @@ func (lute *Lute) Tree2HTML(tree *parse.Tree, options *render.Options) string {
 	return util.BytesToStr(output)
 }
 
-// ParseOption 描述了解析选项设置函数签名。
+// Tree2Markdown 使用指定的 options 将 tree 无损格式化为 Markdown。
+//
+// tree 需要在打开解析选项 SourceSpan 的情况下解析，未修改的块原样输出源码，仅重新生成修改过的块，否则等同于 Format。
+func (lute *Lute) Tree2Markdown(tree *parse.Tree, options *render.Options) string {
+	renderer := render.NewLosslessRenderer(tree, options)
+	output := renderer.Render()
+	return util.BytesToStr(output)
+}
+
+// ParseOption 描述了解析选项设置函数签名，用于 New，在 With 中使用时需要通过 WithEngine 转换。
 type ParseOption func(lute *Lute)
 
 // 以下 Setters 主要是给 JavaScript 端导出方法用。
@@ -351,6 +415,21 @@ func (lute *Lute) SetCodeSyntaxHighlightStyleName(name string) {
 	lute.RenderOptions.CodeSyntaxHighlightStyleName = name
 }
 
+func (lute *Lute) SetCodeSyntaxHighlightClassPrefix(prefix string) {
+	lute.RenderOptions.CodeSyntaxHighlightClassPrefix = prefix
+}
+
+func (lute *Lute) SetCodeBlockRenderer(language string, renderer render.CodeBlockRenderer) {
+	if nil == renderer {
+		delete(lute.RenderOptions.CodeBlockRenderers, language)
+		return
+	}
+	if nil == lute.RenderOptions.CodeBlockRenderers {
+		lute.RenderOptions.CodeBlockRenderers = map[string]render.CodeBlockRenderer{}
+	}
+	lute.RenderOptions.CodeBlockRenderers[language] = renderer
+}
+
 func (lute *Lute) SetFootnotes(b bool) {
 	lute.ParseOptions.Footnotes = b
 }
@@ -365,6 +444,43 @@ func (lute *Lute) SetHeadingID(b bool) {
 	lute.RenderOptions.HeadingID = b
 }
 
+func (lute *Lute) SetToCLevel(min, max int) {
+	lute.RenderOptions.ToCMinLevel = min
+	lute.RenderOptions.ToCMaxLevel = max
+}
+
+func (lute *Lute) SetToCExcludeClass(class string) {
+	lute.RenderOptions.ToCExcludeClass = class
+}
+
+func (lute *Lute) SetToCOrdered(b bool) {
+	lute.RenderOptions.ToCOrdered = b
+}
+
+func (lute *Lute) SetToCTitle(title string) {
+	lute.RenderOptions.ToCTitle = title
+}
+
+func (lute *Lute) SetToCMarkdown(b bool) {
+	lute.RenderOptions.ToCMarkdown = b
+}
+
+func (lute *Lute) SetSlugger(name string) {
+	lute.RenderOptions.Slugger = render.NewSlugger(name)
+}
+
+func (lute *Lute) SetBlockProvider(provider render.BlockProvider) {
+	lute.RenderOptions.BlockProvider = provider
+}
+
+func (lute *Lute) SetBlockEmbedMaxDepth(depth int) {
+	lute.RenderOptions.BlockEmbedMaxDepth = depth
+}
+
+func (lute *Lute) SetFrontMatterRender(mode string) {
+	lute.RenderOptions.FrontMatterRender = mode
+}
+
 func (lute *Lute) SetAutoSpace(b bool) {
 	lute.RenderOptions.AutoSpace = b
 }
@@ -373,6 +489,14 @@ func (lute *Lute) SetFixTermTypo(b bool) {
 	lute.RenderOptions.FixTermTypo = b
 }
 
+func (lute *Lute) SetSmartTypography(b bool) {
+	lute.RenderOptions.SmartTypography = b
+}
+
+func (lute *Lute) SetSmartTypographyLang(lang string) {
+	lute.RenderOptions.SmartTypographyLang = lang
+}
+
 func (lute *Lute) SetEmoji(b bool) {
 	lute.ParseOptions.Emoji = b
 }
@@ -477,6 +601,10 @@ func (lute *Lute) SetMark(b bool) {
 	lute.ParseOptions.Mark = b
 }
 
+func (lute *Lute) SetWikilink(b bool) {
+	lute.ParseOptions.Wikilink = b
+}
+
 func (lute *Lute) SetKramdownIAL(b bool) {
 	lute.ParseOptions.KramdownBlockIAL = b
 	lute.ParseOptions.KramdownSpanIAL = b
@@ -511,6 +639,14 @@ func (lute *Lute) SetSuperBlock(b bool) {
 	lute.RenderOptions.SuperBlock = b
 }
 
+func (lute *Lute) SetSuperBlockClass(class string) {
+	lute.RenderOptions.SuperBlockClass = class
+}
+
+func (lute *Lute) SetSuperBlockInlineStyle(b bool) {
+	lute.RenderOptions.SuperBlockInlineStyle = b
+}
+
 func (lute *Lute) SetSup(b bool) {
 	lute.ParseOptions.Sup = b
 }
@@ -548,6 +684,60 @@ func (lute *Lute) SetProtyleMarkNetImg(b bool) {
 	lute.RenderOptions.ProtyleMarkNetImg = b
 }
 
+func (lute *Lute) SetFormatBulletMarker(marker string) {
+	lute.RenderOptions.FormatBulletMarker = marker
+}
+
+func (lute *Lute) SetFormatEmphasisMarker(marker string) {
+	lute.RenderOptions.FormatEmphasisMarker = marker
+}
+
+func (lute *Lute) SetFormatStrongMarker(marker string) {
+	lute.RenderOptions.FormatStrongMarker = marker
+}
+
+func (lute *Lute) SetFormatOrderedListNumbering(numbering string) {
+	lute.RenderOptions.FormatOrderedListNumbering = numbering
+}
+
+func (lute *Lute) SetFormatCodeFence(fenceChar string, fenceLen int) {
+	lute.RenderOptions.FormatCodeFenceChar = fenceChar
+	lute.RenderOptions.FormatCodeFenceLen = fenceLen
+}
+
+func (lute *Lute) SetFormatThematicBreak(thematicBreak string) {
+	lute.RenderOptions.FormatThematicBreak = thematicBreak
+}
+
+func (lute *Lute) SetFormatHeadingStyle(style string) {
+	lute.RenderOptions.FormatHeadingStyle = style
+}
+
+func (lute *Lute) SetFormatTablePadding(b bool) {
+	lute.RenderOptions.FormatTablePadding = b
+}
+
+func (lute *Lute) SetFormatProseWrap(proseWrap string, column int) {
+	lute.RenderOptions.FormatProseWrap = proseWrap
+	lute.RenderOptions.FormatProseWrapColumn = column
+}
+
+func (lute *Lute) SetSourceSpan(b bool) {
+	lute.ParseOptions.SourceSpan = b
+}
+
+func (lute *Lute) SetInclude(b bool) {
+	lute.ParseOptions.Include = b
+}
+
+func (lute *Lute) SetIncludeFS(fsys fs.FS) {
+	lute.ParseOptions.IncludeFS = fsys
+}
+
+func (lute *Lute) SetIncludeMaxDepth(depth int) {
+	lute.ParseOptions.IncludeMaxDepth = depth
+}
+
 func (lute *Lute) SetJSRenderers(options map[string]map[string]*js.Object) {
 	for rendererType, extRenderer := range options["renderers"] {
 		switch extRenderer.Interface().(type) { // 稍微进行一点格式校验
@@ -0,0 +1,503 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package merge 提供了 Markdown 文档块的三方合并。
+//
+// 块通过内联属性列表中的 id 对应，没有 id 的块先通过内容对应，其余没有 id 的块再按照位置对应到 base 中被修改的块。只有一方修改的块、插入的块、删除的块以及不相交的属性修改都会被自动合并，
+// 双方都修改了同一个容器块（列表、列表项、引述块和超级块等）时会递归合并其子块，比如双方分别修改了同一个列表中的不同列表项，
+// 没有 id 的列表也一样，比如一方添加了列表项，另一方修改了其他列表项。双方在同一位置插入的块都会保留，ours 的块在前。
+// 双方对同一个块的内容或者同一个属性做了不同的修改时才会生成 Git 冲突标记块（ast.NodeGitConflict），冲突块可以通过 Resolve 解决。
+// 列表的子块只能是列表项，所以列表项之间的冲突会使整个列表成为冲突块。
+package merge
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+	"github.com/88250/lute/util"
+)
+
+const (
+	oursMarker   = "<<<<<<< ours"
+	separator    = "======="
+	theirsMarker = ">>>>>>> theirs"
+
+	contentKeyPrefix = "content:" // 无 id 块对应键的前缀
+)
+
+// block 描述了参与合并的块。
+type block struct {
+	key      string     // 对应键，优先使用 id
+	node     *ast.Node  // 块节点
+	markdown string     // 不含内联属性列表的 Markdown，用于比较内容
+	ial      [][]string // 块的内联属性列表
+}
+
+// side 描述了参与合并的一方文档中同一个父块的子块。
+type side struct {
+	blocks []*block
+	keys   map[string]*block
+	docIAL [][]string // 文档内联属性列表，没有时为 nil，仅用于文档的子块
+}
+
+// Merge 以 base 为共同祖先三方合并 ours 和 theirs，返回合并后的语法树以及其中的冲突块。
+//
+// 双方都调整了块顺序时以 ours 的顺序为准，双方对同一属性的不同修改中 updated 属性取较新的值，不会产生冲突。
+func Merge(base, ours, theirs *parse.Tree) (ret *parse.Tree, conflicts []*ast.Node) {
+	options := ours.Context.ParseOption
+	b, o, t := newSide(base.Root, options), newSide(ours.Root, options), newSide(theirs.Root, options)
+
+	ret = &parse.Tree{Name: ours.Name, ID: ours.ID, Box: ours.Box, Path: ours.Path, HPath: ours.HPath, Root: &ast.Node{Type: ast.NodeDocument, ID: ours.Root.ID}, Context: &parse.Context{ParseOption: options}}
+	ret.Context.Tree = ret
+	conflicts = mergeChildren(b, o, t, ret.Root, options)
+
+	if nil != o.docIAL || nil != t.docIAL {
+		docIAL, ok := mergeIAL(b.docIAL, o.docIAL, t.docIAL)
+		if !ok {
+			// 文档属性冲突时保留 ours 的属性
+			docIAL = o.docIAL
+		}
+		ret.Root.KramdownIAL = docIAL
+		ret.Root.AppendChild(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(docIAL)})
+	}
+	return
+}
+
+// mergeChildren 合并三方的子块并按合并后的顺序添加到 parent 中，返回其中的冲突块。
+func mergeChildren(b, o, t *side, parent *ast.Node, options *parse.Options) (conflicts []*ast.Node) {
+	align(b, o)
+	align(b, t)
+
+	merged := map[string]*ast.Node{}
+	for _, key := range unionKeys(b, o, t) {
+		node, nodeConflicts := mergeBlock(b.keys[key], o.keys[key], t.keys[key], options)
+		if nil == node {
+			continue
+		}
+		merged[key] = node
+		conflicts = append(conflicts, nodeConflicts...)
+	}
+
+	closeMarker := parent.ChildByType(ast.NodeSuperBlockCloseMarker)
+	for _, key := range order(b, o, t, merged) {
+		node := merged[key]
+		if nil != closeMarker {
+			closeMarker.InsertBefore(node)
+		} else {
+			parent.AppendChild(node)
+		}
+		if 0 < len(node.KramdownIAL) {
+			node.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(node.KramdownIAL)})
+		}
+	}
+	return
+}
+
+// newSide 收集 parent 的子块，parent 为 nil 时（该方没有这个父块）返回空的一方。
+func newSide(parent *ast.Node, options *parse.Options) (ret *side) {
+	ret = &side{keys: map[string]*block{}}
+	if nil == parent {
+		return
+	}
+
+	occurrences := map[string]int{}
+	for n := parent.FirstChild; nil != n; n = n.Next {
+		if ast.NodeKramdownBlockIAL == n.Type {
+			if ast.NodeDocument == parent.Type && nil == n.Next && util.IsDocIAL(n.Tokens) {
+				ret.docIAL = parse.Tokens2IAL(n.Tokens)
+			}
+			continue
+		}
+		if !n.IsBlock() {
+			continue
+		}
+
+		b := &block{node: n, markdown: formatBlock(n, nil, options), ial: n.KramdownIAL}
+		b.key = n.IALAttr("id")
+		if "" == b.key {
+			// 没有 id 的块通过内容以及内容相同的块的出现次序对应
+			occurrences[b.markdown]++
+			b.key = contentKeyPrefix + strconv.Itoa(occurrences[b.markdown]) + ":" + b.markdown
+		}
+		ret.blocks = append(ret.blocks, b)
+		ret.keys[b.key] = b
+	}
+	return
+}
+
+// align 将 s 中内容与 base 不同的无 id 块按照位置对应到 base 中同一位置被修改的无 id 块。
+//
+// 双方无 id 块的最长公共子序列作为锚点，两个锚点之间 base 和 s 独有的块依次一一对应，多出的块视为插入或者删除。
+func align(base, s *side) {
+	var baseBlocks, blocks []*block
+	for _, blk := range base.blocks {
+		if isContentKey(blk.key) {
+			baseBlocks = append(baseBlocks, blk)
+		}
+	}
+	for _, blk := range s.blocks {
+		if isContentKey(blk.key) {
+			blocks = append(blocks, blk)
+		}
+	}
+	if 1 > len(baseBlocks) || 1 > len(blocks) {
+		return
+	}
+
+	lcs := make([][]int, len(baseBlocks)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(blocks)+1)
+	}
+	for i := len(baseBlocks) - 1; 0 <= i; i-- {
+		for j := len(blocks) - 1; 0 <= j; j-- {
+			if baseBlocks[i].key == blocks[j].key {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var baseGap, gap []*block
+	pair := func() {
+		for k := 0; k < len(baseGap) && k < len(gap); k++ {
+			delete(s.keys, gap[k].key)
+			gap[k].key = baseGap[k].key
+			s.keys[gap[k].key] = gap[k]
+		}
+		baseGap, gap = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(baseBlocks) || j < len(blocks) {
+		switch {
+		case i < len(baseBlocks) && j < len(blocks) && baseBlocks[i].key == blocks[j].key:
+			pair()
+			i, j = i+1, j+1
+		case j == len(blocks) || (i < len(baseBlocks) && lcs[i+1][j] >= lcs[i][j+1]):
+			if nil == s.keys[baseBlocks[i].key] {
+				// 只对应 s 中没有的块，s 调整过顺序的块仍然通过内容对应
+				baseGap = append(baseGap, baseBlocks[i])
+			}
+			i++
+		default:
+			if nil == base.keys[blocks[j].key] {
+				gap = append(gap, blocks[j])
+			}
+			j++
+		}
+	}
+	pair()
+}
+
+// isContentKey 判断 key 是否是无 id 块通过内容生成的对应键。
+func isContentKey(key string) bool {
+	return strings.HasPrefix(key, contentKeyPrefix)
+}
+
+func unionKeys(sides ...*side) (ret []string) {
+	seen := map[string]bool{}
+	for _, s := range sides {
+		for _, b := range s.blocks {
+			if !seen[b.key] {
+				seen[b.key] = true
+				ret = append(ret, b.key)
+			}
+		}
+	}
+	return
+}
+
+// mergeBlock 合并同一个块的三方版本，返回合并后的块、冲突块或者 nil（块被删除），以及其中的冲突块。
+func mergeBlock(b, o, t *block, options *parse.Options) (ret *ast.Node, conflicts []*ast.Node) {
+	if nil == o && nil == t {
+		return
+	}
+
+	if nil != b && (nil == o || nil == t) {
+		// 一方删除，另一方未修改内容时删除，否则冲突
+		remain := o
+		if nil == remain {
+			remain = t
+		}
+		if remain.markdown == b.markdown {
+			return
+		}
+		return conflicted(o, t, options)
+	}
+
+	if nil == o || nil == t {
+		// 只有一方插入
+		if nil == o {
+			o = t
+		}
+		return mergedNode(o, o.ial), nil
+	}
+
+	var chosen *block
+	switch {
+	case o.markdown == t.markdown:
+		chosen = o
+	case nil != b && o.markdown == b.markdown:
+		chosen = t
+	case nil != b && t.markdown == b.markdown:
+		chosen = o
+	case o.node.IsContainerBlock() && o.node.Type == t.node.Type && ("" != o.node.IALAttr("id") || nil != b):
+		// 双方都修改了同一个容器块，递归合并子块，没有 id 的容器块对应到了 base 中的块时子块按照位置对应
+		return mergeContainer(b, o, t, options)
+	default:
+		return conflicted(o, t, options)
+	}
+
+	ial, ok := mergeIAL(baseIAL(b), o.ial, t.ial)
+	if !ok {
+		return conflicted(o, t, options)
+	}
+	return mergedNode(chosen, ial), nil
+}
+
+// mergeContainer 合并双方都修改了的容器块：容器本身的属性按照块内容的规则合并，子块递归合并。
+func mergeContainer(b, o, t *block, options *parse.Options) (ret *ast.Node, conflicts []*ast.Node) {
+	var baseNode *ast.Node
+	if nil != b {
+		baseNode = b.node
+	}
+
+	chosen := o
+	oSignature, tSignature := signature(o.node), signature(t.node)
+	switch {
+	case oSignature == tSignature:
+	case nil != b && oSignature == signature(b.node):
+		chosen = t
+	case nil != b && tSignature == signature(b.node):
+	default:
+		return conflicted(o, t, options)
+	}
+	ial, ok := mergeIAL(baseIAL(b), o.ial, t.ial)
+	if !ok {
+		return conflicted(o, t, options)
+	}
+
+	ret = mergedNode(chosen, ial)
+	for child := ret.FirstChild; nil != child; {
+		next := child.Next
+		if child.IsBlock() {
+			child.Unlink()
+		}
+		child = next
+	}
+	conflicts = mergeChildren(newSide(baseNode, options), newSide(o.node, options), newSide(t.node, options), ret, options)
+	if ast.NodeList == ret.Type {
+		for _, c := range conflicts {
+			if ret == c.Parent {
+				return conflicted(o, t, options)
+			}
+		}
+	}
+	return
+}
+
+// signature 返回容器块本身的签名，不包括子块和内联属性列表。有序列表项的序号会随着位置变化，不作为签名的一部分。
+func signature(n *ast.Node) string {
+	buf := &strings.Builder{}
+	buf.WriteString(n.Type.String())
+	if nil != n.ListData {
+		buf.WriteString(" " + strconv.Itoa(n.ListData.Typ) + " " + strconv.FormatBool(n.ListData.Tight) + " " + strconv.FormatBool(n.ListData.Checked))
+		buf.WriteByte(n.ListData.BulletChar)
+		buf.WriteByte(n.ListData.Delimiter)
+	}
+	if layout := n.ChildByType(ast.NodeSuperBlockLayoutMarker); nil != layout {
+		buf.WriteString(" " + string(layout.Tokens))
+	}
+	return buf.String()
+}
+
+func baseIAL(b *block) [][]string {
+	if nil == b {
+		return nil
+	}
+	return b.ial
+}
+
+// conflicted 返回 o 和 t 的冲突块。
+func conflicted(o, t *block, options *parse.Options) (ret *ast.Node, conflicts []*ast.Node) {
+	ret = conflict(o, t, options)
+	return ret, []*ast.Node{ret}
+}
+
+func mergedNode(b *block, ial [][]string) (ret *ast.Node) {
+	ret = b.node.Clone()
+	ret.KramdownIAL = nil
+	for _, kv := range ial {
+		ret.KramdownIAL = append(ret.KramdownIAL, []string{kv[0], kv[1]})
+	}
+	return
+}
+
+// mergeIAL 三方合并内联属性列表，双方对同一属性做了不同的修改时 ok 为 false，updated 属性除外。
+func mergeIAL(b, o, t [][]string) (ret [][]string, ok bool) {
+	var names []string
+	seen := map[string]bool{}
+	for _, ial := range [][][]string{o, t, b} {
+		for _, kv := range ial {
+			if !seen[kv[0]] {
+				seen[kv[0]] = true
+				names = append(names, kv[0])
+			}
+		}
+	}
+
+	for _, name := range names {
+		bv, bok := attr(b, name)
+		ov, ook := attr(o, name)
+		tv, tok := attr(t, name)
+
+		value, present := ov, ook
+		switch {
+		case ov == tv && ook == tok:
+		case ov == bv && ook == bok:
+			value, present = tv, tok
+		case tv == bv && tok == bok:
+		case "updated" == name:
+			if tv > ov {
+				value, present = tv, tok
+			}
+		default:
+			return nil, false
+		}
+		if present {
+			ret = append(ret, []string{name, value})
+		}
+	}
+	return ret, true
+}
+
+func attr(ial [][]string, name string) (string, bool) {
+	for _, kv := range ial {
+		if name == kv[0] {
+			return kv[1], true
+		}
+	}
+	return "", false
+}
+
+// order 返回合并后块的顺序。
+//
+// 以调整过块顺序的一方为主（双方都调整过时以 ours 为主），另一方独有的块插入到它在该方中前一个块之后。
+// 双方在同一位置都插入了块时不会产生冲突，ours 插入的块排在 theirs 插入的块之前。
+func order(b, o, t *side, merged map[string]*ast.Node) (ret []string) {
+	primary, secondary := o, t
+	if reordered(b, t) && !reordered(b, o) {
+		primary, secondary = t, o
+	}
+
+	placed := map[string]bool{}
+	for _, blk := range primary.blocks {
+		if nil != merged[blk.key] {
+			ret = append(ret, blk.key)
+			placed[blk.key] = true
+		}
+	}
+
+	anchor := "" // 另一方中前一个已经放置的块
+	for _, blk := range secondary.blocks {
+		if nil == merged[blk.key] {
+			continue
+		}
+		if !placed[blk.key] {
+			pos := 0
+			if "" != anchor {
+				for i, key := range ret {
+					if key == anchor {
+						pos = i + 1
+						break
+					}
+				}
+			}
+			if secondary == t {
+				// 双方在同一位置插入的块 ours 在前
+				for pos < len(ret) && nil == b.keys[ret[pos]] && nil == t.keys[ret[pos]] {
+					pos++
+				}
+			}
+			ret = append(ret[:pos], append([]string{blk.key}, ret[pos:]...)...)
+			placed[blk.key] = true
+		}
+		anchor = blk.key
+	}
+	return
+}
+
+// reordered 判断 s 是否调整了与 base 共有的块的相对顺序。
+func reordered(base, s *side) bool {
+	var baseKeys, keys []string
+	for _, blk := range base.blocks {
+		if nil != s.keys[blk.key] {
+			baseKeys = append(baseKeys, blk.key)
+		}
+	}
+	for _, blk := range s.blocks {
+		if nil != base.keys[blk.key] {
+			keys = append(keys, blk.key)
+		}
+	}
+	for i := range keys {
+		if keys[i] != baseKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// conflict 生成冲突块，冲突内容依次为 ours 和 theirs 的 Markdown（包括内联属性列表），被删除的一方内容为空。
+func conflict(o, t *block, options *parse.Options) *ast.Node {
+	var oursMarkdown, theirsMarkdown string
+	if nil != o {
+		oursMarkdown = formatBlock(o.node, o.ial, options)
+	}
+	if nil != t {
+		theirsMarkdown = formatBlock(t.node, t.ial, options)
+	}
+
+	// 记录分隔行的位置，双方内容中也可能出现 ======= 行，比如代码块和 Setext 标题
+	content := oursMarkdown
+	if "" != content {
+		content += "\n"
+	}
+	offset := len(content)
+	content += separator
+	if "" != theirsMarkdown {
+		content += "\n" + theirsMarkdown
+	}
+
+	ret := &ast.Node{Type: ast.NodeGitConflict, ID: ast.NewNodeID()}
+	ret.AppendChild(&ast.Node{Type: ast.NodeGitConflictOpenMarker, Tokens: []byte(oursMarker)})
+	ret.AppendChild(&ast.Node{Type: ast.NodeGitConflictContent, Tokens: []byte(content), GitConflictSeparatorOffset: offset})
+	ret.AppendChild(&ast.Node{Type: ast.NodeGitConflictCloseMarker, Tokens: []byte(theirsMarker)})
+	return ret
+}
+
+// formatBlock 返回块 n 格式化后的 Markdown，ial 不为空时在块后输出内联属性列表。
+func formatBlock(n *ast.Node, ial [][]string, options *parse.Options) string {
+	tree := &parse.Tree{Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: options}}
+	tree.Context.Tree = tree
+	tree.Root.AppendChild(n.Clone())
+	renderOptions := render.NewOptions()
+	if 0 < len(ial) {
+		tree.Root.AppendChild(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(ial)})
+		renderOptions.KramdownBlockIAL = true
+	}
+	renderer := render.NewFormatRenderer(tree, renderOptions)
+	return strings.TrimSpace(string(renderer.Render()))
+}
@@ -0,0 +1,130 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package merge
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/util"
+)
+
+// Resolution 描述了冲突的解决方式。
+type Resolution int
+
+const (
+	ResolveOurs   Resolution = iota // 保留 ours
+	ResolveTheirs                   // 保留 theirs
+	ResolveBoth                     // 依次保留 ours 和 theirs
+)
+
+// Conflicts 返回 tree 中的所有冲突块。
+func Conflicts(tree *parse.Tree) (ret []*ast.Node) {
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeGitConflict == n.Type {
+			ret = append(ret, n)
+			return ast.WalkSkipChildren
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// Sides 返回冲突块 conflict 中 ours 和 theirs 的 Markdown。
+func Sides(conflict *ast.Node) (ours, theirs string, err error) {
+	if ast.NodeGitConflict != conflict.Type {
+		return "", "", errors.New("not a git conflict node")
+	}
+	content := conflict.ChildByType(ast.NodeGitConflictContent)
+	if nil == content {
+		return "", "", errors.New("git conflict content not found")
+	}
+
+	// 按记录的分隔行位置切分，不重新查找分隔行，因为双方内容中也可能出现 ======= 行
+	tokens, offset := string(content.Tokens), content.GitConflictSeparatorOffset
+	if 0 > offset || len(tokens) < offset+len(separator) || separator != tokens[offset:offset+len(separator)] || (0 < offset && '\n' != tokens[offset-1]) {
+		return "", "", errors.New("git conflict separator not found")
+	}
+	ours = strings.TrimSpace(tokens[:offset])
+	theirs = strings.TrimSpace(tokens[offset+len(separator):])
+	return
+}
+
+// Resolve 按 resolution 解决 tree 中的冲突块 conflict，冲突块会被替换为保留的一方或者双方的块。
+//
+// 保留双方时如果 theirs 中的块 ID 与 ours 重复，theirs 中的块会重新生成 ID。
+func Resolve(tree *parse.Tree, conflict *ast.Node, resolution Resolution) error {
+	ours, theirs, err := Sides(conflict)
+	if nil != err {
+		return err
+	}
+
+	var nodes []*ast.Node
+	ids := map[string]bool{}
+	if ResolveOurs == resolution || ResolveBoth == resolution {
+		for _, n := range parseBlocks(ours, tree.Context.ParseOption) {
+			if id := n.IALAttr("id"); "" != id {
+				ids[id] = true
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	if ResolveTheirs == resolution || ResolveBoth == resolution {
+		var renamed *ast.Node // 重新生成了 ID 的块，其后的块级内联属性列表需要随之更新
+		for _, n := range parseBlocks(theirs, tree.Context.ParseOption) {
+			if ast.NodeKramdownBlockIAL == n.Type {
+				if nil != renamed {
+					n.Tokens = parse.IAL2Tokens(renamed.KramdownIAL)
+				}
+			} else if id := n.IALAttr("id"); "" != id && ids[id] {
+				n = n.CloneWithNewIDs()
+				renamed = n
+			} else {
+				renamed = nil
+			}
+			nodes = append(nodes, n)
+		}
+	}
+
+	for _, n := range nodes {
+		conflict.InsertBefore(n)
+	}
+	conflict.Unlink()
+	return nil
+}
+
+// ResolveAll 按 resolution 解决 tree 中的所有冲突块。
+func ResolveAll(tree *parse.Tree, resolution Resolution) error {
+	for _, conflict := range Conflicts(tree) {
+		if err := Resolve(tree, conflict, resolution); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBlocks 将 markdown 解析为顶层块，块级内联属性列表节点紧随其块，文档内联属性列表被剔除。
+func parseBlocks(markdown string, options *parse.Options) (ret []*ast.Node) {
+	if "" == markdown {
+		return
+	}
+
+	tree := parse.Parse("", []byte(markdown), options)
+	for n := tree.Root.FirstChild; nil != n; n = n.Next {
+		if ast.NodeKramdownBlockIAL == n.Type && nil == n.Next && util.IsDocIAL(n.Tokens) {
+			continue
+		}
+		ret = append(ret, n)
+	}
+	return
+}
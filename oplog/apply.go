@@ -0,0 +1,237 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package oplog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// Apply 将操作列表 ops 依次应用到 tree 上，遇到无法应用的操作时返回错误，此前的操作已经生效。
+func Apply(tree *parse.Tree, ops []*Operation) error {
+	for i, op := range ops {
+		if err := apply(tree, op); nil != err {
+			return fmt.Errorf("apply operation [%d] %s [%s] failed: %s", i, op.Action, op.ID, err)
+		}
+	}
+	return nil
+}
+
+func apply(tree *parse.Tree, op *Operation) error {
+	switch op.Action {
+	case ActionInsert:
+		if nil != findBlock(tree.Root, op.ID) {
+			return errors.New("block already exists")
+		}
+		parent, previous, err := position(tree, op)
+		if nil != err {
+			return err
+		}
+		n, ial, err := parseBlock(tree, op)
+		if nil != err {
+			return err
+		}
+		place(parent, previous, n, ial)
+		renumber(parent)
+		return nil
+	case ActionMove:
+		n := findBlock(tree.Root, op.ID)
+		if nil == n {
+			return errors.New("block not found")
+		}
+		parent, previous, err := position(tree, op)
+		if nil != err {
+			return err
+		}
+		if n == previous || isAncestor(n, parent) {
+			return errors.New("can not move block into itself")
+		}
+		ial := blockIAL(n)
+		oldParent := n.Parent
+		n.Unlink()
+		if nil != ial {
+			ial.Unlink()
+		}
+		place(parent, previous, n, ial)
+		renumber(oldParent)
+		renumber(parent)
+		return nil
+	case ActionUpdate:
+		n := findBlock(tree.Root, op.ID)
+		if nil == n {
+			return errors.New("block not found")
+		}
+		updated, updatedIAL, err := parseBlock(tree, op)
+		if nil != err {
+			return err
+		}
+		update(n, updated, updatedIAL)
+		return nil
+	case ActionDelete:
+		n := findBlock(tree.Root, op.ID)
+		if nil == n {
+			return errors.New("block not found")
+		}
+		if ial := blockIAL(n); nil != ial {
+			ial.Unlink()
+		}
+		parent := n.Parent
+		n.Unlink()
+		renumber(parent)
+		return nil
+	}
+	return errors.New("unknown action")
+}
+
+// update 使用 updated 更新块 n，叶子块会被整个替换，容器块只更新容器本身的属性。
+func update(n, updated, updatedIAL *ast.Node) {
+	ial := blockIAL(n)
+	if n.IsContainerBlock() && n.Type == updated.Type {
+		n.ListData = updated.ListData
+		n.KramdownIAL = updated.KramdownIAL
+		if layout, updatedLayout := n.ChildByType(ast.NodeSuperBlockLayoutMarker), updated.ChildByType(ast.NodeSuperBlockLayoutMarker); nil != layout && nil != updatedLayout {
+			layout.Tokens = updatedLayout.Tokens
+		}
+	} else {
+		n.InsertBefore(updated)
+		n.Unlink()
+		n = updated
+	}
+
+	switch {
+	case nil != ial && nil != updatedIAL:
+		ial.Tokens = updatedIAL.Tokens
+	case nil != ial:
+		ial.Unlink()
+	case nil != updatedIAL:
+		n.InsertAfter(updatedIAL)
+	}
+}
+
+// position 返回 op 描述的位置，即父块以及前一个兄弟块（或者其内联属性列表），放置在第一个子块时 previous 为 nil。
+func position(tree *parse.Tree, op *Operation) (parent, previous *ast.Node, err error) {
+	parent = tree.Root
+	if "" != op.ParentID {
+		if parent = findBlock(tree.Root, op.ParentID); nil == parent {
+			return nil, nil, errors.New("parent block not found")
+		}
+	}
+	if "" == op.PreviousID {
+		return
+	}
+
+	previous = findBlock(parent, op.PreviousID)
+	if nil == previous || previous.Parent != parent {
+		return nil, nil, errors.New("previous block not found")
+	}
+	return
+}
+
+// place 将块 n 及其内联属性列表 ial 放置到 previous 之后，previous 为 nil 时放置为 parent 的第一个子块。
+func place(parent, previous, n, ial *ast.Node) {
+	if nil != previous {
+		if previousIAL := blockIAL(previous); nil != previousIAL {
+			previous = previousIAL
+		}
+		previous.InsertAfter(n)
+	} else {
+		// 跳过容器开头的标记符
+		c := parent.FirstChild
+		for nil != c && c.IsMarker() && ast.NodeSuperBlockCloseMarker != c.Type {
+			c = c.Next
+		}
+		if nil != c {
+			c.InsertBefore(n)
+		} else {
+			parent.AppendChild(n)
+		}
+	}
+	if nil != ial {
+		n.InsertAfter(ial)
+	}
+}
+
+// renumber 按位置重新计算有序列表 list 中列表项的序号，序号不属于操作携带的数据，移动、插入和删除列表项后需要重新计算。
+func renumber(list *ast.Node) {
+	if nil == list || ast.NodeList != list.Type || nil == list.ListData {
+		return
+	}
+
+	num := list.ListData.Start
+	if 1 > num {
+		num = 1
+	}
+	for li := list.FirstChild; nil != li; li = li.Next {
+		if ast.NodeListItem != li.Type || nil == li.ListData || 0 != li.ListData.BulletChar {
+			continue
+		}
+		marker := []byte(strconv.Itoa(num))
+		if 0 != li.ListData.Delimiter && bytes.HasSuffix(li.ListData.Marker, []byte{li.ListData.Delimiter}) {
+			marker = append(marker, li.ListData.Delimiter)
+		}
+		li.ListData.Num = num
+		li.ListData.Marker = marker
+		num++
+	}
+}
+
+func isAncestor(ancestor, n *ast.Node) bool {
+	for ; nil != n; n = n.Parent {
+		if ancestor == n {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBlock 解析 op 携带的 kramdown 源码，返回其中 ID 为 op.ID 的块及其内联属性列表。
+func parseBlock(tree *parse.Tree, op *Operation) (n, ial *ast.Node, err error) {
+	options := parse.NewOptions()
+	if nil != tree.Context && nil != tree.Context.ParseOption {
+		copied := *tree.Context.ParseOption
+		options = &copied
+	}
+	options.KramdownBlockIAL = true
+
+	parsed := parse.Parse("", []byte(op.Data), options)
+	if n = findBlock(parsed.Root, op.ID); nil == n {
+		return nil, nil, errors.New("block not found in data")
+	}
+	ial = blockIAL(n)
+	n.Unlink()
+	if nil != ial {
+		ial.Unlink()
+	}
+	return
+}
+
+func blockIAL(n *ast.Node) *ast.Node {
+	if nil != n.Next && ast.NodeKramdownBlockIAL == n.Next.Type {
+		return n.Next
+	}
+	return nil
+}
+
+func findBlock(root *ast.Node, id string) (ret *ast.Node) {
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && isBlock(n) && id == n.ID {
+			ret = n
+			return ast.WalkStop
+		}
+		return ast.WalkContinue
+	})
+	return
+}
@@ -0,0 +1,343 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package oplog 提供了块级操作日志，用于比较两棵语法树得到插入、更新、删除和移动操作，以及将操作应用到语法树上。
+//
+// 操作以块 ID 为键，块的位置通过父块 ID 和前一个兄弟块 ID 描述。插入和更新操作携带块的 kramdown 源码，
+// 叶子块的更新会替换整个块，容器块（列表、列表项、引述、超级块等）的更新只修改容器本身的属性，其子块由各自的操作维护。
+package oplog
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/editor"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// Action 描述了操作类型。
+type Action string
+
+const (
+	ActionInsert Action = "insert" // 插入
+	ActionUpdate Action = "update" // 更新
+	ActionDelete Action = "delete" // 删除
+	ActionMove   Action = "move"   // 移动
+)
+
+// Operation 描述了一个块级操作。
+type Operation struct {
+	Action     Action `json:"action"`         // 操作类型
+	ID         string `json:"id"`             // 块 ID
+	ParentID   string `json:"parentID"`       // 父块 ID，为空时表示文档根节点，删除操作为删除前的位置
+	PreviousID string `json:"previousID"`     // 前一个兄弟块 ID，为空时表示第一个子块，删除操作为删除前的位置
+	Data       string `json:"data,omitempty"` // 插入和更新操作的块 kramdown 源码
+}
+
+// Derive 比较 oldTree 和 newTree 并返回将 oldTree 变为 newTree 的有序操作列表。
+//
+// 插入、移动和更新按 newTree 的文档顺序排列，删除排在最后，这样每个操作引用的父块和前一个兄弟块在应用时都已经就位。
+// 新插入的容器块只携带其中新建的子块，已经存在的子块通过移动操作放入容器中。
+func Derive(oldTree, newTree *parse.Tree) (ret []*Operation) {
+	olds := blocks(oldTree.Root)
+	news := blocks(newTree.Root)
+	stable := stableBlocks(newTree.Root, olds)
+	inserted := map[*ast.Node]bool{} // 已经包含在插入操作中的块
+	var placeholders []*ast.Node
+
+	ast.Walk(newTree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !isBlock(n) {
+			return ast.WalkContinue
+		}
+
+		o := olds[n.ID]
+		if nil == o {
+			if !inserted[n.Parent] {
+				block, blockPlaceholders := shell(n, olds)
+				placeholders = append(placeholders, blockPlaceholders...)
+				ret = append(ret, &Operation{Action: ActionInsert, ID: n.ID, ParentID: parentID(n), PreviousID: previousID(n), Data: kramdownBlock(n, block, newTree.Context.ParseOption)})
+			}
+			inserted[n] = true
+			return ast.WalkContinue
+		}
+		if !stable[n.ID] {
+			ret = append(ret, &Operation{Action: ActionMove, ID: n.ID, ParentID: parentID(n), PreviousID: previousID(n)})
+		}
+		if signature(o, oldTree.Context.ParseOption) != signature(n, newTree.Context.ParseOption) {
+			ret = append(ret, &Operation{Action: ActionUpdate, ID: n.ID, ParentID: parentID(n), PreviousID: previousID(n), Data: kramdown(n, newTree.Context.ParseOption)})
+		}
+		return ast.WalkContinue
+	})
+
+	// 已经存在的子块移入后删除占位块
+	for _, placeholder := range placeholders {
+		ret = append(ret, &Operation{Action: ActionDelete, ID: placeholder.ID, ParentID: placeholder.Parent.ID, PreviousID: lastChildID(news[placeholder.Parent.ID])})
+	}
+
+	ast.Walk(oldTree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !isBlock(n) {
+			return ast.WalkContinue
+		}
+		if nil == news[n.ID] && ("" == parentID(n) || nil != news[parentID(n)]) {
+			// 子块如果还存在的话已经被移动走了，父块也被删除的块会随父块一起删除；父块仍然存在时父块会被移动走并带着它，
+			// 所以即使祖先块被删除也需要单独删除
+			ret = append(ret, &Operation{Action: ActionDelete, ID: n.ID, ParentID: parentID(n), PreviousID: previousID(n)})
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// isBlock 判断 n 是否为参与比较的块，即除文档和块级内联属性列表以外有 ID 的块级节点。
+func isBlock(n *ast.Node) bool {
+	return n.IsBlock() && ast.NodeDocument != n.Type && ast.NodeKramdownBlockIAL != n.Type && "" != n.ID
+}
+
+func blocks(root *ast.Node) (ret map[string]*ast.Node) {
+	ret = map[string]*ast.Node{}
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && isBlock(n) {
+			ret[n.ID] = n
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+func parentID(n *ast.Node) string {
+	if nil == n.Parent || ast.NodeDocument == n.Parent.Type {
+		return ""
+	}
+	return n.Parent.ID
+}
+
+func previousID(n *ast.Node) string {
+	for previous := n.Previous; nil != previous; previous = previous.Previous {
+		if isBlock(previous) {
+			return previous.ID
+		}
+	}
+	return ""
+}
+
+// stableBlocks 返回不需要移动的块。
+//
+// 父块没有变化的子块中，按旧顺序构成最长递增子序列的子块保持不动，其余子块以及父块发生变化的块需要移动。
+func stableBlocks(root *ast.Node, olds map[string]*ast.Node) (ret map[string]bool) {
+	ret = map[string]bool{}
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || (ast.NodeDocument != n.Type && !isBlock(n)) {
+			return ast.WalkContinue
+		}
+
+		var children []string
+		var indexes []int
+		for c := n.FirstChild; nil != c; c = c.Next {
+			if !isBlock(c) {
+				continue
+			}
+			if o := olds[c.ID]; nil != o && parentID(o) == parentID(c) {
+				children = append(children, c.ID)
+				indexes = append(indexes, siblingIndex(o))
+			}
+		}
+		for _, i := range increasingSubsequence(indexes) {
+			ret[children[i]] = true
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+func siblingIndex(n *ast.Node) (ret int) {
+	for previous := n.Previous; nil != previous; previous = previous.Previous {
+		if isBlock(previous) {
+			ret++
+		}
+	}
+	return
+}
+
+// increasingSubsequence 返回 values 的最长递增子序列的下标。
+func increasingSubsequence(values []int) (ret []int) {
+	var tails []int // tails[k] 为长度 k+1 的递增子序列的末尾下标
+	prev := make([]int, len(values))
+	for i, v := range values {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if values[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[i] = -1
+		if 0 < lo {
+			prev[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	if 0 < len(tails) {
+		for i := tails[len(tails)-1]; -1 != i; i = prev[i] {
+			ret = append([]int{i}, ret...)
+		}
+	}
+	return
+}
+
+// signature 返回用于判断块是否更新的签名，叶子块为其 kramdown 源码，容器块为容器本身的属性。
+func signature(n *ast.Node, options *parse.Options) string {
+	if !n.IsContainerBlock() {
+		return kramdown(n, options)
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteString(n.Type.String())
+	if nil != n.ListData {
+		// 有序列表项的序号会随着位置变化，不作为签名的一部分
+		buf.WriteString(" " + strconv.Itoa(n.ListData.Typ) + " " + strconv.FormatBool(n.ListData.Tight) + " " + strconv.FormatBool(n.ListData.Checked))
+		buf.WriteByte(n.ListData.BulletChar)
+		buf.WriteByte(n.ListData.Delimiter)
+	}
+	if layout := n.ChildByType(ast.NodeSuperBlockLayoutMarker); nil != layout {
+		buf.WriteString(" " + string(layout.Tokens))
+	}
+	buf.WriteString(" " + string(parse.IAL2Tokens(n.KramdownIAL)))
+	return buf.String()
+}
+
+// shell 返回插入块 n 时使用的副本，副本中去掉了 blocks 中已经存在的子块及其内联属性列表，这些子块通过移动操作放入。
+//
+// 子块全部被去掉的容器块无法通过 kramdown 准确描述，这时会在其中放入一个占位段落，所有子块移入后再删除占位段落。
+func shell(n *ast.Node, blocks map[string]*ast.Node) (ret *ast.Node, placeholders []*ast.Node) {
+	ret = n.Clone()
+	var removes, containers []*ast.Node
+	itemData := map[*ast.Node]*ast.ListData{} // 列表中第一个列表项的列表数据
+	ast.Walk(ret, func(c *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || !isBlock(c) {
+			return ast.WalkContinue
+		}
+		if ast.NodeListItem == c.Type && nil != c.ListData && nil != c.Parent && nil == itemData[c.Parent] {
+			itemData[c.Parent] = c.ListData
+		}
+		if ret != c && nil != blocks[c.ID] {
+			removes = append(removes, c)
+			return ast.WalkSkipChildren
+		}
+		if c.IsContainerBlock() {
+			containers = append(containers, c)
+		}
+		return ast.WalkContinue
+	})
+	for _, c := range removes {
+		if ial := blockIAL(c); nil != ial {
+			ial.Unlink()
+		}
+		c.Unlink()
+	}
+
+	for _, container := range containers {
+		if "" != lastChildID(container) {
+			continue
+		}
+
+		placeholder := newPlaceholder(ast.NodeParagraph)
+		// 空段落无法解析，放入一个零宽空格
+		placeholder.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: []byte(editor.Zwsp)})
+		if ast.NodeList == container.Type {
+			// 列表中只能放入列表项，Protyle 列表节点上没有列表标识符，所以占位列表项使用原列表项的列表数据
+			li := newPlaceholder(ast.NodeListItem)
+			li.ListData = placeholderListData(container, itemData[container])
+			appendBlock(li, placeholder)
+			placeholder = li
+		}
+		appendBlock(container, placeholder)
+		placeholders = append(placeholders, placeholder)
+	}
+	return
+}
+
+// placeholderListData 返回列表 list 中占位列表项的列表数据，优先复制原列表项的列表数据 item。
+func placeholderListData(list *ast.Node, item *ast.ListData) *ast.ListData {
+	if nil != item {
+		ret := *item
+		return &ret
+	}
+
+	ret := *list.ListData
+	if 1 == ret.Typ {
+		ret.BulletChar = 0
+		if 0 == ret.Delimiter {
+			ret.Delimiter = '.'
+		}
+	} else if 0 == ret.BulletChar {
+		ret.BulletChar = '*'
+	}
+	return &ret
+}
+
+func newPlaceholder(typ ast.NodeType) (ret *ast.Node) {
+	ret = &ast.Node{Type: typ, ID: ast.NewNodeID()}
+	ret.KramdownIAL = [][]string{{"id", ret.ID}}
+	return
+}
+
+// appendBlock 将块 n 及其内联属性列表追加到 container 中。
+func appendBlock(container, n *ast.Node) {
+	container.AppendChild(n)
+	container.AppendChild(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(n.KramdownIAL)})
+}
+
+// lastChildID 返回块 n 的最后一个子块 ID，没有子块时返回空字符串。
+func lastChildID(n *ast.Node) string {
+	for c := n.LastChild; nil != c; c = c.Previous {
+		if isBlock(c) {
+			return c.ID
+		}
+	}
+	return ""
+}
+
+// kramdown 返回块 n 及其内联属性列表的 kramdown 源码，列表项会被包裹在只包含它的列表中。
+func kramdown(n *ast.Node, options *parse.Options) string {
+	return kramdownBlock(n, n.Clone(), options)
+}
+
+// kramdownBlock 返回块 n 的副本 block 及 n 的内联属性列表的 kramdown 源码。
+func kramdownBlock(n, block *ast.Node, options *parse.Options) string {
+	tree := &parse.Tree{Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: options}}
+	tree.Context.Tree = tree
+
+	parent := tree.Root
+	if ast.NodeListItem == n.Type && nil != n.Parent && ast.NodeList == n.Parent.Type {
+		list := &ast.Node{Type: ast.NodeList, ID: n.Parent.ID}
+		listData := *n.Parent.ListData
+		list.ListData = &listData
+		tree.Root.AppendChild(list)
+		parent = list
+	}
+	parent.AppendChild(block)
+	if 0 < len(n.KramdownIAL) {
+		parent.AppendChild(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: parse.IAL2Tokens(n.KramdownIAL)})
+	}
+
+	renderOptions := render.NewOptions()
+	renderOptions.KramdownBlockIAL = true
+	renderer := render.NewFormatRenderer(tree, renderOptions)
+	return strings.TrimSpace(string(renderer.Render()))
+}
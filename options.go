@@ -0,0 +1,194 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package lute
+
+import (
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// Option 描述了选项快照设置函数签名，用于 With。渲染选项、解析选项和引擎设置函数分别通过 WithRender、WithParse 和 WithEngine 转换为 Option，
+// 预设比如 PresetGFM 以及 func(*Lute) 字面量可以直接作为 Option 使用。
+type Option func(lute *Lute)
+
+// With 返回 lute 的选项快照，并在快照上依次应用 opts，lute 本身不会被修改。
+//
+// 快照复制了解析选项、渲染选项、自定义渲染器函数和语法树变换器，所以可以在每次调用时使用不同的选项，
+// 比如 engine.With(WithRender(render.WithSanitize(true))).Markdown("", markdown)，多个 goroutine 可以并发地从同一个引擎创建快照，
+// 前提是创建快照期间不再调用该引擎的 Set* 方法。
+func (lute *Lute) With(opts ...Option) (ret *Lute) {
+	ret = &Lute{
+		ParseOptions:                  lute.ParseOptions.Clone(),
+		RenderOptions:                 lute.RenderOptions.Clone(),
+		HTML2MdRendererFuncs:          copyExtRendererFuncs(lute.HTML2MdRendererFuncs),
+		HTML2VditorDOMRendererFuncs:   copyExtRendererFuncs(lute.HTML2VditorDOMRendererFuncs),
+		HTML2VditorIRDOMRendererFuncs: copyExtRendererFuncs(lute.HTML2VditorIRDOMRendererFuncs),
+		HTML2BlockDOMRendererFuncs:    copyExtRendererFuncs(lute.HTML2BlockDOMRendererFuncs),
+		HTML2VditorSVDOMRendererFuncs: copyExtRendererFuncs(lute.HTML2VditorSVDOMRendererFuncs),
+		Md2HTMLRendererFuncs:          copyExtRendererFuncs(lute.Md2HTMLRendererFuncs),
+		Md2VditorDOMRendererFuncs:     copyExtRendererFuncs(lute.Md2VditorDOMRendererFuncs),
+		Md2VditorIRDOMRendererFuncs:   copyExtRendererFuncs(lute.Md2VditorIRDOMRendererFuncs),
+		Md2BlockDOMRendererFuncs:      copyExtRendererFuncs(lute.Md2BlockDOMRendererFuncs),
+		Md2VditorSVDOMRendererFuncs:   copyExtRendererFuncs(lute.Md2VditorSVDOMRendererFuncs),
+		transformers:                  append([]*transformer{}, lute.transformers...),
+	}
+
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return
+}
+
+// WithRender 返回在快照上依次应用渲染选项 opts 的 Option，比如 engine.With(WithRender(render.WithSanitize(true)))。
+func WithRender(opts ...render.Option) Option {
+	return func(lute *Lute) {
+		for _, opt := range opts {
+			opt(lute.RenderOptions)
+		}
+	}
+}
+
+// WithParse 返回在快照上依次应用解析选项 opts 的 Option，比如 engine.With(WithParse(parse.WithMark(true)))。
+func WithParse(opts ...parse.Option) Option {
+	return func(lute *Lute) {
+		for _, opt := range opts {
+			opt(lute.ParseOptions)
+		}
+	}
+}
+
+// WithEngine 返回在快照上依次应用引擎设置函数 opts 的 Option，用于复用 New 的 ParseOption，比如 engine.With(WithEngine(opts...))。
+func WithEngine(opts ...ParseOption) Option {
+	return func(lute *Lute) {
+		for _, opt := range opts {
+			opt(lute)
+		}
+	}
+}
+
+func copyExtRendererFuncs(funcs map[ast.NodeType]render.ExtRendererFunc) (ret map[ast.NodeType]render.ExtRendererFunc) {
+	ret = make(map[ast.NodeType]render.ExtRendererFunc, len(funcs))
+	for nodeType, rendererFunc := range funcs {
+		ret[nodeType] = rendererFunc
+	}
+	return
+}
+
+// 以下预设用于 New 或者 With，比如 lute.New(lute.PresetGFM) 或者 engine.With(lute.PresetProtyle)。
+//
+// 预设会完整设置语法扩展和编辑器模式相关的解析、渲染选项，不涉及 Sanitize、LinkBase、代码块渲染器等和语法无关的选项，
+// 所以预设之后可以继续使用渲染选项和解析选项覆盖，比如 engine.With(lute.PresetGFM, lute.WithRender(render.WithSanitize(true)))。
+
+// PresetCommonMark 设置为严格的 CommonMark 规范：关闭所有语法扩展，软换行不转换为硬换行，代码块不进行语法高亮。
+func PresetCommonMark(lute *Lute) {
+	lute.SetGFMTable(false)
+	lute.SetGFMTaskListItem(false)
+	lute.SetGFMStrikethrough(false)
+	lute.SetGFMAutoLink(false)
+	lute.SetFootnotes(false)
+	lute.SetToC(false)
+	lute.SetHeadingID(false)
+	lute.SetEmoji(false)
+	lute.SetYamlFrontMatter(false)
+	lute.SetBlockRef(false)
+	lute.SetFileAnnotationRef(false)
+	lute.SetMark(false)
+	lute.SetWikilink(false)
+	lute.SetKramdownIAL(false)
+	lute.SetTag(false)
+	lute.SetImgPathAllowSpace(false)
+	lute.SetSuperBlock(false)
+	lute.SetSup(false)
+	lute.SetSub(false)
+	lute.SetGitConflict(false)
+	lute.SetTextMark(false)
+	lute.SetHTMLTag2TextMark(false)
+	lute.SetParagraphBeginningSpace(false)
+	lute.SetInlineMathAllowDigitAfterOpenMarker(false)
+	lute.ParseOptions.Include = false
+	lute.ParseOptions.DataImage = true
+	lute.SetSetext(true)
+	lute.SetLinkRef(true)
+	lute.SetIndentCodeBlock(true)
+
+	lute.SetVditorWYSIWYG(false)
+	lute.SetVditorIR(false)
+	lute.SetVditorSV(false)
+	lute.SetProtyleWYSIWYG(false)
+
+	lute.SetSoftBreak2HardBreak(false)
+	lute.SetCodeSyntaxHighlight(false)
+	lute.SetAutoSpace(false)
+	lute.SetFixTermTypo(false)
+	lute.SetSmartTypography(false)
+	lute.SetChineseParagraphBeginningSpace(false)
+	lute.SetHeadingAnchor(false)
+}
+
+// PresetGFM 设置为 GitHub Flavored Markdown：表格、任务列表、删除线、自动链接、脚注、Emoji 别名和 YAML Front Matter，软换行不转换为硬换行。
+func PresetGFM(lute *Lute) {
+	PresetCommonMark(lute)
+	lute.SetGFMTable(true)
+	lute.SetGFMTaskListItem(true)
+	lute.SetGFMStrikethrough(true)
+	lute.SetGFMAutoLink(true)
+	lute.SetFootnotes(true)
+	lute.SetEmoji(true)
+	lute.SetYamlFrontMatter(true)
+	lute.SetCodeSyntaxHighlight(true)
+}
+
+// PresetProtyle 设置为思源笔记 Protyle 编辑器使用的语法：kramdown 内联属性列表、内容块引用、超级块、标签、标记、上下标和通用行级节点等，
+// 不支持 Setext 标题、缩进代码块、链接引用和 YAML Front Matter。
+func PresetProtyle(lute *Lute) {
+	PresetCommonMark(lute)
+	lute.SetProtyleWYSIWYG(true)
+	lute.SetGFMTable(true)
+	lute.SetGFMTaskListItem(true)
+	lute.SetGFMStrikethrough(true)
+	lute.SetGFMAutoLink(true)
+	lute.SetEmoji(true)
+	lute.SetBlockRef(true)
+	lute.SetFileAnnotationRef(true)
+	lute.SetKramdownIAL(true)
+	lute.SetTag(true)
+	lute.SetSuperBlock(true)
+	lute.SetImgPathAllowSpace(true)
+	lute.SetGitConflict(true)
+	lute.SetMark(true)
+	lute.SetSup(true)
+	lute.SetSub(true)
+	lute.SetTextMark(true)
+	lute.SetHTMLTag2TextMark(true)
+	lute.SetInlineMathAllowDigitAfterOpenMarker(true)
+	lute.SetParagraphBeginningSpace(true)
+	lute.SetSetext(false)
+	lute.SetIndentCodeBlock(false)
+	lute.SetLinkRef(false)
+}
+
+// PresetVditorIR 设置为 Vditor 即时渲染模式使用的语法：GFM、脚注、标题自定义 ID、Emoji 别名和 YAML Front Matter，软换行转换为硬换行。
+func PresetVditorIR(lute *Lute) {
+	PresetGFM(lute)
+	lute.SetVditorIR(true)
+	lute.SetHeadingID(true)
+	lute.SetSoftBreak2HardBreak(true)
+}
+
+// PresetObsidian 设置为兼容 Obsidian 的语法：GFM、脚注、==标记==、[[wikilink]] 和 YAML Front Matter，软换行转换为硬换行，不进行 Emoji 别名替换。
+func PresetObsidian(lute *Lute) {
+	PresetGFM(lute)
+	lute.SetMark(true)
+	lute.SetWikilink(true)
+	lute.SetEmoji(false)
+	lute.SetSoftBreak2HardBreak(true)
+}
@@ -33,6 +33,7 @@ func BlockQueryEmbedStart(t *Tree, container *ast.Node) int {
 	for !t.Context.Tip.CanContain(ast.NodeBlockQueryEmbed) {
 		t.Context.finalize(t.Context.Tip) // 注意调用 finalize 会向父节点方向进行迭代
 	}
+	t.Context.sourceSpan(node)
 	t.Context.Tip.AppendChild(node)
 	t.Context.Tip = node
 	return 2
@@ -24,6 +24,7 @@ func (t *Tree) parseBlocks() {
 	t.Context.Tip = t.Root
 	lines := 0
 	for line := t.lexer.NextLine(); nil != line; line = t.lexer.NextLine() {
+		t.Context.lineStart = t.lexer.Offset() - len(line)
 		if t.Context.ParseOption.VditorWYSIWYG || t.Context.ParseOption.VditorIR || t.Context.ParseOption.VditorSV || t.Context.ParseOption.ProtyleWYSIWYG {
 			if !bytes.Equal(line, editor.CaretNewlineTokens) && t.Context.Tip.ParentIs(ast.NodeListItem) && bytes.HasPrefix(line, editor.CaretTokens) {
 				// 插入符在开头的话移动到上一行结尾，处理 https://github.com/Vanessa219/vditor/issues/633 中的一些情况
@@ -152,6 +153,7 @@ func (t *Tree) incorporateLine(line []byte) {
 			lex.ItemDollar != maybeMarker && // 数学公式
 			lex.ItemOpenBracket != maybeMarker && // 脚注
 			lex.ItemOpenBrace != maybeMarker && // kramdown 内联属性列表或超级块开始
+			lex.ItemSemicolon != maybeMarker && // JSON Front Matter
 			lex.ItemCloseBrace != maybeMarker && // 超级块闭合
 			lex.ItemBang != maybeMarker && "！"[0] != maybeMarker && // 内容块嵌入
 			editor.Caret[0] != maybeMarker { // Vditor 编辑器支持
@@ -26,7 +26,7 @@ func FenceCodeBlockStart(t *Tree, container *ast.Node) int {
 		return 0
 	}
 
-	if ok, codeBlockFenceChar, codeBlockFenceLen, codeBlockFenceOffset, codeBlockOpenFence, codeBlockInfo := t.parseFencedCode(); ok {
+	if ok, codeBlockFenceChar, codeBlockFenceLen, codeBlockFenceOffset, codeBlockOpenFence, codeBlockInfo, codeBlockInfoMeta := t.parseFencedCode(); ok {
 		t.Context.closeUnmatchedBlocks()
 		container := t.Context.addChild(ast.NodeCodeBlock)
 		container.IsFencedCodeBlock = true
@@ -35,6 +35,7 @@ func FenceCodeBlockStart(t *Tree, container *ast.Node) int {
 		container.CodeBlockFenceOffset = codeBlockFenceOffset
 		container.CodeBlockOpenFence = codeBlockOpenFence
 		container.CodeBlockInfo = codeBlockInfo
+		container.CodeBlockInfoMeta = codeBlockInfoMeta
 		t.Context.advanceNextNonspace()
 		t.Context.advanceOffset(codeBlockFenceLen, false)
 		return 2
@@ -112,7 +113,7 @@ func (context *Context) codeBlockFinalize(codeBlock *ast.Node) {
 
 var codeBlockBacktick = util.StrToBytes("`")
 
-func (t *Tree) parseFencedCode() (ok bool, fenceChar byte, fenceLen int, fenceOffset int, openFence, codeBlockInfo []byte) {
+func (t *Tree) parseFencedCode() (ok bool, fenceChar byte, fenceLen int, fenceOffset int, openFence, codeBlockInfo, codeBlockInfoMeta []byte) {
 	marker := t.Context.currentLine[t.Context.nextNonspace]
 	if lex.ItemBacktick != marker && lex.ItemTilde != marker {
 		return
@@ -144,11 +145,14 @@ func (t *Tree) parseFencedCode() (ok bool, fenceChar byte, fenceLen int, fenceOf
 		return
 	}
 	info := lex.TrimWhitespace(infoTokens)
-	info = html.UnescapeBytes(info)
+	var meta []byte
 	if idx := bytes.IndexByte(info, ' '); 0 <= idx {
+		// 元数据保留原文，格式化时原样输出，渲染时再反转义
+		meta = lex.TrimWhitespace(info[idx+1:])
 		info = info[:idx]
 	}
-	return true, fenceChar, fenceLen, t.Context.indent, openFence, info
+	info = html.UnescapeBytes(info)
+	return true, fenceChar, fenceLen, t.Context.indent, openFence, info, meta
 }
 
 func (context *Context) isFencedCodeClose(tokens []byte, openMarker byte, num int) (ok bool, closeFence []byte) {
@@ -55,10 +55,22 @@ func (context *Context) gitConflictFinalize(gitConflictBlock *ast.Node) {
 	closeMarkerTokens := bytes.TrimSpace(context.currentLine)
 	gitConflictBlock.Tokens = nil
 	gitConflictBlock.AppendChild(&ast.Node{Type: ast.NodeGitConflictOpenMarker, Tokens: openMarkerTokens})
-	gitConflictBlock.AppendChild(&ast.Node{Type: ast.NodeGitConflictContent, Tokens: content})
+	gitConflictBlock.AppendChild(&ast.Node{Type: ast.NodeGitConflictContent, Tokens: content, GitConflictSeparatorOffset: gitConflictSeparatorOffset(content)})
 	gitConflictBlock.AppendChild(&ast.Node{Type: ast.NodeGitConflictCloseMarker, Tokens: closeMarkerTokens})
 }
 
+// gitConflictSeparatorOffset 返回冲突内容 content 中第一个 ======= 分隔行的起始偏移，没有分隔行时返回 -1。
+func gitConflictSeparatorOffset(content []byte) int {
+	offset := 0
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if bytes.Equal(bytes.TrimSpace(line), []byte("=======")) {
+			return offset
+		}
+		offset += len(line)
+	}
+	return -1
+}
+
 func (t *Tree) parseGitConflict() (ok bool) {
 	return bytes.HasPrefix(t.Context.currentLine, []byte("<<<<<<<"))
 }
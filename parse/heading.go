@@ -79,6 +79,9 @@ func SetextHeadingStart(t *Tree, container *ast.Node) int {
 	// 解析链接引用定义
 	for tokens := container.Tokens; 0 < len(tokens) && lex.ItemOpenBracket == tokens[0]; tokens = container.Tokens {
 		if remains := t.Context.parseLinkRefDef(tokens); nil != remains {
+			if t.Context.ParseOption.SourceSpan {
+				t.Context.linkRefDefSourceSpan(container, tokens[:len(tokens)-len(remains)])
+			}
 			container.Tokens = remains
 		} else {
 			break
@@ -88,6 +91,7 @@ func SetextHeadingStart(t *Tree, container *ast.Node) int {
 	if 0 < len(container.Tokens) {
 		child := &ast.Node{Type: ast.NodeHeading, HeadingLevel: level, HeadingSetext: true}
 		child.Tokens = lex.TrimWhitespace(container.Tokens)
+		child.SourceSpan = container.SourceSpan
 		container.InsertAfter(child)
 		container.Unlink()
 		t.Context.Tip = child
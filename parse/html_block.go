@@ -107,7 +107,9 @@ func (t *Tree) isHTMLBlockClose(tokens []byte, htmlType int) bool {
 		if ial := t.Context.parseKramdownBlockIAL(tokens); 0 < len(ial) {
 			t.Context.Tip.ID = IAL2Map(ial)["id"]
 			t.Context.Tip.KramdownIAL = ial
-			t.Context.Tip.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: tokens})
+			ialNode := &ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: tokens}
+			t.Context.sourceSpan(ialNode)
+			t.Context.Tip.InsertAfter(ialNode)
 			return true
 		}
 	}
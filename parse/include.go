@@ -0,0 +1,407 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/util"
+)
+
+// IncludeDefaultMaxDepth 为文件包含默认的最大嵌套深度。
+const IncludeDefaultMaxDepth = 8
+
+// includeDirective 描述了一个文件包含指令。
+//
+// 支持以下两种写法：
+//
+//	!include path.md
+//	!include path.md#标题
+//	!include path.md:10-20
+//	{{include "path.md"}}
+//	{{include "path.md" heading="标题" lines="10-20"}}
+type includeDirective struct {
+	node    *ast.Node // 指令所在的块节点
+	path    string    // 被包含文件路径
+	heading string    // 仅包含该标题下的内容
+	start   int       // 起始行号（从 1 开始），0 表示从文件开头
+	end     int       // 结束行号（包含），0 表示到文件末尾
+
+	nodes []*ast.Node // 被包含文件解析后的块节点
+	err   error       // 包含失败的原因
+}
+
+// includeDirectives 收集树上所有的文件包含指令。需要在行级解析前调用，因为此时段落中还保留着原始 tokens。
+func (t *Tree) includeDirectives() (ret []*includeDirective) {
+	if !t.Context.ParseOption.Include || nil == t.Context.ParseOption.IncludeFS {
+		return
+	}
+
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+
+		var directive *includeDirective
+		switch n.Type {
+		case ast.NodeParagraph:
+			directive = parseIncludeDirective(n.Tokens)
+		case ast.NodeBlockQueryEmbed:
+			// 开启内容块引用时 {{include "path.md"}} 会被解析为内容块查询嵌入
+			if script := n.ChildByType(ast.NodeBlockQueryEmbedScript); nil != script {
+				directive = parseIncludeDirective([]byte("{{" + util.BytesToStr(script.Tokens) + "}}"))
+			}
+		default:
+			if n.IsContainerBlock() {
+				return ast.WalkContinue
+			}
+			return ast.WalkSkipChildren
+		}
+
+		if nil != directive {
+			directive.node = n
+			ret = append(ret, directive)
+		}
+		return ast.WalkSkipChildren
+	})
+	return
+}
+
+// parseIncludes 解析 directives 指定的文件。需要在行级解析前调用，这样当前文件和被包含文件中的链接引用可以使用对方的链接引用定义。
+func (t *Tree) parseIncludes(directives []*includeDirective) {
+	for _, directive := range directives {
+		directive.nodes, directive.err = t.include(directive)
+	}
+}
+
+// expandIncludes 使用 directives 指定的文件解析后的块节点替换掉指令所在的块节点。包含失败的指令保持原样，错误记录在 t.IncludeErrs 中。
+func (t *Tree) expandIncludes(directives []*includeDirective) {
+	for _, directive := range directives {
+		if nil != directive.err {
+			t.IncludeErrs = append(t.IncludeErrs, directive.err)
+			continue
+		}
+
+		for _, n := range directive.nodes {
+			clearSourceSpans(n)
+			if span := directive.node.SourceSpan; nil != span {
+				// 被包含的节点对应的源码是包含指令
+				n.SourceSpan = &ast.SourceSpan{Start: span.Start}
+			}
+			directive.node.InsertBefore(n)
+		}
+		directive.node.Unlink()
+	}
+}
+
+func (t *Tree) include(directive *includeDirective) (ret []*ast.Node, err error) {
+	options := t.Context.ParseOption
+	current := ""
+	if stack := t.Context.includeStack; 0 < len(stack) {
+		current = stack[len(stack)-1]
+	}
+
+	p := directive.path
+	if strings.HasPrefix(p, "/") {
+		p = path.Clean(p[1:])
+	} else {
+		p = path.Join(path.Dir(current), p)
+	}
+	if !fs.ValidPath(p) {
+		return nil, errors.New("invalid include path [" + directive.path + "]")
+	}
+
+	for _, included := range t.Context.includeStack {
+		if included == p {
+			return nil, errors.New("include cycle detected [" + strings.Join(append(t.Context.includeStack, p), " -> ") + "]")
+		}
+	}
+
+	maxDepth := options.IncludeMaxDepth
+	if 1 > maxDepth {
+		maxDepth = IncludeDefaultMaxDepth
+	}
+	if len(t.Context.includeStack) >= maxDepth {
+		return nil, errors.New("include depth exceeds [" + strconv.Itoa(maxDepth) + "] at [" + p + "]")
+	}
+
+	data, err := fs.ReadFile(options.IncludeFS, p)
+	if nil != err {
+		return nil, errors.New("read include file [" + p + "] failed: " + err.Error())
+	}
+	data = includeLines(data, directive.start, directive.end)
+
+	stack := make([]string, len(t.Context.includeStack), len(t.Context.includeStack)+1)
+	copy(stack, t.Context.includeStack)
+	stack = append(stack, p)
+	subTree := parseIncluded(p, data, options, stack, t.linkRefDefRoots())
+	t.IncludeErrs = append(t.IncludeErrs, subTree.IncludeErrs...)
+	t.Context.includeRoots = append(t.Context.includeRoots, subTree.Root)
+
+	// 被包含文件中的相对链接是相对该文件所在目录的，需要改为相对当前文件所在目录
+	rebaseIncludeLinks(subTree, relativeDir(path.Dir(current), path.Dir(p)))
+
+	for c := subTree.Root.FirstChild; nil != c; c = c.Next {
+		ret = append(ret, c)
+	}
+
+	if "" != directive.heading {
+		if ret = headingSection(ret, directive.heading); nil == ret {
+			return nil, errors.New("not found heading [" + directive.heading + "] in include file [" + p + "]")
+		}
+	}
+	return
+}
+
+// parseIncluded 解析被包含的文件，includeStack 为当前包含链，includeRoots 为包含链上的文件的根节点。
+func parseIncluded(name string, markdown []byte, options *Options, includeStack []string, includeRoots []*ast.Node) (tree *Tree) {
+	tree = &Tree{Name: name, Context: &Context{ParseOption: options, includeStack: includeStack, includeRoots: includeRoots}}
+	tree.Context.Tree = tree
+	tree.lexer = lex.NewLexer(markdown)
+	tree.Root = &ast.Node{Type: ast.NodeDocument}
+	tree.parseBlocks()
+	includes := tree.includeDirectives()
+	tree.parseIncludes(includes)
+	tree.parseInlines()
+	tree.expandIncludes(includes)
+	tree.lexer = nil
+	return
+}
+
+// parseIncludeDirective 从块 tokens 中解析文件包含指令，不是文件包含指令时返回 nil。
+func parseIncludeDirective(tokens []byte) (ret *includeDirective) {
+	tokens = bytes.TrimSpace(tokens)
+	if bytes.ContainsAny(tokens, "\r\n") {
+		return
+	}
+
+	str := util.BytesToStr(tokens)
+	if strings.HasPrefix(str, "!include ") {
+		target := strings.TrimSpace(str[len("!include "):])
+		if "" == target {
+			return
+		}
+		ret = &includeDirective{}
+		ret.path, ret.heading, ret.start, ret.end = splitIncludeTarget(target)
+		if "" == ret.path {
+			return nil
+		}
+		return
+	}
+
+	if !strings.HasPrefix(str, "{{") || !strings.HasSuffix(str, "}}") {
+		return
+	}
+	str = strings.TrimSpace(str[2 : len(str)-2])
+	if !strings.HasPrefix(str, "include ") {
+		return
+	}
+	str = strings.TrimSpace(str[len("include "):])
+	target, remains, ok := unquoteIncludeArg(str)
+	if !ok {
+		return
+	}
+
+	ret = &includeDirective{}
+	ret.path, ret.heading, ret.start, ret.end = splitIncludeTarget(target)
+	for remains = strings.TrimSpace(remains); "" != remains; remains = strings.TrimSpace(remains) {
+		eq := strings.Index(remains, "=")
+		if 1 > eq {
+			return nil
+		}
+		name := strings.TrimSpace(remains[:eq])
+		var value string
+		if value, remains, ok = unquoteIncludeArg(strings.TrimSpace(remains[eq+1:])); !ok {
+			return nil
+		}
+		switch name {
+		case "heading":
+			ret.heading = value
+		case "lines":
+			if ret.start, ret.end, ok = parseIncludeLines(value); !ok {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
+	if "" == ret.path {
+		return nil
+	}
+	return
+}
+
+// splitIncludeTarget 将 path.md#标题 或者 path.md:10-20 形式的包含目标拆分为路径、标题和行范围。
+func splitIncludeTarget(target string) (p, heading string, start, end int) {
+	if idx := strings.Index(target, "#"); 0 <= idx {
+		return target[:idx], strings.TrimSpace(target[idx+1:]), 0, 0
+	}
+
+	if idx := strings.LastIndex(target, ":"); 0 < idx {
+		if s, e, ok := parseIncludeLines(target[idx+1:]); ok {
+			return target[:idx], "", s, e
+		}
+	}
+	return target, "", 0, 0
+}
+
+// parseIncludeLines 解析 10-20、10- 或者 10 形式的行范围。
+func parseIncludeLines(str string) (start, end int, ok bool) {
+	parts := strings.SplitN(str, "-", 2)
+	var err error
+	if start, err = strconv.Atoi(strings.TrimSpace(parts[0])); nil != err || 1 > start {
+		return 0, 0, false
+	}
+	if 1 == len(parts) {
+		return start, start, true
+	}
+	if e := strings.TrimSpace(parts[1]); "" != e {
+		if end, err = strconv.Atoi(e); nil != err || end < start {
+			return 0, 0, false
+		}
+	}
+	return start, end, true
+}
+
+// unquoteIncludeArg 读取 str 开头的一个双引号包裹的参数值，返回参数值和剩余部分。
+func unquoteIncludeArg(str string) (value, remains string, ok bool) {
+	if !strings.HasPrefix(str, "\"") {
+		return
+	}
+	end := strings.Index(str[1:], "\"")
+	if 0 > end {
+		return
+	}
+	return str[1 : end+1], str[end+2:], true
+}
+
+// includeLines 截取 data 中 [start, end] 行，行号从 1 开始，end 为 0 时表示到末尾。
+func includeLines(data []byte, start, end int) []byte {
+	if 1 > start {
+		return data
+	}
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if start > len(lines) {
+		return nil
+	}
+	if 1 > end || end > len(lines) {
+		end = len(lines)
+	}
+	return bytes.Join(lines[start-1:end], nil)
+}
+
+// headingSection 在 nodes 中查找文本或者自定义 ID 为 heading 的标题，返回该标题及其下属的内容块。
+func headingSection(nodes []*ast.Node, heading string) (ret []*ast.Node) {
+	level := 0
+	for _, n := range nodes {
+		if 0 < level {
+			if ast.NodeHeading == n.Type && n.HeadingLevel <= level {
+				break
+			}
+			ret = append(ret, n)
+			continue
+		}
+
+		if ast.NodeHeading != n.Type {
+			continue
+		}
+		id := ""
+		if headingID := n.ChildByType(ast.NodeHeadingID); nil != headingID {
+			id = util.BytesToStr(headingID.Tokens)
+		}
+		if heading == strings.TrimSpace(n.Text()) || heading == id {
+			level = n.HeadingLevel
+			ret = append(ret, n)
+		}
+	}
+	return
+}
+
+// rebaseIncludeLinks 使用 base 作为被包含文件 tree 中链接、图片相对地址的前缀。
+//
+// 链接引用的地址取自链接引用定义，引用的是包含链上其他文件中的定义时，地址已经相对于那个文件，不需要添加前缀。
+func rebaseIncludeLinks(tree *Tree, base string) {
+	if "" == base || "." == base {
+		return
+	}
+
+	own := &Tree{Root: tree.Root, Context: &Context{ParseOption: tree.Context.ParseOption}}
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeLinkDest != n.Type {
+			return ast.WalkContinue
+		}
+		if 1 > len(n.Tokens) || '#' == n.Tokens[0] || hasURIScheme(n.Tokens) {
+			return ast.WalkContinue
+		}
+		if link := n.Parent; 3 == link.LinkType && nil == own.FindLinkRefDefLink(link.LinkRefLabel) {
+			return ast.WalkContinue
+		}
+		n.Tokens = util.RelativePath(base, n.Tokens)
+		return ast.WalkContinue
+	})
+}
+
+// hasURIScheme 判断链接地址 dest 是否以 URI 协议（[A-Za-z][A-Za-z0-9+.-]*:）开头，比如 mailto:、tel: 和 data:。
+func hasURIScheme(dest []byte) bool {
+	if 1 > len(dest) || !lex.IsASCIILetter(dest[0]) {
+		return false
+	}
+	for _, c := range dest[1:] {
+		if ':' == c {
+			return true
+		}
+		if !lex.IsASCIILetter(c) && !lex.IsDigit(c) && '+' != c && '.' != c && '-' != c {
+			return false
+		}
+	}
+	return false
+}
+
+// relativeDir 返回从目录 from 到目录 to 的相对路径，两者都是 fs.FS 中的路径。
+func relativeDir(from, to string) string {
+	if from == to {
+		return "."
+	}
+
+	var fromParts, toParts []string
+	if "." != from {
+		fromParts = strings.Split(from, "/")
+	}
+	if "." != to {
+		toParts = strings.Split(to, "/")
+	}
+	i := 0
+	for ; i < len(fromParts) && i < len(toParts) && fromParts[i] == toParts[i]; i++ {
+	}
+
+	var parts []string
+	for j := i; j < len(fromParts); j++ {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, toParts[i:]...)
+	if 1 > len(parts) {
+		return "."
+	}
+	return strings.Join(parts, "/")
+}
+
+// linkRefDefRoots 返回查找链接引用定义的根节点，依次为当前文件、被包含文件以及包含当前文件的文件。
+func (t *Tree) linkRefDefRoots() []*ast.Node {
+	return append([]*ast.Node{t.Root}, t.Context.includeRoots...)
+}
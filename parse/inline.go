@@ -158,7 +158,8 @@ func (t *Tree) parseCloseBracket(ctx *InlineContext) *ast.Node {
 	ob2 := ctx.tokens[opener.index+1]
 	
 	// if we are at ]] and the opener is [[
-	if ob1 == lex.ItemOpenBracket &&
+	if t.Context.ParseOption.Wikilink &&
+		ob1 == lex.ItemOpenBracket &&
 		ob2 == lex.ItemOpenBracket &&
 		(ctx.tokens[ctx.pos-1] == lex.ItemCloseBracket) &&
 		(ctx.tokens[ctx.pos-2] == lex.ItemCloseBracket) {
@@ -35,6 +35,7 @@ func IALStart(t *Tree, container *ast.Node) int {
 		t.Context.offset = t.Context.currentLineLen // 整行过
 		if util.IsDocIAL2(ial) {                    // 文档块 IAL
 			t.Context.rootIAL = &ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: t.Context.currentLine[t.Context.nextNonspace:]}
+			t.Context.sourceSpan(t.Context.rootIAL)
 			t.Root.KramdownIAL = ial
 			t.Root.ID = ial[0][1]
 			t.ID = t.Root.ID
@@ -64,13 +64,18 @@ func (context *Context) parseInlineLinkDest(tokens []byte) (passed, remains, des
 			}
 		}
 
-		if !matchEnd || length <= i+1 || lex.ItemCloseParen != tokens[i+1] {
+		if !matchEnd || length <= i+1 || (lex.ItemCloseParen != tokens[i+1] && !lex.IsWhitespace(tokens[i+1])) {
 			passed = nil
 			return
 		}
 
-		passed = append(passed, tokens[i+1])
-		remains = tokens[i+2:]
+		if lex.ItemCloseParen == tokens[i+1] {
+			passed = append(passed, tokens[i+1])
+			remains = tokens[i+2:]
+		} else {
+			// <dest> 后跟空白时后续尝试 title 解析
+			remains = tokens[i+1:]
+		}
 	} else {
 		var openParens int
 		i := 0
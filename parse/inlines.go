@@ -99,7 +99,7 @@ func (t *Tree) walkParseInline(node *ast.Node) {
 			// 细化围栏代码块子节点
 			openMarker := &ast.Node{Type: ast.NodeCodeBlockFenceOpenMarker, Tokens: node.CodeBlockOpenFence, CodeBlockFenceLen: node.CodeBlockFenceLen}
 			node.PrependChild(openMarker)
-			info := &ast.Node{Type: ast.NodeCodeBlockFenceInfoMarker, CodeBlockInfo: node.CodeBlockInfo}
+			info := &ast.Node{Type: ast.NodeCodeBlockFenceInfoMarker, CodeBlockInfo: node.CodeBlockInfo, CodeBlockInfoMeta: node.CodeBlockInfoMeta}
 			node.AppendChild(info)
 			code := &ast.Node{Type: ast.NodeCodeBlockCode, Tokens: node.Tokens}
 			node.AppendChild(code)
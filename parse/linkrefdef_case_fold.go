@@ -29,20 +29,26 @@ func (t *Tree) FindLinkRefDefLink(label []byte) (link *ast.Node) {
 	if t.Context.ParseOption.VditorIR || t.Context.ParseOption.VditorSV || t.Context.ParseOption.VditorWYSIWYG || t.Context.ParseOption.ProtyleWYSIWYG {
 		label = bytes.ReplaceAll(label, editor.CaretTokens, nil)
 	}
-	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
-		if !entering || ast.NodeLinkRefDef != n.Type {
-			return ast.WalkContinue
-		}
-		if bytes.EqualFold(n.Tokens, label) {
-			link = n.FirstChild
-			return ast.WalkStop
-		}
+	// 文件包含时还需要查找被包含文件以及包含它的文件中的链接引用定义
+	for _, root := range t.linkRefDefRoots() {
+		ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeLinkRefDef != n.Type {
+				return ast.WalkContinue
+			}
+			if bytes.EqualFold(n.Tokens, label) {
+				link = n.FirstChild
+				return ast.WalkStop
+			}
 
-		if c := cases.Fold(); bytes.EqualFold(c.Bytes(label), n.Tokens) || bytes.EqualFold(c.Bytes(n.Tokens), label) {
-			link = n.FirstChild
-			return ast.WalkStop
+			if c := cases.Fold(); bytes.EqualFold(c.Bytes(label), n.Tokens) || bytes.EqualFold(c.Bytes(n.Tokens), label) {
+				link = n.FirstChild
+				return ast.WalkStop
+			}
+			return ast.WalkContinue
+		})
+		if nil != link {
+			return
 		}
-		return ast.WalkContinue
-	})
+	}
 	return
 }
@@ -28,17 +28,23 @@ func (t *Tree) FindLinkRefDefLink(label []byte) (link *ast.Node) {
 	if t.Context.ParseOption.VditorIR || t.Context.ParseOption.VditorSV || t.Context.ParseOption.VditorWYSIWYG || t.Context.ParseOption.ProtyleWYSIWYG {
 		label = bytes.ReplaceAll(label, editor.CaretTokens, nil)
 	}
-	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
-		if !entering || ast.NodeLinkRefDef != n.Type {
+	// 文件包含时还需要查找被包含文件以及包含它的文件中的链接引用定义
+	for _, root := range t.linkRefDefRoots() {
+		ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeLinkRefDef != n.Type {
+				return ast.WalkContinue
+			}
+			if bytes.EqualFold(n.Tokens, label) {
+				link = n.FirstChild
+				return ast.WalkStop
+			}
+			// JS 版不支持 Unicode case fold https://spec.commonmark.org/0.30/#example-539
+			// 因为引入 golang.org/x/text/cases 后打包体积太大
 			return ast.WalkContinue
+		})
+		if nil != link {
+			return
 		}
-		if bytes.EqualFold(n.Tokens, label) {
-			link = n.FirstChild
-			return ast.WalkStop
-		}
-		// JS 版不支持 Unicode case fold https://spec.commonmark.org/0.30/#example-539
-		// 因为引入 golang.org/x/text/cases 后打包体积太大
-		return ast.WalkContinue
-	})
+	}
 	return
 }
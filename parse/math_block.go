@@ -120,7 +120,9 @@ func (context *Context) isMathBlockClose(tokens []byte) bool {
 		if ial := context.parseKramdownBlockIAL(tokens); 0 < len(ial) {
 			context.Tip.ID = IAL2Map(ial)["id"]
 			context.Tip.KramdownIAL = ial
-			context.Tip.InsertAfter(&ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: tokens})
+			ialNode := &ast.Node{Type: ast.NodeKramdownBlockIAL, Tokens: tokens}
+			context.sourceSpan(ialNode)
+			context.Tip.InsertAfter(ialNode)
 			return true
 		}
 	}
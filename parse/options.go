@@ -0,0 +1,173 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+// Option 描述了解析选项覆盖函数签名，用于在选项快照上修改解析选项，比如 engine.With(lute.WithParse(parse.WithMark(true)))。
+type Option func(options *Options)
+
+// Clone 返回 options 的副本，在副本上设置选项不会影响 options。表情映射 AliasEmoji 和 EmojiAlias 由副本和 options 只读共享，
+// 副本第一次 PutEmojis 时才会复制一份，所以在副本上 PutEmojis 不会修改 options 的表情映射。
+func (options *Options) Clone() (ret *Options) {
+	copied := *options
+	ret = &copied
+	ret.sharedEmojis = true
+	return
+}
+
+// PutEmojis 将 emojiMap 合并覆盖到表情映射 AliasEmoji 和 EmojiAlias 中，表情映射是和其他选项共享的时会先复制一份。
+func (options *Options) PutEmojis(emojiMap map[string]string) {
+	EmojiLock.Lock()
+	defer EmojiLock.Unlock()
+
+	if options.sharedEmojis {
+		options.AliasEmoji = cloneStrMap(options.AliasEmoji)
+		options.EmojiAlias = cloneStrMap(options.EmojiAlias)
+		options.sharedEmojis = false
+	}
+	for k, v := range emojiMap {
+		options.AliasEmoji[k] = v
+		options.EmojiAlias[v] = k
+	}
+}
+
+func cloneStrMap(m map[string]string) (ret map[string]string) {
+	ret = make(map[string]string, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return
+}
+
+// WithGFMTable 设置是否打开“GFM 表”支持。
+func WithGFMTable(b bool) Option {
+	return func(options *Options) {
+		options.GFMTable = b
+	}
+}
+
+// WithGFMTaskListItem 设置是否打开“GFM 任务列表项”支持。
+func WithGFMTaskListItem(b bool) Option {
+	return func(options *Options) {
+		options.GFMTaskListItem = b
+	}
+}
+
+// WithGFMStrikethrough 设置是否打开“GFM 删除线”支持。
+func WithGFMStrikethrough(b bool) Option {
+	return func(options *Options) {
+		options.GFMStrikethrough = b
+	}
+}
+
+// WithGFMAutoLink 设置是否打开“GFM 自动链接”支持。
+func WithGFMAutoLink(b bool) Option {
+	return func(options *Options) {
+		options.GFMAutoLink = b
+	}
+}
+
+// WithFootnotes 设置是否打开“脚注”支持。
+func WithFootnotes(b bool) Option {
+	return func(options *Options) {
+		options.Footnotes = b
+	}
+}
+
+// WithEmoji 设置是否对 Emoji 别名替换为原生 Unicode 字符。
+func WithEmoji(b bool) Option {
+	return func(options *Options) {
+		options.Emoji = b
+	}
+}
+
+// WithSetext 设置是否解析 Setext 标题。
+func WithSetext(b bool) Option {
+	return func(options *Options) {
+		options.Setext = b
+	}
+}
+
+// WithYamlFrontMatter 设置是否开启 YAML Front Matter 支持。
+func WithYamlFrontMatter(b bool) Option {
+	return func(options *Options) {
+		options.YamlFrontMatter = b
+	}
+}
+
+// WithMark 设置是否打开 ==标记== 支持。
+func WithMark(b bool) Option {
+	return func(options *Options) {
+		options.Mark = b
+	}
+}
+
+// WithWikilink 设置是否打开 [[wikilink]] 支持。
+func WithWikilink(b bool) Option {
+	return func(options *Options) {
+		options.Wikilink = b
+	}
+}
+
+// WithTag 设置是否开启 #标签# 支持。
+func WithTag(b bool) Option {
+	return func(options *Options) {
+		options.Tag = b
+	}
+}
+
+// WithSup 设置是否打开 ^上标^ 支持。
+func WithSup(b bool) Option {
+	return func(options *Options) {
+		options.Sup = b
+	}
+}
+
+// WithSub 设置是否打开 ~下标~ 支持。
+func WithSub(b bool) Option {
+	return func(options *Options) {
+		options.Sub = b
+	}
+}
+
+// WithLinkRef 设置是否打开“链接引用”支持。
+func WithLinkRef(b bool) Option {
+	return func(options *Options) {
+		options.LinkRef = b
+	}
+}
+
+// WithIndentCodeBlock 设置是否打开“缩进代码块”支持。
+func WithIndentCodeBlock(b bool) Option {
+	return func(options *Options) {
+		options.IndentCodeBlock = b
+	}
+}
+
+// WithDataImage 设置是否打开 ![foo](data:image...) 形式的图片支持。
+func WithDataImage(b bool) Option {
+	return func(options *Options) {
+		options.DataImage = b
+	}
+}
+
+// WithImgPathAllowSpace 设置是否支持图片路径带空格。
+func WithImgPathAllowSpace(b bool) Option {
+	return func(options *Options) {
+		options.ImgPathAllowSpace = b
+	}
+}
+
+// WithInlineMathAllowDigitAfterOpenMarker 设置内联数学公式是否允许起始 $ 后紧跟数字。
+func WithInlineMathAllowDigitAfterOpenMarker(b bool) Option {
+	return func(options *Options) {
+		options.InlineMathAllowDigitAfterOpenMarker = b
+	}
+}
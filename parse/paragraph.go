@@ -36,6 +36,9 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 	hasReferenceDefs := false
 	for tokens := p.Tokens; 0 < len(tokens) && lex.ItemOpenBracket == tokens[0]; tokens = p.Tokens {
 		if tokens = context.parseLinkRefDef(tokens); nil != tokens {
+			if context.ParseOption.SourceSpan {
+				context.linkRefDefSourceSpan(p, p.Tokens[:len(p.Tokens)-len(tokens)])
+			}
 			p.Tokens = tokens
 			hasReferenceDefs = true
 			continue
@@ -123,6 +126,8 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 								}
 								subBlock.ID = p.ID
 								subBlock.KramdownIAL = p.KramdownIAL
+								clearSourceSpans(subBlock)
+								subBlock.SourceSpan = p.SourceSpan
 								p.InsertAfter(subBlock)
 								p.Unlink()
 							}
@@ -138,6 +143,7 @@ func paragraphFinalize(p *ast.Node, context *Context) (insertTable bool) {
 			if nil != paragraph {
 				p.Tokens = paragraph.Tokens
 				p.InsertAfter(table)
+				context.tableSourceSpan(p, table, bytes.Count(p.Tokens, []byte{lex.ItemNewline})+1)
 				// 设置末梢及其状态
 				table.Close = true
 				context.Tip = table
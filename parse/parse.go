@@ -11,6 +11,7 @@
 package parse
 
 import (
+	"io/fs"
 	"sync"
 
 	"github.com/88250/lute/ast"
@@ -21,11 +22,20 @@ import (
 func Parse(name string, markdown []byte, options *Options) (tree *Tree) {
 	tree = &Tree{Name: name, Context: &Context{ParseOption: options}}
 	tree.Context.Tree = tree
+	var source []byte
+	if options.SourceSpan {
+		// 词法分析时会统一换行符，这里复制一份原始输入
+		source = append([]byte{}, markdown...)
+	}
 	tree.lexer = lex.NewLexer(markdown)
 	tree.Root = &ast.Node{Type: ast.NodeDocument}
 	tree.parseBlocks()
+	includes := tree.includeDirectives()
+	tree.parseIncludes(includes)
 	tree.parseInlines()
+	tree.expandIncludes(includes)
 	tree.finalParseBlockIAL()
+	tree.finalSourceSpan(source)
 	tree.lexer = nil
 	return
 }
@@ -144,7 +154,10 @@ type Context struct {
 	indented, blank, partiallyConsumedTab, allClosed         bool      // 是否是缩进行、空行等标识
 	lastMatchedContainer                                     *ast.Node // 最后一个匹配的块节点
 
-	rootIAL *ast.Node // 根节点 kramdown IAL
+	rootIAL      *ast.Node   // 根节点 kramdown IAL
+	includeStack []string    // 文件包含链，用于检测循环包含和限制包含深度
+	includeRoots []*ast.Node // 被包含文件以及包含当前文件的文件的根节点，用于查找其中的链接引用定义
+	lineStart    int         // 当前行行首在源码中的位置，打开 SourceSpan 时使用
 }
 
 // InlineContext 描述了行级元素解析上下文。
@@ -288,6 +301,7 @@ func (context *Context) addChild(nodeType ast.NodeType) (ret *ast.Node) {
 	}
 
 	ret = &ast.Node{Type: nodeType}
+	context.sourceSpan(ret)
 	context.Tip.AppendChild(ret)
 	context.Tip = ret
 	return
@@ -316,6 +330,15 @@ type Tree struct {
 	Created int64    // 创建时间
 	Updated int64    // 更新时间
 	Hash    string   // 内容哈希
+
+	IncludeErrs []error // 文件包含时出现的错误
+
+	FrontMatter          map[string]interface{} // Front Matter 元数据，格式化时如果被修改过会重新输出
+	FrontMatterErr       error                  // Front Matter 解析错误
+	frontMatterFormat    string                 // Front Matter 格式
+	frontMatterCanonical []byte                 // 解析时 Front Matter 规范化后的文本
+
+	Source []byte // 原始输入的源码，仅在打开解析选项 SourceSpan 时记录，保留了 \r\n 和 \r 换行符
 }
 
 // Options 描述了解析选项。
@@ -362,6 +385,8 @@ type Options struct {
 	FileAnnotationRef bool
 	// Mark 设置是否打开 ==标记== 支持。
 	Mark bool
+	// Wikilink 设置是否打开 [[wikilink]] 支持。
+	Wikilink bool
 	// KramdownBlockIAL 设置是否打开 kramdown 块级内联属性列表支持。 https://kramdown.gettalong.org/syntax.html#inline-attribute-lists
 	KramdownBlockIAL bool
 	// KramdownSpanIAL 设置是否打开 kramdown 行级内联属性列表支持。
@@ -392,6 +417,17 @@ type Options struct {
 	// 这个开关主要用于兼容 Markdown 输入 API 上 https://github.com/siyuan-note/siyuan/issues/6039
 	// 不用于 Protyle 自旋过程 https://github.com/siyuan-note/siyuan/issues/5877
 	HTMLTag2TextMark bool
+	// Include 设置是否打开文件包含 !include path.md 或者 {{include "path.md"}} 支持，包含链上的文件可以使用彼此的链接引用定义。
+	Include bool
+	// IncludeFS 设置文件包含时读取文件使用的文件系统，为 nil 时不处理文件包含。
+	IncludeFS fs.FS
+	// IncludeMaxDepth 设置文件包含的最大嵌套深度，小于 1 时使用 IncludeDefaultMaxDepth。
+	IncludeMaxDepth int
+	// SourceSpan 设置是否记录块级节点在源码中的位置，用于无损格式化渲染时原样输出未修改的节点。
+	SourceSpan bool
+
+	// sharedEmojis 表示表情映射是否和 Clone 前的选项共享。
+	sharedEmojis bool
 }
 
 var EmojiLock = sync.Mutex{}
@@ -413,6 +449,7 @@ func NewOptions() *Options {
 		BlockRef:          false,
 		FileAnnotationRef: false,
 		Mark:              false,
+		Wikilink:          true,
 		KramdownBlockIAL:  false,
 		HeadingID:         true,
 		LinkRef:           true,
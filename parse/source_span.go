@@ -0,0 +1,208 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package parse
+
+import (
+	"bytes"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/lex"
+)
+
+// 这里实现了块级节点源码位置的记录，用于无损格式化渲染 LosslessRenderer。
+//
+// 块级解析时只记录节点的起始行，解析完成后再根据兄弟节点的起始位置计算结束位置并计算节点指纹。
+// 解析时的位置是词法分析预处理（统一换行符等）后的输入中的位置，最后再映射到原始输入中。
+
+// sourceSpan 为新建的块级节点 block 记录起始位置（当前行行首）。
+func (context *Context) sourceSpan(block *ast.Node) {
+	if context.ParseOption.SourceSpan {
+		contentStart := context.offset
+		if !block.IsContainerBlock() && context.nextNonspace < contentStart {
+			// 叶子块新建时可能已经跳过了标题标记符、代码块围栏等，内容从首个非空白字符开始
+			contentStart = context.nextNonspace
+		}
+		block.SourceSpan = &ast.SourceSpan{Start: context.lineStart, ContentStart: context.lineStart + contentStart}
+	}
+}
+
+// skipSourceLines 返回源码中从 start 开始跳过 lines 行后的位置。
+func (context *Context) skipSourceLines(start, lines int) int {
+	source := context.Tree.lexer.Input()
+	for ; 0 < lines && start < len(source); lines-- {
+		i := bytes.IndexByte(source[start:], lex.ItemNewline)
+		if 0 > i {
+			return len(source)
+		}
+		start += i + 1
+	}
+	return start
+}
+
+// linkRefDefSourceSpan 将段落 p 开头解析出的链接引用定义块移动到段落前面，并拆分段落的源码位置。
+// consumed 为链接引用定义占用的段落 tokens。
+func (context *Context) linkRefDefSourceSpan(p *ast.Node, consumed []byte) {
+	defBlock := p.Parent.LastChild
+	if nil == p.SourceSpan || ast.NodeLinkRefDefBlock != defBlock.Type {
+		return
+	}
+
+	p.InsertBefore(defBlock)
+	defBlock.SourceSpan = &ast.SourceSpan{Start: p.SourceSpan.Start}
+	lines := bytes.Count(consumed, []byte{lex.ItemNewline})
+	if 0 < len(consumed) && lex.ItemNewline != consumed[len(consumed)-1] {
+		lines++
+	}
+	p.SourceSpan.Start = context.skipSourceLines(p.SourceSpan.Start, lines)
+}
+
+// tableSourceSpan 为从段落 p 中拆分出来的表格 table 记录起始位置，paragraphLines 为段落剩余的行数。
+func (context *Context) tableSourceSpan(p, table *ast.Node, paragraphLines int) {
+	if nil != p.SourceSpan {
+		table.SourceSpan = &ast.SourceSpan{Start: context.skipSourceLines(p.SourceSpan.Start, paragraphLines)}
+	}
+}
+
+// clearSourceSpans 清除 n 及其子节点的源码位置，用于从其他源码解析出来的节点。
+func clearSourceSpans(n *ast.Node) {
+	ast.Walk(n, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering {
+			n.SourceSpan = nil
+		}
+		return ast.WalkContinue
+	})
+}
+
+// finalSourceSpan 计算所有节点的结束位置和指纹，并将位置映射到原始输入 source 中。
+func (t *Tree) finalSourceSpan(source []byte) {
+	if !t.Context.ParseOption.SourceSpan {
+		return
+	}
+
+	t.Source = t.lexer.Input()
+	t.Root.SourceSpan = &ast.SourceSpan{Start: 0, End: len(t.Source)}
+	t.sourceSpanEnds(t.Root)
+
+	offsets := sourceOffsets(t.Source, source)
+	t.Source = source
+	ast.Walk(t.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && nil != n.SourceSpan {
+			span := n.SourceSpan
+			span.Start, span.ContentStart, span.End = offsets[span.Start], offsets[span.ContentStart], offsets[span.End]
+			span.Fingerprint = n.Fingerprint()
+			span.NodeFingerprint = n.NodeFingerprint()
+		}
+		return ast.WalkContinue
+	})
+}
+
+// sourceOffsets 返回预处理后的输入 input 中每个位置（包括结尾）对应的原始输入 source 中的位置。
+// 预处理会将 \r\n 和 \r 替换为 \n，将 \u0000 替换为 \uFFFD，并在结尾没有换行时补充换行。
+func sourceOffsets(input, source []byte) (ret []int) {
+	ret = make([]int, len(input)+1)
+	j := 0
+	for i := 0; i < len(input); i++ {
+		ret[i] = j
+		if j >= len(source) {
+			// 结尾补充的换行
+			continue
+		}
+
+		switch {
+		case lex.ItemCarriageReturn == source[j] && j+1 < len(source) && lex.ItemNewline == source[j+1]:
+			j += 2
+		case 0 == source[j]:
+			// \uFFFD 的三个字节都对应 \u0000
+			ret[i+1], ret[i+2] = j, j
+			i += 2
+			j++
+		default:
+			j++
+		}
+	}
+	ret[len(input)] = len(source)
+	return
+}
+
+// sourceSpanEnds 计算 parent 下子节点的结束位置：结束于下一个记录了位置的兄弟节点的起始位置或者父节点的结束位置，并剔除结尾的空行。
+func (t *Tree) sourceSpanEnds(parent *ast.Node) {
+	start, end := parent.SourceSpan.Start, parent.SourceSpan.End
+	quoted := ast.NodeBlockquote == parent.Type || parent.ParentIs(ast.NodeBlockquote) // 引述块中的空行带有 >
+	for c := parent.FirstChild; nil != c; c = c.Next {
+		if nil == c.SourceSpan {
+			continue
+		}
+		if c.SourceSpan.Start < start || c.SourceSpan.Start > end {
+			// 位置不在父节点范围内或者和前面的兄弟节点顺序不一致，说明不是从当前源码中解析出来的
+			clearSourceSpans(c)
+			continue
+		}
+
+		start = c.SourceSpan.Start
+		c.SourceSpan.End = end
+		for next := c.Next; nil != next; next = next.Next {
+			if nil != next.SourceSpan && start < next.SourceSpan.Start && next.SourceSpan.Start <= end {
+				c.SourceSpan.End = next.SourceSpan.Start
+				break
+			}
+		}
+		c.SourceSpan.End = start + trimBlankLines(t.Source[start:c.SourceSpan.End], quoted)
+		t.sourceSpanEnds(c)
+	}
+}
+
+// trimBlankLines 返回 src 剔除结尾空行后的长度，最后一个非空行的换行符会被保留。quoted 为 true 时仅包含 > 的行也认为是空行。
+func trimBlankLines(src []byte, quoted bool) int {
+	last := bytes.LastIndexFunc(src, func(r rune) bool {
+		return ' ' != r && '\t' != r && '\n' != r && (!quoted || '>' != r)
+	})
+	if 0 > last {
+		return 0
+	}
+	if i := bytes.IndexByte(src[last:], lex.ItemNewline); 0 <= i {
+		return last + i + 1
+	}
+	return len(src)
+}
+
+// Position 返回节点 n 在源码中的行号和列号（从 1 开始，列号按字节计算），行级节点使用其所在块级节点的位置。
+// text 不为空并且出现在该块级节点的源码中时返回 text 第一次出现的位置。没有打开解析选项 SourceSpan 时返回 0, 0。
+func (t *Tree) Position(n *ast.Node, text string) (line, column int) {
+	for nil != n && nil == n.SourceSpan {
+		n = n.Parent
+	}
+	if nil == n || nil == t.Source || ast.NodeDocument == n.Type {
+		return
+	}
+
+	span := n.SourceSpan
+	offset := span.ContentStart
+	for offset < len(t.Source) && (lex.ItemSpace == t.Source[offset] || lex.ItemTab == t.Source[offset]) {
+		offset++
+	}
+	if "" != text && span.End <= len(t.Source) {
+		if idx := bytes.Index(t.Source[span.Start:span.End], []byte(text)); 0 <= idx {
+			offset = span.Start + idx
+		}
+	}
+
+	lineStart := 0
+	line = 1
+	for i := 0; i < offset; i++ {
+		// \r\n 和单独的 \r 都是换行
+		if lex.ItemNewline == t.Source[i] || (lex.ItemCarriageReturn == t.Source[i] && (i+1 >= len(t.Source) || lex.ItemNewline != t.Source[i+1])) {
+			line++
+			lineStart = i + 1
+		}
+	}
+	column = offset - lineStart + 1
+	return
+}
@@ -12,14 +12,16 @@ package parse
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/88250/lute/ast"
 	"github.com/88250/lute/editor"
+	"github.com/88250/lute/frontmatter"
 	"github.com/88250/lute/lex"
 	"github.com/88250/lute/util"
 )
 
-// 判断 YAML Front Matter（---）是否开始。
+// 判断 Front Matter 是否开始，支持 YAML（---）、TOML（+++）和 JSON（;;; 或者 {）。
 func YamlFrontMatterStart(t *Tree, container *ast.Node) int {
 	if !t.Context.ParseOption.YamlFrontMatter || t.Context.indented || nil != t.Root.FirstChild {
 		return 0
@@ -27,6 +29,7 @@ func YamlFrontMatterStart(t *Tree, container *ast.Node) int {
 
 	if t.parseYamlFrontMatter() {
 		node := &ast.Node{Type: ast.NodeYamlFrontMatter}
+		t.Context.sourceSpan(node)
 		t.Root.AppendChild(node)
 		t.Context.Tip = node
 		return 2
@@ -35,7 +38,10 @@ func YamlFrontMatterStart(t *Tree, container *ast.Node) int {
 }
 
 func YamlFrontMatterContinue(node *ast.Node, context *Context) int {
-	if isYamlFrontMatterClose(context) {
+	if isYamlFrontMatterClose(context, node.Tokens[0]) {
+		if lex.ItemOpenBrace == node.Tokens[0] {
+			node.AppendTokens(context.currentLine) // 裸 JSON 的闭合花括号也是内容
+		}
 		context.finalize(node)
 		return 2
 	}
@@ -47,7 +53,63 @@ var YamlFrontMatterMarkerNewline = util.StrToBytes("---\n")
 var YamlFrontMatterMarkerCaret = util.StrToBytes("---" + editor.Caret)
 var YamlFrontMatterMarkerCaretNewline = util.StrToBytes("---" + editor.Caret + "\n")
 
+// FrontMatterMarker 返回 Front Matter 节点 node 使用的标记符，裸 JSON（{ 开头）的 Front Matter 没有标记符。
+func FrontMatterMarker(node *ast.Node) []byte {
+	if ast.NodeYamlFrontMatter != node.Type {
+		node = node.Parent
+	}
+	if marker := node.ChildByType(ast.NodeYamlFrontMatterOpenMarker); nil != marker && 0 < len(marker.Tokens) {
+		return marker.Tokens
+	}
+	if content := node.ChildByType(ast.NodeYamlFrontMatterContent); nil != content && isBareJSONFrontMatter(content.Tokens) {
+		return nil
+	}
+	return YamlFrontMatterMarker
+}
+
+// FrontMatterDOMMarker 返回编辑器 DOM 中记录的标记符文本 marker 对应的标记节点 Tokens。
+//
+// YAML 和裸 JSON 的标记节点不记录 Tokens，由 FrontMatterMarker 根据内容推断。
+func FrontMatterDOMMarker(marker string) []byte {
+	marker = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(marker, editor.Caret, ""), editor.Zwsp, ""))
+	if "+++" == marker || ";;;" == marker {
+		return []byte(marker)
+	}
+	return nil
+}
+
+// FrontMatterFormat 返回 Front Matter 节点 node 的格式。
+func FrontMatterFormat(node *ast.Node) string {
+	switch marker := util.BytesToStr(FrontMatterMarker(node)); marker {
+	case "---":
+		return frontmatter.YAML
+	case "+++":
+		return frontmatter.TOML
+	}
+	return frontmatter.JSON
+}
+
+// isBareJSONFrontMatter 判断 Front Matter 内容 tokens 是否是第一行仅为 { 的 JSON。
+func isBareJSONFrontMatter(tokens []byte) bool {
+	firstLine := tokens
+	if i := bytes.IndexByte(tokens, lex.ItemNewline); 0 <= i {
+		firstLine = tokens[:i]
+	}
+	return "{" == string(bytes.TrimSpace(firstLine))
+}
+
 func (context *Context) yamlFrontMatterFinalize(node *ast.Node) {
+	if lex.ItemOpenBrace == node.Tokens[0] {
+		// 裸 JSON 保留首尾的花括号
+		node.Tokens = lex.TrimWhitespace(node.Tokens)
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: node.Tokens})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker})
+		context.Tree.setFrontMatter(frontmatter.JSON, node.Tokens)
+		return
+	}
+
+	marker := node.Tokens[:3]
 	tokens := node.Tokens[3:] // 剔除开头的 ---\n
 	tokens = lex.TrimWhitespace(tokens)
 	if context.ParseOption.VditorWYSIWYG || context.ParseOption.VditorIR || context.ParseOption.VditorSV {
@@ -58,28 +120,111 @@ func (context *Context) yamlFrontMatterFinalize(node *ast.Node) {
 			tokens = append(tokens, editor.CaretTokens...)
 		}
 	}
-	if bytes.HasSuffix(tokens, YamlFrontMatterMarker) {
+	if bytes.HasSuffix(tokens, marker) {
 		tokens = tokens[:len(tokens)-3] // 剔除结尾的 ---
 	}
 	node.Tokens = tokens
-	node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker})
-	node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: tokens})
-	node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker})
+	if bytes.Equal(marker, YamlFrontMatterMarker) {
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: tokens})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker})
+	} else {
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker, Tokens: marker})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: tokens})
+		node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker, Tokens: marker})
+	}
+
+	format := FrontMatterFormat(node)
+	content := bytes.TrimSuffix(tokens, editor.CaretTokens)
+	if frontmatter.JSON == format && !bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+		// ;;; 之间可以省略 JSON 对象的花括号
+		content = []byte("{" + string(content) + "}")
+	}
+	context.Tree.setFrontMatter(format, content)
+}
+
+// setFrontMatter 解析 format 格式的 Front Matter 内容 tokens，并记录规范化后的文本用于判断元数据是否被修改。
+func (t *Tree) setFrontMatter(format string, tokens []byte) {
+	if nil != t.FrontMatter || nil != t.FrontMatterErr {
+		return
+	}
+
+	t.frontMatterFormat = format
+	t.FrontMatter, t.FrontMatterErr = frontmatter.Unmarshal(format, tokens)
+	if nil == t.FrontMatterErr {
+		t.frontMatterCanonical, _ = frontmatter.Marshal(format, t.FrontMatter)
+	}
+}
+
+// FrontMatterFormat 返回 Front Matter 的格式，文档中没有 Front Matter 时返回 YAML。
+func (t *Tree) FrontMatterFormat() string {
+	if "" == t.frontMatterFormat {
+		return frontmatter.YAML
+	}
+	return t.frontMatterFormat
+}
+
+// FrontMatterModified 判断解析后 FrontMatter 是否被修改过。
+func (t *Tree) FrontMatterModified() bool {
+	if nil != t.FrontMatterErr {
+		return false
+	}
+	if "" == t.frontMatterFormat {
+		return 0 < len(t.FrontMatter)
+	}
+	canonical, err := frontmatter.Marshal(t.frontMatterFormat, t.FrontMatter)
+	if nil != err {
+		return false
+	}
+	return !bytes.Equal(canonical, t.frontMatterCanonical)
 }
 
 func (t *Tree) parseYamlFrontMatter() bool {
-	if lex.ItemHyphen != t.Context.currentLine[0] {
+	marker := t.Context.currentLine[0]
+	if "{" == string(bytes.TrimSpace(t.Context.currentLine)) {
+		marker = lex.ItemOpenBrace
+	} else if !isFrontMatterMarker(t.Context.currentLine, t.Context.currentLineLen) {
 		return false
 	}
+	if lex.ItemHyphen == marker || nil == t.lexer {
+		return true
+	}
+
+	// TOML 和 JSON 必须闭合，避免将普通的 +++、;;; 和 { 行作为 Front Matter
+	remains := t.lexer.Input()[t.lexer.Offset():]
+	length := 0
+	for _, line := range bytes.Split(remains, []byte("\n")) {
+		length += len(line) + 1
+		if lex.ItemOpenBrace == marker {
+			if "}" == string(bytes.TrimRight(line, " \t\r")) {
+				// 裸 JSON 还必须是合法的 JSON 对象，否则按照普通文本解析
+				content := append([]byte("{\n"), remains[:length-1]...)
+				content = bytes.ReplaceAll(content, editor.CaretTokens, nil)
+				_, err := frontmatter.Unmarshal(frontmatter.JSON, content)
+				return nil == err
+			}
+		} else if 0 < len(line) && marker == line[0] && isFrontMatterMarker(line, len(line)) {
+			return true
+		}
+	}
+	return false
+}
 
-	hyphenLength := 0
-	for i := 0; i < t.Context.currentLineLen && lex.ItemHyphen == t.Context.currentLine[i]; i++ {
-		hyphenLength++
+// isFrontMatterMarker 判断 line 是否以 ---、+++ 或者 ;;; 开头。
+func isFrontMatterMarker(line []byte, lineLen int) bool {
+	marker := line[0]
+	if lex.ItemHyphen != marker && lex.ItemPlus != marker && lex.ItemSemicolon != marker {
+		return false
+	}
+
+	markerLength := 0
+	for i := 0; i < lineLen && marker == line[i]; i++ {
+		markerLength++
 	}
-	return 3 == hyphenLength
+	return 3 == markerLength
 }
 
-func isYamlFrontMatterClose(context *Context) bool {
+func isYamlFrontMatterClose(context *Context, marker byte) bool {
 	if context.ParseOption.KramdownBlockIAL && simpleCheckIsBlockIAL(context.currentLine) {
 		// 判断 IAL 打断
 		if ial := context.parseKramdownBlockIAL(context.currentLine); 0 < len(ial) {
@@ -90,13 +235,11 @@ func isYamlFrontMatterClose(context *Context) bool {
 		}
 	}
 
-	if lex.ItemHyphen != context.currentLine[0] {
-		return false
+	if lex.ItemOpenBrace == marker {
+		return "}" == string(bytes.TrimRight(context.currentLine, " \t\n"))
 	}
-
-	hyphenLength := 0
-	for i := 0; i < context.currentLineLen && lex.ItemHyphen == context.currentLine[i]; i++ {
-		hyphenLength++
+	if marker != context.currentLine[0] {
+		return false
 	}
-	return 3 == hyphenLength
+	return isFrontMatterMarker(context.currentLine, context.currentLineLen)
 }
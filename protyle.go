@@ -12,6 +12,7 @@ package lute
 
 import (
 	"bytes"
+	"errors"
 	"strconv"
 	"strings"
 
@@ -19,10 +20,13 @@ import (
 	"github.com/88250/lute/lex"
 
 	"github.com/88250/lute/ast"
+	"github.com/88250/lute/blockop"
 	"github.com/88250/lute/html"
 	"github.com/88250/lute/html/atom"
+	"github.com/88250/lute/oplog"
 	"github.com/88250/lute/parse"
 	"github.com/88250/lute/render"
+	"github.com/88250/lute/tableop"
 	"github.com/88250/lute/util"
 )
 
@@ -116,6 +120,7 @@ func (lute *Lute) BlockDOM2InlineBlockDOM(vHTML string) (vIHTML string) {
 
 func (lute *Lute) Md2BlockDOM(markdown string) (vHTML string) {
 	tree := parse.Parse("", []byte(markdown), lute.ParseOptions)
+	lute.transform(tree)
 	lute.NestedInlines2FlattedSpans(tree)
 	renderer := render.NewProtyleRenderer(tree, lute.RenderOptions)
 	for nodeType, rendererFunc := range lute.Md2BlockDOMRendererFuncs {
@@ -379,6 +384,11 @@ func RenderNodeBlockDOM(node *ast.Node, parseOptions *parse.Options, renderOptio
 	return renderer.Writer.String()
 }
 
+// BlockDOMOperations 比较两个块 DOM 状态，返回将 oldHTML 变为 newHTML 的块级操作列表。
+func (lute *Lute) BlockDOMOperations(oldHTML, newHTML string) []*oplog.Operation {
+	return oplog.Derive(lute.BlockDOM2Tree(oldHTML), lute.BlockDOM2Tree(newHTML))
+}
+
 func (lute *Lute) BlockDOM2Tree(htmlStr string) (ret *parse.Tree) {
 	htmlStr = strings.ReplaceAll(htmlStr, "\n<wbr>\n</strong>", "</strong>\n<wbr>\n")
 	htmlStr = strings.ReplaceAll(htmlStr, "\n<wbr>\n</em>", "</em>\n<wbr>\n")
@@ -503,241 +513,109 @@ func (lute *Lute) MergeSameSpan(n *ast.Node) {
 
 func (lute *Lute) CancelSuperBlock(ivHTML string) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	if ast.NodeSuperBlock != tree.Root.FirstChild.Type {
+	if !blockop.CancelSuperBlock(tree.Root.FirstChild) {
 		return ivHTML
 	}
 
-	sb := tree.Root.FirstChild
-
-	var blocks []*ast.Node
-	for b := sb.FirstChild; nil != b; b = b.Next {
-		blocks = append(blocks, b)
-	}
-	for _, b := range blocks {
-		tree.Root.AppendChild(b)
-	}
-	sb.Unlink()
-
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
 func (lute *Lute) CancelList(ivHTML string) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	if ast.NodeList != tree.Root.FirstChild.Type {
+	if !blockop.CancelList(tree.Root.FirstChild) {
 		return ivHTML
 	}
 
-	list := tree.Root.FirstChild
-
-	var appends, unlinks []*ast.Node
-	for li := list.FirstChild; nil != li; li = li.Next {
-		for c := li.FirstChild; nil != c; c = c.Next {
-			if ast.NodeTaskListItemMarker != c.Type {
-				appends = append(appends, c)
-			}
-		}
-		unlinks = append(unlinks, li)
-	}
-	for _, c := range appends {
-		tree.Root.AppendChild(c)
-	}
-	for _, n := range unlinks {
-		n.Unlink()
-	}
-	list.Unlink()
-
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
 func (lute *Lute) CancelBlockquote(ivHTML string) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	if ast.NodeBlockquote != tree.Root.FirstChild.Type {
+	if !blockop.CancelBlockquote(tree.Root.FirstChild) {
 		return ivHTML
 	}
 
-	bq := tree.Root.FirstChild
-
-	var appends, unlinks []*ast.Node
-	for sub := bq.FirstChild; nil != sub; sub = sub.Next {
-		if ast.NodeBlockquoteMarker != sub.Type {
-			appends = append(appends, sub)
-		}
-		unlinks = append(unlinks, sub)
-	}
-	for _, c := range appends {
-		tree.Root.AppendChild(c)
-	}
-	bq.Unlink()
-
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
 func (lute *Lute) Blocks2Ps(ivHTML string) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	node := tree.Root.FirstChild
-
-	var unlinks []*ast.Node
-	for n := node; nil != n; n = n.Next {
-		switch n.Type {
-		case ast.NodeHeading:
-			n.Type = ast.NodeParagraph
-		case ast.NodeBlockquote:
-			// 多选块类型转换 https://github.com/siyuan-note/siyuan/issues/4706
-			//var children []*ast.Node
-			//for c := n.LastChild; nil != c; c = c.Previous {
-			//	if ast.NodeBlockquoteMarker == c.Type {
-			//		unlinks = append(unlinks, c)
-			//		continue
-			//	}
-			//	children = append(children, c)
-			//}
-			//for _, c := range children {
-			//	n.InsertBefore(c)
-			//}
-			//unlinks = append(unlinks, n)
-		}
-	}
-	for _, n := range unlinks {
-		n.Unlink()
-	}
+	// 多选块类型转换 https://github.com/siyuan-note/siyuan/issues/4706
+	blockop.Blocks2Ps(blocks(tree)...)
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
 func (lute *Lute) Blocks2Hs(ivHTML, level string) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	node := tree.Root.FirstChild
-
-	for p := node; nil != p; p = p.Next {
-		if ast.NodeParagraph == p.Type || ast.NodeHeading == p.Type {
-			p.Type = ast.NodeHeading
-			p.HeadingLevel, _ = strconv.Atoi(level)
-		}
-	}
+	headingLevel, _ := strconv.Atoi(level)
+	blockop.Blocks2Hs(headingLevel, blocks(tree)...)
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
 func (lute *Lute) OL2TL(ivHTML string) (ovHTML string) {
-	tree := lute.BlockDOM2Tree(ivHTML)
-
-	tree.Root.FirstChild.ListData.Typ = 3
-	for li := tree.Root.FirstChild.FirstChild; nil != li; li = li.Next {
-		if ast.NodeListItem == li.Type {
-			li.ListData.Typ = 3
-			li.PrependChild(&ast.Node{Type: ast.NodeTaskListItemMarker})
-		}
-	}
-	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
-	return
+	return lute.convertList(ivHTML, -1, 3)
 }
 
 func (lute *Lute) UL2TL(ivHTML string) (ovHTML string) {
-	tree := lute.BlockDOM2Tree(ivHTML)
-
-	tree.Root.FirstChild.ListData.Typ = 3
-	for li := tree.Root.FirstChild.FirstChild; nil != li; li = li.Next {
-		if ast.NodeListItem == li.Type {
-			li.ListData.Typ = 3
-			li.PrependChild(&ast.Node{Type: ast.NodeTaskListItemMarker})
-		}
-	}
-	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
-	return
+	return lute.convertList(ivHTML, -1, 3)
 }
 
 func (lute *Lute) TL2OL(ivHTML string) (ovHTML string) {
-	tree := lute.BlockDOM2Tree(ivHTML)
-	list := tree.Root.FirstChild
-	if ast.NodeList != list.Type || 3 != list.ListData.Typ {
-		return ivHTML
-	}
+	return lute.convertList(ivHTML, 3, 1)
+}
 
-	num := 1
-	list.ListData.Typ = 1
-	var unlinks []*ast.Node
-	for li := list.FirstChild; nil != li; li = li.Next {
-		if ast.NodeKramdownBlockIAL == li.Type {
-			continue
-		}
-		unlinks = append(unlinks, li.FirstChild) // task marker
-		li.ListData.Typ = 1
-		li.ListData.Num = num
-		num++
-	}
-	for _, n := range unlinks {
-		n.Unlink()
-	}
+func (lute *Lute) TL2UL(ivHTML string) (ovHTML string) {
+	return lute.convertList(ivHTML, 3, 0)
+}
 
-	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
-	return
+func (lute *Lute) OL2UL(ivHTML string) (ovHTML string) {
+	return lute.convertList(ivHTML, -1, 0)
 }
 
-func (lute *Lute) TL2UL(ivHTML string) (ovHTML string) {
+func (lute *Lute) UL2OL(ivHTML string) (ovHTML string) {
+	return lute.convertList(ivHTML, -1, 1)
+}
+
+// convertList 将块 DOM 中的第一个列表转换为 typ 类型的列表，from 不为 -1 时只转换 from 类型的列表。
+func (lute *Lute) convertList(ivHTML string, from, typ int) (ovHTML string) {
 	tree := lute.BlockDOM2Tree(ivHTML)
 	list := tree.Root.FirstChild
-	if ast.NodeList != list.Type || 3 != list.ListData.Typ {
+	if ast.NodeList != list.Type || (-1 != from && from != list.ListData.Typ) {
 		return ivHTML
 	}
 
-	list.ListData.Typ = 0
-	var unlinks []*ast.Node
-	for li := list.FirstChild; nil != li; li = li.Next {
-		if ast.NodeKramdownBlockIAL == li.Type {
-			continue
-		}
-		unlinks = append(unlinks, li.FirstChild) // task marker
-		li.ListData.Typ = 0
-	}
-	for _, n := range unlinks {
-		n.Unlink()
-	}
-
+	blockop.ConvertList(list, typ)
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
-func (lute *Lute) OL2UL(ivHTML string) (ovHTML string) {
+// EditTableDOM 使用 edit 编辑块 DOM 中的第一个表格，edit 可以使用 tableop 包中的表格操作。
+func (lute *Lute) EditTableDOM(ivHTML string, edit func(table *ast.Node) error) (ovHTML string, err error) {
 	tree := lute.BlockDOM2Tree(ivHTML)
-	list := tree.Root.FirstChild
-	if ast.NodeList != list.Type {
-		return ivHTML
+	tables := tableop.Tables(tree)
+	if 1 > len(tables) {
+		return ivHTML, errors.New("table not found")
 	}
 
-	list.ListData.Typ = 0
-	for li := list.FirstChild; nil != li; li = li.Next {
-		if ast.NodeKramdownBlockIAL == li.Type {
-			continue
-		}
-		li.ListData.Typ = 0
+	if err = edit(tables[0]); nil != err {
+		return ivHTML, err
 	}
-
 	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
-func (lute *Lute) UL2OL(ivHTML string) (ovHTML string) {
-	tree := lute.BlockDOM2Tree(ivHTML)
-	list := tree.Root.FirstChild
-	if ast.NodeList != list.Type {
-		return ivHTML
-	}
-
-	num := 1
-	list.ListData.Typ = 1
-	for li := list.FirstChild; nil != li; li = li.Next {
-		if ast.NodeKramdownBlockIAL == li.Type {
-			continue
+// blocks 返回 tree 的顶层块，不包括块级内联属性列表节点。
+func blocks(tree *parse.Tree) (ret []*ast.Node) {
+	for n := tree.Root.FirstChild; nil != n; n = n.Next {
+		if ast.NodeKramdownBlockIAL != n.Type {
+			ret = append(ret, n)
 		}
-		li.ListData.Typ = 1
-		li.ListData.Num = num
-		num++
 	}
-
-	ovHTML = lute.Tree2BlockDOM(tree, lute.RenderOptions)
 	return
 }
 
@@ -0,0 +1,303 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package query 提供了类似 CSS 选择器的语法树查询。
+//
+// 类型选择器使用节点类型名，比如 NodeHeading，也可以省略 Node 前缀并忽略大小写，比如 heading，* 匹配任意节点。
+//
+// 属性选择器支持 [name]、[name=value]、[name!=value]、[name^=value]、[name$=value]、[name*=value] 和 [name~=value]，
+// 以下属性名有特殊含义，其他属性名取节点的内联属性列表（IAL）：
+//   - level：标题级别
+//   - list：列表和列表项类型，bullet、ordered 或者 task
+//   - checked：任务列表项是否勾选，true 或者 false
+//   - info：代码块信息字符串
+//   - lang：代码块语言，即信息字符串的第一个单词
+//   - dest：链接和图片地址
+//   - id：节点 ID
+//
+// 组合符支持后代（空格）、子节点 >、相邻兄弟 + 和后续兄弟 ~，兄弟组合符会跳过块级内联属性列表节点。
+// 因为 Markdown 的章节没有对应的节点，后代组合符会把标题视为其所在章节内容的祖先，比如 NodeHeading[level=2] NodeImage
+// 可以查询所有二级标题章节中的图片。
+//
+// 伪类支持 :has()、:not()、:first-child、:last-child 和 :empty，:first-child 和 :last-child 会跳过标记符节点和内联属性列表节点。
+package query
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// Query 返回 tree 中匹配 selector 的所有节点，节点按文档顺序排列。
+func Query(tree *parse.Tree, selector string) ([]*ast.Node, error) {
+	s, err := Compile(selector)
+	if nil != err {
+		return nil, err
+	}
+	return s.Find(tree.Root), nil
+}
+
+// QueryFirst 返回 tree 中第一个匹配 selector 的节点，没有匹配的节点时返回 nil。
+func QueryFirst(tree *parse.Tree, selector string) (*ast.Node, error) {
+	s, err := Compile(selector)
+	if nil != err {
+		return nil, err
+	}
+	return s.First(tree.Root), nil
+}
+
+// Find 返回 root 的子孙节点中匹配 s 的所有节点，节点按文档顺序排列。
+func (s *Selector) Find(root *ast.Node) (ret []*ast.Node) {
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && n != root && s.Match(n) {
+			ret = append(ret, n)
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// First 返回 root 的子孙节点中第一个匹配 s 的节点，没有匹配的节点时返回 nil。
+func (s *Selector) First(root *ast.Node) (ret *ast.Node) {
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && n != root && s.Match(n) {
+			ret = n
+			return ast.WalkStop
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// Match 判断节点 n 是否匹配 s。
+func (s *Selector) Match(n *ast.Node) bool {
+	return s.match(n, nil)
+}
+
+func (s *Selector) match(n, scope *ast.Node) bool {
+	for _, c := range s.complexes {
+		if c.match(n, len(c.compounds)-1, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *complexSelector) match(n *ast.Node, i int, scope *ast.Node) bool {
+	if !c.compounds[i].match(n, scope) {
+		return false
+	}
+	if 0 == i {
+		return true
+	}
+
+	switch c.combinators[i-1] {
+	case '>':
+		return nil != n.Parent && c.match(n.Parent, i-1, scope)
+	case '+':
+		previous := previousSibling(n)
+		return nil != previous && c.match(previous, i-1, scope)
+	case '~':
+		for previous := previousSibling(n); nil != previous; previous = previousSibling(previous) {
+			if c.match(previous, i-1, scope) {
+				return true
+			}
+		}
+	default:
+		for _, ancestor := range ancestors(n) {
+			if c.match(ancestor, i-1, scope) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *compound) match(n, scope *ast.Node) bool {
+	if c.scope {
+		return n == scope
+	}
+	if !c.anyType && c.typ != n.Type {
+		return false
+	}
+	for _, attr := range c.attrs {
+		if !attr.match(n) {
+			return false
+		}
+	}
+	for _, ps := range c.pseudos {
+		if !ps.match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *attribute) match(n *ast.Node) bool {
+	value, ok := attrValue(n, a.name)
+	if !ok {
+		return "!=" == a.op
+	}
+
+	switch a.op {
+	case "=":
+		return value == a.value
+	case "!=":
+		return value != a.value
+	case "^=":
+		return strings.HasPrefix(value, a.value)
+	case "$=":
+		return strings.HasSuffix(value, a.value)
+	case "*=":
+		return strings.Contains(value, a.value)
+	case "~=":
+		for _, word := range strings.Fields(value) {
+			if word == a.value {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (ps *pseudo) match(n *ast.Node) bool {
+	switch ps.name {
+	case "first-child":
+		return nil != n.Parent && n == firstElement(n.Parent)
+	case "last-child":
+		return nil != n.Parent && n == lastElement(n.Parent)
+	case "empty":
+		return nil == firstElement(n) && 0 == len(n.Tokens)
+	case "not":
+		return !ps.arg.match(n, nil)
+	case "has":
+		found := false
+		// 相对选择器可能以兄弟组合符开头，所以后续兄弟节点也需要检查
+		for c := n; nil != c && !found; c = c.Next {
+			ast.Walk(c, func(m *ast.Node, entering bool) ast.WalkStatus {
+				if entering && m != n && ps.arg.match(m, n) {
+					found = true
+					return ast.WalkStop
+				}
+				return ast.WalkContinue
+			})
+		}
+		return found
+	}
+	return false
+}
+
+// attrValue 返回节点 n 的属性 name 的值，ok 为 false 时表示节点没有该属性。
+func attrValue(n *ast.Node, name string) (value string, ok bool) {
+	switch name {
+	case "level":
+		if ast.NodeHeading == n.Type {
+			return strconv.Itoa(n.HeadingLevel), true
+		}
+		return
+	case "list":
+		if (ast.NodeList == n.Type || ast.NodeListItem == n.Type) && nil != n.ListData {
+			switch n.ListData.Typ {
+			case 0:
+				return "bullet", true
+			case 1:
+				return "ordered", true
+			case 3:
+				return "task", true
+			}
+		}
+		return
+	case "checked":
+		if ast.NodeListItem == n.Type && nil != n.ListData && 3 == n.ListData.Typ {
+			return strconv.FormatBool(n.ListData.Checked), true
+		}
+		return
+	case "info", "lang":
+		if ast.NodeCodeBlock != n.Type {
+			return
+		}
+		value = string(n.CodeBlockInfo)
+		if "lang" == name {
+			if fields := strings.Fields(value); 0 < len(fields) {
+				value = fields[0]
+			}
+		} else if 0 < len(n.CodeBlockInfoMeta) {
+			value += " " + string(n.CodeBlockInfoMeta)
+		}
+		return value, "" != value
+	case "dest":
+		if ast.NodeLink == n.Type || ast.NodeImage == n.Type {
+			if dest := n.ChildByType(ast.NodeLinkDest); nil != dest {
+				return string(dest.Tokens), true
+			}
+		}
+		return
+	case "id":
+		return n.ID, "" != n.ID
+	}
+
+	for _, kv := range n.KramdownIAL {
+		if name == kv[0] {
+			return n.IALAttr(name), true
+		}
+	}
+	return
+}
+
+// ancestors 返回 n 的祖先节点，包括 n 及其祖先节点所在章节的标题。
+func ancestors(n *ast.Node) (ret []*ast.Node) {
+	for c := n; nil != c; c = c.Parent {
+		level := 7
+		if ast.NodeHeading == c.Type {
+			level = c.HeadingLevel
+		}
+		for previous := c.Previous; nil != previous && 1 < level; previous = previous.Previous {
+			if ast.NodeHeading == previous.Type && previous.HeadingLevel < level {
+				ret = append(ret, previous)
+				level = previous.HeadingLevel
+			}
+		}
+		if nil != c.Parent {
+			ret = append(ret, c.Parent)
+		}
+	}
+	return
+}
+
+func previousSibling(n *ast.Node) *ast.Node {
+	previous := n.Previous
+	for nil != previous && isIAL(previous) {
+		previous = previous.Previous
+	}
+	return previous
+}
+
+func firstElement(n *ast.Node) *ast.Node {
+	c := n.FirstChild
+	for nil != c && (c.IsMarker() || isIAL(c)) {
+		c = c.Next
+	}
+	return c
+}
+
+func lastElement(n *ast.Node) *ast.Node {
+	c := n.LastChild
+	for nil != c && (c.IsMarker() || isIAL(c)) {
+		c = c.Previous
+	}
+	return c
+}
+
+func isIAL(n *ast.Node) bool {
+	return ast.NodeKramdownBlockIAL == n.Type || ast.NodeKramdownSpanIAL == n.Type
+}
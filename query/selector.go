@@ -0,0 +1,303 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package query
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+)
+
+// Selector 描述了编译后的选择器。
+type Selector struct {
+	source    string
+	complexes []*complexSelector // 逗号分隔的多个选择器，匹配任意一个即可
+}
+
+// String 返回选择器源码。
+func (s *Selector) String() string {
+	return s.source
+}
+
+// complexSelector 描述了由组合符连接的复合选择器，combinators[i] 为 compounds[i] 和 compounds[i+1] 之间的组合符。
+type complexSelector struct {
+	compounds   []*compound
+	combinators []byte // ' '：后代，'>'：子节点，'+'：相邻兄弟，'~'：后续兄弟
+}
+
+// compound 描述了复合选择器，比如 NodeHeading[level=2]:has(NodeImage)。
+type compound struct {
+	scope   bool // :has() 中的相对选择器起点，仅匹配 :has() 所在的节点
+	anyType bool
+	typ     ast.NodeType
+	attrs   []*attribute
+	pseudos []*pseudo
+}
+
+// attribute 描述了属性谓词，op 为空时仅判断属性是否存在。
+type attribute struct {
+	name, op, value string
+}
+
+// pseudo 描述了伪类，比如 :has()、:not() 和 :first-child。
+type pseudo struct {
+	name string
+	arg  *Selector
+}
+
+// typeNames 用于通过不带 Node 前缀的小写名称查找节点类型，比如 heading 和 codeblock。
+var typeNames = map[string]ast.NodeType{}
+
+func init() {
+	for t := ast.NodeDocument; t < ast.NodeTypeMaxVal; t++ {
+		if name := t.String(); strings.HasPrefix(name, "Node") && !strings.HasPrefix(name, "NodeType") {
+			typeNames[strings.ToLower(name[len("Node"):])] = t
+		}
+	}
+}
+
+// Compile 编译选择器 selector。
+func Compile(selector string) (ret *Selector, err error) {
+	p := &selectorParser{src: selector}
+	if ret, err = p.parseList(false); nil != err {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.error("unexpected [" + string(p.src[p.pos]) + "]")
+	}
+	return
+}
+
+// MustCompile 编译选择器 selector，选择器有误时 panic。
+func MustCompile(selector string) *Selector {
+	ret, err := Compile(selector)
+	if nil != err {
+		panic(err)
+	}
+	return ret
+}
+
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func (p *selectorParser) error(msg string) error {
+	return errors.New("invalid selector [" + p.src + "] at " + strconv.Itoa(p.pos) + ": " + msg)
+}
+
+func (p *selectorParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *selectorParser) skipSpaces() (skipped bool) {
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+		skipped = true
+	}
+	return
+}
+
+// parseList 解析逗号分隔的选择器列表，relative 为 true 时解析 :has() 中的相对选择器。
+func (p *selectorParser) parseList(relative bool) (ret *Selector, err error) {
+	start := p.pos
+	ret = &Selector{}
+	for {
+		var complex *complexSelector
+		if complex, err = p.parseComplex(relative); nil != err {
+			return
+		}
+		ret.complexes = append(ret.complexes, complex)
+		p.skipSpaces()
+		if ',' != p.peek() {
+			break
+		}
+		p.pos++
+	}
+	ret.source = strings.TrimSpace(p.src[start:p.pos])
+	return
+}
+
+func (p *selectorParser) parseComplex(relative bool) (ret *complexSelector, err error) {
+	ret = &complexSelector{}
+	p.skipSpaces()
+	if relative {
+		ret.compounds = append(ret.compounds, &compound{scope: true})
+		combinator := byte(' ')
+		if c := p.peek(); '>' == c || '+' == c || '~' == c {
+			combinator = c
+			p.pos++
+		}
+		ret.combinators = append(ret.combinators, combinator)
+	}
+
+	for {
+		p.skipSpaces()
+		var c *compound
+		if c, err = p.parseCompound(); nil != err {
+			return
+		}
+		ret.compounds = append(ret.compounds, c)
+
+		spaces := p.skipSpaces()
+		switch next := p.peek(); next {
+		case '>', '+', '~':
+			p.pos++
+			ret.combinators = append(ret.combinators, next)
+		case ',', ')', 0:
+			return
+		default:
+			if !spaces {
+				return nil, p.error("unexpected [" + string(next) + "]")
+			}
+			ret.combinators = append(ret.combinators, ' ')
+		}
+	}
+}
+
+func (p *selectorParser) parseCompound() (ret *compound, err error) {
+	ret = &compound{anyType: true}
+	start := p.pos
+	if '*' == p.peek() {
+		p.pos++
+	} else if name := p.parseIdent(); "" != name {
+		if ret.typ, err = p.nodeType(name); nil != err {
+			return
+		}
+		ret.anyType = false
+	}
+
+	for {
+		switch p.peek() {
+		case '[':
+			var attr *attribute
+			if attr, err = p.parseAttribute(); nil != err {
+				return
+			}
+			ret.attrs = append(ret.attrs, attr)
+		case ':':
+			var ps *pseudo
+			if ps, err = p.parsePseudo(); nil != err {
+				return
+			}
+			ret.pseudos = append(ret.pseudos, ps)
+		default:
+			if start == p.pos {
+				return nil, p.error("expected selector")
+			}
+			return
+		}
+	}
+}
+
+func (p *selectorParser) nodeType(name string) (ast.NodeType, error) {
+	if ret := ast.Str2NodeType(name); 0 <= ret {
+		return ret, nil
+	}
+	if ret, ok := typeNames[strings.ToLower(strings.TrimPrefix(name, "Node"))]; ok {
+		return ret, nil
+	}
+	return 0, p.error("unknown node type [" + name + "]")
+}
+
+func (p *selectorParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) && isIdent(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *selectorParser) parseAttribute() (ret *attribute, err error) {
+	p.pos++ // [
+	p.skipSpaces()
+	ret = &attribute{name: p.parseIdent()}
+	if "" == ret.name {
+		return nil, p.error("expected attribute name")
+	}
+	p.skipSpaces()
+	for _, op := range []string{"=", "!=", "^=", "$=", "*=", "~="} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			ret.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if "" != ret.op {
+		p.skipSpaces()
+		if ret.value, err = p.parseValue(); nil != err {
+			return
+		}
+		p.skipSpaces()
+	}
+	if ']' != p.peek() {
+		return nil, p.error("expected ]")
+	}
+	p.pos++
+	return
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	if quote := p.peek(); '"' == quote || '\'' == quote {
+		end := strings.IndexByte(p.src[p.pos+1:], quote)
+		if 0 > end {
+			return "", p.error("unclosed quote")
+		}
+		ret := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return ret, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && ']' != p.src[p.pos] && !isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.error("expected attribute value")
+	}
+	return p.src[start:p.pos], nil
+}
+
+func (p *selectorParser) parsePseudo() (ret *pseudo, err error) {
+	p.pos++ // :
+	ret = &pseudo{name: p.parseIdent()}
+	switch ret.name {
+	case "first-child", "last-child", "empty":
+		return
+	case "has", "not":
+		if '(' != p.peek() {
+			return nil, p.error("expected (")
+		}
+		p.pos++
+		if ret.arg, err = p.parseList("has" == ret.name); nil != err {
+			return
+		}
+		p.skipSpaces()
+		if ')' != p.peek() {
+			return nil, p.error("expected )")
+		}
+		p.pos++
+		return
+	}
+	return nil, p.error("unknown pseudo-class [:" + ret.name + "]")
+}
+
+func isSpace(c byte) bool {
+	return ' ' == c || '\t' == c || '\n' == c || '\r' == c
+}
+
+func isIdent(c byte) bool {
+	return ('a' <= c && 'z' >= c) || ('A' <= c && 'Z' >= c) || ('0' <= c && '9' >= c) || '-' == c || '_' == c
+}
@@ -0,0 +1,160 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// BlockEmbedDefaultMaxDepth 为内容块查询嵌入默认的最大嵌套深度。
+const BlockEmbedDefaultMaxDepth = 4
+
+// BlockProvider 描述了内容块提供者，渲染内容块引用 ((id "text")) 和内容块查询嵌入 {{SELECT ...}} 时通过它获取被引用的内容块。
+type BlockProvider interface {
+	// Block 返回 id 对应的内容块所在的语法树以及访问该内容块的链接地址，内容块不存在时返回 nil。
+	Block(id string) (tree *parse.Tree, url string)
+
+	// Query 执行内容块查询嵌入脚本 stmt，返回查询到的内容块 ID 列表。
+	Query(stmt string) (ids []string, err error)
+}
+
+// providedBlock 描述了通过 BlockProvider 获取到的内容块。
+type providedBlock struct {
+	id    string
+	tree  *parse.Tree
+	node  *ast.Node
+	url   string
+	embed bool // 是否可以嵌入，超过最大嵌套深度或者循环嵌入时仅渲染为引用
+}
+
+// block 通过 Options.BlockProvider 获取 id 对应的内容块，获取不到时返回 nil。
+func (r *BaseRenderer) block(id string) *providedBlock {
+	if nil == r.Options.BlockProvider {
+		return nil
+	}
+
+	tree, url := r.Options.BlockProvider.Block(id)
+	if nil == tree || nil == tree.Root {
+		return nil
+	}
+	var node *ast.Node
+	if id == tree.ID || id == tree.Root.ID {
+		node = tree.Root
+	} else {
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if entering && id == n.ID && n.IsBlock() {
+				node = n
+				return ast.WalkStop
+			}
+			return ast.WalkContinue
+		})
+	}
+	if nil == node {
+		return nil
+	}
+	return &providedBlock{id: id, tree: tree, node: node, url: url}
+}
+
+// embeddedBlocks 执行内容块查询嵌入节点 node 的脚本，返回查询到的内容块。
+func (r *BaseRenderer) embeddedBlocks(node *ast.Node) (ret []*providedBlock, err error) {
+	script := node.ChildByType(ast.NodeBlockQueryEmbedScript)
+	if nil == script {
+		return
+	}
+
+	ids, err := r.Options.BlockProvider.Query(strings.TrimSpace(string(script.Tokens)))
+	if nil != err {
+		return
+	}
+
+	maxDepth := r.Options.BlockEmbedMaxDepth
+	if 1 > maxDepth {
+		maxDepth = BlockEmbedDefaultMaxDepth
+	}
+	for _, id := range ids {
+		block := r.block(id)
+		if nil == block {
+			continue
+		}
+		block.embed = len(r.embedStack) < maxDepth
+		for _, embedded := range r.embedStack {
+			if embedded == id {
+				block.embed = false
+				break
+			}
+		}
+		ret = append(ret, block)
+	}
+	return
+}
+
+// nodes 返回嵌入内容块时需要渲染的节点，标题块会带上它下方直到下一个同级或者更高级标题之前的块。
+func (block *providedBlock) nodes() (ret []*ast.Node) {
+	ret = append(ret, block.node)
+	if ast.NodeHeading != block.node.Type {
+		return
+	}
+	for n := block.node.Next; nil != n; n = n.Next {
+		if ast.NodeHeading == n.Type && n.HeadingLevel <= block.node.HeadingLevel {
+			break
+		}
+		ret = append(ret, n)
+	}
+	return
+}
+
+// text 返回内容块的纯文本，文档块使用文档标题。
+func (block *providedBlock) text() (ret string) {
+	if ast.NodeDocument == block.node.Type {
+		if ret = block.node.IALAttr("title"); "" == ret {
+			ret = block.tree.Name
+		}
+		return
+	}
+
+	return strings.Join(strings.Fields(block.node.Content()), " ")
+}
+
+// blockRefAnchor 返回内容块引用或者内容块查询嵌入节点 ref 的锚文本，动态锚文本和没有锚文本时使用被引用内容块 block 的文本，
+// 都为空时使用内容块 ID。
+func blockRefAnchor(ref *ast.Node, block *providedBlock) (ret string) {
+	if text := ref.ChildByType(ast.NodeBlockRefText); nil != text {
+		return text.TokensStr()
+	}
+	if nil != block {
+		if ret = SubStr(block.text(), BlockRefAnchorMaxLen); "" != ret {
+			return
+		}
+		return block.id
+	}
+	if text := ref.ChildByType(ast.NodeBlockRefDynamicText); nil != text {
+		return text.TokensStr()
+	}
+	return ref.ChildByType(ast.NodeBlockRefID).TokensStr()
+}
+
+// blockRefTitle 返回内容块引用悬浮提示使用的文本。
+func blockRefTitle(block *providedBlock) string {
+	text := block.text()
+	if BlockRefTitleMaxLen < len([]rune(text)) {
+		text = SubStr(text, BlockRefTitleMaxLen) + "..."
+	}
+	return text
+}
+
+// 内容块引用锚文本和悬浮提示的最大长度（字符数）。
+const (
+	BlockRefAnchorMaxLen = 64
+	BlockRefTitleMaxLen  = 128
+)
@@ -0,0 +1,220 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build !javascript
+// +build !javascript
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/chroma"
+	chromahtml "github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/styles"
+)
+
+const (
+	// ChromaDarkModeMedia 使用 prefers-color-scheme 媒体查询切换亮色和暗色样式。
+	ChromaDarkModeMedia = "media"
+	// ChromaDarkModeAttr 使用根元素上的 data-theme="dark" 属性切换亮色和暗色样式。
+	ChromaDarkModeAttr = "attr"
+)
+
+// ChromaStyleCSS 返回名为 styleName 的 Chroma 语法高亮样式表，classPrefix 为类名前缀，为空时使用 "highlight-"。
+//
+// 样式表包含 diff 模式代码块新增行和删除行的样式。
+func ChromaStyleCSS(styleName, classPrefix string) (css string, err error) {
+	style, err := chromaStyle(styleName)
+	if nil != err {
+		return
+	}
+	css = chromaStyleCSS(style, classPrefix)
+	return
+}
+
+// ChromaDualStyleCSS 返回亮色样式 lightStyleName 和暗色样式 darkStyleName 合并后的语法高亮样式表。
+//
+// mode 为 ChromaDarkModeMedia 时按系统配色切换，为 ChromaDarkModeAttr 时按根元素的 data-theme 属性切换。
+func ChromaDualStyleCSS(lightStyleName, darkStyleName, classPrefix, mode string) (css string, err error) {
+	light, err := chromaStyle(lightStyleName)
+	if nil != err {
+		return
+	}
+	dark, err := chromaStyle(darkStyleName)
+	if nil != err {
+		return
+	}
+
+	lightCSS, darkCSS := chromaStyleCSS(light, classPrefix), chromaStyleCSS(dark, classPrefix)
+	buf := &strings.Builder{}
+	switch mode {
+	case ChromaDarkModeMedia:
+		buf.WriteString("@media not all and (prefers-color-scheme: dark) {\n")
+		buf.WriteString(lightCSS)
+		buf.WriteString("}\n@media (prefers-color-scheme: dark) {\n")
+		buf.WriteString(darkCSS)
+		buf.WriteString("}\n")
+	case ChromaDarkModeAttr:
+		buf.WriteString(scopeChromaCSS(lightCSS, ":root:not([data-theme=\"dark\"])"))
+		buf.WriteString(scopeChromaCSS(darkCSS, "[data-theme=\"dark\"]"))
+	default:
+		err = errors.New("unknown dark mode [" + mode + "]")
+		return
+	}
+	css = buf.String()
+	return
+}
+
+// chromaStyles 保存通过 RegisterChromaStyle 注册的样式，不写入 Chroma 的全局样式表，避免并发写入以及覆盖内置样式。
+var chromaStyles = map[string]*chroma.Style{}
+var chromaStylesLock = sync.RWMutex{}
+
+// RegisterChromaStyle 解析 Chroma 样式定义并注册为语法高亮样式，返回样式名，注册后可以通过样式名使用该样式。
+//
+// 样式名已经存在（包括 Chroma 内置样式和已经注册的样式）时返回错误，override 为 true 时覆盖已有样式。
+//
+// 支持 XML 定义：
+//
+//	<style name="mystyle">
+//	  <entry type="Background" style="bg:#ffffff"/>
+//	  <entry type="Keyword" style="bold #0000ff"/>
+//	</style>
+//
+// 以及 JSON 定义：
+//
+//	{"name": "mystyle", "entries": {"Background": "bg:#ffffff", "Keyword": "bold #0000ff"}}
+func RegisterChromaStyle(definition []byte, override bool) (name string, err error) {
+	var entries map[string]string
+	definition = bytes.TrimSpace(definition)
+	if bytes.HasPrefix(definition, []byte("<")) {
+		name, entries, err = parseChromaStyleXML(definition)
+	} else {
+		name, entries, err = parseChromaStyleJSON(definition)
+	}
+	if nil != err {
+		return
+	}
+	if "" == name {
+		err = errors.New("style name is empty")
+		return
+	}
+
+	styleEntries := chroma.StyleEntries{}
+	for typ, entry := range entries {
+		var tokenType chroma.TokenType
+		if err = tokenType.UnmarshalJSON([]byte(strconv.Quote(typ))); nil != err {
+			return
+		}
+		styleEntries[tokenType] = entry
+	}
+	style, err := chroma.NewStyle(name, styleEntries)
+	if nil != err {
+		return
+	}
+
+	chromaStylesLock.Lock()
+	defer chromaStylesLock.Unlock()
+	if !override {
+		if _, ok := chromaStyles[name]; ok || nil != styles.Registry[name] {
+			err = errors.New("style [" + name + "] already exists")
+			return
+		}
+	}
+	chromaStyles[name] = style
+	return
+}
+
+func parseChromaStyleXML(definition []byte) (name string, entries map[string]string, err error) {
+	var style struct {
+		Name    string `xml:"name,attr"`
+		Entries []struct {
+			Type  string `xml:"type,attr"`
+			Style string `xml:"style,attr"`
+		} `xml:"entry"`
+	}
+	if err = xml.Unmarshal(definition, &style); nil != err {
+		return
+	}
+
+	name, entries = style.Name, map[string]string{}
+	for _, entry := range style.Entries {
+		entries[entry.Type] = entry.Style
+	}
+	return
+}
+
+func parseChromaStyleJSON(definition []byte) (name string, entries map[string]string, err error) {
+	var style struct {
+		Name    string            `json:"name"`
+		Entries map[string]string `json:"entries"`
+	}
+	if err = json.Unmarshal(definition, &style); nil != err {
+		return
+	}
+	name, entries = style.Name, style.Entries
+	return
+}
+
+func chromaStyle(name string) (ret *chroma.Style, err error) {
+	// styles.Get 找不到时会返回默认样式，这里需要明确报错
+	chromaStylesLock.RLock()
+	ret = chromaStyles[name]
+	chromaStylesLock.RUnlock()
+	if nil == ret {
+		ret = styles.Registry[name]
+	}
+	if nil == ret {
+		err = errors.New("not found style [" + name + "]")
+	}
+	return
+}
+
+// highlightStyle 返回名为 name 的语法高亮样式，优先使用注册的样式，找不到时使用 Chroma 内置样式或者默认样式。
+func highlightStyle(name string) *chroma.Style {
+	if ret, err := chromaStyle(name); nil == err {
+		return ret
+	}
+	return styles.Get(name)
+}
+
+func chromaStyleCSS(style *chroma.Style, classPrefix string) string {
+	if "" == classPrefix {
+		classPrefix = "highlight-"
+	}
+
+	buf := &bytes.Buffer{}
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.ClassPrefix(classPrefix))
+	formatter.WriteCSS(buf, style)
+	add := diffBackground(style, chroma.GenericInserted)
+	del := diffBackground(style, chroma.GenericDeleted)
+	buf.WriteString("/* DiffAdd */ ." + classPrefix + "chroma ." + classPrefix + "diff-add { background-color: " + add + " }\n")
+	buf.WriteString("/* DiffDel */ ." + classPrefix + "chroma ." + classPrefix + "diff-del { background-color: " + del + " }\n")
+	return buf.String()
+}
+
+// scopeChromaCSS 在 Chroma 样式表每条规则的选择器前加上 scope 祖先选择器。
+func scopeChromaCSS(css, scope string) string {
+	buf := &strings.Builder{}
+	for _, line := range strings.SplitAfter(css, "\n") {
+		if idx := strings.Index(line, "*/ "); 0 <= idx {
+			idx += len("*/ ")
+			line = line[:idx] + scope + " " + line[idx:]
+		}
+		buf.WriteString(line)
+	}
+	return buf.String()
+}
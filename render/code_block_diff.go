@@ -0,0 +1,118 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build !javascript
+// +build !javascript
+
+package render
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+)
+
+// splitDiffMarkers 去掉 diff 模式代码中每行开头的 +、- 或者空格，返回去掉标记后的代码以及每行的标记，行首不是这些字符时标记为 0。
+func splitDiffMarkers(code string) (ret string, markers []byte) {
+	lines := strings.SplitAfter(code, "\n")
+	buf := &strings.Builder{}
+	for _, line := range lines {
+		if "" == line {
+			continue
+		}
+
+		var marker byte
+		if c := line[0]; '+' == c || '-' == c || ' ' == c {
+			marker = c
+			line = line[1:]
+		}
+		markers = append(markers, marker)
+		buf.WriteString(line)
+	}
+	ret = buf.String()
+	return
+}
+
+// diffIterator 在每行语法高亮 Token 前插入该行的 diff 标记。
+func diffIterator(iterator chroma.Iterator, markers []byte) chroma.Iterator {
+	var tokens []chroma.Token
+	for i, line := range chroma.SplitTokensIntoLines(iterator.Tokens()) {
+		if i < len(markers) {
+			switch markers[i] {
+			case '+':
+				tokens = append(tokens, chroma.Token{Type: chroma.GenericInserted, Value: "+"})
+			case '-':
+				tokens = append(tokens, chroma.Token{Type: chroma.GenericDeleted, Value: "-"})
+			case ' ':
+				tokens = append(tokens, chroma.Token{Type: chroma.Text, Value: " "})
+			}
+		}
+		tokens = append(tokens, line...)
+	}
+	return chroma.Literator(tokens...)
+}
+
+// markDiffLines 标记 Chroma 渲染结果中的新增行和删除行。
+//
+// 使用 class 时新增行和删除行分别添加 diff-add 和 diff-del（加上类名前缀 classPrefix），使用内联样式时使用高亮样式中 GenericInserted 和 GenericDeleted 的背景色。
+func markDiffLines(formatted, markers []byte, classes bool, classPrefix string, style *chroma.Style) []byte {
+	linePrefix := []byte("<span class=\"" + classPrefix + "line")
+	add, del := " "+classPrefix+"diff-add", " "+classPrefix+"diff-del"
+	if !classes {
+		linePrefix = []byte("<span style=\"display:flex;")
+		add = " background-color:" + diffBackground(style, chroma.GenericInserted) + ";"
+		del = " background-color:" + diffBackground(style, chroma.GenericDeleted) + ";"
+	}
+
+	buf := &bytes.Buffer{}
+	for line := 0; ; line++ {
+		idx := bytes.Index(formatted, linePrefix)
+		if 0 > idx {
+			break
+		}
+
+		buf.Write(formatted[:idx+len(linePrefix)])
+		formatted = formatted[idx+len(linePrefix):]
+		if line < len(markers) {
+			switch markers[line] {
+			case '+':
+				buf.WriteString(add)
+			case '-':
+				buf.WriteString(del)
+			}
+		}
+	}
+	buf.Write(formatted)
+	return buf.Bytes()
+}
+
+// diffBackground 返回 diff 模式新增行（tokenType 为 GenericInserted）或者删除行的背景色。
+//
+// 样式没有单独设置时按样式整体背景色的明暗使用默认颜色。
+func diffBackground(style *chroma.Style, tokenType chroma.TokenType) string {
+	background := style.Get(chroma.Background).Background
+	// 样式没有单独设置时 Get 会继承整体背景色
+	if entry := style.Get(tokenType); entry.Background.IsSet() && entry.Background != background {
+		return entry.Background.String()
+	}
+
+	dark := background.IsSet() && 0.5 > background.Brightness()
+	if chroma.GenericInserted == tokenType {
+		if dark {
+			return "#033a16"
+		}
+		return "#e6ffed"
+	}
+	if dark {
+		return "#67060c"
+	}
+	return "#ffeef0"
+}
@@ -27,19 +27,26 @@ import (
 	"github.com/alecthomas/chroma"
 	chromahtml "github.com/alecthomas/chroma/formatters/html"
 	chromalexers "github.com/alecthomas/chroma/lexers"
-	"github.com/alecthomas/chroma/styles"
 )
 
 func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Newline()
 
+	if entering {
+		if rendered, ok := r.renderCodeBlockByLanguage(node); ok {
+			r.WriteString(rendered)
+			r.Newline()
+			return ast.WalkSkipChildren
+		}
+	}
+
 	if !node.IsFencedCodeBlock {
 		if entering {
 			// 缩进代码块处理
 			rendered := false
 			tokens := node.FirstChild.Tokens
 			if r.Options.CodeSyntaxHighlight {
-				rendered = highlightChroma(node, tokens, "", r)
+				rendered = highlightChroma(node, tokens, "", nil, r)
 				if !rendered {
 					tokens = html.EscapeHTML(tokens)
 					r.Write(tokens)
@@ -71,6 +78,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 	}
 	preDiv := NoHighlight(language)
 	if entering {
+		r.renderCodeBlockTitle(node.Previous, true)
 		var attrs [][]string
 		r.handleKramdownBlockIAL(node.Parent)
 		attrs = append(attrs, node.Parent.KramdownIAL...)
@@ -94,7 +102,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 				rendered = true
 			} else {
 				if r.Options.CodeSyntaxHighlight && !preDiv {
-					rendered = highlightChroma(node.Parent, tokens, language, r)
+					rendered = highlightChroma(node.Parent, tokens, language, codeBlockInfo(node.Previous), r)
 				}
 			}
 
@@ -113,7 +121,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 		} else {
 			rendered := false
 			if r.Options.CodeSyntaxHighlight {
-				rendered = highlightChroma(node.Parent, tokens, "", r)
+				rendered = highlightChroma(node.Parent, tokens, "", nil, r)
 				if !rendered {
 					tokens = html.EscapeHTML(tokens)
 					r.Write(tokens)
@@ -140,16 +148,25 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 		} else {
 			r.WriteString("</code></pre>")
 		}
+		r.renderCodeBlockTitle(node.Previous, false)
 	}
 	return ast.WalkContinue
 }
 
-func highlightChroma(codeNode *ast.Node, tokens []byte, language string, r *HtmlRenderer) (rendered bool) {
+// highlightChroma 使用 Chroma 对代码块进行语法高亮，info 为代码块信息，用于设置行高亮、行号和 diff 模式，可以为 nil。
+func highlightChroma(codeNode *ast.Node, tokens []byte, language string, info *CodeBlockInfo, r *HtmlRenderer) (rendered bool) {
 	var attrs [][]string
 	r.handleKramdownBlockIAL(codeNode)
 	attrs = append(attrs, codeNode.KramdownIAL...)
 
 	codeBlock := util.BytesToStr(tokens)
+	diff := nil != info && info.Diff
+	var diffMarkers []byte
+	if diff {
+		language = info.DiffLanguage
+		codeBlock, diffMarkers = splitDiffMarkers(codeBlock)
+	}
+
 	var lexer chroma.Lexer
 	if "" != language {
 		lexer = chromalexers.Get(language)
@@ -161,23 +178,49 @@ func highlightChroma(codeNode *ast.Node, tokens []byte, language string, r *Html
 	} else {
 		language = lexer.Config().Aliases[0]
 	}
+	if diff {
+		language = "diff-" + language
+	}
 	lexer = chroma.Coalesce(lexer)
 	iterator, err := lexer.Tokenise(nil, codeBlock)
 	if nil == err {
+		if diff {
+			iterator = diffIterator(iterator, diffMarkers)
+		}
+
 		chromahtmlOpts := []chromahtml.Option{
 			chromahtml.PreventSurroundingPre(true),
-			chromahtml.ClassPrefix("highlight-"),
+			chromahtml.ClassPrefix(r.Options.CodeSyntaxHighlightClassPrefix),
 		}
 		if !r.Options.CodeSyntaxHighlightInlineStyle {
 			chromahtmlOpts = append(chromahtmlOpts, chromahtml.WithClasses(true))
 		}
-		if r.Options.CodeSyntaxHighlightLineNum {
+		lineNum := r.Options.CodeSyntaxHighlightLineNum
+		if nil != info {
+			if 0 != info.LineNumbers {
+				lineNum = 0 < info.LineNumbers
+			}
+			if 0 < len(info.HighlightLines) {
+				// 高亮行是代码块内的行序号，Chroma 按起始行号之后的行号判断
+				var ranges [][2]int
+				for _, lines := range info.HighlightLines {
+					ranges = append(ranges, [2]int{lines[0] + info.LineNumberStart - 1, lines[1] + info.LineNumberStart - 1})
+				}
+				chromahtmlOpts = append(chromahtmlOpts, chromahtml.HighlightLines(ranges))
+			}
+			chromahtmlOpts = append(chromahtmlOpts, chromahtml.BaseLineNumber(info.LineNumberStart))
+		}
+		if lineNum {
 			chromahtmlOpts = append(chromahtmlOpts, chromahtml.WithLineNumbers(true))
 		}
 		formatter := chromahtml.New(chromahtmlOpts...)
-		style := styles.Get(r.Options.CodeSyntaxHighlightStyleName)
+		style := highlightStyle(r.Options.CodeSyntaxHighlightStyleName)
 		var b bytes.Buffer
 		if err = formatter.Format(&b, style, iterator); nil == err {
+			formatted := b.Bytes()
+			if diff {
+				formatted = markDiffLines(formatted, diffMarkers, !r.Options.CodeSyntaxHighlightInlineStyle, r.Options.CodeSyntaxHighlightClassPrefix, style)
+			}
 			if !r.Options.CodeSyntaxHighlightInlineStyle {
 				r.Tag("pre", attrs, false)
 			} else {
@@ -193,10 +236,10 @@ func highlightChroma(codeNode *ast.Node, tokens []byte, language string, r *Html
 				if "" != language {
 					r.WriteByte(lex.ItemSpace)
 				}
-				r.WriteString("highlight-chroma")
+				r.WriteString(r.Options.CodeSyntaxHighlightClassPrefix + "chroma")
 			}
 			r.WriteString("\">")
-			r.Write(b.Bytes())
+			r.Write(formatted)
 			rendered = true
 		}
 	}
@@ -23,6 +23,14 @@ import (
 func (r *HtmlRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Newline()
 
+	if entering {
+		if rendered, ok := r.renderCodeBlockByLanguage(node); ok {
+			r.WriteString(rendered)
+			r.Newline()
+			return ast.WalkSkipChildren
+		}
+	}
+
 	if !node.IsFencedCodeBlock {
 		if entering {
 			// 缩进代码块处理
@@ -48,6 +56,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 
 	if entering {
 		r.Newline()
+		r.renderCodeBlockTitle(node.Previous, true)
 		var attrs [][]string
 		r.handleKramdownBlockIAL(node)
 		attrs = append(attrs, node.KramdownIAL...)
@@ -81,6 +90,7 @@ func (r *HtmlRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.Wa
 		} else {
 			r.WriteString("</code></pre>")
 		}
+		r.renderCodeBlockTitle(node.Previous, false)
 		r.Newline()
 	}
 	return ast.WalkContinue
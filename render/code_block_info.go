@@ -0,0 +1,158 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/editor"
+	"github.com/88250/lute/html"
+)
+
+// CodeBlockInfo 描述了围栏代码块信息字符串（```go {3,5-7} title="main.go" linenos=10 中 ``` 之后的部分）解析后的属性。
+type CodeBlockInfo struct {
+	Info     string            // 原始信息字符串
+	Language string            // 语言，即信息字符串中的第一个单词
+	Attrs    map[string]string // 语言之后的属性，key=value 和 key="value" 形式的属性按键值保存，单独的单词作为键，值为空字符串
+
+	HighlightLines  [][2]int // 需要高亮的行区间（包含两端），行序号从代码块第一行的 1 开始计算，不受起始行号影响，来自 {3,5-7} 或者 hl_lines="3 5-7"
+	Title           string   // 标题，一般是文件名，来自 title 或者 filename 属性
+	LineNumbers     int      // 是否显示行号，linenos 为 true 或者数字时为 1，为 false 时为 -1，没有设置时为 0
+	LineNumberStart int      // 起始行号，来自 linenos=10 或者 linenostart=10，默认为 1
+	Diff            bool     // 语言为 diff-<lang> 时使用 diff 模式，代码行以 +、- 或者空格开头标识新增、删除和未改动
+	DiffLanguage    string   // diff 模式下代码本身的语言，即 diff-<lang> 中的 <lang>
+}
+
+// ParseCodeBlockInfo 解析围栏代码块信息字符串 info。
+func ParseCodeBlockInfo(info []byte) (ret *CodeBlockInfo) {
+	ret = &CodeBlockInfo{Info: string(info), Attrs: map[string]string{}, LineNumberStart: 1}
+	words := splitCodeBlockInfo(strings.TrimSpace(ret.Info))
+	if 1 > len(words) {
+		return
+	}
+
+	ret.Language = words[0]
+	if strings.HasPrefix(ret.Language, "diff-") && len("diff-") < len(ret.Language) {
+		ret.Diff = true
+		ret.DiffLanguage = ret.Language[len("diff-"):]
+	}
+
+	for _, word := range words[1:] {
+		if strings.HasPrefix(word, "{") && strings.HasSuffix(word, "}") {
+			ret.HighlightLines = append(ret.HighlightLines, parseLineRanges(word[1:len(word)-1])...)
+			continue
+		}
+
+		key, value := word, ""
+		if idx := strings.IndexByte(word, '='); 0 < idx {
+			key, value = word[:idx], unquoteCodeBlockInfoValue(word[idx+1:])
+		}
+		ret.Attrs[key] = value
+
+		switch key {
+		case "hl_lines":
+			ret.HighlightLines = append(ret.HighlightLines, parseLineRanges(value)...)
+		case "title", "filename":
+			ret.Title = value
+		case "linenos":
+			switch value {
+			case "false":
+				ret.LineNumbers = -1
+			case "", "true", "table", "inline":
+				ret.LineNumbers = 1
+			default:
+				if start, err := strconv.Atoi(value); nil == err {
+					ret.LineNumbers = 1
+					ret.LineNumberStart = start
+				}
+			}
+		case "linenostart":
+			if start, err := strconv.Atoi(value); nil == err {
+				ret.LineNumberStart = start
+			}
+		}
+	}
+	return
+}
+
+// codeBlockInfo 解析代码块信息标记节点 infoMarker 上的语言和元数据。
+func codeBlockInfo(infoMarker *ast.Node) *CodeBlockInfo {
+	info := bytes.ReplaceAll(infoMarker.CodeBlockInfo, editor.CaretTokens, nil)
+	if 0 < len(infoMarker.CodeBlockInfoMeta) {
+		info = append(info, ' ')
+		info = append(info, html.UnescapeBytes(bytes.ReplaceAll(infoMarker.CodeBlockInfoMeta, editor.CaretTokens, nil))...)
+	}
+	return ParseCodeBlockInfo(info)
+}
+
+// parseLineRanges 解析形如 3,5-7 或者 3 5-7 的行区间列表，忽略无法识别的部分。
+func parseLineRanges(ranges string) (ret [][2]int) {
+	for _, part := range strings.FieldsFunc(ranges, func(r rune) bool { return ',' == r || ' ' == r }) {
+		from, to := part, part
+		if idx := strings.IndexByte(part, '-'); 0 < idx {
+			from, to = part[:idx], part[idx+1:]
+		}
+		start, err := strconv.Atoi(from)
+		if nil != err {
+			continue
+		}
+		end, err := strconv.Atoi(to)
+		if nil != err || end < start {
+			continue
+		}
+		ret = append(ret, [2]int{start, end})
+	}
+	return
+}
+
+// splitCodeBlockInfo 按空白切分信息字符串，引号和花括号之间的空白不作为分隔。
+func splitCodeBlockInfo(info string) (ret []string) {
+	var quote rune
+	braces := 0
+	start := -1
+	for i, c := range info {
+		switch {
+		case 0 != quote:
+			if c == quote {
+				quote = 0
+			}
+		case '"' == c || '\'' == c:
+			quote = c
+		case '{' == c:
+			braces++
+		case '}' == c && 0 < braces:
+			braces--
+		case (' ' == c || '\t' == c) && 1 > braces:
+			if -1 < start {
+				ret = append(ret, info[start:i])
+				start = -1
+			}
+			continue
+		}
+		if -1 == start {
+			start = i
+		}
+	}
+	if -1 < start {
+		ret = append(ret, info[start:])
+	}
+	return
+}
+
+func unquoteCodeBlockInfoValue(value string) string {
+	if 2 <= len(value) && ('"' == value[0] || '\'' == value[0]) && value[0] == value[len(value)-1] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
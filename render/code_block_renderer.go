@@ -0,0 +1,64 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/editor"
+)
+
+// CodeBlockRenderer 描述了按语言注册的代码块渲染器。
+//
+// code 为代码块内容，info 为解析后的代码块信息，返回渲染好的 HTML。ok 为 false 时回退到渲染器原有的代码块渲染（包括语法高亮）。
+type CodeBlockRenderer func(code []byte, info *CodeBlockInfo) (html string, ok bool)
+
+// renderedCodeBlock 描述了代码块渲染器的渲染结果。
+type renderedCodeBlock struct {
+	html string
+	ok   bool
+}
+
+// renderCodeBlockByLanguage 使用 Options.CodeBlockRenderers 中按语言注册的渲染器渲染围栏代码块 codeBlock，ok 为 false 时需要使用原有渲染。
+//
+// 渲染结果会被缓存，所以离开节点时再次调用不会重复调用渲染器。
+func (r *BaseRenderer) renderCodeBlockByLanguage(codeBlock *ast.Node) (ret string, ok bool) {
+	if 1 > len(r.Options.CodeBlockRenderers) || !codeBlock.IsFencedCodeBlock {
+		return
+	}
+
+	if rendered := r.renderedCodeBlocks[codeBlock]; nil != rendered {
+		return rendered.html, rendered.ok
+	}
+
+	infoMarker := codeBlock.ChildByType(ast.NodeCodeBlockFenceInfoMarker)
+	if nil == infoMarker || 1 > len(infoMarker.CodeBlockInfo) {
+		return
+	}
+
+	info := codeBlockInfo(infoMarker)
+	renderer := r.Options.CodeBlockRenderers[info.Language]
+	if nil == renderer {
+		return
+	}
+
+	var code []byte
+	if codeNode := codeBlock.ChildByType(ast.NodeCodeBlockCode); nil != codeNode {
+		code = bytes.ReplaceAll(codeNode.Tokens, editor.CaretTokens, nil)
+	}
+	ret, ok = renderer(code, info)
+	if nil == r.renderedCodeBlocks {
+		r.renderedCodeBlocks = map[*ast.Node]*renderedCodeBlock{}
+	}
+	r.renderedCodeBlocks[codeBlock] = &renderedCodeBlock{html: ret, ok: ok}
+	return
+}
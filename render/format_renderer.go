@@ -660,8 +660,8 @@ func (r *FormatRenderer) renderFileAnnotationRefText(node *ast.Node, entering bo
 }
 
 func (r *FormatRenderer) renderYamlFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	if entering {
-		r.Write(parse.YamlFrontMatterMarker)
+	if marker := parse.FrontMatterMarker(node); entering && 0 < len(marker) {
+		r.Write(marker)
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
@@ -676,8 +676,8 @@ func (r *FormatRenderer) renderYamlFrontMatterContent(node *ast.Node, entering b
 }
 
 func (r *FormatRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	if entering {
-		r.Write(parse.YamlFrontMatterMarker)
+	if marker := parse.FrontMatterMarker(node); entering && 0 < len(marker) {
+		r.Write(marker)
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
@@ -686,6 +686,9 @@ func (r *FormatRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, enterin
 func (r *FormatRenderer) renderYamlFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Newline()
+		if r.Tree.FrontMatterModified() && r.writeFrontMatter(parse.FrontMatterFormat(node), parse.FrontMatterMarker(node)) {
+			return ast.WalkSkipChildren
+		}
 		if !entering && !r.isLastNode(r.Tree.Root, node) {
 			r.WriteByte(lex.ItemNewline)
 		}
@@ -716,6 +719,10 @@ func (r *FormatRenderer) renderBackslash(node *ast.Node, entering bool) ast.Walk
 
 func (r *FormatRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		if r.Options.ToCMarkdown {
+			r.WriteString(r.markdownToC() + "\n")
+			return ast.WalkContinue
+		}
 		r.WriteString("[toc]\n\n")
 	}
 	return ast.WalkContinue
@@ -781,6 +788,9 @@ func (r *FormatRenderer) renderEmoji(node *ast.Node, entering bool) ast.WalkStat
 
 func (r *FormatRenderer) renderTableCell(node *ast.Node, entering bool) ast.WalkStatus {
 	padding := node.TableCellContentMaxWidth - node.TableCellContentWidth
+	if !r.Options.FormatTablePadding {
+		padding = 0
+	}
 	if entering {
 		r.WriteByte(lex.ItemPipe)
 		if !r.Options.ProtyleWYSIWYG {
@@ -822,11 +832,15 @@ func (r *FormatRenderer) renderTableHead(node *ast.Node, entering bool) ast.Walk
 				continue
 			}
 
+			maxWidth := th.TableCellContentMaxWidth
+			if !r.Options.FormatTablePadding {
+				maxWidth = 3
+			}
 			align := th.TableCellAlign
 			switch align {
 			case 0:
 				r.WriteString("| -")
-				if padding := th.TableCellContentMaxWidth - 1; 0 < padding {
+				if padding := maxWidth - 1; 0 < padding {
 					r.Write(bytes.Repeat([]byte{lex.ItemHyphen}, padding))
 				}
 				if !r.Options.ProtyleWYSIWYG {
@@ -834,7 +848,7 @@ func (r *FormatRenderer) renderTableHead(node *ast.Node, entering bool) ast.Walk
 				}
 			case 1:
 				r.WriteString("| :-")
-				if padding := th.TableCellContentMaxWidth - 2; 0 < padding {
+				if padding := maxWidth - 2; 0 < padding {
 					r.Write(bytes.Repeat([]byte{lex.ItemHyphen}, padding))
 				}
 				if !r.Options.ProtyleWYSIWYG {
@@ -842,13 +856,13 @@ func (r *FormatRenderer) renderTableHead(node *ast.Node, entering bool) ast.Walk
 				}
 			case 2:
 				r.WriteString("| :-")
-				if padding := th.TableCellContentMaxWidth - 3; 0 < padding {
+				if padding := maxWidth - 3; 0 < padding {
 					r.Write(bytes.Repeat([]byte{lex.ItemHyphen}, padding))
 				}
 				r.WriteString(": ")
 			case 3:
 				r.WriteString("| -")
-				if padding := th.TableCellContentMaxWidth - 2; 0 < padding {
+				if padding := maxWidth - 2; 0 < padding {
 					r.Write(bytes.Repeat([]byte{lex.ItemHyphen}, padding))
 				}
 				r.WriteString(": ")
@@ -887,7 +901,7 @@ func (r *FormatRenderer) renderTable(node *ast.Node, entering bool) ast.WalkStat
 		var maxWidth int
 		for col := 0; col < len(cells[0]); col++ {
 			for row := 0; row < len(cells) && col < len(cells[row]); row++ {
-				cells[row][col].TableCellContentWidth = cells[row][col].TokenLen()
+				cells[row][col].TableCellContentWidth = tableCellWidth(cells[row][col])
 				// 自动添加空格会导致单元格宽度发生变化
 				if r.Options.AutoSpace {
 					ret := 0
@@ -961,13 +975,27 @@ func (r *FormatRenderer) renderStrikethrough2CloseMarker(node *ast.Node, enterin
 
 func (r *FormatRenderer) renderLinkTitle(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		tokens := node.Tokens
+		if r.titleUnescaped() {
+			// 非编辑器模式下解析器会去掉标题中的转义，输出时需要重新转义反斜杠
+			tokens = bytes.ReplaceAll(tokens, []byte("\\"), []byte("\\\\"))
+		}
 		r.WriteByte(lex.ItemDoublequote)
-		r.Write(html.EscapeHTML(node.Tokens))
+		r.Write(html.EscapeHTML(tokens))
 		r.WriteByte(lex.ItemDoublequote)
 	}
 	return ast.WalkContinue
 }
 
+// titleUnescaped 判断链接标题是否已经被解析器去掉了转义，解析器只在非编辑器模式下去掉转义。
+func (r *FormatRenderer) titleUnescaped() bool {
+	if nil == r.Tree.Context || nil == r.Tree.Context.ParseOption {
+		return false
+	}
+	options := r.Tree.Context.ParseOption
+	return !options.VditorWYSIWYG && !options.VditorIR && !options.VditorSV && !options.ProtyleWYSIWYG
+}
+
 func (r *FormatRenderer) renderLinkDest(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		tokens := node.Tokens
@@ -992,6 +1020,9 @@ func (r *FormatRenderer) renderLinkText(node *ast.Node, entering bool) ast.WalkS
 		} else {
 			tokens = node.Tokens
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		r.Write(tokens)
 	}
 	return ast.WalkContinue
@@ -1089,6 +1120,25 @@ func (r *FormatRenderer) renderLink(node *ast.Node, entering bool) ast.WalkStatu
 
 func (r *FormatRenderer) renderHTML(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		if r.Options.ToCMarkdown && isToCMarkdownMarker(node, ToCMarkdownStart) {
+			// 刷新 Markdown 目录，移除起止标记之间原有的目录，没有结束标记时不移除
+			end := node.Next
+			for ; nil != end && !isToCMarkdownMarker(end, ToCMarkdownEnd); end = end.Next {
+			}
+			for nil != end && node.Next != end {
+				node.Next.Unlink()
+			}
+			if nil != end {
+				end.Unlink()
+			}
+			r.Newline()
+			r.WriteString(r.markdownToC())
+			if !r.isLastNode(r.Tree.Root, node) {
+				r.WriteByte(lex.ItemNewline)
+			}
+			return ast.WalkContinue
+		}
+
 		r.Newline()
 		tokens := node.Tokens
 		tokens = r.tagSrcPath(tokens)
@@ -1114,6 +1164,10 @@ func (r *FormatRenderer) renderDocument(node *ast.Node, entering bool) ast.WalkS
 	if entering {
 		r.Writer = &bytes.Buffer{}
 		r.NodeWriterStack = append(r.NodeWriterStack, r.Writer)
+		if nil == frontMatterNode(node) && 0 < len(r.Tree.FrontMatter) {
+			// 文档中没有 Front Matter 时在开头插入设置的元数据
+			r.writeFrontMatter(r.Tree.FrontMatterFormat(), frontMatterMarker(r.Tree.FrontMatterFormat()))
+		}
 	} else {
 		r.NodeWriterStack = r.NodeWriterStack[:len(r.NodeWriterStack)-1]
 		var buf []byte
@@ -1131,11 +1185,23 @@ func (r *FormatRenderer) renderDocument(node *ast.Node, entering bool) ast.WalkS
 }
 
 func (r *FormatRenderer) renderParagraph(node *ast.Node, entering bool) ast.WalkStatus {
-	if !entering {
+	if entering {
+		if r.proseWrap(node) {
+			r.Writer = &bytes.Buffer{}
+			r.NodeWriterStack = append(r.NodeWriterStack, r.Writer)
+		}
+	} else {
 		if !r.Options.KeepParagraphBeginningSpace && nil != node.FirstChild {
 			node.FirstChild.Tokens = bytes.TrimSpace(node.FirstChild.Tokens)
 		}
 
+		if r.proseWrap(node) {
+			writer := r.NodeWriterStack[len(r.NodeWriterStack)-1]
+			r.NodeWriterStack = r.NodeWriterStack[:len(r.NodeWriterStack)-1]
+			r.Writer = r.NodeWriterStack[len(r.NodeWriterStack)-1]
+			r.Write(r.wrapProse(node, writer.Bytes()))
+		}
+
 		if node.ParentIs(ast.NodeTableCell) {
 			if nil != node.Next && ast.NodeText != node.Next.Type {
 				r.WriteString("<br /><br />")
@@ -1188,6 +1254,9 @@ func (r *FormatRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatu
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		if (nil == node.Previous || ast.NodeTaskListItemMarker == node.Previous.Type) &&
 			nil != node.Parent.Parent && nil != node.Parent.Parent.ListData && 3 == node.Parent.Parent.ListData.Typ {
 			if ' ' == r.LastOut {
@@ -1228,14 +1297,10 @@ func (r *FormatRenderer) renderCodeSpan(node *ast.Node, entering bool) ast.WalkS
 
 func (r *FormatRenderer) renderCodeSpanOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteByte(lex.ItemBacktick)
-		if 1 < node.Parent.CodeMarkerLen {
-			r.WriteByte(lex.ItemBacktick)
-			text := util.BytesToStr(node.Next.Tokens)
-			firstc, _ := utf8.DecodeRuneInString(text)
-			if '`' == firstc {
-				r.WriteByte(lex.ItemSpace)
-			}
+		content := node.Next.Tokens
+		r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, codeSpanMarkerLen(node.Parent.CodeMarkerLen, content)))
+		if codeSpanPadded(content) {
+			r.WriteByte(lex.ItemSpace)
 		}
 	}
 	return ast.WalkContinue
@@ -1256,19 +1321,41 @@ func (r *FormatRenderer) renderCodeSpanContent(node *ast.Node, entering bool) as
 
 func (r *FormatRenderer) renderCodeSpanCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		if 1 < node.Parent.CodeMarkerLen {
-			text := util.BytesToStr(node.Previous.Tokens)
-			lastc, _ := utf8.DecodeLastRuneInString(text)
-			if '`' == lastc {
-				r.WriteByte(lex.ItemSpace)
-			}
-			r.WriteByte(lex.ItemBacktick)
+		content := node.Previous.Tokens
+		if codeSpanPadded(content) {
+			r.WriteByte(lex.ItemSpace)
 		}
-		r.WriteByte(lex.ItemBacktick)
+		r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, codeSpanMarkerLen(node.Parent.CodeMarkerLen, content)))
 	}
 	return ast.WalkContinue
 }
 
+// codeSpanMarkerLen 返回代码 content 使用的反引号标记符长度：多个反引号统一使用两个，代码中有更长的连续反引号时比其多一个。
+func codeSpanMarkerLen(markerLen int, content []byte) int {
+	if 1 < markerLen {
+		markerLen = 2
+	} else {
+		markerLen = 1
+	}
+	run := 0
+	for _, c := range content {
+		if lex.ItemBacktick != c {
+			run = 0
+			continue
+		}
+		if run++; markerLen <= run {
+			markerLen = run + 1
+		}
+	}
+	return markerLen
+}
+
+// codeSpanPadded 判断代码 content 两侧是否需要用空格和标记符隔开：以反引号开头或者结尾时两侧都添加空格，解析时两侧的空格会被去掉。
+func codeSpanPadded(content []byte) bool {
+	length := len(content)
+	return 0 < length && (lex.ItemBacktick == content[0] || lex.ItemBacktick == content[length-1])
+}
+
 func (r *FormatRenderer) renderInlineMath(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		if r.Options.AutoSpace {
@@ -1349,7 +1436,7 @@ func (r *FormatRenderer) renderMathBlock(node *ast.Node, entering bool) ast.Walk
 func (r *FormatRenderer) renderCodeBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Newline()
-		r.Write(node.Tokens)
+		r.Write(r.codeFence(node.Parent, node.Tokens))
 		r.Newline()
 		if !r.isLastNode(r.Tree.Root, node) {
 			if r.withoutKramdownBlockIAL(node.Parent) {
@@ -1370,6 +1457,10 @@ func (r *FormatRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.
 func (r *FormatRenderer) renderCodeBlockInfoMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Write(node.CodeBlockInfo)
+		if 0 < len(node.CodeBlockInfoMeta) {
+			r.WriteByte(lex.ItemSpace)
+			r.Write(node.CodeBlockInfoMeta)
+		}
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
@@ -1377,7 +1468,7 @@ func (r *FormatRenderer) renderCodeBlockInfoMarker(node *ast.Node, entering bool
 
 func (r *FormatRenderer) renderCodeBlockOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.Write(node.Tokens)
+		r.Write(r.codeFence(node.Parent, node.Tokens))
 	}
 	return ast.WalkContinue
 }
@@ -1386,10 +1477,11 @@ func (r *FormatRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.Walk
 	if entering {
 		r.Newline()
 		if !node.IsFencedCodeBlock {
-			r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, 3))
+			fence := r.codeFence(node, bytes.Repeat([]byte{lex.ItemBacktick}, 3))
+			r.Write(fence)
 			r.WriteByte(lex.ItemNewline)
 			r.Write(node.FirstChild.Tokens)
-			r.Write(bytes.Repeat([]byte{lex.ItemBacktick}, 3))
+			r.Write(fence)
 			r.Newline()
 			if !r.isLastNode(r.Tree.Root, node) {
 				if r.withoutKramdownBlockIAL(node) {
@@ -1413,28 +1505,28 @@ func (r *FormatRenderer) renderEmphasis(node *ast.Node, entering bool) ast.WalkS
 
 func (r *FormatRenderer) renderEmAsteriskOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteByte(lex.ItemAsterisk)
+		r.WriteString(r.emphasisMarker(node, "*"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderEmAsteriskCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteByte(lex.ItemAsterisk)
+		r.WriteString(r.emphasisMarker(node, "*"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderEmUnderscoreOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteByte(lex.ItemUnderscore)
+		r.WriteString(r.emphasisMarker(node, "_"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderEmUnderscoreCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteByte(lex.ItemUnderscore)
+		r.WriteString(r.emphasisMarker(node, "_"))
 	}
 	return ast.WalkContinue
 }
@@ -1450,28 +1542,28 @@ func (r *FormatRenderer) renderStrong(node *ast.Node, entering bool) ast.WalkSta
 
 func (r *FormatRenderer) renderStrongA6kOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString("**")
+		r.WriteString(r.strongMarker(node, "**"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderStrongA6kCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString("**")
+		r.WriteString(r.strongMarker(node, "**"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderStrongU8eOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString("__")
+		r.WriteString(r.strongMarker(node, "__"))
 	}
 	return ast.WalkContinue
 }
 
 func (r *FormatRenderer) renderStrongU8eCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString("__")
+		r.WriteString(r.strongMarker(node, "__"))
 	}
 	return ast.WalkContinue
 }
@@ -1536,12 +1628,12 @@ func (r *FormatRenderer) renderBlockquoteMarker(node *ast.Node, entering bool) a
 
 func (r *FormatRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		if !node.HeadingSetext {
+		if !r.headingSetext(node) {
 			r.Write(bytes.Repeat([]byte{lex.ItemCrosshatch}, node.HeadingLevel))
 			r.WriteByte(lex.ItemSpace)
 		}
 	} else {
-		if node.HeadingSetext {
+		if r.headingSetext(node) {
 			r.WriteByte(lex.ItemNewline)
 			contentLen := r.setextHeadingLen(node)
 			if 1 == node.HeadingLevel {
@@ -1607,10 +1699,21 @@ func (r *FormatRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkS
 	} else {
 		writer := r.NodeWriterStack[len(r.NodeWriterStack)-1]
 		r.NodeWriterStack = r.NodeWriterStack[:len(r.NodeWriterStack)-1]
+		listItemBuf := bytes.Buffer{}
+		if 1 == node.ListData.Typ || (3 == node.ListData.Typ && 0 == node.ListData.BulletChar) {
+			listItemBuf.WriteString(strconv.Itoa(r.orderedListNum(node)) + string(node.ListData.Delimiter))
+		} else {
+			listItemBuf.Write(r.bulletMarker(node))
+		}
+		listItemBuf.WriteByte(lex.ItemSpace)
+
 		indent := len(node.ListData.Marker) + 1
 		if 1 == node.ListData.Typ || (3 == node.ListData.Typ && 0 == node.ListData.BulletChar) {
 			indent++
 		}
+		if "one" == r.Options.FormatOrderedListNumbering && 0 == node.ListData.BulletChar {
+			indent = listItemBuf.Len()
+		}
 		indentSpaces := bytes.Repeat([]byte{lex.ItemSpace}, indent)
 		indentedLines := bytes.Buffer{}
 		buf := writer.Bytes()
@@ -1629,13 +1732,6 @@ func (r *FormatRenderer) renderListItem(node *ast.Node, entering bool) ast.WalkS
 			buf = buf[indent:]
 		}
 
-		listItemBuf := bytes.Buffer{}
-		if 1 == node.ListData.Typ || (3 == node.ListData.Typ && 0 == node.ListData.BulletChar) {
-			listItemBuf.WriteString(strconv.Itoa(node.ListData.Num) + string(node.ListData.Delimiter))
-		} else {
-			listItemBuf.Write(node.ListData.Marker)
-		}
-		listItemBuf.WriteByte(lex.ItemSpace)
 		buf = append(listItemBuf.Bytes(), buf...)
 		if node.ParentIs(ast.NodeTableCell) {
 			buf = bytes.ReplaceAll(buf, []byte("\n"), nil)
@@ -1678,7 +1774,7 @@ func (r *FormatRenderer) renderThematicBreak(node *ast.Node, entering bool) ast.
 		if node.ParentIs(ast.NodeTableCell) {
 			r.WriteString("<hr/>")
 		} else {
-			r.WriteString("---")
+			r.WriteString(r.thematicBreak())
 			if r.withoutKramdownBlockIAL(node) {
 				r.WriteByte(lex.ItemNewline)
 				r.WriteByte(lex.ItemNewline)
@@ -1705,6 +1801,15 @@ func (r *FormatRenderer) renderHardBreak(node *ast.Node, entering bool) ast.Walk
 
 func (r *FormatRenderer) renderSoftBreak(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
+		for p := node.Parent; nil != p; p = p.Parent {
+			if ast.NodeParagraph == p.Type {
+				if r.proseWrap(p) {
+					r.WriteByte(lex.ItemSpace) // 段落重新折行时软换行作为单词分隔
+					return ast.WalkContinue
+				}
+				break
+			}
+		}
 		r.Newline()
 	}
 	return ast.WalkContinue
@@ -0,0 +1,302 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/util"
+)
+
+// 这里实现了格式化渲染器 FormatRenderer 的各项风格选项，选项说明见 Options.Format* 字段。
+
+// bulletMarker 返回列表项 listItem 使用的无序列表标记符。
+func (r *FormatRenderer) bulletMarker(listItem *ast.Node) []byte {
+	marker := r.Options.FormatBulletMarker
+	if "*" != marker && "-" != marker && "+" != marker {
+		return listItem.ListData.Marker
+	}
+
+	// 相邻的无序列表使用相同标记符的话会被合并为一个列表，所以需要交替使用
+	alternate := false
+	if list := listItem.Parent; nil != list {
+		for previous := list.Previous; nil != previous && isBulletList(previous); previous = previous.Previous {
+			alternate = !alternate
+		}
+	}
+	if alternate {
+		if "-" == marker {
+			marker = "*"
+		} else {
+			marker = "-"
+		}
+	}
+	return []byte(marker)
+}
+
+func isBulletList(n *ast.Node) bool {
+	return ast.NodeList == n.Type && nil != n.ListData && 0 != n.ListData.BulletChar
+}
+
+// orderedListNum 返回有序列表项 listItem 的序号。
+func (r *FormatRenderer) orderedListNum(listItem *ast.Node) int {
+	if "one" == r.Options.FormatOrderedListNumbering {
+		if list := listItem.Parent; nil != list && nil != list.ListData {
+			return list.ListData.Start
+		}
+	}
+	return listItem.ListData.Num
+}
+
+// emphasisMarker 返回强调标记符节点 marker 使用的标记符，original 为原文标记符。
+func (r *FormatRenderer) emphasisMarker(marker *ast.Node, original string) string {
+	switch r.Options.FormatEmphasisMarker {
+	case "*":
+		return "*"
+	case "_":
+		if !r.intraword(marker.Parent) {
+			return "_"
+		}
+	}
+	return original
+}
+
+// strongMarker 返回加粗标记符节点 marker 使用的标记符，original 为原文标记符。
+func (r *FormatRenderer) strongMarker(marker *ast.Node, original string) string {
+	switch r.Options.FormatStrongMarker {
+	case "**":
+		return "**"
+	case "__":
+		if !r.intraword(marker.Parent) {
+			return "__"
+		}
+	}
+	return original
+}
+
+// intraword 判断强调或者加粗节点 n 是否紧挨着字母数字，这种情况下 _ 不能作为标记符。
+func (r *FormatRenderer) intraword(n *ast.Node) bool {
+	if text := n.PreviousNodeText(); "" != text {
+		if c, _ := utf8.DecodeLastRuneInString(text); unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return true
+		}
+	}
+	if text := n.NextNodeText(); "" != text {
+		if c, _ := utf8.DecodeRuneInString(text); unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// codeFence 返回代码块 codeBlock 使用的围栏，original 为原文围栏。
+func (r *FormatRenderer) codeFence(codeBlock *ast.Node, original []byte) []byte {
+	fenceChar := original[0]
+	switch r.Options.FormatCodeFenceChar {
+	case "`":
+		fenceChar = lex.ItemBacktick
+	case "~":
+		fenceChar = lex.ItemTilde
+	}
+	if lex.ItemBacktick == fenceChar && bytes.Contains(codeBlock.CodeBlockInfo, []byte{lex.ItemBacktick}) {
+		fenceChar = lex.ItemTilde // 反引号围栏的信息字符串中不能包含反引号
+	}
+
+	fenceLen := len(original)
+	if 0 < r.Options.FormatCodeFenceLen {
+		fenceLen = r.Options.FormatCodeFenceLen
+		if 3 > fenceLen {
+			fenceLen = 3
+		}
+	}
+	if fenceChar == original[0] && fenceLen == len(original) {
+		return original
+	}
+
+	// 围栏需要比代码内容中行首出现的同字符序列更长
+	var code []byte
+	if c := codeBlock.ChildByType(ast.NodeCodeBlockCode); nil != c {
+		code = c.Tokens
+	}
+	for _, line := range bytes.Split(code, []byte{lex.ItemNewline}) {
+		line = bytes.TrimLeft(line, " ")
+		run := 0
+		for run < len(line) && fenceChar == line[run] {
+			run++
+		}
+		if fenceLen <= run {
+			fenceLen = run + 1
+		}
+	}
+	return bytes.Repeat([]byte{fenceChar}, fenceLen)
+}
+
+// thematicBreak 返回分隔线的写法。
+func (r *FormatRenderer) thematicBreak() string {
+	switch r.Options.FormatThematicBreak {
+	case "***", "___", "* * *", "- - -", "_ _ _":
+		return r.Options.FormatThematicBreak
+	}
+	return "---"
+}
+
+// headingSetext 判断标题 heading 是否使用 Setext 写法。
+func (r *FormatRenderer) headingSetext(heading *ast.Node) bool {
+	if heading.ParentIs(ast.NodeTableCell) {
+		return false
+	}
+	switch r.Options.FormatHeadingStyle {
+	case "atx":
+		return false
+	case "setext":
+		return 2 >= heading.HeadingLevel
+	}
+	return heading.HeadingSetext
+}
+
+// proseWrap 判断段落 paragraph 是否需要重新折行。
+func (r *FormatRenderer) proseWrap(paragraph *ast.Node) bool {
+	if "column" != r.Options.FormatProseWrap && "sentence" != r.Options.FormatProseWrap || r.Options.KeepParagraphBeginningSpace {
+		return false
+	}
+	return ast.NodeParagraph == paragraph.Type && !paragraph.ParentIs(ast.NodeTableCell)
+}
+
+// wrapProse 对段落渲染结果 buf 重新折行，paragraph 用于计算段落在列表和引述中的缩进。
+func (r *FormatRenderer) wrapProse(paragraph *ast.Node, buf []byte) []byte {
+	column := r.Options.FormatProseWrapColumn
+	if 1 > column {
+		column = 80
+	}
+	for p := paragraph.Parent; nil != p; p = p.Parent {
+		switch p.Type {
+		case ast.NodeListItem:
+			column -= len(p.ListData.Marker) + 1
+			if 0 == p.ListData.BulletChar {
+				column--
+			}
+		case ast.NodeBlockquote:
+			column -= 2
+		}
+	}
+
+	ret := bytes.Buffer{}
+	// 段落中剩下的换行都是硬换行，需要保留
+	for i, hardLine := range strings.Split(string(buf), "\n") {
+		if 0 < i {
+			ret.WriteByte(lex.ItemNewline)
+		}
+		words := proseWords(hardLine)
+		width := 0
+		for j, word := range words {
+			wordWidth := util.DisplayWidth(word)
+			if 0 < j {
+				var newLine bool
+				if "sentence" == r.Options.FormatProseWrap {
+					newLine = sentenceEnd(words[j-1])
+				} else {
+					newLine = column < width+1+wordWidth
+				}
+				if newLine && !unsafeLineStart(word) {
+					ret.WriteByte(lex.ItemNewline)
+					width = 0
+				} else {
+					ret.WriteByte(lex.ItemSpace)
+					width++
+				}
+			}
+			ret.WriteString(word)
+			width += wordWidth
+		}
+	}
+	return ret.Bytes()
+}
+
+// proseWords 将一行 Markdown 按空格拆分为单词，代码、链接地址、自动链接和 HTML 标签中的空格不会被拆分。
+func proseWords(line string) (ret []string) {
+	var word strings.Builder
+	codeLen := 0 // 当前代码的反引号长度
+	inParen := 0 // 链接地址括号层级
+	inAngle := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case '\\' == c && i+1 < len(line):
+			word.WriteByte(c)
+			i++
+			c = line[i]
+		case '`' == c:
+			run := 1
+			for i+run < len(line) && '`' == line[i+run] {
+				run++
+			}
+			if 0 == codeLen {
+				codeLen = run
+			} else if codeLen == run {
+				codeLen = 0
+			}
+			word.WriteString(line[i : i+run])
+			i += run - 1
+			continue
+		case 0 < codeLen:
+		case '(' == c && (0 < inParen || (0 < i && ']' == line[i-1])):
+			inParen++
+		case ')' == c && 0 < inParen:
+			inParen--
+		case '<' == c && i+1 < len(line) && ('/' == line[i+1] || lex.IsASCIILetter(line[i+1])):
+			inAngle = true
+		case '>' == c:
+			inAngle = false
+		case ' ' == c && 0 == inParen && !inAngle:
+			if 0 < word.Len() {
+				ret = append(ret, word.String())
+				word.Reset()
+			}
+			continue
+		}
+		word.WriteByte(c)
+	}
+	if 0 < word.Len() {
+		ret = append(ret, word.String())
+	}
+	return
+}
+
+// sentenceEnd 判断单词 word 是否是句子的结尾。
+func sentenceEnd(word string) bool {
+	word = strings.TrimRight(word, "*_)\"'”’")
+	last, _ := utf8.DecodeLastRuneInString(word)
+	return strings.ContainsRune(".!?。！？", last)
+}
+
+// unsafeLineStart 判断单词 word 出现在行首时是否会被解析为其他块级元素。
+func unsafeLineStart(word string) bool {
+	switch word[0] {
+	case '>', '|', '<':
+		return true
+	case '#', '-', '+', '*', '_', '=':
+		return "" == strings.Trim(word, word[:1]) // 标题、列表、分隔线或者 Setext 标题
+	case '`', '~', '$':
+		return strings.HasPrefix(word, strings.Repeat(word[:1], 2)) // 代码块或者数学公式块
+	case '[':
+		return strings.Contains(word, "]:") // 链接引用定义或者脚注定义
+	}
+	digits := 0
+	for digits < len(word) && lex.IsDigit(word[digits]) {
+		digits++
+	}
+	return 0 < digits && digits == len(word)-1 && ('.' == word[digits] || ')' == word[digits])
+}
@@ -0,0 +1,100 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"sort"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/frontmatter"
+	"github.com/88250/lute/html"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/util"
+)
+
+// Front Matter 的 HTML 渲染方式。
+const (
+	FrontMatterRenderCode = "code" // 渲染为代码块
+	FrontMatterRenderNone = "none" // 不渲染
+	FrontMatterRenderMeta = "meta" // 渲染为 <meta> 标签
+)
+
+// frontMatterMetas 将元数据 m 展开为 <meta> 标签的 name 和 content，嵌套的键使用 . 连接。
+func frontMatterMetas(prefix string, m map[string]interface{}) (ret [][]string) {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := k
+		if "" != prefix {
+			name = prefix + "." + k
+		}
+		if child, ok := m[k].(map[string]interface{}); ok {
+			ret = append(ret, frontMatterMetas(name, child)...)
+			continue
+		}
+		ret = append(ret, []string{name, frontmatter.String(m[k])})
+	}
+	return
+}
+
+// renderFrontMatterMetas 将 Front Matter 元数据渲染为 <meta> 标签。
+func (r *HtmlRenderer) renderFrontMatterMetas() {
+	for _, meta := range frontMatterMetas("", r.Tree.FrontMatter) {
+		name := util.BytesToStr(html.EscapeHTML(util.StrToBytes(meta[0])))
+		content := util.BytesToStr(html.EscapeHTML(util.StrToBytes(meta[1])))
+		r.Tag("meta", [][]string{{"name", name}, {"content", content}}, true)
+		r.Newline()
+	}
+}
+
+// writeFrontMatter 将 Front Matter 元数据按照 format 格式规范化输出，marker 为首尾的标记符。元数据为空时不输出。
+func (r *FormatRenderer) writeFrontMatter(format string, marker []byte) bool {
+	data, err := frontmatter.Marshal(format, r.Tree.FrontMatter)
+	if nil != err {
+		return false
+	}
+	if 0 == len(r.Tree.FrontMatter) {
+		return true
+	}
+
+	r.Write(marker)
+	if 0 < len(marker) {
+		r.WriteByte(lex.ItemNewline)
+	}
+	r.Write(data)
+	r.Write(marker)
+	if 0 < len(marker) {
+		r.WriteByte(lex.ItemNewline)
+	}
+	return true
+}
+
+// frontMatterMarker 返回 format 格式的 Front Matter 使用的标记符。
+func frontMatterMarker(format string) []byte {
+	switch format {
+	case frontmatter.TOML:
+		return []byte("+++")
+	case frontmatter.JSON:
+		return nil
+	}
+	return []byte("---")
+}
+
+// frontMatterNode 返回文档的 Front Matter 节点。
+func frontMatterNode(root *ast.Node) *ast.Node {
+	if nil != root.FirstChild && ast.NodeYamlFrontMatter == root.FirstChild.Type {
+		return root.FirstChild
+	}
+	return nil
+}
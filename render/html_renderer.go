@@ -317,6 +317,21 @@ func (r *HtmlRenderer) renderGitConflict(node *ast.Node, entering bool) ast.Walk
 }
 
 func (r *HtmlRenderer) renderSuperBlock(node *ast.Node, entering bool) ast.WalkStatus {
+	if !r.Options.SuperBlock {
+		return ast.WalkContinue
+	}
+
+	r.Newline()
+	if entering {
+		attrs := r.superBlockAttrs(SuperBlockLayout(node))
+		r.handleKramdownBlockIAL(node)
+		attrs = append(attrs, node.KramdownIAL...)
+		r.Tag("div", attrs, false)
+		r.Newline()
+	} else {
+		r.Tag("/div", nil, false)
+		r.Newline()
+	}
 	return ast.WalkContinue
 }
 
@@ -447,6 +462,13 @@ func (r *HtmlRenderer) renderSubCloseMarker(node *ast.Node, entering bool) ast.W
 }
 
 func (r *HtmlRenderer) renderBlockQueryEmbed(node *ast.Node, entering bool) ast.WalkStatus {
+	if nil != r.Options.BlockProvider {
+		if entering {
+			r.renderEmbeddedBlocks(node)
+		}
+		return ast.WalkSkipChildren
+	}
+
 	if entering {
 		r.Newline()
 		r.Tag("div", nil, false)
@@ -466,8 +488,72 @@ func (r *HtmlRenderer) renderBlockQueryEmbedScript(node *ast.Node, entering bool
 	return ast.WalkContinue
 }
 
+func (r *HtmlRenderer) renderEmbeddedBlocks(node *ast.Node) {
+	r.Newline()
+	blocks, err := r.embeddedBlocks(node)
+	if nil != err {
+		r.Tag("div", [][]string{{"class", "block-embed block-embed--error"}}, false)
+		r.WriteString(html.EscapeHTMLStr(err.Error()))
+		r.Tag("/div", nil, false)
+		r.Newline()
+		return
+	}
+
+	r.Tag("div", [][]string{{"class", "block-embed"}}, false)
+	r.Newline()
+	for _, block := range blocks {
+		r.Tag("div", [][]string{{"class", "block-embed__item"}, {"data-id", block.id}}, false)
+		r.Newline()
+		if block.embed {
+			embedded := NewHtmlRenderer(block.tree, r.Options)
+			embedded.embedStack = append(append([]string{}, r.embedStack...), block.id)
+			r.Write(embedded.renderNodes(block.nodes()...))
+		} else {
+			// 超过最大嵌套深度或者循环嵌入时渲染为引用
+			r.Tag("p", nil, false)
+			r.renderBlockRefLink(block, blockRefAnchor(node, block))
+			r.Tag("/p", nil, false)
+		}
+		r.Newline()
+		r.Tag("/div", nil, false)
+		r.Newline()
+	}
+	r.Tag("/div", nil, false)
+	r.Newline()
+}
+
+func (r *HtmlRenderer) renderBlockRefLink(block *providedBlock, anchor string) {
+	href := block.url
+	if "" == href {
+		href = "#" + block.id
+	}
+	attrs := [][]string{{"href", html.EscapeHTMLStr(href)}, {"class", "block-ref"}, {"data-id", block.id}}
+	if title := blockRefTitle(block); "" != title {
+		attrs = append(attrs, []string{"title", html.EscapeHTMLStr(title)})
+	}
+	r.Tag("a", attrs, false)
+	r.WriteString(html.EscapeHTMLStr(anchor))
+	r.Tag("/a", nil, false)
+}
+
 func (r *HtmlRenderer) renderBlockRef(node *ast.Node, entering bool) ast.WalkStatus {
-	return ast.WalkContinue
+	if nil == r.Options.BlockProvider {
+		return ast.WalkContinue
+	}
+
+	if entering {
+		id := node.ChildByType(ast.NodeBlockRefID).TokensStr()
+		block := r.block(id)
+		anchor := blockRefAnchor(node, block)
+		if nil == block {
+			r.Tag("span", [][]string{{"class", "block-ref block-ref--missing"}, {"data-id", id}}, false)
+			r.WriteString(html.EscapeHTMLStr(anchor))
+			r.Tag("/span", nil, false)
+		} else {
+			r.renderBlockRefLink(block, anchor)
+		}
+	}
+	return ast.WalkSkipChildren
 }
 
 func (r *HtmlRenderer) renderBlockRefID(node *ast.Node, entering bool) ast.WalkStatus {
@@ -539,12 +625,22 @@ func (r *HtmlRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, entering
 		attrs := [][]string{{"class", "vditor-yml-front-matter"}}
 		attrs = append(attrs, node.Parent.KramdownIAL...)
 		r.Tag("pre", attrs, false)
-		r.WriteString("<code class=\"language-yaml\">")
+		r.WriteString("<code class=\"language-" + parse.FrontMatterFormat(node) + "\">")
 	}
 	return ast.WalkContinue
 }
 
 func (r *HtmlRenderer) renderYamlFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
+	switch r.Options.FrontMatterRender {
+	case FrontMatterRenderNone:
+		return ast.WalkSkipChildren
+	case FrontMatterRenderMeta:
+		if entering && nil == r.Tree.FrontMatterErr {
+			r.Newline()
+			r.renderFrontMatterMetas()
+		}
+		return ast.WalkSkipChildren
+	}
 	r.Newline()
 	return ast.WalkContinue
 }
@@ -639,6 +735,27 @@ func (r *HtmlRenderer) renderFootnotesDef(node *ast.Node, entering bool) ast.Wal
 	return ast.WalkContinue
 }
 
+// renderCodeBlockTitle 在代码块信息设置了标题时使用 <figure> 包裹代码块，并将标题渲染为 <figcaption>。
+func (r *HtmlRenderer) renderCodeBlockTitle(infoMarker *ast.Node, entering bool) {
+	if nil == infoMarker || 1 > len(infoMarker.CodeBlockInfoMeta) {
+		return
+	}
+
+	title := codeBlockInfo(infoMarker).Title
+	if "" == title {
+		return
+	}
+
+	if entering {
+		r.Tag("figure", [][]string{{"class", "code-block"}}, false)
+		r.Tag("figcaption", nil, false)
+		r.WriteString(html.EscapeHTMLStr(title))
+		r.Tag("/figcaption", nil, false)
+	} else {
+		r.Tag("/figure", nil, false)
+	}
+}
+
 func (r *HtmlRenderer) renderCodeBlockCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	return ast.WalkContinue
 }
@@ -850,6 +967,9 @@ func (r *HtmlRenderer) renderLinkText(node *ast.Node, entering bool) ast.WalkSta
 		} else {
 			tokens = node.Tokens
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		r.Write(html.EscapeHTML(tokens))
 	}
 	return ast.WalkContinue
@@ -1035,6 +1155,9 @@ func (r *HtmlRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatus
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		r.Write(html.EscapeHTML(tokens))
 	}
 	return ast.WalkContinue
@@ -1183,7 +1306,7 @@ func (r *HtmlRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStat
 		r.Newline()
 		level := headingLevel[node.HeadingLevel : node.HeadingLevel+1]
 		r.WriteString("<h" + level)
-		id := HeadingID(node)
+		id := r.HeadingID(node)
 		if r.Options.ToC || r.Options.HeadingID || r.Options.KramdownBlockIAL {
 			r.WriteString(" id=\"" + id + "\"")
 			if r.Options.KramdownBlockIAL {
@@ -1201,7 +1324,7 @@ func (r *HtmlRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkStat
 		r.WriteString(">")
 	} else {
 		if r.Options.HeadingAnchor {
-			id := HeadingID(node)
+			id := r.HeadingID(node)
 			r.Tag("a", [][]string{{"id", "vditorAnchor-" + id}, {"class", "vditor-anchor"}, {"href", "#" + id}}, false)
 			r.WriteString(`<svg viewBox="0 0 16 16" version="1.1" width="16" height="16"><path fill-rule="evenodd" d="M4 9h1v1H4c-1.5 0-3-1.69-3-3.5S2.55 3 4 3h4c1.45 0 3 1.69 3 3.5 0 1.41-.91 2.72-2 3.25V8.59c.58-.45 1-1.27 1-2.09C10 5.22 8.98 4 8 4H4c-.98 0-2 1.22-2 2.5S3 9 4 9zm9-3h-1v1h1c1 0 2 1.22 2 2.5S13.98 12 13 12H9c-.98 0-2-1.22-2-2.5 0-.83.42-1.64 1-2.09V6.25c-1.09.53-2 1.84-2 3.25C6 11.31 7.55 13 9 13h4c1.45 0 3-1.69 3-3.5S14.5 6 13 6z"></path></svg>`)
 			r.Tag("/a", nil, false)
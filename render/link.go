@@ -11,8 +11,6 @@
 package render
 
 import (
-	"bytes"
-
 	"github.com/88250/lute/util"
 )
 
@@ -33,33 +31,5 @@ func (r *BaseRenderer) PrefixPath(dest []byte) []byte {
 }
 
 func (r *BaseRenderer) RelativePath(dest []byte) []byte {
-	if "" == r.Options.LinkBase {
-		return dest
-	}
-
-	if !r.isRelativePath(dest) {
-		return dest
-	}
-
-	dest = bytes.ReplaceAll(dest, []byte("%5C"), []byte("\\"))
-	linkBase := util.StrToBytes(r.Options.LinkBase)
-	if !bytes.HasSuffix(linkBase, []byte("/")) {
-		linkBase = append(linkBase, []byte("/")...)
-	}
-	ret := append(linkBase, dest...)
-	if bytes.Equal(linkBase, ret) {
-		return []byte("")
-	}
-	return ret
-}
-
-func (r *BaseRenderer) isRelativePath(dest []byte) bool {
-	if 1 > len(dest) {
-		return true
-	}
-
-	if '/' == dest[0] {
-		return false
-	}
-	return !bytes.Contains(dest, []byte(":/")) && !bytes.Contains(dest, []byte(":\\")) && !bytes.Contains(dest, []byte(":%5C"))
+	return util.RelativePath(r.Options.LinkBase, dest)
 }
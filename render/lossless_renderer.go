@@ -0,0 +1,231 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/lex"
+	"github.com/88250/lute/parse"
+)
+
+// LosslessRenderer 描述了无损格式化渲染器。
+//
+// 语法树需要在打开解析选项 SourceSpan 的情况下解析：未修改的块级节点原样输出源码，修改过的或者新插入的节点使用格式化渲染器重新生成，
+// 这样通过 API 修改文档后产生的差异仅限于修改过的块。需要注意的是：
+//
+//   - 未修改的节点原样输出源码（包括 \r\n 和 \r 换行符），重新生成的节点使用源码中第一个换行符作为换行符
+//   - 列表、列表项和引述块自身没有修改时会逐个输出其子块，仅重新生成修改过的子块，其他容器块中的节点修改时会重新生成整个容器块
+//   - 修改行级节点时会重新生成其所在的块级节点
+type LosslessRenderer struct {
+	*FormatRenderer
+
+	emitted map[ast.SourceSpan]bool // 已经输出过的源码位置，多个节点可能对应同一段源码，比如文件包含指令
+	newline []byte                  // 重新生成节点时使用的换行符
+}
+
+// NewLosslessRenderer 创建一个无损格式化渲染器。
+func NewLosslessRenderer(tree *parse.Tree, options *Options) *LosslessRenderer {
+	return &LosslessRenderer{FormatRenderer: NewFormatRenderer(tree, options)}
+}
+
+// Render 渲染输出。没有记录源码位置的语法树使用格式化渲染器渲染。
+func (r *LosslessRenderer) Render() (output []byte) {
+	source, root := r.Tree.Source, r.Tree.Root
+	if nil == source || nil == root.SourceSpan {
+		return r.FormatRenderer.Render()
+	}
+	if !root.SourceModified() {
+		return source
+	}
+
+	buf := &bytes.Buffer{}
+	r.emitted = map[ast.SourceSpan]bool{}
+	r.newline = lineEnding(source)
+	r.renderChildren(buf, root, nil)
+	return buf.Bytes()
+}
+
+// renderChildren 输出容器块 parent 的子块，cont 为子块非首行的行首前缀，比如引述块的 "> " 和列表项内容的缩进。
+func (r *LosslessRenderer) renderChildren(buf *bytes.Buffer, parent *ast.Node, cont []byte) {
+	source := r.Tree.Source
+	prev := &ast.SourceSpan{Start: parent.SourceSpan.Start, End: parent.SourceSpan.Start} // 上一个输出节点的源码位置，新插入的节点没有源码位置
+	first := true
+	for n := parent.FirstChild; nil != n; n = n.Next {
+		if ast.NodeBlockquoteMarker == n.Type {
+			continue
+		}
+
+		span := n.SourceSpan
+		modified := n.SourceModified()
+		if !modified && r.emitted[ast.SourceSpan{Start: span.Start, End: span.End}] {
+			continue
+		}
+
+		// 节点间的空行尽量沿用源码
+		if nil != prev && nil != span && prev.End <= span.Start && isBlankLines(source[prev.End:span.Start], cont) {
+			buf.Write(source[prev.End:span.Start])
+		} else {
+			if 0 < buf.Len() && !isLineEnd(buf.Bytes()[buf.Len()-1]) {
+				buf.Write(r.newline)
+			}
+			if !first && ast.NodeKramdownBlockIAL != n.Type && !isTight(parent) {
+				buf.Write(bytes.TrimRight(cont, " \t"))
+				buf.Write(r.newline)
+			}
+		}
+		first = false
+
+		if !modified {
+			buf.Write(source[span.Start:span.End])
+			r.emitted[ast.SourceSpan{Start: span.Start, End: span.End}] = true
+		} else if r.reusable(n) {
+			r.renderChildren(buf, n, childLinePrefix(n, cont))
+		} else {
+			lines := prefixLines(r.renderNode(n), r.firstLinePrefix(parent, n, cont), cont)
+			buf.Write(bytes.ReplaceAll(lines, []byte{lex.ItemNewline}, r.newline))
+			if nil == span || isLineEnd(source[span.End-1]) {
+				buf.Write(r.newline)
+			}
+		}
+		prev = span
+	}
+	if nil != prev && prev.End <= parent.SourceSpan.End && isBlankLines(source[prev.End:parent.SourceSpan.End], cont) {
+		buf.Write(source[prev.End:parent.SourceSpan.End])
+	}
+}
+
+// reusable 判断修改过的节点 n 是否可以逐个输出其子块：n 是自身没有修改过的列表、列表项或者引述块，并且列表项的内容从首行开始。
+func (r *LosslessRenderer) reusable(n *ast.Node) bool {
+	if n.SourceNodeModified() {
+		return false
+	}
+
+	switch n.Type {
+	case ast.NodeList, ast.NodeBlockquote:
+		return true
+	case ast.NodeListItem:
+		for c := n.FirstChild; nil != c; c = c.Next {
+			if c.IsBlock() {
+				return nil != c.SourceSpan && n.SourceSpan.Start == c.SourceSpan.Start
+			}
+		}
+	}
+	return false
+}
+
+// firstLinePrefix 返回容器块 parent 的子块 n 首行的行首前缀。n 和 parent 从同一行开始时沿用源码中 parent 首行的标记符，否则使用 cont。
+func (r *LosslessRenderer) firstLinePrefix(parent, n *ast.Node, cont []byte) []byte {
+	if nil == n.SourceSpan || nil == parent.SourceSpan || n.SourceSpan.Start != parent.SourceSpan.Start || ast.NodeDocument == parent.Type {
+		return cont
+	}
+	if ast.NodeList == parent.Type {
+		// 列表没有自己的标记符，列表项标记符由列表项渲染
+		return r.firstLinePrefix(parent.Parent, parent, cont)
+	}
+	return r.Tree.Source[parent.SourceSpan.Start:parent.SourceSpan.ContentStart]
+}
+
+// childLinePrefix 返回容器块 n 的子块非首行的行首前缀，cont 为 n 非首行的行首前缀。
+func childLinePrefix(n *ast.Node, cont []byte) (ret []byte) {
+	ret = append(ret, cont...)
+	switch n.Type {
+	case ast.NodeBlockquote:
+		ret = append(ret, "> "...)
+	case ast.NodeListItem:
+		ret = append(ret, bytes.Repeat([]byte{lex.ItemSpace}, n.ListData.MarkerOffset+n.ListData.Padding)...)
+	}
+	return
+}
+
+// prefixLines 为 lines 的首行加上前缀 first，其他行加上前缀 cont，空行仅保留前缀中的标记符。
+func prefixLines(lines, first, cont []byte) []byte {
+	if 0 == len(first) && 0 == len(cont) {
+		return lines
+	}
+
+	buf := &bytes.Buffer{}
+	for i, line := range bytes.Split(lines, []byte{lex.ItemNewline}) {
+		if 0 < i {
+			buf.WriteByte(lex.ItemNewline)
+		}
+		if 0 == i {
+			buf.Write(first)
+		} else if 0 == len(line) {
+			buf.Write(bytes.TrimRight(cont, " \t"))
+		} else {
+			buf.Write(cont)
+		}
+		buf.Write(line)
+	}
+	return buf.Bytes()
+}
+
+// isTight 判断容器块 parent 的子块之间是否不需要空行分隔，即 parent 是紧凑列表或者紧凑列表中的列表项。
+func isTight(parent *ast.Node) bool {
+	switch parent.Type {
+	case ast.NodeList:
+		return parent.ListData.Tight
+	case ast.NodeListItem:
+		return nil != parent.Parent && nil != parent.Parent.ListData && parent.Parent.ListData.Tight
+	}
+	return false
+}
+
+// renderNode 使用格式化渲染器渲染节点 node。
+func (r *LosslessRenderer) renderNode(node *ast.Node) []byte {
+	r.LastOut = lex.ItemNewline
+	r.Writer = &bytes.Buffer{}
+	r.NodeWriterStack = []*bytes.Buffer{r.Writer}
+	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if extRender := r.ExtRendererFuncs[n.Type]; nil != extRender {
+			output, status := extRender(n, entering)
+			r.WriteString(output)
+			return status
+		}
+		if render := r.RendererFuncs[n.Type]; nil != render {
+			return render(n, entering)
+		}
+		return r.renderDefault(n, entering)
+	})
+
+	ret := r.Writer.Bytes()
+	if r.Options.KeepParagraphBeginningSpace {
+		return bytes.TrimLeft(bytes.TrimRight(ret, " \t\n"), "\n")
+	}
+	return bytes.Trim(ret, " \t\n")
+}
+
+// isBlankLines 判断 src 是否仅由空行组成，cont 包含引述块标记符时空行可以带有 >。
+func isBlankLines(src, cont []byte) bool {
+	if 0 <= bytes.IndexByte(cont, lex.ItemGreater) {
+		return 0 == len(bytes.Trim(src, " \t\r\n>"))
+	}
+	return 0 == len(bytes.Trim(src, " \t\r\n"))
+}
+
+// lineEnding 返回源码 source 中的第一个换行符（\n、\r\n 或者 \r），没有换行时返回 \n。
+func lineEnding(source []byte) []byte {
+	i := bytes.IndexAny(source, "\r\n")
+	if 0 > i {
+		return []byte{lex.ItemNewline}
+	}
+	if lex.ItemCarriageReturn == source[i] && i+1 < len(source) && lex.ItemNewline == source[i+1] {
+		return source[i : i+2]
+	}
+	return source[i : i+1]
+}
+
+// isLineEnd 判断 b 是否为换行符 \n 或者 \r。
+func isLineEnd(b byte) bool {
+	return lex.ItemNewline == b || lex.ItemCarriageReturn == b
+}
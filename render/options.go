@@ -0,0 +1,176 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+// Option 描述了渲染选项覆盖函数签名，用于在选项快照上修改渲染选项，比如 engine.With(lute.WithRender(render.WithSanitize(true)))。
+type Option func(options *Options)
+
+// Clone 返回 options 的副本，在副本上设置选项不会影响 options。代码块渲染器和术语字典也会复制一份，Slugger 和 BlockProvider 由调用方实现，副本和 options 共享它们。
+func (options *Options) Clone() (ret *Options) {
+	copied := *options
+	ret = &copied
+	if nil != options.CodeBlockRenderers {
+		ret.CodeBlockRenderers = make(map[string]CodeBlockRenderer, len(options.CodeBlockRenderers))
+		for language, renderer := range options.CodeBlockRenderers {
+			ret.CodeBlockRenderers[language] = renderer
+		}
+	}
+	if nil != options.Terms {
+		ret.Terms = make(map[string]string, len(options.Terms))
+		for k, v := range options.Terms {
+			ret.Terms[k] = v
+		}
+	}
+	return
+}
+
+// WithSanitize 设置是否启用 XSS 安全过滤。
+func WithSanitize(b bool) Option {
+	return func(options *Options) {
+		options.Sanitize = b
+	}
+}
+
+// WithSoftBreak2HardBreak 设置是否将软换行渲染为硬换行。
+func WithSoftBreak2HardBreak(b bool) Option {
+	return func(options *Options) {
+		options.SoftBreak2HardBreak = b
+	}
+}
+
+// WithAutoSpace 设置是否对普通文本中的中西文间自动插入空格。
+func WithAutoSpace(b bool) Option {
+	return func(options *Options) {
+		options.AutoSpace = b
+	}
+}
+
+// WithFixTermTypo 设置是否对普通文本中出现的术语进行修正。
+func WithFixTermTypo(b bool) Option {
+	return func(options *Options) {
+		options.FixTermTypo = b
+	}
+}
+
+// WithSmartTypography 设置是否对普通文本进行印刷排版美化。
+func WithSmartTypography(b bool) Option {
+	return func(options *Options) {
+		options.SmartTypography = b
+	}
+}
+
+// WithChineseParagraphBeginningSpace 设置是否使用传统中文排版“段落开头空两格”。
+func WithChineseParagraphBeginningSpace(b bool) Option {
+	return func(options *Options) {
+		options.ChineseParagraphBeginningSpace = b
+	}
+}
+
+// WithRenderListStyle 设置在渲染 OL、UL 时是否添加 data-style 属性。
+func WithRenderListStyle(b bool) Option {
+	return func(options *Options) {
+		options.RenderListStyle = b
+	}
+}
+
+// WithCodeSyntaxHighlight 设置是否对代码块进行语法高亮。
+func WithCodeSyntaxHighlight(b bool) Option {
+	return func(options *Options) {
+		options.CodeSyntaxHighlight = b
+	}
+}
+
+// WithCodeSyntaxHighlightInlineStyle 设置语法高亮是否为内联样式。
+func WithCodeSyntaxHighlightInlineStyle(b bool) Option {
+	return func(options *Options) {
+		options.CodeSyntaxHighlightInlineStyle = b
+	}
+}
+
+// WithCodeSyntaxHighlightLineNum 设置语法高亮是否显示行号。
+func WithCodeSyntaxHighlightLineNum(b bool) Option {
+	return func(options *Options) {
+		options.CodeSyntaxHighlightLineNum = b
+	}
+}
+
+// WithCodeSyntaxHighlightStyleName 指定语法高亮样式名。
+func WithCodeSyntaxHighlightStyleName(name string) Option {
+	return func(options *Options) {
+		options.CodeSyntaxHighlightStyleName = name
+	}
+}
+
+// WithCodeBlockRenderer 为 language 注册代码块渲染器，renderer 为 nil 时移除该语言的渲染器。
+func WithCodeBlockRenderer(language string, renderer CodeBlockRenderer) Option {
+	return func(options *Options) {
+		// 写时复制，避免修改其他快照共享的映射
+		renderers := make(map[string]CodeBlockRenderer, len(options.CodeBlockRenderers)+1)
+		for lang, r := range options.CodeBlockRenderers {
+			renderers[lang] = r
+		}
+		if nil == renderer {
+			delete(renderers, language)
+		} else {
+			renderers[language] = renderer
+		}
+		options.CodeBlockRenderers = renderers
+	}
+}
+
+// WithHeadingAnchor 设置是否对标题生成链接锚点。
+func WithHeadingAnchor(b bool) Option {
+	return func(options *Options) {
+		options.HeadingAnchor = b
+	}
+}
+
+// WithSlugger 设置标题 ID 的生成算法。
+func WithSlugger(slugger Slugger) Option {
+	return func(options *Options) {
+		options.Slugger = slugger
+	}
+}
+
+// WithBlockProvider 设置内容块提供者。
+func WithBlockProvider(provider BlockProvider) Option {
+	return func(options *Options) {
+		options.BlockProvider = provider
+	}
+}
+
+// WithFrontMatterRender 设置 HTML 渲染 Front Matter 的方式。
+func WithFrontMatterRender(mode string) Option {
+	return func(options *Options) {
+		options.FrontMatterRender = mode
+	}
+}
+
+// WithLinkBase 设置链接、图片、脚注的基础路径。
+func WithLinkBase(linkBase string) Option {
+	return func(options *Options) {
+		options.LinkBase = linkBase
+	}
+}
+
+// WithLinkPrefix 设置链接、图片的路径前缀。
+func WithLinkPrefix(linkPrefix string) Option {
+	return func(options *Options) {
+		options.LinkPrefix = linkPrefix
+	}
+}
+
+// WithImageLazyLoading 设置图片懒加载时使用的图片路径，为空时不启用懒加载。
+func WithImageLazyLoading(dataSrc string) Option {
+	return func(options *Options) {
+		options.ImageLazyLoading = dataSrc
+	}
+}
@@ -0,0 +1,400 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build !javascript
+// +build !javascript
+
+package render
+
+// 汉字拼音表，数据由 ICU Han-Latin 转写规则生成，覆盖 CJK 统一表意文字 U+4E00 至 U+9FFF，不含声调。
+// pinyinTable 中每个汉字使用两个字符编码其在 pinyinSyllables 中的下标（从 1 开始，0 表示没有读音），编码字符集见 pinyinAlphabet。
+
+const pinyinAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_"
+
+var pinyinSyllables = []string{
+	"", "yi", "ding", "kao", "qi", "shang", "xia", "han", "wan", "zhang", "san", "ji", "bu", "yu", "mian", "gai",
+	"chou", "zhuan", "qie", "pi", "shi", "qiu", "bing", "ye", "cong", "dong", "si", "cheng", "diu", "liang", "you", "yan",
+	"sang", "gun", "jiu", "ge", "ya", "qiang", "zhong", "jie", "feng", "guan", "chuan", "chan", "lin", "zhuo", "zhu", "ba",
+	"dan", "wei", "jing", "li", "ju", "pie", "fu", "nai", "wu", "tuo", "me", "zhi", "zha", "hu", "fa", "le",
+	"yin", "ping", "pang", "qiao", "guai", "mie", "xi", "xiang", "shu", "dou", "nang", "jia", "mao", "mai", "luan", "zi",
+	"ru", "xue", "sha", "na", "gan", "suo", "cui", "zhe", "qian", "gui", "jue", "ma", "zheng", "er", "chu", "kui",
+	"yun", "sui", "gen", "xie", "tou", "wang", "kang", "da", "jiao", "hai", "heng", "mu", "ting", "qin", "bo", "lian",
+	"duo", "men", "ren", "shen", "ze", "jin", "pu", "reng", "fo", "lun", "cang", "ta", "xian", "hong", "tong", "dai",
+	"ling", "chao", "chang", "sa", "fan", "yang", "wo", "jian", "yao", "fen", "di", "fang", "pei", "diao", "dun", "xin",
+	"ai", "xiu", "tang", "huo", "hui", "che", "xun", "nu", "gu", "ni", "ban", "xu", "zhou", "qu", "ci", "beng",
+	"dian", "bi", "zhao", "zuo", "ti", "zhan", "he", "she", "gou", "ning", "yong", "wa", "ka", "bao", "lao", "bai",
+	"ming", "hen", "quan", "tiao", "xing", "kan", "lai", "chi", "kua", "guang", "mi", "an", "lu", "mou", "cha", "gong",
+	"cun", "zhen", "ce", "kuai", "chai", "nong", "hou", "jiong", "tui", "nan", "xiao", "bian", "cu", "e", "ku", "jun",
+	"zu", "hun", "su", "lia", "pai", "biao", "fei", "bei", "zong", "tian", "dao", "tan", "chui", "peng", "kong", "juan",
+	"luo", "song", "leng", "ben", "cai", "zhai", "qing", "ying", "ruan", "chun", "ruo", "pian", "sheng", "huang", "duan", "ou",
+	"za", "lou", "sou", "yuan", "rong", "jiang", "bang", "hao", "shan", "zai", "bin", "nuo", "can", "lei", "zao", "ao",
+	"chuang", "piao", "man", "zun", "liao", "tie", "seng", "min", "sai", "dang", "xuan", "tai", "lan", "meng", "qiong", "lie",
+	"kuang", "zan", "chen", "teng", "long", "rang", "xiong", "chong", "dui", "ke", "tu", "nei", "liu", "shou", "ran", "gang",
+	"gua", "kou", "pan", "qia", "mei", "zhun", "cou", "du", "kai", "deng", "wen", "hua", "yue", "bie", "pao", "geng",
+	"shua", "cuo", "kei", "la", "pou", "tuan", "mo", "keng", "shao", "gao", "lang", "weng", "tao", "nao", "zang", "suan",
+	"nian", "shuai", "ang", "que", "zui", "rou", "shuang", "die", "rui", "po", "mang", "tun", "fou", "shun", "guo", "pen",
+	"ne", "m", "ga", "huai", "pin", "ha", "yo", "o", "shuo", "huan", "nou", "ken", "a", "chuai", "pa", "se",
+	"nie", "wai", "miao", "n", "cao", "de", "hei", "ceng", "hm", "ca", "chuo", "zen", "lo", "nin", "kun", "qun",
+	"ri", "lue", "zhui", "hang", "sao", "zeng", "zhuang", "en", "zou", "nuan", "niu", "rao", "niang", "niao", "nen", "sun",
+	"kuan", "cen", "cuan", "shui", "kuo", "te", "re", "zei", "den", "zhua", "shuan", "zhuai", "rua", "zuan", "shai", "sen",
+	"run", "chua", "nue", "gei", "neng", "fiao", "ei", "miu", "shei", "eng", "nun",
+}
+
+const pinyinTable = "" +
+	"0102030405060708090A05060B0C0D0E0F0G0G0H0I0J0K0K0L0M0N0O0P0Q0R0S0L0T0S0U0T0V0M0W0X0Y0Z0a0b0c0B0d0e0f0g0h0i0j0k0l080m0n0k" +
+	"0o0p0q0r0s01010t0u0Y0Y0v0w01010x0u0y0z0-0_101112130z140R0R01100a150Y040N16170F0Y060z18190K0B1A1B0q0K1C0z1D1E1F1G1H0V0s1I" +
+	"1J1K1L0D1M1N1O0x1P1K1E0i011Q0_1R0D1S0K0K1T1U0D1V0D1W0z040u0o0Q1X1Y1Y0a1Z0a040a0B1a1b1c1d1e1f010h1g1h0N170o1i0T170o0N1j1k" +
+	"0U1Z0m1l1m1n1o1o0B0B1b011p1o0_021q1r1s0G0l091r0d0M1t0O1u0A1v0M1w1F0K1x090s1y1y0v1z1-1o1O1K0Z1k1_200121222322011h1n1o2421" +
+	"251O0c0J260u271B280e1w1o1b292A2B0c042C0D2D2E0u012F1c010B2G0u0B0s0-2H1r0J0m0s2I0c0U2J2K0D1M1W0A0n0g2L0a1y05221v1w2M2F0n0k" +
+	"1q1y2N1k2O2P2P1Z2Q2R202S1p2T2U2V0K1B0J010Q011S2W071D0m0k0C2T2X2Y2U0n2A0k2Z0U252a2b2c2X0v2d0D010s2Z2e2f1-2P1y2T2g2h1O0K2i" +
+	"2j2C2K2c2k170Z252l0-2m1B1T0M0B2n2J1P2o2p1e2U010K2q1p0v2r0x0F2s012t2u2v0p100K2w0k2R0U2x2y2z1T1v0P2-2t2M2_2S011G30060Q1_2y" +
+	"1x1e31321333342f351r0u36370R312Z0G1j2y0q181i1p381k393A3B380D163C3D0L2R2v3E0u3F010s0T3G130p2g3H0o1O0A2C3I0s160p0s112j0D04" +
+	"062F2H0D2A2L0G0x0V3J0p2s0Q272H0s2J0q3A3K273L1U3M0e0a2x3N0D2F2X0z220x0M0Y281M3J082s1w3O0Z0f3N3P18181n3Q3R1Q3S2q3T2I360104" +
+	"2a1K0o0d1X220d2B0x3U3V3O0q1O2P1v0j263W3X3Y3H0P1F3Z0u0q0t3a273b0N0x1I3c2f3d0R1O0V3e0c3f1B0B0n0D0M3g2a0n3h0V0e2I263D1Z2L3i" +
+	"2r2A2Z2-1i3N1Z3j282b0G0V0U272R0y2U0s2X0x3O0E0B011Z2M3a3k32313l1a1a3N3m3n0d0n29221P3o0x3I060s3p3q0p2N1W3r1R3s2W2I3t0d163u" +
+	"1O1Q1w1U0A3N3A2g283R1L250-0M1B1_3v2I2O3w1U3x3y3z1M2g3-3O2V3X3_0g0D3b3G05400o2t1I07093c0V2A1Z3n3N411r1l2y421O1y3R3d0P0H17" +
+	"3u133738431s162k222v44040R0h0n0B1k2K0g450m1e0Y46291y0q3D1e271-0i1k2O1y3I1y3r470N1r1B130J0e2S2G48013F350h01490o4A33271U0m" +
+	"1e1I3v3y3w2x1G4B0G344C2P1r1O4D0u2f4E2P224F3z2y4G2j0D3L4H0x0Q0U3t3c4I0p4J0n4K1U0h4L182K0p3W4H3x2I0V3z1A1T0u1W4H3p4M4N2Y4M" +
+	"1y2v4O4P4O0E4Q221T4O1T1r4Q0Q0V0V0K00491O19291C1p19000o0p3j1G1b4R2o0T0D0l2_4S16074C2_3P0f2q0M040q2W1F2925274T0B010B0h371C" +
+	"4U4R3p1C4V4U3237323v4W371C2S1C2e2R0E2w3q101Z2r3F35012w0K0f4D0c0q3p2m4X0i0s1Z2w0M0P4B4V0e0M0z4N1Q0z4G0N3Y4Y0s470P1y4F4Z27" +
+	"0o3o4a4Q042O4b3X0o0T3c2D200P1K27104c2G0p402m4b1M0Q1m1r0i0i2f164d0B2424240e0q1U1S0e1h0x0s0e110e4e3j4e1K4f111O4M334Q3_1U0B" +
+	"4907073-3Q2D3Q1o1o40290I010B2r1O301U4g0B0m2q4h081Q0p4i4F4S1q4V400s1U2T2D3u47200c4Y4j0d0d4k0p3u4j0h0o4W4l3Q401V3E1m1T0x4m" +
+	"2o1I2U4P0d1P2U1P4e1m0B2a0o3n3W1q3p4n1H4o4p1O1I404W274n0p2a3M4q0h04401F4V081k0B1m3c3u4d270B1k0V0q2J3i271m3k0u4W0s3i270Z1d" +
+	"4e400g0h4r2y0p3T3u414X1e4W131Q4h0y0j1l0q0J4S1P1e1P27272I2J0B2701270x0h274s0p0k0p0a2o2Q2_1B0u1D4F1r4t1Z0x0P0k2N0d2T4u010k" +
+	"4s0p1r2k2k3V4X252h3A2z4G0d4F2c0K4P1r4v1k472t4w2g2g0E4P2M3V3c2y0C4D2t3z4e0E0P2R2R2r0u012M4x3i2k1h2y410K0B1j3r212o17011Q24" +
+	"3V1-0q0m1Z1D2M2M2y0p2L4L2o2j4u1W0Y2j2e0u1W4g4M0F0F2j0O014M3T0q4y0Z1s3D4k0s2_1d0Y2_2X4h3N4z0K2B0Y013m3r1c3r4G0z062T241P0I" +
+	"4-4G3M0z0D1P1V2K0m1P1l1l4_4d0Y1Q160J2T014P0V3B2P2T0K2M1O50233G3i0u2K2Q0K16084h1Z083N3G0j1Z0m1D390m0B1k511k4G3B0C2b2i2y0U" +
+	"2y164W261Z0d0d0n524E0x1C100n4u0B531E2t3V1Z2R1r530u0B3D3c160A220n3D1i0p1N070p0a0a0V2d2A0y120a0I0a0x32122a0p2d361i544n3M3p" +
+	"323p170V0p1Q1I2W1U0Y1r3_1P0V0Q0p224C0p0V0V3p0Q2_0i552T2T1T3z4d1y0H0A3y3y3y3y2G1_0U2-0B0U56244T140l0-3g0K180j2T4T3B2R064Y" +
+	"3o0B0n3o57580O4X2O0q204W3Q4X0x1e2Y0l024P4B2t0K0U0L590N3t0Q3R2t0_2D0B441z152R5A2t0Z4A281F2c0B2D301-2m360p4Q170y060N2y0a1R" +
+	"3l2J013F0G0i5B103M2X1j1j0d0C5C0l2E293D071i4t5D041z0x100u0u211J1H163S194g361z0u4v0a3F2y3D0Z4a1_040R0u4v0s1e1z2t3i1J5B0s01" +
+	"1_3l0p3N3p5E4g0b0u3D0K3V5F4g5G5H204U0U2A2S0K2S45160104111F2O2U0n2R2c4z5I2C013A1p0z2m1d2T0q073m0v1m4q4k4j0s252c3m2c1f0Y2g" +
+	"0s1d2S2h2i2O2i2Z0C4K0P2f1x0Q1y2J041T3D2v0y16014F1F152w0x280B2S0Z184H3A1f2K2u5J4y1y3D4A2H5E0V2k012G5K1p1-1z4M1m2h5L3v0U57" +
+	"3K172G1Y4G0a1d3A2X2K504h2q331m290B352z5M3t3p4K4q5A0Z5N2t4u0p1J3G2c3E3A1y2k1k1N0y0T0l154F1X0s0C071g4l5O0Z0U0V2O2O3N071L3f" +
+	"012G1B4Q1y080p162I2Z0L2L0u3-0a19042A1j1R4s2_192T2k0T1L3-5P4w1I0B3G260e1r0z044T0n4m221T0p0b2x1q5M500D3P2s1I160v0z2G2Y5Q5R" +
+	"0j0j052A1g0i5S3a175B0u4g1M1I2O04044y0m0m0N1F2X1M5T2c0a041N3M0T1y0J1I4p1q3d4W5U1N5V0H5W5E3W0V2A2o0h1k024w3A0q2I2t2a2x0Y0m" +
+	"2i2g0n393u0D1N4p0d3607572S345X3x0D103m285N0E0z1W0g2K5P5P162c0B1V0c0n1I2R3j1m5W4A0T0D0W2t130V0m5F3y0p5M0y0n5Y3d5F0C1V160D" +
+	"0d3n3E3-0z2a282c5S2H0b5V2g3I1z1Z2G1L1R2-1f4P1d0W4I1G3o2h0B120u1O0K0Z1F0d2k4x2h0Q2t3t1L001f1L1j5W2c0x485Z0Z1J572G0b1-2X3_" +
+	"3_1l541N4s3o3o3R2A041e4N1e4e3R3u5a1B2G3A413n5I2O3A0z2K5E3l1y1q222R595b1R1R0z3z4d5I2I0N2V3d481e2w3A4h1D4U5T3T2k3A0B0k211V" +
+	"543A0Q3t0s4413161U0h0m5c2M3D43242t2K4H403C0m0D5B5d1e0N16043t1l2R4f2K101s1Q1j2M5W2y0Q0V3d1d2b5N2S1r352K1Z043D3-010K1e3p2G" +
+	"2g1Q330D5F3Q5I5e2E492F480J0J10542f2A4C1x2J1G3t060N1m0J0G0B1r3t2a222M0w5f2a2y2K1k0U5W100z0w1z1N0p4S1f1A3A4s0V0p2y4K4s0m4I" +
+	"5K0J172J4s161m3E0V0h3d4L2W4p1x3A1Q5g5P2J0H5W3A5f0p0h340p013W1A3m3I165h273m0k4C5W1A4C5i0n2K100L0Q5j272K2F10394r4r2E1c3p37" +
+	"3h1W0O0z2K3p3D5E5k0O1-4Q0n1v5E5l5m202O5E4B5E4Q0U5E103H1s0D073p1v2o0D3c5E0g0n3p2o3E1s3p3p0a4Q4Q4Q4r5n2K015P1E1E4Q0a4Q1i3i" +
+	"1s2y330a3v0n0Z0D0u1P0J015b1O1O310j494Z063u4G22045W4s0B1B0x0x2Q2M011j4a3F3q5B2B3Z3Z3R2r5J2Z4t2X0o2A0o0B332A0o273R0p0l0u29" +
+	"5o592Q2I5k2T3R0x0v1K112W4W2P4B0J37251u3_2y0L1h4P2e1H0l2t2L200k0s0z0x3S4p4K4K2y3_1_4k472q0P0B2c2y2U2t3z0F10364O2Y0s2v281m" +
+	"1m1P2-25100-2e3p571Z5R054T3D0M2W1z0a2u1d2i494e5p4z2x2q1y3p3s0s0l0110072R3S1j4l2G2V2B532g3F1B2A1D4w3V0R3u1r1N4F4F0C0R4h0C" +
+	"0K2M5E370N502A0D0C0a2o1X0J3c080q1v1S3U4N0P1_3R2x3a1U2V2r0x1m010x012C0B4b045q0q2P3E4P2I5k2P274O1r4V0D3D3T2O4Q3Y2B0a1O5k2x" +
+	"1p1m4z4Q0R103H2X1l5E570H362j2j0D2A1C0d3e0N4l2r3O0D3j3D280V2j2U4a224d0v100e0c0d1r1g4V3f27113z173j3Y3k084A0B0B333d1x0R2g4e" +
+	"3I3I0K2w1x4x0R4Q2I530c0p0c3s484-4O3P0u1S2M0Z312G2_0V2r3P3p4g1Z4S1f4w223T2V4I2y2y3l1O4a4s0H56183n2t423L0o32180x092r2g2W4I" +
+	"0x165E0b1r2A051h1M0V1x5r1O0b0T0n5o135r2R3u3u0l1s330P24534s2E2E432A3i1m1m3R4f1h293j3R1d0N0k273_0b0B135R010J2X2W3r0N2g1H3R" +
+	"4C0q5J494L1O2M1y162c2G0a3Q3t3e1r3z4G2y0V3R0n5J4K4K580p0i4L0h2M0V3z0l080K1o0A5s5s3i011D4P0k5s0z0z5k010z2R5k4T5A434T010x2O" +
+	"1U3r0e3N3b3B1X5l200s4n064M1Z4z061V165X3p1C3I1m1m0N3c5X2e2e044D4D102J4I1d1q3P4B0s1428255p4v0K4y4B1a0V2X012u1B1m4h4G1W1B0l" +
+	"5t1l5P2A0V4k3V040t0e1Z292W2o1V5u5P044e0y3Z013r4y4-3Z163j3M2D2M2V2W3_2d4x5L3_0u3_3r1l1m1W3r0K292J2X1E1m2N2N020t1O271x0Y5v" +
+	"2-3t1y240B5O1G3M1b1z5s0s1R0m1o0s0o0V1f4g0c5U4d0B4t0c281r1W5Y5C2t4i5s5w0V1J2F292X0D0v0e082B0u0D1P4d0l2P2S0j2Y1d0t3p1a1y0x" +
+	"3D4a4s042X1p0I3D2c2R0-1S472Q1h0s201F1F0K4U3u25420d2O0Q2q0n1F0q3u5K1o280P3r180B0F174h3V1e2e2k272701500x0B0B1y1g2v3F2u0V2m" +
+	"4F2C3D0U0V2-1p100K1P2o1F3X0n1z2h3n0a5x1e1E111y4u0p0R1Z5A0s1L4a0n4P5g5g1i5y2q390D1J4q4R3V1p0x072A5s3D5K381y0E0u0V0u2G0V0D" +
+	"0Q0D2h0p1y0q2T5o041y0j0P222y2G3D3D3n0E0O4q0q593a20083L3A18042K2426583R3M3M0d3P2P2o0o3H0o1O2W2q0z082s2X100G4z0s0o1v2x4C5k" +
+	"100a0q0p2W1y4h4h3d0h1p1i49280u395g1B1a2R0D0n2A554a0m3e1j2K261O3f5Y0s0d3k010c4a3j0E2x3d4A0d0n4a3p1S0L0K1Z0v1l1C4U0Q3h0n2h" +
+	"3C0z3_0d2j2R1a1P1U280J163p3d3q1G2t4S4a4Y3_1R2e1V1j1B5q313p0d3q2m3d0B3I5z1y4y124w4z2j2G0J5K01410D3z4A4201091c2g2P0p2A1P0V" +
+	"1r0H221q075-2k4s1N0z0z3_5-0b1R0r2O0u130v2b5Y1y1y4s441l4h1P4f0x2R014h161V5x160V0h1e4a24241y012K1e0s0K2X3u1X0b1l5P2F5z0P01" +
+	"3y2G5y2f1R2p0G1r2U0D5K3q1G0t0V4B3d1O5z4i3d0E2X1R1p2q2P4d4S3p4C0V56201e5y4C1O3d562K2o2w0p1E0V0k4C1F0d1Q1Q3U1W1R1F305_0s3N" +
+	"1F3A2F4D0Q4B2j0B2O2N1H0U0H1f1E5_4z150O1O183y0a1F2P0s1F0p1H1k1G0t5W5W3d1E0E2f3q1x1P3b4E0D4T2x4Q3X0855281z010o4b2w0k491z3O" +
+	"0f2S0208012j0K0K4N1p4P4A0K0U5P012p0K1y2_0R5l2_3A3v0y2j1f0V3A1B1p4I3q3j2w4X603w3I3a4H0B3p0B102w4X3c2c31270s2f0M5P4a1j070D" +
+	"0K2f1r2f0x0D2j602f1j4s2-0q4W1j0z0u440K2f3b1p0n1Z602K443F5P01012j1j4N2j0e304O0Q2M3Q2y4O4T590e0H0s2d4P3r3r0H0n432M184O3Q3A" +
+	"0d4u1T1T1T5I27184I05054s5I22441y1y5k0U1b0U4444285A1b1b1b5I281m1V0c0Y1K2O1K381K1K0K102t032P1r0n5z0q0J5d162X0q0d3P2T2a0d0u" +
+	"2D0K0K110B1Z311Z2P2b160n423D3n112a3M181Z4Q2y2y165d2y0q1Z0q1Q441Q18162L5B2P3u2h1y0p3D2K2K4K01041o0u071p0D1U1X041o4i2Q2852" +
+	"0a0u0d3D0B1O290804611O042-0d2T4V1y3_4C3Q0l2Z2Z250q4V4P2e1H590p2p2T0V0s2H1B200v0J3_1_4G4i2T0z59472x2p202t110P071V2H1C1-1H" +
+	"013B2c0l3W3D0s2M572y5t1T0F2o0P011h0K2x0n5P0x2w0p0B1-0n0U4Z060p281e1S1E1e3D3D0D1Z0C135l0e0e4z0p0U1y3q3Q1p0R4Q4l3F4v06100D" +
+	"4w2r2k2s1y533U4N4N1x0i4h0q2s04475k5k3G2O1M0a0a4V1v1v3Y1Q1m1S5E100P071S0n3A0J0V3X0d2V3G3E0P2b2O101F1q3j0D5X250e0L252a010x" +
+	"0K3v283D0k2r2y0V4a070B0B5P1i3i4a1O0u0D3O4C4P0V0V0n3O2-1X3q4P1j0D043n4Q4O164x1w493q0d4e4S0u3X131F0n2V2W4n1O2g5W4n0B0K3g3X" +
+	"3O3r441c0h5761024Q3n092b2b3_5a2T0b1M543Q3Q160D2C4K175d1k1j1e0V2k2b0i44441r4f1m431e1P281e281Q2b011H4z0N0N015W1y0B1Z4P162A" +
+	"3_540n013q3Q200d0D4i101G0d0p1P4K4K2W3q160q0h3d1V0V0n4z2o21621E2W2W5W0V0V0V1V0V0g330g2S3j0o2M21214F2_2Z130q2_0q0u1s1s2-0L" +
+	"0L0B010Q0l0x2Y17011r2M3V0l2M1r0s3m2X0K0C0251245W0K295U0x160z0m0n092I1_4s2C5U451k1l0x2S1k0x2A4s0101114Z3V1G511_1S6313310K" +
+	"5l163s1_1P0G11090A081_0n221I041q5E1C4d361S2R2w0n260s013s11572_4Y3j4y2w1B4J2K0c3u421h3L5E1q1h3s090o0h0s0x0z24402X2X092w13" +
+	"0h294D3s0G151U0d1y4C1K1150270M0M2q1K285P0U0U0B2v0J1i1q2v5s4s3c2X1j2E401P0a2l0d2R2y0u5s3E3d2A4k2W0a5Y4l2U0s1-123M17010x2p" +
+	"0x2H4d2Z3A4Q1P3E5A1i0U0C0M0R2s2X0B2x181c2g0v3X183c0D0D5Y3o32173M0Y3D1P4S1I1l4w3o0x0C3c0Y0Y1r3_643n10441_2y011U0h4Q0Q2F5Y" +
+	"220u3M2v3E332X0b1Z0i0i442y0B3d1y1i2g0p1i102M0V1i2A3K272K0t2K2_504e3B010435290q0V014-2X01011T0A0K1T0K0K2_2D100z0s1z0u382t" +
+	"3r0l1p2A091Q4y0s2A2w1y0z212N0o31012w2o084u3g4A0o2D093r0b3T0m0b2X2X2d0m272e0Z0-2X4X274j3A0m5E3r1z2w5E081Q0B0B1P492y2y4r2K" +
+	"0x2K2K010101014i4i3u2q4g1-0V0V0D2t3a3L2D3w3T2g41093d2t2t0j0v0B2B0c011b2L2X2A200s1b1S3C1b0o1_162M2n255J2y361b0R0x2R0o4Q0O" +
+	"0x2s0O5b3K160P0B220x0O2S2s0D1Z0d270K1B3B3j0s2M0n12280n161S412a5b1S0x4j5b4N2L1e2K1e2K4a4K172j2T2F2F2X010_1o3Q020F0B1o1o0h" +
+	"3R65651K040K300x1b5A16243d3P474g0c4N0u0B0u161B0U080O3X330D3B0x041M4I4B5B1O503H4M5w4G1y2F1c0z4e295J4B3X0u3l22400q012j2147" +
+	"2C2Z5h250q2Q2N4z1S5U0C450z0z0q1d1l0Q0G2A1_014Q0U0s0B3T2q3p2P140s162X0U0I4A0O0M3j2R1U2X18163R2g3O4O4s0x010K5-2M0K162k1g4G" +
+	"2z0x1Z1l2p3j573t3U1P1g161e180Q0z0L252K2K2t1B014M140i2K1F2R2t052N2n5t4P0P3P2_2o164Z4i3T5R5b2K3D3A1-0V4e324z1W5A2g2g3p0J5k" +
+	"134i0D4Q0d161N0i2a073t0I2a0C011O2K163N42011g3X2o0R1V0u0u0U0p0T5P0O014i0p5j4z3D534A1O0u470O3M3N5b1M221n0p0B0f0f2q3Q043U3P" +
+	"1v162r0X2P3c0G2E5E2b0o083p1r0B4C0D2J2c2o3R2a2a5W1b5g0z3H16222F0n2K3D1L3O272g2W0q3y0R5b3N0I3y0m0f1m4z1W175o573j3f4E662q32" +
+	"3B473O2a130G3N4A0n0Z1O0n0D0D2X4A5P472X010E2g4e49103D4I1C4Z4P0D2G0I0V3x1K1W3O483Y293d1V1V532_1W3I3I04283X3j0B2O0q402P1Z4e" +
+	"1S2g5a2M1p1k4e3p163H2g250p5q4y102U2R1O4B3j1W1p2m2_2d0O411h1h5E2t3y3y3y1M4765091-3_56420f533-0Y2K4e1l3l3X1j102y050n4r421O" +
+	"2d2g3c1c2A0x3n3V04040D11440O0U4N0x1-0R042T3T3N4j4E1e5r2t1l111V2K130R101016160m3R1m4O4O3I1Q323A24292k2k4N07041y470o440u3y" +
+	"1Q3C1y3R3i0J011U1y4z0m3R0o3X071e0n4A0P1j1j0q5a5R1Z3d3_1C010i5V3F5J1n4C2G0i0V64062t0D101_4D2G4D4O044s4C1n0G0x3x3x0V251k0x" +
+	"4G4G0U0s4S150R2K0h4D4C5J4A4L0h0B0q5P2d011l392w2I1Q4V4V5s0Z4i0u272R183q160R260d0Z270b2J0b2b0P041B57671B0B0x2r0B1V0F4f2b0b" +
+	"0Z270d0D270V2y0z2b16165g1_2T0z0z0z3D0K2a1C0z0p2B1L3B2W370501013u0z3M0V4T4T3a0y0L0_1s0l1d1t241G3v0v092D1c0D3E1K1p2-0v2O4X" +
+	"0u681O0x1o641n5q255w2Q2L5x161O2Q1B0D0s3_160J0x0x3D682Y0R0B0V4G3B210q4g0z4i1Q0l1j0m1S1W085G0118694q1a191c1N4q0s4k0l3_1q4r" +
+	"4X1v0b1W0z2j0M0x3T390C0J4B28310y252j2c2P0N2A2t0J1B4s4a4I0a0G2T471U1B0s0y0k0m341h504p0s4k2Q3K0i1J141O0q1x0l0v0v3_0q0j4Y2Y" +
+	"2l2l2A2P0q644K274Z2g4C2f1k1q1O2n640K0d1S5j2_2_2o6A303m03011Z322K5K6B0K1J2l2t4W0x641m1m0x0I2x35310Z1e2u0P1J2p4F0y2y572h1Q" +
+	"4F0q0x1E0a261x1Z4z491e1S0B2K1y0D2G0v3x4n1k4l2a310R23234t4a350q3T27011i3u6C081Z2-0e1e0u3F0Y1-5k2J4Q0j4q2y0l074u5W3V1q180N" +
+	"1Q0C080C430N3b2y3o0v2k5_3s275P3Q0n081j3T2d4F471n0s2l0q3Q262G3V4i3O4I3S0d4Q3Z1J503g2Z26041y0R2W5q1v3c4V1m4T2D4q2A093H0B4y" +
+	"4Z043K181O200N0a1Q1S0T4W012J3u1S5n3a3R2L0M0d2a3U380V4n2S0q3P1O5R2l5U0d2y142m0d0x0m4D3y5q0f3T3p3x271S0Y270D0V1V391z550J0n" +
+	"485u4A5Y2a5W2-0K3O31012M2g3B255P0V4H2x2R0a264P5T0B2a4p4p4I4e0Y0Y4Q0d2K1Y4N3A571Z3p1O0N2-0y3N280n2V4C4g1j0h0Z3n3O1Y1e2e1j" +
+	"3q530G5T2b5_5_1k1U3q3s4n5q4P283Q0x2N4p273o0L4v1y5O0W1r153D3S3x3u1x0y2I4Y2Q1d0p4y0z0x2h4h1O4g0b3P313D1Z3x2o2-0y0Z0u5t2d1c" +
+	"2d182l283w3o3R230h1L0Y4N40140M0e512A043o3b1l0R2t0f2y3W3n3O0F0z0y402I4h1M0t4s3r1P3d0x3_0x5W420h4X1U2d4r1e4s4s1N3y4t3L3r28" +
+	"2e1O440B3d1Q0r0r2k2E1y3e1P4H011y0R0R234z1z0Q072v1d43500i1S2K5s1e0B5a0m0m2L1k2L1Q0s443Z0s131k4n0j0H0n1s1j2E504h1Z2y1e621x" +
+	"071326271K2g3z1A2y3u0j1q1s5g0B495V5a3c3c5P0d1j330m1Z2i0J2l3_0q0N3D4D3o2w0B4B0j3Q2q4C5f0q0N1G0N0N2P260d3w2f0Z0x0x644s271Z" +
+	"4F3R2l3o2y5n5x2a4Y253z5f184H501y3F2J0p4p5P3d2y4K1O1O4H1O4C1y3d4a4L0h4x621Z2d3W3F2w2t4H1E3R6D0p2W2h491e1Q4C0p1A0x1P1P042M" +
+	"1s1s4T030U0F012_1K2Q2B1S592W4X470u2O2c323A2w1U0Z2A2R1e474I0Y1p1m0D2t3_2l2R1e1m1l5W2X222W1m011K0A4P0V2E0B1a3A1m1e0o250647" +
+	"182G132G1S2A310s18442T4M011e3u1e0j011l2X0p3A3A4g1H04043b3w1Q3b4w3M2Q2Q4C0D4C0n193i441B0z1Z1B0D311e262p191r2t100s0b2b2T0j" +
+	"2b3k4n0Q2F0j0j1j0i0j1U3k0k2B0h5p0D0K2C0U4a12042b1C2y2C0J4S0s2B4A0o0o2P3G2Y014S4u270D01040x2441242b331X0D0u0B0B0B2J5m0m0Y" +
+	"0x3-1Z2p2M2R5I4p1K074B2A2R0h0K4G250K1b47475B3f0u1W3N521q2Q0d5k3i0z2B3t1P224A2m3H291j0z01162F0V1q2B3R1p0q254H0M2q3d4A5931" +
+	"203f3t4a2Z4s3B2R3H2Y3O0K0K0D3M571C2P224g0P2G0M522S4K1y4G2p210K3j3j4A1V2R1e1r0x1r051-1z0V0F176E3A0N1W2K07073F081y5k2S160R" +
+	"3i0C1N1N0u082K3t4I083P0j542S1s0o163u2P163c040o1P1S010x2x080i0T221b3A4H3M4A4l01061W2K2R471V0N3d180n183c1C39275v2x253f281L" +
+	"1s2m1e4e4v4x22043t0V0p2G0B0B1n4H1Z3t1h4s0O2P092K2j074A0g441y3R0o0r0i5B16010B3j1_0N0N0p3R1-3A3M1p2Y3t01172q1p1e2j0o0V2G0N" +
+	"1G184D2M281s0p4I4G57440V2J2y163q4K1A3W1E6E2I0V0k4i4i2T0N4l0N0z2c185a5a3i425d5d2a543y2R2K100I290J4i0U3e3T290s203M2T2a2N2p" +
+	"5O314w4w542m3j1b5B210B043d3O1b1-4w2k4D4K1h4f0n4s3Z0y18181h0k1o0l1s1m1m3Q0p1P0B0Y2X2H0R2U1I1G3m2o1O0D1K0u2-3u2M240u1F0p2q" +
+	"3a301o3L0v2A095A2t010F2_4d0p04184V2p3r0E082s0Y5A251R5Y0Q3p5p3M3N0d0P4v281y1U3f5U184h2F0G0k0G3X2Q3X0B261r2e0B1C0J2X1b522B" +
+	"29010s39160z0a192F31280i583D4a2Y5E0x0O1W543i183-2A0p2y270R3X0b0e2b3A1y3E114B160x143A1B1B2e2j4s010N0N0K5W2X1m01200M2P4p2c" +
+	"2Q240c1_2U250s2l2z1K044U551C4u3X1N060U1p1P0v0y392f2g2A0x0y2-0m2O0C0Y3_0s270l1m4P0t0k2X4S343u0Q1U2C0K140y280R0Y0K0x4S4a0p" +
+	"3q0y3-3L2b0x4K0P2y3i0p4C2g182M6A0431040p0117310p5V4W2r3Z1o3A2l1o0M1F0G012U2R0k27541T1T0U0-2_032k2b4F10252c1Y010K0Z3v1E0s" +
+	"0d1g1P4y2v0n4G1G2x2x3V010j3E0x4E1-0W0W5P0q0Y1H1m5o0D4H003d0d4S2b0a5x314904134h1P3r5s2M1L1I313N1i640o593Z0s581-1Q164w4S0e" +
+	"044g3F1K3I0T0L1i0U4a3s4K3T5s2A4A4Q3-3_2O2X2A071F0x1o3N4l275P083x1B2p0B3A2y3H4u61293X4D0u0p0p191j3d1L0q2a1Z5k0j180h240n0o" +
+	"0p3w061u4y0x2s1l270j200p040M1v0O1O0E04043a0X0h5b3M3K3s3s3H3O0R3-0B0p3T0D0D2O3F0P2I4V1b2A4n240R2b043p0V0D2o016F1o3S3Y040j" +
+	"0s4P2s5u5u2Y0f29291p3c2P085E2y3t0d010G0q0q0R2Z0T0b0x3S0a0q3N1e0j1F3w3T021U221n4h271P164d1O3Q1P2W3W0x2o2m0s4l3T3u010v6F1m" +
+	"0N0s0n0n3k1B3O27011p160V0V0g273f0D2c0y263h2X282J2R3g254p0V3Z2K1V0d1V0Q0e1Z0v0x271h1C1U0z0z1l3Y1i390D0U4a3X4A4A25313h0N0B" +
+	"0d0N1U2E0D5u0n4a2a0B0d4e0L3d553j3n0_2o175K0K0F3R4C4g0D4I2y0q1p1U2X1Z1B012b0s3x2w4w3q2O270q1x28313s1I3p1F2m3I1B280d3j1K3M" +
+	"0y1O1R5_3p1Z3q0K0x1M4g1i4S3q2I533b0Q3i1x4P162O044v4v5_4Y4y0Z3f2W5Q0B5O2e3S0b2-1O5J4a2R4V4v0j0v13252W1B2r543Q4K3w0k0W160B" +
+	"1l2K2g1O5E0F0F4r4h046F1M3T0U0z3r0z5P1P5W014v1c1P1P5a421r2A5s0_4w4I0O0p2H3c56241-0f1q3I3z2y0T2w3n213I4P1U2I3L2y0Y1N0y1809" +
+	"424s5z252p3T0k1I162o1g270O0B0V0b1H3d1T2M0x13540O1s184h1V31434i3u163f2W0-1K4s0u135x0i4S131y6G242b0v2k1W5D2E0R2I4D0q0R3I1Q" +
+	"1Q2W2K0B3x170v2f580k1-5r294E4U1g1O2O4S2k4v1U163i1F0A0B190o2y271U3p1x183r3R0i3510162K3u544A0R1K0q54011j1s0V3z0e2K490B1X1k" +
+	"110R1U691P0B0d1B3c3b270b3Q013L3X2d0i0p2-4D104y4B0E044r3w2J0B1O2P2f014v2r105Q3c0V042w2Y1P3f0B1V594f1U0Z0E0U0x3j1O3z3z232y" +
+	"0p622y152K3l2y0x4v4d3p0p3M0j3o1l3r1U3c0k2y0V0p0k4I0d3D3I5J5W0D4K2s1e1y1P0q3A203d27100U3d17351k0h4C0q562d0n0O2o2T1w0Y0D3W" +
+	"0p621E491Q0V4C4C0k3z0p0l1A0D202v1O2U5P2F0D011O3l2R211U044e011Q162R2c0D1V4w605O162G01046H2t1j602r602r0g1I4W102F1Z0D1O3A0N" +
+	"0Z0u3R1r3l0z2a5P2R5F163A6H2d3u071U013D0D5g5P0x1S2U0C0u040C0C5X0q1O2t5V2t5V0c1X1X0p1q0D0p1P1_3D0Q271N4s4s284s3C253P3i1_05" +
+	"2R2M183y1Q414Z0L3I3c1W1l015C0x0N3y3H0m0B57311W4g0G3w2a1r05102D0Y2K62010m4d3r1l3w4d2727183l3k0k103c011I134P3A2M2W2K2K2O13" +
+	"0B013l2K3k013A0u0f1h4a4a2G0d4d0D2X2X2X0J0J2X0h1C3t3a0J4F1B2b481h0v2M1T3q1y0q1h3t0L191I3R2C0q1m1M2X0A0A1C4818180v2c271x0A" +
+	"2y1h1C1-3q221s2y2b5q2b4D2y2T570K2A471Q5A040r0t043Q1y0g29254R3w0s1p0P3c0410161f252x0a4P3c0a0P0m2y3c251W1W63631S0M2g49630_" +
+	"2P5B241P1i0x0L3w1q0E622K2D072-0j0g08241d160v5A0L043u5K071O0u0u2M0Q1G2_3r2t0u4Q0Y2I0x0x1O2w2O1b0o0o583F1z4B2o0B3B3B1K4g0c" +
+	"2B4M1Q0z5w04292R2R1j01261W3p5p0V1p4I0m0U2E0z2J041h2N4a1d0E2w4N122X1I0x2C4Y5o3m2e4S4a1q0e3l0p1v1w0e0n0z4s4a180q3m0v0v0v2c" +
+	"0p2w010-3M0U3P0x2Y2O2b0V0Q4G370q1Z0L011B0c2o592K2w3Z1q0k0_0U2O1z1K0-1C0Q0z112U240x3I2f0R204k1k040Q2P0q230k3i3z4A1Q0s4Y47" +
+	"4B250B2g0f2V1H4K2y0m3W1Z591q0o104Y0d0N2K2K3v0R100n3627254F0Q0B1T2q0s235V0x100u16030k3r3W3W2x0P2a2z3z012w2o1r590n3A1Z1z2R" +
+	"3I4G4y0I0q1T2S1G112M4M0x2v5P2m2J2h4Z3K0u2T4S011B0o1O3r1e310K0j320-2K0B4S0h3H0z352M1r4F0L0n1N3F073s5A0j0U161k195P1z011s3d" +
+	"4C3t4w070p4l0s0u1l3f0e010D1-2k1f1r1B4N374a1X0R2C1y1p4Q5k115W070o3A2d504Q2g3A1y1i3D3I5B3V612a0p630Q3z634y4d2k2s1l0n261W5P" +
+	"2A1g6G27095V0s0f2q4T6A0a5g090N3U26070v0P2c260q2d0T3H1x0j2W0I5b3V1F163A042O5E0V0i2I2S3T3t2218042B0x2y4z0q4y0O3z1N113M3X3P" +
+	"0J0m0D2P0D2y1K2w0o201v101M2T5J0D501p3L3f0z3p2s3H3c0V1O3P5Y0x101k3Z3p4g3g3M3c3p4P0B2d3p5V2y1F4d01270E3K160D3p1p1p555P0k27" +
+	"5v0D0L1i2T4d240y1k26262A0n4g1G1Z320n2c4V0V1z4A2w4P1C3d0V0U1z5Y3i4a3v3H0t1P2t3D3K4a1l04044a3P4c0n3y4r0E2K4s2R0B5F27270z0e" +
+	"1701102b0K0d0R3j3R0D2X470K4Q3i2g0q0P4r1e1e0L0V2I4K2J3p392Q0U2o5s0T0h1y3f5W1F080K423d4p1V0e272R3n0n0F1k3d591r0V2I3p1L3p1l" +
+	"284D4b0R4P4B1x2h4S2e5q2m0y0K011v1R1s0n0p3v0u164g0b1q0K3I2G1j3o1W2H103q3H3I1L2P1x0K1G2G4Y1U1U124x1w150Z2W3t3j161F2A0x2q0s" +
+	"0d4h0Z1F4y4J1X2X1e2K0X104v4K0x0V2d423d3f2y4C1E283w3R0D2H0z2X3L0x3r4X1p052A2w3_2y0z0z0U0h242g0X423c0D410B0a2104160B2y3n4K" +
+	"1r5E0O3n0x0F0b0p0V5a1e0O3f4r3l4J0N162w2I4s05071l4C2h2t1K0e4A01421F5A1c3W3T1809095s2R5P2J270V56441M2a253r0O3d1z2H180f3d3A" +
+	"3O5k2R1l0x0n0J0D1e59492K0d0u5U0B4Y0n3I1O1O162y162M2E3j476G3I2k310O011N083u3R212M1V0N4u4Q0k235c2X3u0h0h181-1s0i0n5V5V0R37" +
+	"0R4h1e2k2L1K301z0Q183T071W4S1z0s3t2c1y273u160D2y4C2f0D0i0E3-495P1q1Z0D0p0K1H20081F2g2K3y1l2W0N3_5P310h420m0m011X0J0q1x1j" +
+	"0B0j1l355E1r295V0B1X2K1U1x3X025V0k2s3w1l2w0K182w2f3d3d4D1r042X0B3t1G1M264y10104O2U2J3c4C3F2G1s0j0n3w2O1O3d3w643M1w0w270n" +
+	"3W4H2y0p0U252y0Q0x3d4d1b2K1Z4Y1p3L0h4s4S271s5V0R2O3w2J1y2y1j073d3q0p0o3A3d1X0n1Z5J1H0k4K2s4O240z2s18203d2w0B1l273d290i01" +
+	"274i0h1_4L274C24563p0j0e2d3z4C0O2T2g1O0-0f1Q0V3t3d234H1E0V0p2w3u3R491e0h3d3t0l0k4C4C1A081E2M1y0V1K0V0D2J3L152v4f2K3A3A2K" +
+	"1z203-0H0Y0y1Z2t0j3v3v3y25040c295w374g1s012y3S0J4e4Y0V4e121h21441P1c2E2v2F0x2v2v0n0b3B1d061S0k4P2Y0s0l1Z1Z200j4A0q3R4k37" +
+	"4k4B4B0M251-3u0k0y2W0n0K1l2t3j2S0z5O4C1i1e2R1g2o4F5P252H2H1y100u2S280K0n1-153v4e1z2k060k4A1S590V2K2v2L2K030q244u0N2K002I" +
+	"1r664F160s371Z1s1i0j1i081f3T4w0V2R0e2t3q0z16182c2M3E3V3A160V075s3F2A1Z0B0u0V2y070V5P1n0q3Q3N290i5k3H5B161M0u1z210s261e0O" +
+	"0e114E3g164E2F210V0V011Q0D4V4U0J4M4V3i224u4M504l0n4I2c1V0c3k062K0e1l4A2q3j1e272X3d0k0n4r3u165v5v0h0V37370D4a1I0n0y1r4E55" +
+	"4a5P2R2Y0n240L1X254F0k0d3-4W2j0z1W390K0T3B2e382I213u5t1k3j1Z160u161W2c2c161W4M0t3u4E282M2w1l3d0u3q2_0V0b4S162X3L0O2y2718" +
+	"013n3T1X014J1Q3O1W0z010x3_0n4S073l6637425k05625r27161616013A2t3j0h0N3R4U0V2M133F4f2E1p1e290Q440D0i1-4u29240V2M4C4a2I0137" +
+	"1n0o1e3d0D011H4C4B3-3y1X16533O1l2K0k1Z200n011Z2Y2K1d354C1G1y2c2M1r0G3Q282c4C3L3q0p4s2j3g2y4p3_2M4G5O440p2y1Q440V161Z4K0N" +
+	"3y4L4i4C0O1Q4N0f0q2L2w2I4C0k4C20620D2Y2Y5U1S4k0R3p2G0n071Q1Q0s0N0l570N283G561T4Y404P4-570b2g0b3h2Q4Y21273K4d400D0y3B573s" +
+	"1k400U0U4d0a0R5w5w5K0Y2z1x1h2k1o5A2B1C1h4V0u0V0Z3N0Q272O0U0Z3i1h2A1O2o2o1F65165A4t1O0u2O160p0p4q0B4V0x3Z2o3f4d0q1B270e3h" +
+	"4P0q031U163N3W0d1R0A0n1C2E1-133r160p4d4F3K411k160G0n1V0G2o2o0l240L0B340j2x0Z5s2v1R0U1c1k360a105P5s1W4G5w2A4G0c1h3N0J0q01" +
+	"3i4k060v0z203M0J2P280U2e1H0q0m1k3E1y2f5P2n1e2c2Y0B2M3u1x3q4T1-2k4d060K331S0D5_0D2X5A163V0p06104_4w3N0x0V1I0p071y0o3K3M3A" +
+	"2l042P3L102s4F270b5k0V5E3O2w22010x1S0a4D3a3C2d4F2W3W0z3O1P0n0e263p2q0k1C0n0g1y4r0a4z1Z1B363B0U0U4a2-285_1k2m4h3p3o1R3p1_" +
+	"0D0K3t0b01311w3t420o3r4s090h3_3_3t1M3Z1Q2X2X3j1s0i2R1-28445O3A4T2E1e0Z3V4d2K331y1Z1x1y2M2f3B2J5Q4D4F4z2v4T2y1x1y2w4L5P4z" +
+	"3W1y041Q4A5Y1F2y2y0D3I1b0L5I020_0l0B1z2A0g1K0Y0D040D221R1z0u0s4g0d0a3w3B3s4i1Q1n1Q08274a0m5K0n5P1y0b201_012x112W0s4A161k" +
+	"2U2e1B4u592U4P4U3i1p013G1B473u4S2X31311Q0-4K1r1e270p2v1y2S2_0V2H252R3W3I0k1j102M2j1T1728065p1P4N2R2Q2C2k493d2K4g3D0R2A0u" +
+	"0u0R3F4a3N1i1y1U074A0V0L4A4w0p2H0s4S0a16200p1r1l1L1L0e082W5K2b5V470D0q4I2s473i0n3P1U2Z2V0R0z043D5k22042V082y0O0f0V2D3N0i" +
+	"1j0J5U530j1j0-1r4E4d0d3H0D1C4a3f4A2a2q1_5547270n3e5P1Z0g270H221l2o063k3p0a4z0z3d0D3j585V4S0K3q1L284g0u311r3d1R4y4S2I0p4w" +
+	"1P310b4n1Q2Y282G3w18225k0H0O1r011M0O040p0o1L0L4A3_1l1n09100N3d0n2y0u4f2H5r2M2T490i444E3I3j1P1s0o241r4S0B2K0o2G2X3y2T3-49" +
+	"1e0X3R2K5P5V1X3P1U0D1r2y3w184g544C161F4A3e260F3z4d0p0x550p4H4E2a1P1X4p4K2y0p4H4C3d2w174E0f3Q4H5P4W1k571k0z0x412Q4L0p2h00" +
+	"171O2Q5F2B0m4x3l00002h0z2001112U2l3V222t00494D0C5o113B2S31002U3d041y3n2A3l4D0H2V0i5r0u0J0m4x3d0V1K1_1p3P3P07223i3c1p0h0h" +
+	"583i3I1p2g512y0s2g2V0e2f3P0U1B1p0y2W0s392W111i4h1i313v4D2X2X4S2M4S221h1W240s4l3P0d0d2o0n0s3P1h1m4Y3r2h1d394S3Z311U1h1h32" +
+	"3P0F2X1d0x5n045n4Y01244h2d0D1h3F014S2d570G4h495o0B083r0R225B3z0B2-4S574r0i3r3r0G0J57570J0d0m18180x015G0t022X0d444V0Z0Y2S" +
+	"063u2R6I0p254I0U0l0d1Q04061M2X010p3O400e0k4k0J1K4P2U1H0x0m310-0x4J0q0B3M0q3u1B4A0y0M5W1S2g0o2o4J1-010d0n2K3R252t0x2n0a4a" +
+	"190o3A1-4Q5A0J3A4_0s0p0x4n1m0u1I2k4T5P1y012V090f3R3M1R0i2t0B3P2x2t2X2X472O4O3D0n0D1M0a0k3C0m1p0c2t0D360e4p254I4Q0D5E4g5P" +
+	"3E1B10013n5q1Q2t160f014g0B402Q2K4S344T6I2W1d4j3R093L1p3C3W013O0G093b3o5V532D3n3n4s1j103d3j0s444K134S2k1y3M0m102c2G2Q1y0f" +
+	"1P350D0n012g0J3z0p180m0i2W0i2s4j0B2t254A0d1S0w0p2J2s0B2W4A3d102T2g3R2W3W1E1E1k1k1P0l0-4f0-2l2l0I0B3-3-1C5b5U0d3j1P2U204v" +
+	"4s0B1e3T4v2G3D3t072X080G1O162G3A3t3j3t1q1M3t3A0N593t1e2G2q3j0p412c1e0J1K4k2S3F0L30530y2O3F3F2S0y2O2Y4d47043d0D3N2Y0c5F2c" +
+	"3d2c011k082c522b0V272c0D1V240F3Q4Y0s0L3i3Q2y2b4D0p1r2R274Y0f2x2y2R2S492x2O0p1h021K2R5A1b0x043p3P172E2F164Y0e2E472m3i0K1W" +
+	"0E4Y2B5Y0m4a1C2r1y4X0K251S281p2J1d314G0q1p013i4a4s0k31310E0K3p572P1F1F210y4A0M2w4K1X1-2w572A5G2m4A2t4G3V2z312p250V4s0c4s" +
+	"1N1S4a1L4u075P2A0R4n3V3D421y165k2s273u3P0X083Y0K4E4F0a0o1S0p2s1X3V631X4d2X0J1h3H2P2y010d3a2S0D3H1R062q2K0X3v3f274a4d364A" +
+	"3P1V4v581C2R0-265Y0G1V2w4x4X494I4P3o064E4s2m42631q09012D4X4s5D0O3n2t42410R1P4D086G0r16131s0k4f1p5D442L1y2r0N2R1-2z0i1P27" +
+	"0N2G2K2b272O2Y2T4a0G5q2f2M282J4D0E5K0E3z4G1Q4A0E2J2y4D4K0f42161U2I2r0k1C1r1r0D5O1q1Q0K011p0x361p3d0q2S1e4n3k2G1e5r4i0l0K" +
+	"02040B1F1K0u1N3E4V16244G491R1I0m1Q0p0s473D2J1c0x042r0d3w3D0a0J1N0V1X0H2L2E2h0V1r0e0-4s0y0q0D4P0v0v2A3b313D0s1h0k4p3B2N11" +
+	"3T204k0_591k591p3m2G0p4K1-2g0p4G1U4t2o0k4G1P3D4z4Z2y0n2G0Z1y2q0V0P3T162k1z5O06133c0n13014t3A530h4w1z0D3A065A3W2g2L2L264S" +
+	"3d5A530V1I5k0D2t4h2y4I276I3X0j4t3T0V5o3U0R043O3c0i3F1k02472D272c2y2G1X533Y3N104O0u041v082W4z3N044I3e0V57024d0v0d3d3B4P2X" +
+	"0n5O313k06492a4z3T272A3R2-3P042E0e4A53531R2_503I3D2U4S0Q2I3s4h0J0n0W3z4n3P06161l4Y0n1W4O1N4P4p0H280X0H0h043_3T4S2y2r404I" +
+	"103z3L044s041M3O3c5g1v0B3u2k2T5r4f27160i023R3j4Y3m132A0p271e1609132E270D5o2c4P1q3z0d1U0N5349013r0J0J0D5K3D2G4P270D3e4D4k" +
+	"2U1k251R5f1y4G3z3z0x0p0p24534k3d0p4K4K4s1k560f4C5f0V0K0K0p1t2d4i0Q041x1R1Z281y04040x2V4O0c1o010K0U0x2p0s0s2w3G0x4_4a2Z2T" +
+	"0z0k1p1X2U342w2y0D170u2p410k1P060x0B4v314v631r1p0F5k2A3Q2J4y042O0f54202y0M1r3Q0x2y0h2X1N2K0U16101F2J310s3p0u1y250x014a0Q" +
+	"2A3N0j312g0B4v2I0Q1R1x0s4A040D160B0Q0h0m1P1X0p352w3Q0p4L4i2a4H3z550D0D0p1Z1j2c4Q2H0Q1o4Q1F2-1K011y0M500L0L0c293t1W4P5Y0x" +
+	"0o2X0x0D2w3E2Q0J2P0p0U3G0J1k204s0R501j252Z0x0x180q1F2J0B0R1-0x2J2c101F0x0d1o4d010k2K350s164v4w0s2M632y5k1K0o2a0R4Q4u630a" +
+	"1v2y2O2Z1o4b3s2l0B0x0x5k3Y3T4P0M0G540D3I5n1701163B0B0s0J3x0d0c3O2R0R3Q4g1y1F0D0B2R310x3Q1B0B4v4v2O3q1X3q0B1c1h3y4a0x0B2y" +
+	"3I0B3d4g0L5V2c013j0I0B1X3A1s1e0j0c542y1X355V2K4L3x0D5K0B384g0R2J4G2y3L5V4L0j0p621H2h0Y4E164E3U0D1p0o280g4b4Q2k0I3b283B2j" +
+	"280M2h0k1e132D0u1P280x40282p1e40373A0R4X62260m3E4P0j2R3I0f1V190j2M262h0a0D0q4E28282p210D3P2D0q44160u1V402Y60604K0R1M443-" +
+	"62134E193-4K0I0p1U0K0s1O1U1z043t3i29185Y2T2b0k204K0M0o0o092l0Q3F1z1-3X0o2D01180o2T0d113k0p0H5d4f305X0o2r0o0k0k0_3T0D2t1K" +
+	"5A0k084d0B1e0l4_0B1j2Y5_0a5o3p0z5p3A612X2X27010P3u3i1d2A0k1J2t2O0p0I472j2p0Q0s323Z0-1d1F2A201q2N0s2e241B1K240K1C592a274E" +
+	"4K473B3W1P2T2t10281y2X4E644f3A1r2o5_1G0-4G0k1-0B1d5p320c4X2s2X6E491S320s1W4Q5U0p4w0q0f27071-060x0R4_0K0k2Z3A4u1i320V4v33" +
+	"1K0G4G4V1W3l1O3A274q2s5u2X2X2X0Z4B140D273Q2O2t1S3c1I2S2y1k0B0i4_3F0s0y2O3U1O1O3F3S0f3p323G1k1q0I0v3W0m3A3g274A3B5_171y11" +
+	"312q0z010k4i3f2y0u0P5O0B0d3j2q4a240g0H3h0e0k3j0I360L5Y1O2O1V0K3n1W2c2I4i0G4v3M3g1S2e5W1O3A624K3T4d0p2X0j1U6E2t0k0b4K4C27" +
+	"0C0p2K2X2A0O0V3T3y0H0J41190D154r1q6E1P010z0h4X3C113-0B1P3I3n322y501L622D1L0_3k0T3A1k2w6E49440m2W0s27471V1_1e4f3j5_2k4H3A" +
+	"2y0K4H043K043K1K0q2y2y0V1k4948692e1O1l0C2S2s0K4C1V0D4i3t314B2a5W0G0B01044J0H2S243o2S1O0j4J2y2y270v3d0D2s4K0I1l4C1O4i0c2T" +
+	"1l3B3k6D0p0Q3W3d4i0j0D2w2A241p1N1p2N2c3z1y1F2P30091O3b2X2Q0u1I1c55292X1M101N2w4B0z0l0p1K0q594s3C2b2S2t3I2p0p163I1z1-1F32" +
+	"4i2S0i5s2l2k291T2T2c0T1y0s0T3y0o0p4i2y0q041M2l090i3O0o5E4h0A0A2I3B550E362R3O0z274H2U0p1Z0s3x3N2O2H4v2I0L1B5a5s2I2w0A293-" +
+	"1c3r4s0A0A3x160T3r331k5P183O1y3x4r5W0p2Z2A5W2p4C2w0Q0Y162_1S0Y0U0B2-2S2M4i1z0D2c081o4g4g0L1J1F1a5w5C0B183f0J311I1z0x0B29" +
+	"1W1o0m1r3I2B1L1M0Y3m0l1r0s0x041F0G1z3m3z160s1Z1p1k0k2T200k4u1K250s0v311_1U0K0c1y3G372Q2T4s18544G0o1o5p1Z0d0k0G4W2l1Q4G0z" +
+	"2U5P4l4y0d3E1e2o0F3W4A2V1y0s6J0P3q2p103z1Z3V2R0F571-0Q3r172K1Q0x273V2t0E312y0R0L183s1-3A5P1j4l2H2a1a1Z1z160s1i1X4O5k0s0o" +
+	"0z0x0V370e0B2R1o3O4I1m0p2y0T0G2o4u04044b04081O1y4T0n044y084V1b2V5o3a5E1M1v4S042b2X5g200E040I3P3O0X5u161F2q0T1r3M58470D3O" +
+	"242y2R3d05042R17274P1y3e0E0B3k4N2A475Y3p1Z2j0Q0L3B5P4l0O0E0n0s0n1a2e5Y1Z1l3O3B1W102a4W0x1W0R0h1_063p3O2R3i0n4l4A3d1r015o" +
+	"2P3s2O4Y2S272U2o561W061M163q4y0s1W4I4v1G0z3v4J1y3I313O4y3j3a2X0e3C0p1L0V163O3z3V1O420x2y1h411l2w4A3O0B3u1X242y2V015q2z28" +
+	"0b3H1y0B1I2H4U4A1X135r2Z0x3u0A0i0D24445g43275x0h582H2K4h6D160b1W1d3i2K165V273r5P3-0O1Z1e2X0m01351X016E2R0B3w1O4C1s2M6D04" +
+	"3T284s3z1Z6D4G0U2R3z1y0h1e2y0h3d3a4L1y546D3W0p3Q4C3z1l0Q0Y0D1z2S1y0Z4i0B084G0B1o0n1W1z3f0J1I4V1J1o3O1v290x4g2B0k315w181y" +
+	"1K1Z0s1l3G1p160x0c2S2Q0s1U4u010o1_3s3q0d3E5x575p2K6J4A3r3W1Q1e1-4l3A3V2H161X4y0B2a0B2R203d2R043M5g050X3i0n0E4T2V0G4y4S2o" +
+	"3O2b082y5o1F4P17270E4C2a5Y0B1W2K0Q1m3k3B1y2e5o5P2A2y3B473p1r0s1G310e1M4v0h0p01273w41423z3d1L2z5q1Z443u5r3r1O135P1e6D5C1Z" +
+	"4V5C535C041k11172Y4V3d3d3c060f433R0R044x3d3z3R2y0f1b1b4V1b073W3W0s1p0-2O0k0q1C2O474V0l4W2a3V0s1p0V2Y544W0j0D0x2x0-4C180Q" +
+	"0J1R4S0l0-0p210n2X0B5r4N4S0B3V2w2Y3W0J0B0B1E252w0b1d4a250U0U290l4v252O0b4-4v20010k2A2H0b011y3q5l5l0b5P1L1y01250b1O0D4l0d" +
+	"2I3p16243u293u1l3z4l5Q0b0h0D2_014N4x291z2t2t1M0s063Z014p010J204S0x2T161Z1716164P132K2K3A1I1z3r2A1M3M3Q1I2t0k274A2t3h3O08" +
+	"2K362c2c073_41011l363_0i5F133_24012K4A3Q282k2k031C1N042e2e2e57571T4m3e0t0t3k3z1i1F4l213t1W0l0J010Q2T1B0q2J1U2k1v0B2I3l3n" +
+	"5Q3r120y3n0B2k2J0U4s5J1T01020N1d3X1j1W2t0m0m1z4l0x4Y5W0m312L201S0U2h444K0x2f2p1T0a454W2R1l3t3i4F5K0o0q2X2A5E4g2R110O022P" +
+	"1i0q0O1V1l1V0O1l4x1V1l1l0O3_3i3X1i1V5W0x0m2f0I2P1i1i4K0D0D2Y0Q3I013I0Q2Y2Y55010_0B0L5R5a0Z1k5P3j2t1o3A1G2S3p4d4V3q1K2-26" +
+	"222O0x070s3M292C12272B4b0U1J525R4U2_0D4g28040J1O16163M5R0o4B1p0c091Z1p0n2S570m3M0l1k2T3P3N4W4B1F3M0x2P111F0s12311y2Z2C1B" +
+	"3i0x2j1h2T0z4P2t102R254K0P2i2y0o2N0V122u012v1f0Z0P2t1e4M4M1T2x1g3h6K1F1P0R2p0x1M4a1Z1M1Z1D1D0B1Z5j33234-044z2w351E081k4g" +
+	"082H1e0o0U1g4n4F3u1i4a3f1p1O5b3V3C2H2F0v4k0R4R1s190v5z4z0J2O3W0p1l091M0d0T630J3L1v3h3z1V3S0m3P4R0o0t4p0N0V1o1p5g0s0s0q3M" +
+	"0b080P0J5E3O02264a2P0H2t4c3W3l2A2x2q4z186A391W0c553D484Q28270n1e0D1B3k2X220s1y2P0E2h4J383s1O2y2h4T2I3I5o0Z011k440B0J1Z4v" +
+	"2y3w3l222y5E125T3L3r0s2I4s160H2y1e3d2y0x1H300i1-3T2P5T441M1P3A4J240x1e3u0z1M6G171X293d3u690m33355B1l2X2g1Q1U013V4p1l5q5B" +
+	"2O041M3w2M4z264-1y3L2q604p0V2y2J3m3W2T4-1E2P3m4I1O262v4-0i2v1F1e5W0G0B4v0G0E5W0x0x0Z27570x2H4B310Y1y0D2-280D4N16160Y0D0D" +
+	"2q0q0Y2F2d2d2d0Y0K3R180K3P3R1s1s0f4h3P0g5D060u2S3Q0g3u01245U4B242Q0g5p2B2Q2X2y0c271w200k1q1m1k1y0Z0g062y4E12162u0s3-0e0p" +
+	"4u0D4w1i0D0n1k4D500q3j4T4P3B1h573Q3s2-013o1w5a3n1_1H284N4f490b2y010B272J4D042y2y0h561Y0T27275V0V0s110V0V5a5a010_1i1e2G0t" +
+	"2p1e0d3T0801340E2w1K1O0D0D4u4E4d0z045A1F2K1X0x170J0s5B0n0u0x043u4g1O1o0s4X0d2y2R0B1j040V290l582F0B4h4h2B0u1Q2e0x1W1j3_1U" +
+	"1C0a3M1t5p0O100U3B010I0n0p0J3D1y221w0k3I2a3p4U204B4u2A5Y3c0p2g4P1h3N2j2e4701010q0r3g3E2f2P1k0M3u2H281y3Z1z3d0y0P0q575W1K" +
+	"0z114a0s3i2O2X0n0s0j1C241B1C1C0l2U4s1F0x2t0B0o4K0O5z3p1H3d4E0Z2m0p3q101Y1O344I0D3t1F4F0u0B1P2U272U2e2v5A2-1e1e0s0D0k1F3r" +
+	"2K102-0-3q1G4N5A1-0c1O0k2M5P0s2o0F1d0o2q0g5a0o1T2x132t1o272a3j110p1r2k185s1d1B5x2X32132K0B491F3q3H2q3W3d2M1r5_101D1z2S28" +
+	"4d0n0p190s1o102c2X0C1W2A4Q1X1X0R4I0u4j164l0p1s0k4s0p5s2Z0v0L1I1L4I3T0q4a4D2q0o2L1p3F0V1i0U4n0f070U4n1B1b3I5w4u1y4w0s3D4s" +
+	"4g0d391h2r2s1l0K264Q1y2J0U3d3d2_3f5A5A2U080o2A2T0P275u2O4p2y0q0n3F5W5k2c1s3v4v5E0s1v220G3X3S2b1n3a0l0p4Q1k072j1j3V161j2A" +
+	"0d1s491r134B4l4h2O203M1j2x1b2V2S0V0q270i3R183P3Q0z042c1M4y3f2X225P3M2s044D110n0m1I5P0V012p0408320t310v0Y453W2X014Y1k4k02" +
+	"3d3d3d3A230L4P17080D0D0s1l4A4A3932263f3A0D3B1C2x3D3W3d64643r0E2Z2Z3G2j55160N2x2T270s2y0o5F0e1z1z360V4Q1N1F171o0Z4Z3c2w3j" +
+	"1p1s0F0P2S270n1k0n5U0B0z4-1B3k281X0O2o0n311V1i3H160K044C3O283p4a1W182A0H0f4U1H0h4e1V4h3r3n0n3K0U3o100K3f0K1W314w1G4D0p53" +
+	"4_3p0p0q163s1U2R4Q4S2J2W1O3G594n3p1U0D334Y1s1s1J5O16291W1S270B3g1w5t2w3t5_312m3o2R4S162O4w3q4x0F4n0K2I3W1G1L4A3N281P2X3O" +
+	"0X2Z2p322C4C0m0B0p1p4w0D203d4s2D2p1C1-1U3T2x1l0O16110L1r3f0d0n385a0D011F442X2y2R0C093z0b420V200B3L0X072A3I2y2d052A152M42" +
+	"1k2A4n1N1p4A0n0z3_2w3n3C0c3a593r2w0O5z2K3V10275018105E4I0z1I4X1O1R4-1q0b191l0i4X2G2X0p0n0B1O3i244D3l0h2W2M1e58583z0D131U" +
+	"4h271D1W2j0U2T2y5x2K3D2a3M1Q540-1G291V5D580a2R0s1Q490u0P0Q3A164K4g4u04271W5_200D064x0B1z0Q353z4A1W0D163t2j3t2G0n2K2K0B2U" +
+	"170815013Y3r3y1p0b1l4P3p1d2a2I1H2X2b5_1y24021Z2O1Z18273t1z232F2M282l3o182M4O5K0n2f0G1D1G414B0B3-4I311T2P3d4v0O3A040-272R" +
+	"1V0B3B2D2w4C1r1w5Y4E0I1y443l1y3I2y012R1Z0p014p3z1e2A0x3N4J284s5P3L243o3R384E130n4S2K3l4v1W2j0p181U2G0i3-4A1j2s2J0v0u5858" +
+	"041g2y3I384D1W110D2M0B374A4s0L3I373T5W1k4L011y0D0q1l1l100b3d4K1a4h4i202T28244a071V4C0B49423z3z2K0e0x0n1V2b5J0p0B2w3z5J3W" +
+	"0B1V2y27234J3z2o3A011E1n4j0z0z2y6I2y0Q3A1O1U0z2R4n0s2R2R2y0z0D3t1e0q5E2j0V2b2b1V3w16184N0L2D0B0L020K061Q1N2d0D071F1z2K4D" +
+	"0Z1X06340K011R172B3D0l2t1O4g4g583s0J4i4i3F041-10043y3p1Q2K1j040c0a3t1h1b29295p2_3-0s4U0d0s2t192j1y2P1_0L0U0y112t0U2c070q" +
+	"0p0s4U0y2e0J0J1y0k2D4j0M2O2b2T2d45202O0m2O3d0p0R2T2z0Z2U2K2K5A0s252h4F0k011y641e0p0111045L2d011b4s4E0I1P4E0x422k1N1B4z0Q" +
+	"042q0d0L4u2g1B382L3N3D07184A0e1p1p0s1y1N0u0s0p4w2X1U3p0U0d0m0V1i2W382K260x3X3M0q2w04040D3F4p4D0b0Q161v0p572p4y5k07070D3s" +
+	"3M0J0n2E013p1L2o1O582P3c0n0T5E080P3D2Q2A1b3y253d5E0h024p4P0d1Z1i1C2R0E0D0d0K4A3j0V3B550n0s3p4a0n0s1G1Z0U0L1C063d0K4N2I0k" +
+	"3O2a0s3p1V4D4p4d0z0L570p261W2T393n3f3q3d3r2Q4w120Q162U163p4x1l3o2Q3q3q0B0u2H071j012X4h2I014d0t2c0z1P1R2m014g3d650c1w5q04" +
+	"422p050K5a2t2A3_2y0n0x2I4I412T0J0D273W3n1j0c103r514g3A081N1N1R1R5E4S1C160O0p423A22095A174s540Q0L650x3T3T1e2T4j444Y1P160B" +
+	"0H3j3M2k1Q1Q2K100h1e3u4z3A0u4N2M0Q1U0R490p1Z3u010o1d0h042U172d3W1j3d340p674A1l0k1q1Z5A1Z043q274D3t1G2J0j0d5K2c15243z0d4p" +
+	"470p3f0p0L5W2y4d3A0k4K0p4K0e0N0J1A2O3V3d18163y2T2o4d3y422T0d0k0j1H3j2N2C2N2F0c1D1T2i15162q0V2r3p2T204A181y1-170d1y0a0z0n" +
+	"3Q4N0n3Q4b1g2T01010C1K0D3L2-013u4I0s0X29510d1J0c0m010c0c0d0x1Z4U0x1o1j1r3F3p4a343_5z2K4U1B0v201_2j4k282Z2X4u3R0q2c1H2H31" +
+	"015U1k2A2h0s0X0x0x4U4Y011C0v1J2e4A1N2T3N0D162w1k1k0s2t2t3E1o3r4Z271k0d1T0Z1G0k1P103a4F2i2q5s492R5k5R5z181B5k0R0p3V1p4q0Z" +
+	"010D314S0L5l0B010C5s631I5l0p1l1l3E275C0h2X5k4y3p202t220G1m3L0T052C2C3M3p3W5E0V4d2a0x0q01045E4W5R042a2a0s4N1Z3B575k3k2H2H" +
+	"2c3p2j2j0s0D4r0V2K3N1U2y4k0m1W1x2e1d5J3q3p1G0t371L2Q382t0W5z3d0d1O5J3E1l4C0p1N0K2y01571Z1y0n3L5a0B0b6F2j172X0s270H271M0B" +
+	"0m3m241k172F4j5x424C3_1q1P5a1X350h1l2X1r49183R2X4C0s1G0x4O182h0K2l1Z1k4I2s4K161y4C1N1_0q4H0K274Y014C0a1616280e3R0s6L0s0l" +
+	"2c0B0B270f3B0V1P1Q3h1C2w2w150K0Q0h3W1Q2w2p1l280x3F163u0n163P0D4C3D4d1j120B2m3d2e2T2b1r0f4f273W2T270n1Q2T3W4C1p2A0f270f0V" +
+	"1P2w0K0h4C1Q0B162A3P0D2e1r2T1e0L1r3C1Q0x210B2O0m1F2A054h2o0Z0K0d1P2_1U0d3H0L2q3I2P0B2y0x0y2X2q0z052_0x1H1U16010p1Q160V16" +
+	"0V0V020s0L0L1e1z0B242M2D1z344y2R0d011o2M103u040v0B2M103D290a283X1p102F1Q3A5G4I0U0x4M2B2F212d0V234b2R01013I2t2c1p2c2R310k" +
+	"1S2e1F1F2b2O0s2757202A250p4z4Y2S1K010q280y01012T2Y112X4M2T0l1d3G4y0k2U1N2g2R2M013j2c0K2-3A0K2n2-2e1P2o2K0d4h0F170n1p2S1-" +
+	"2w2b2m3D2K0V4M4W1T0M2p013z0k4G2u0u0D4J0B0x1o3C4w3D4G6M0K1i0m3N0h0U4t131j4m2x0D3A0R0d1y0u0u4v3X0C2K0o5O315O4d4h22630d4P2T" +
+	"0O3A1X1b1y3M2t1x012P102D0J0j0h4I4b0B043R5o0n0q3c0P1S1q5u1O0j0T271U3t1v1p3L4h3h0D572R3h0K4A0K3H4h3D0c2A1Z0s1s1i27040D1F0H" +
+	"162K102x1y394I0e0k250V3j4A0Z3x042z0N0n2q4J2S3u27591V3j2J0Z3d2w3A2w160b4I1H2a3I3s2t1O0K3r3p1Z2c4y28282y0D3L0O3c0p4s4s051N" +
+	"6N271q0d1l3n3y3l0X160j3_3_1r1N010z3r4221074h0h2R5r5V160y4O1S4z4C3D3d1Q0B431e1k2K0H0u5h0y0K133R5h1s3i4A3-3R491X1y0B1e0o2b" +
+	"1A012G2b0J2K4h01013u4L5Q1O4O1x0z2S3t2G3d270D272K4d1N4A4H3z1p0n0h0p013B1N0V3D0G0n0G280h4L104C4I1Z5W5P4H01492b0V4d0V0B020s" +
+	"1o0B0d1z4y4L3u040v2M012M0B1o3r2K3l0q0a5G2R3D1v4M3X0e2d2B1Q1S2O2c113G0K4M0y3I312A2S2U2T2Y2X01014G3z0K4W0K0B2K0R0k1p4h0m2e" +
+	"2o1P2M011S0F172-3H2R2S0d0u0D130u4v0U2K4G5O3X6M3c0k5u3x4d0j3M4P0n0D6O1p2D0h0T4b1X3R1p012z4I573j271Z1H0N0n3D0D4A0h1F2x0V2A" +
+	"2w3h2R4s493I1Z283s0K1O2w1r421N276N3R5h134C1s1Q0V1O2b4I2O1O1z060B1z071z16162J44074d4K193r040K0p4f082X181y0e0x0x0V0V0K1U2K" +
+	"5B015B01270l363D1U175P275R0F0q0s163w3t0D0k1B29161k4g5P3w2A3O29010x2j342x0J1J0J2e1J0U2D4s0Q2H5P5k2c3t4s2x1C0p2P2X0D1B4r1C" +
+	"0J16010q4s1U3R5P1Q3N313p0s3a2_65015p085K2J243R0f1q0x1T0k0K2X1F1T1P3h3B1D1_3i4G3M45012t1C2c2X2y0i2K0F3h1F1B2R671e0F4-273d" +
+	"2M312d3w3w0L2d0g4-2S2s4H2U4I053P2C4l1y1D271X0s3R0O0O0x0B094d1r4M3f1W2j3v2s0e1w0B3i010H0s2e481q44012l4I080x5o3L1W5r0m4H0V" +
+	"1s3u083d1r1K1y4-2X4d180V054A4K1K4-3N310s3p2_3a1q1y2l092J0x243R5K3B2e0k0f1T273Z0K451P4G1_1C3M2c01670x1B2K1F0i2y4-1F0F1r0L" +
+	"312s2d0s4d0B18052U2X2S4l2C0m2s0e5o0s0H481q0V4H1W5r3u3d1K2t162d391-160R2c0R1N062I5u5u0p0Y0s2Y1K043u4E101y1F1Q1j2t2U4I4I57" +
+	"0q212A162b1Q4i2T0B2t1U4W1H1F2p1m4F1K1L3C162Y3I100q27532I5g1M2y2T490L1F2a2T2t3j13131e3-2a1T4H4H3G5U2j3E4P2E1Q0s4I272B0x1x" +
+	"4i0l044i0b0v4B0150204a0l573E0v1B2U4k4Z0k0q2W0x0s4Y0q3u1k2P0q0p1Y010B1m1y1e1m0k2o2u6B1P4E1V172t2y3h0x1B2p3a271d132X1y1m0B" +
+	"0q0B184Q1U0o5W3A0C1H301h180T2g1e0G132z1x2704260n5g0d0B5W0q5W1v2y3Y5J0q2t082o2a1k3G0I013C3O3a3O3T0x1S2W0x0D1m2E0g2g0c2A0y" +
+	"4I5T274W2I0q0s3G573h553x2a2-38273Q4n041x0b502W2a0B5W424S4H2X4N2y443C2I1_3I161V0B0x0b2A4Y3O1l2V3-504j380q4f5d1y241U0c2E1k" +
+	"3C3C1Q1Q0i1x131Q1s442E620f3-1d2X2X0k0q1U132E0G0B0u4i500i4F0x0p0x0h1U3k0n4K0i1y0n6D4C1Z4L235W1x2T0B624n161V1Q0i1p2_0m292T" +
+	"2a1m1m2_4w1o3W2G0B0q2I3U2k0V4a1c2T3n2k1m0x0V2a3Q3d0D2L0a1P3F0n4i2F1_4A241o3u4G185B4I1_3D1J041C3e4G1O0H1z0z2T4G2A201_3_31" +
+	"244G253T3N2O2O4k0k3q3D0l2S0x284P010x0K111T2_0q1e2v2c4e2o2S3v0x2d0T0D4u0U08101N080s3c2S2P3Y1N2b0T1F2K1b5g5E2r013T1O0X5011" +
+	"0f3N1v3K0T3e550B251y0g4c3f0Z0U1z180s1F0s4g3Z2b0D4g4y2O31063p2y1e210H0n3H1H1N1e2b0C2k29240i0Z5V2r5P010B5o1T0D271z3z2C0p0p" +
+	"2y0i2L0a1P4A1_1o0H3D1v3e1z2O4P2y2S0x010z310p283c0K3v0x1e2S2o2y1e1N0s0T503N2K0X1b0T5g1F4c0s0B4g182C3p06502y1N0i2F2O2U2U0J" +
+	"543B4p4p2U1H2Q3B3B3B1H3B2Q2U3B3B4I1G35350h5g5g011t3B3B0K0D441d0h1K1O0D0D042M015E1D043m1b4Q4b3d1d1W1r5p0a240u1d3D1f1N1d1r" +
+	"3p0n1l2t2L2P2p0x01371B4I1_1T2A590k571q4y180v2T0o2K0P0U2w2V0B0t010d5o4F2M383X0K4y12362P2E374A2M0C0U3A0L1a0k0L2A2A4Q0o2a19" +
+	"011N1-2v0u0K0R3I3-5l0e1l1L2K0p2O2s3Z4n1Q2V5P1_2y0U2S1r0D5g1V0n2a011d3p3W2X3x0D491X2E1X0V0g2t2a0D0K310U1W3D3B5E3D063j0L3Q" +
+	"1d0n39012e280G4S2M1x2A2t3p3I1x1O1R280f093_0K5f2t3I3-1N2E2A3n2t4n0i435x1O4A0D013D440q0K2X281D1Z1X1f2b4J1T5Y3B3B4p0p3p283W" +
+	"0p011i4f042g3u070D5A1G4E164G0s1c3w2B2q1J2F1p3s3p302J1Z3s0u0q0U074B0L2X0J0M4u3N2h2A5u0N0i4G1P0k0K3E0D0F2c0I0x0B5P362q1e16" +
+	"1P3x4w1B331S4w1W0V0R19162y0s0u0s4v3t4w1B4l3F3d1k163N0p1W0C3A040J3c5E2S3R5u112s2P4I0U0C170m0q2g1301190V4a3g3N3D183V0D1W36" +
+	"1V17173o2I2m161G1U1F5u0N0u171W3t2g2X1C210s44100H0z130V0942132R4f2X2M2X5r0n1S1C3u0i590m4D0N5a330e4D5u4G1l4H0h0U0B0V0h4n20" +
+	"5P160e4H0p0U020L0j2C2S011K0D0Y0V541C312R1931293p0s1W4B3P4Z0v3C072O3I590G3v2m2k5g0G0U1-0x1y3r0R104Q1e4a3E4_3z1s541f0V6E5y" +
+	"0n2y4C0V4y2C2b3f3R545o3C5k2a1y4d0z2R2q3R0L3f1W594P3o2w2o0G4n1W2g520y1f2I3r414I0D0p3-2k013r0C1e163R0-35010p0q0V015y1G2M0G" +
+	"0V202w2w5y2F1e6E2w0V3B3a0K0U0K0K0p0c0N0T161r1r0L01443Q2Y02590L0l0s310x0l1E0s0t2D3u134X0g1F244h4h074V045A5m2A0Q1601340K4Q" +
+	"162N1O0L270J0N1r0l2B4I2q194i1O0s0J1J2F3D1Q2E2e101O2Q231o215w291W011j0J5E1z103F2D010c160F5m2J4B1c3p2y3D1j1m1F2P4Q0K472O4P" +
+	"200M0Q2O1k0J0D0Q2Z0C0U3P1B310K0K0x0q0h0K0K4A2Y2j2c2X3i1U0K1k0k2t3m591-1O0s3b4S1O0s0p4i0J252Q1k0d2e181S1h16162A1B1h3R5P01" +
+	"0Q4G2i3N271-2q1z1e2t1T3W0M0K2z1B103F2S4N171-4s3z0B0D2R1o430x4E3u2t1y2q2o0J450k172m2u281y1y2H3F2-2k0B0J1G2w01102v2x0S0U5V" +
+	"031O1E0Q2G2D07580K4t0L3A1N2H4-2a4n4W1z0c1a2y4a4w082F1W3N0u3I0D0h021k071B1z620e0h080x0Q4A4h0D2p4G0j5n2q1j1p075n0N1U5r0q1y" +
+	"455A1s0p4Y580R4v0p650M0k314Q4S540q223p274V2D4y221v5E200J2y0p0b4q3V47543T2x0J1y0a5o3z4P3U1x5k4d4R3S1F1S3Z5W3O3f3R02041O5o" +
+	"0B0D1r0f1C223P161l4y2O4n18312y4D2y4h3L5I2s5R2B0u0t084H0z5b1y3h2J0T0-1n4e3d2A1l5E1y4d4Q0n3O0s550B3D3F4I2a0y0z253k060D4t3i" +
+	"3j0n0s2Y2-0I0K1z1V3P2z1313361a0O5P0N47273k273X1V0z4A1m0d313B0c1F2H0N4a3K2G0d1O4a1L1d3s061l1L4e4S280N5Q4x3q2I1L0b0p5O3S1k" +
+	"4Y1d2X0W4V1F0u3d3j2p4S4e5_1I3o083t31314w013p2I5W161B0Z1R3V3X3G1L060e4g1J2y1L3l3G4r2H0f4A1l4T3_424s3W2X0n4S2A0A3O012y3_4t" +
+	"0b1M04222I422g0h0e0o3L183n2H0O4K4H275a0p06161c562V091O0R2y4h0B1s2K0b590i5V2H0A0R1V0Q4S4z3j0r1X24132o252I171Q1e43440I2k4O" +
+	"2F4H0B270c4f0a3d4O1Q5Q4H1s45240R023u4e273M1X2y3V2K0D1l0j13270j3z2X455P0N1m5E490q291d3N012G3O2M2D0k1g5o0B5W2c2J3c3w3d1V2f" +
+	"2R27271O2-0x150p3z0B6D4G053T4p4d5O5g2y3L2j2y1y604K3D2y2F274C1k27280h1727160f1w5W3z622T4Y3W6D1E3-5W1Q2I0k4C1r5I0131022Y59" +
+	"444Q1O0g3u23242D1n2N25342q0F0C4B0q2E210c1J3N4V2Q1O281j3F0u2e1c2B2J1a5w0l0D1O1S1O2O1k4P590C1k4i6D1h3R1B2W0U451k205O1O1C2j" +
+	"0K4A1x2X2P0J1m2q032k1T5A0a0U0R1B0N4z0x491-2y2D104e0y0k16020S1y4h2o1I5L2D0Z2m1S5V1e010h4N2I2x101G0k2k1s0u2s651l4t3A1L0p5r" +
+	"1U5E4v3D2H4n5n0e2F4S4e27582a4w1j0q5S0b1N3x4n1C3Z045b4P5k22162O3W3S5o1r0x1y3V2J2C3R02270q4D1F0I3d4e0b0Q3D2-130c3k3o3j5P2G" +
+	"4d4a3n1F3M4a4s311k0Z5W2I3V5W1J4S4v3s011B3w3q3L2I423W2V2g0o2A3G4A4S0h1Q441s2y4O4C1s620b4f2J3z5P0j1l012-3L4p0h1709220Y3_57" +
+	"2T442w091n1R6A3u2J1n0V2X072X3u4e1c2V1z6G0A1y1y27470663190y4z2b3T06203B2X6G2G0f0Z0Z0-1U1z1P475V5k4w2y1i1I0q4i4i0h2T0i226E" +
+	"5k0V4g0V3D3H0D4g1z2j1z2T284g2Q2x0n1064534C4d2o0e3P5W1x4e2c53400f19041V2I0f412r162K0h0J495P1x4g1x1n6A3u0V072X4g406G0n1y1z" +
+	"27471c1n0y4z1P4g1x472y4e0-0Z2c5k0Y4i4w4d0D0V22164g3H0V3D0h4C2T2K64532c3P1d53075P0s0s0_4O2F1O0u0F0x1025193D3i2Q2C4t1W3e0x" +
+	"0J0o2B2510310d0R3D2T2A3G2Z2W205S0v0v3N0M0s0B2y4K4I2q1m3n4s3r181m1y1T1P0D0F3u3F132q3f0s2X063u3i0x1s193p311U1y3Q5W1W1y2C3M" +
+	"5u014O1v100q3S4I0J204y1y2y3i1y100k251t064N0V10182A0D4K0n0n5W4O1X2x3j0d1X100F0V2K0Z1W0u1V2G162I0B093Q3_1610235x0i384f1e1X" +
+	"1X3_1y292P1T0B3Q16100x2K4K160p0p0p5o0z0x5_3V3901530V1j1O4M0a0B2O5P0x2e3V2U2g0q1U0z3m3W0D0G2D1X0726560f1U3m2g0B160G4S0p39" +
+	"1H3m0B0B0D0D1H1J5C5V1h4g29121W0p2t25203z2x2j0u2W490z0u2D2R0B1h4I3A0y1i312C4a20042S2J1I3M1z2b102P0k5B0i200P3d0u205620061z" +
+	"101D1D1W4S4D3w0u0n641016012G0m4J1y0D2y4K1_0B12250l0J0n0e160B1D4D4D3z0p2J2G3M1_4K202G0e0p2j2c2c2c0M3c3c0o3P310o0R3c0o0o2W" +
+	"0o3P3M3M032w0E0E2j0N3P2K0N0Z022-1O1o2A4d0u1o1j1r1H5w0l10231J4s3G1d2Q01284y3N0d1z4k250M100Z4y0d1Z2x2x2n2_4Z1d131i423d1X2p" +
+	"134A3U2V1x050M640q4p1Z553s6P0L0L2c131h0q273B2A274g4y2e1x3N1Z4Y0Z2X642I3n1P131H0B273r0h1d0z1y1O4d2h274C0n1o0s4a2o0Z0n1307" +
+	"2264551W2d0n0Z2l4y2e1W4v2X0n1X4d2h4d0n1o0s070n1W4y0Y0Y1y1Z1y0B103m1W4u0_3T3j3d1W3T2x10170z0N023c1V175D072R012R3D3X1V045p" +
+	"0D082Q2E2A0m4Y59202L0o3z2c133D3D0n1Z641p01011f4O0D113z0s1B1a2K1V1B3W1i0R3d1W0z070o38385K2s381F1F3S022s3R071O4P1M4A1j0148" +
+	"2a3D3D0V4g2r2g0H0V1y2F013p0W2W2W3r1V3z2k415X423C283t132O2M0V2K0h1G4D3w1y5K2y4C5W2o0N023c07175D2R2R082O2E042Q3X5p0D2y2059" +
+	"0o0d1B1i2c3d374P015K2K38073d3d4P2a2g3D0H0V3D5W422W0W3t3z0h1G5K2o0e3L4W0s062b3L230l4B4F4W4A4u0q3L0Q0n25283o4e3o244S164S41" +
+	"414S3L3L3L443L5V0e2H0e252b3L230q0Q3o284S413L3L3M243M3M0K0K3y0B020Q0v2b5_175B1o0D3V2t1024245_101a012Z2X0d4y2j2U450Q2j0K1m" +
+	"1f1o3P1e1B0M281-2U17253V1T0V0_163y1k4R3D0C3F193I0D0K283H5E0K275o0M1y0C0N3R3M090n0f3D5v1W0z3j452K27362G2I290n2O2-3X2I1k4v" +
+	"161V4S3o4y0N4g4s2I422X0D2H1r0A1V0H3u2t0m010B5x0R2g4y0n172b291f4D0V4s0h173W4H1A0K020B0v2I5B161o0D2t2410270K2j0Q1m011T5x17" +
+	"2c0_1e160M1k193D0D4R3F5E3H1y0f2-1V2O3o0h0N4s1k4S2H1r420A0H1A4T1V5E17291k2P2X1k4Q073M272x2G0s1y1W2F295K2F1R0D0e072A0v1N2t" +
+	"2M0k0x2C2F5m231W4g0x0m2y0U1k2j1Q0v012T4g2T37592Y3p2C2S0q0k2N0q0J4-1B20314B0s250K2X0v0v0Q4S1R3h4y0x3q4J0P2M2o1p371T1f1k0k" +
+	"103W2S0m1f4S0q3X1j5A4w074Q4A383F3D0R2q2G2y5o2S2d3h5k4y2s3O4P04040V3M5q0V0Z280u3h0O3h1O3M3j1O2J0D2a2o063O1V550Q4W0v1P3o1O" +
+	"0R0x4S3T4J165a4d0V3p5u5q3u040x562y163W094s3_3y3L0O2T2X0x0D2R4h1k3I3A0i2b2E4S0v5d2W1e450V3W2b0o010N0v5K2S0V4K2y4J170B560q" +
+	"165P0p3L1R0D0v2M2t2T5m1k2y4-0K0Q0s0q5u0k0v2N1B011_3A1R101e4h3W1f3h3L0p0R0V2q1j3F04044P5o3O3I3y3h0x1V5q0u3_4S1O3u3L3W0O0h" +
+	"2S0B56172O0n0n0n0D1K01521a0d2j3N2U2a2A3E1f13362u0Z384l3h2X4P4Z0D1X3n1k3A3s1k2U603w4s443n3A4d4-1X2a3w602y4v4v1303132k5q3L" +
+	"5k5k2A2B2H4U1C0m5k3w0-2p0J1F0-4U2a2j2X1C0s1T3q2T2_2H640B3T694u1L2a0p3w3O2A3T3X1S2o3O5D270v0z4p0Y041l313w3T1R0A4242462R4F" +
+	"1O1O1A5P642f3w4F4L19194z1z16190719190Y220D0D0Z0V0s1j1P3O4S1P050D1P4a0B045I1V3H0l594a2R0V3A0T0D38041b0T0n1K2t412X4s0B2R0G" +
+	"0V2b0D3Q1o0d0l1z0v2D0B2R3D3D1I5p5B4s0d1p2Q3p0J2y4g0z2y3m2B291J0U3h4s2c062T070J200v1k0L110s2X2U0n0q2D0l0U0X0J502q4B2j0s0y" +
+	"0q2O0K0P1_1x0d1836171T2x0n2Y0k104F3W1-2a010M0n1e3E1P1y0Z2K2k0s032H1m3F2a0E4u0y1L1j0D4R1N0X4l3I0u0L3u1s5P2p0p1I1I034D0R0p" +
+	"5u162g1p1F041S174R3f0B2D0I2O2S0P2s3M2P015k2y0Y220o1v205u0p4D3O0x500z0D2A0K1p5P2a362q0k4p3O673B3B5P2o670n0n0D3f55573j1l0V" +
+	"0L0L272X3D250s481K060v0z0K3g4A4g1O3t0u2B5q4S1R0K0K0f1F4J1x283D2g1O044g3g1p1l3_0_2K470B2p2T271p42160L3L0B0B0k3r2H0H2g091c" +
+	"1H4j0D2T171k1e2M3I3j433u3u241P0i2M5Y165r17290f3633675q2b1K1P3d0p223z182G1G0B2R0z180p4F0p1531173D2y0f0p1y0D3Q0B0U5B2y2B0l" +
+	"2c0l11502y0U0y0s0l2j360J4B1P0d030n1T1-6736330B1e1y0y172M4l0p1l270p0K2p0X1I5P3F0B2g3c20045u3M5k222O2P502D0o1p0K1F29572X22" +
+	"2a4g0n483D0L0s3j2o3r3B5q3_041x0f2812270_3L1H4j42472g0n161P3u0i430z1K0p2b0f5z010s0p0Y0C0V0s2D0B0e1G1K0K0e2m2j3p0x0z1j0s2Q" +
+	"4g270K0D5C281Q1Q0J5P312j0V0a1S2B0e4g3l1_0Z1G20150s0v470p3B0x0Z3p2U2T3A2t0m0q282O0c0D250D0a450D3P3d4O0u1T4W2G0x0V1g3A1B4F" +
+	"0k252a1z3W1G2z0Z1o1e2H2S2t3W1g503D1E1B0B4Q5P0v0C0u3V0D1k3F3F2X163F0q4Q0o2a3D3D4G0z0u1p2s1e4Y2y0J180s2x0j3T1j1O3N2D2y5327" +
+	"0q4Q0a3p040p0N5o3U1m5k3i040o01010o1F2s0P043f4l0q1Q01430B183d2t5Y552x0L2a0z2a3D0d1C0s3f4Q0V2c3p3h5k4a0z3d0g0u0q0P1w2B2c3d" +
+	"3p1y4x0K2c1U2I063g4S0B2O275_072U2U01280V0B0p3P4X2a2a014Q1R3A4v3P4I0B4r1N3_28013l2t0x4S2g2y2X560j0D0u1Q102a0Q1e014h2X3d3I" +
+	"3j241e440V4v0Y1y1y4Q1D430D3d2y4r1y1H010J183W16010B1q0D2b0N250J2f0z2w3d4D2A4i0D3z0C2y2c4K564i3d0f2T0p1E5z0Y0B3p2m0K3l0a1w" +
+	"2j312O0P2y0a3A25202t2T3p1H0v0Q0x1T4W2H1g2S0Z1E1z0u1k0p3V2O3D0D1y2a0u535Y2x5k3N3T1O3f4l3p3I0z2c3D2O0L2U4a0u01284x4S0B0127" +
+	"2c013d1N4S441e0Y0D2y5P2b3d0z4D0f562y1r20271y4n27270V4n2y0U3C0B4k3C4k0k3F0k272w2w0D4S4I3F0i2P042y0Y3F0o0p171y1B2w0p2d090i" +
+	"0o04200V3C1D1D2c210s0E0E0s4k2T2T2z0s1y2s2T0E2t0e0s2T0E1R0w4s2K4s5u6Q293j3j1r2v3P1a1z4h4G1z180p502t5c5c011O0m165B4s4s1O1_" +
+	"1U0U2W01060V2T4a0V3c4i0p494d3y0V0V0V0m2x311_3y014a2b0V4d2y0x290s0s0E0E3p3C2T212h0k0x4D3_4j0v2X3p210v022w0t021F2O2O0P294y" +
+	"3p0J224v043p2I4J1818293M4g0l2D0v0c2T3i0K0U0K1i0u0q0o3H0q0V4Q0Q161y0V3z2X280L070u0u361Z3D0y2H4x0y351A043b0B1F0B0B040B2t4I" +
+	"4I2c0a101Z2j1q1Z342t0V0q2p20201U2o1Z5R5W0Y285g1W0D1U012P1q5u2T1W0V3l3D26012U5u2W1U1r0a2t4I2c100q202j2p1F5R0D5g2T264K122_" +
+	"120V4K4K2_2r1d201d4K2_2r1P0L4j1P4i3S2c1Q1Z0D00000000000000000000000000000000000000000000000000000000003u0000000000000000" +
+	"004V1x1D000000000Z0m0000000000000000000000000000000000000000003_3P2P0000000000000P0x4w2x00001D0000000000"
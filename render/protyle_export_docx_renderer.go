@@ -348,7 +348,58 @@ func (r *ProtyleExportDocxRenderer) renderGitConflict(node *ast.Node, entering b
 }
 
 func (r *ProtyleExportDocxRenderer) renderSuperBlock(node *ast.Node, entering bool) ast.WalkStatus {
-	return ast.WalkContinue
+	if !r.Options.SuperBlock {
+		return ast.WalkContinue
+	}
+
+	layout := SuperBlockLayout(node)
+	if SuperBlockLayoutRow != layout {
+		r.Newline()
+		if entering {
+			attrs := r.superBlockAttrs(layout)
+			r.handleKramdownBlockIAL(node)
+			attrs = append(attrs, node.KramdownIAL...)
+			r.Tag("div", attrs, false)
+			r.Newline()
+		} else {
+			r.Tag("/div", nil, false)
+			r.Newline()
+		}
+		return ast.WalkContinue
+	}
+
+	if !entering {
+		return ast.WalkContinue
+	}
+
+	// Word 不支持 flex 和 grid 布局，横向布局导出为一行多列的无边框表格
+	children := superBlockChildren(node)
+	width := superBlockCellWidth(len(children))
+	r.Newline()
+	var attrs [][]string
+	for _, attr := range r.superBlockAttrs(layout) {
+		if "style" != attr[0] {
+			attrs = append(attrs, attr)
+		}
+	}
+	attrs = append(attrs, []string{"style", "width: 100%; table-layout: fixed; border-collapse: collapse; border: none"})
+	r.handleKramdownBlockIAL(node)
+	attrs = append(attrs, node.KramdownIAL...)
+	r.Tag("table", attrs, false)
+	r.Tag("tr", nil, false)
+	r.Newline()
+	for _, child := range children {
+		r.Tag("td", [][]string{{"style", "width: " + width + "; vertical-align: top"}}, false)
+		r.Newline()
+		r.walk(child)
+		r.Newline()
+		r.Tag("/td", nil, false)
+		r.Newline()
+	}
+	r.Tag("/tr", nil, false)
+	r.Tag("/table", nil, false)
+	r.Newline()
+	return ast.WalkSkipChildren
 }
 
 func (r *ProtyleExportDocxRenderer) renderSuperBlockOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
@@ -644,6 +695,16 @@ func (r *ProtyleExportDocxRenderer) renderFootnotesDef(node *ast.Node, entering
 func (r *ProtyleExportDocxRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
 	r.Newline()
 
+	if rendered, ok := r.renderCodeBlockByLanguage(node); ok {
+		if entering {
+			language := ParseCodeBlockInfo(node.FirstChild.Next.CodeBlockInfo).Language
+			r.Tag("div", [][]string{{"class", "code-block"}, {"data-language", language}}, false)
+			r.WriteString(rendered)
+			r.Tag("/div", nil, false)
+		}
+		return ast.WalkSkipChildren
+	}
+
 	noHighlight := false
 	var language string
 	if nil != node.FirstChild.Next && 0 < len(node.FirstChild.Next.CodeBlockInfo) {
@@ -1225,7 +1286,7 @@ func (r *ProtyleExportDocxRenderer) renderHeading(node *ast.Node, entering bool)
 		r.Newline()
 		level := headingLevel[node.HeadingLevel : node.HeadingLevel+1]
 		r.WriteString("<h" + level)
-		id := HeadingID(node)
+		id := r.HeadingID(node)
 		if r.Options.ToC || r.Options.HeadingID || r.Options.KramdownBlockIAL {
 			r.WriteString(" id=\"" + id + "\"")
 			if r.Options.KramdownBlockIAL {
@@ -1243,7 +1304,7 @@ func (r *ProtyleExportDocxRenderer) renderHeading(node *ast.Node, entering bool)
 		r.WriteString(">")
 	} else {
 		if r.Options.HeadingAnchor {
-			id := HeadingID(node)
+			id := r.HeadingID(node)
 			r.Tag("a", [][]string{{"id", "vditorAnchor-" + id}, {"class", "vditor-anchor"}, {"href", "#" + id}}, false)
 			r.WriteString(`<svg viewBox="0 0 16 16" version="1.1" width="16" height="16"><path fill-rule="evenodd" d="M4 9h1v1H4c-1.5 0-3-1.69-3-3.5S2.55 3 4 3h4c1.45 0 3 1.69 3 3.5 0 1.41-.91 2.72-2 3.25V8.59c.58-.45 1-1.27 1-2.09C10 5.22 8.98 4 8 4H4c-.98 0-2 1.22-2 2.5S3 9 4 9zm9-3h-1v1h1c1 0 2 1.22 2 2.5S13.98 12 13 12H9c-.98 0-2-1.22-2-2.5 0-.83.42-1.64 1-2.09V6.25c-1.09.53-2 1.84-2 3.25C6 11.31 7.55 13 9 13h4c1.45 0 3-1.69 3-3.5S14.5 6 13 6z"></path></svg>`)
 			r.Tag("/a", nil, false)
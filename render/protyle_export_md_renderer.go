@@ -629,6 +629,13 @@ func (r *ProtyleExportMdRenderer) renderBlockQueryEmbedScript(node *ast.Node, en
 }
 
 func (r *ProtyleExportMdRenderer) renderBlockQueryEmbed(node *ast.Node, entering bool) ast.WalkStatus {
+	if nil != r.Options.BlockProvider {
+		if entering {
+			r.renderEmbeddedBlocks(node)
+		}
+		return ast.WalkSkipChildren
+	}
+
 	if entering {
 		r.Newline()
 	} else {
@@ -637,8 +644,67 @@ func (r *ProtyleExportMdRenderer) renderBlockQueryEmbed(node *ast.Node, entering
 	return ast.WalkContinue
 }
 
+func (r *ProtyleExportMdRenderer) renderEmbeddedBlocks(node *ast.Node) {
+	r.Newline()
+	blocks, err := r.embeddedBlocks(node)
+	if nil != err {
+		// 查询失败时保留原始的查询嵌入
+		script := node.ChildByType(ast.NodeBlockQueryEmbedScript)
+		r.WriteString("{{")
+		r.Write(script.Tokens)
+		r.WriteString("}}")
+		r.Newline()
+		return
+	}
+
+	for i, block := range blocks {
+		if 0 < i {
+			r.WriteByte(lex.ItemNewline)
+		}
+		if block.embed {
+			embedded := NewProtyleExportMdRenderer(block.tree, r.Options)
+			embedded.embedStack = append(append([]string{}, r.embedStack...), block.id)
+			r.Write(bytes.TrimSpace(embedded.renderNodes(block.nodes()...)))
+		} else {
+			// 超过最大嵌套深度或者循环嵌入时渲染为引用
+			r.renderBlockRefLink(block, blockRefAnchor(node, block))
+		}
+		r.Newline()
+	}
+	if r.withoutKramdownBlockIAL(node) {
+		r.WriteByte(lex.ItemNewline)
+	}
+}
+
+func (r *ProtyleExportMdRenderer) renderBlockRefLink(block *providedBlock, anchor string) {
+	href := block.url
+	if "" == href {
+		href = "#" + block.id
+	}
+	if strings.ContainsAny(href, " ()") {
+		href = "<" + href + ">"
+	}
+	anchor = strings.NewReplacer("\\", "\\\\", "[", "\\[", "]", "\\]").Replace(anchor)
+	if title := blockRefTitle(block); "" != title {
+		href += " \"" + strings.ReplaceAll(title, "\"", "\\\"") + "\""
+	}
+	r.WriteString("[" + anchor + "](" + href + ")")
+}
+
 func (r *ProtyleExportMdRenderer) renderBlockRef(node *ast.Node, entering bool) ast.WalkStatus {
-	return ast.WalkContinue
+	if nil == r.Options.BlockProvider {
+		return ast.WalkContinue
+	}
+
+	if entering {
+		id := node.ChildByType(ast.NodeBlockRefID).TokensStr()
+		if block := r.block(id); nil != block {
+			r.renderBlockRefLink(block, blockRefAnchor(node, block))
+		} else {
+			r.WriteString(blockRefAnchor(node, nil))
+		}
+	}
+	return ast.WalkSkipChildren
 }
 
 func (r *ProtyleExportMdRenderer) renderBlockRefID(node *ast.Node, entering bool) ast.WalkStatus {
@@ -707,8 +773,8 @@ func (r *ProtyleExportMdRenderer) renderFileAnnotationRefText(node *ast.Node, en
 }
 
 func (r *ProtyleExportMdRenderer) renderYamlFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	if entering {
-		r.Write(parse.YamlFrontMatterMarker)
+	if marker := parse.FrontMatterMarker(node); entering && 0 < len(marker) {
+		r.Write(marker)
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
@@ -723,8 +789,8 @@ func (r *ProtyleExportMdRenderer) renderYamlFrontMatterContent(node *ast.Node, e
 }
 
 func (r *ProtyleExportMdRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
-	if entering {
-		r.Write(parse.YamlFrontMatterMarker)
+	if marker := parse.FrontMatterMarker(node); entering && 0 < len(marker) {
+		r.Write(marker)
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
@@ -934,7 +1000,7 @@ func (r *ProtyleExportMdRenderer) renderTable(node *ast.Node, entering bool) ast
 		var maxWidth int
 		for col := 0; col < len(cells[0]); col++ {
 			for row := 0; row < len(cells) && col < len(cells[row]); row++ {
-				cells[row][col].TableCellContentWidth = cells[row][col].TokenLen()
+				cells[row][col].TableCellContentWidth = tableCellWidth(cells[row][col])
 				// 自动添加空格会导致单元格宽度发生变化
 				if r.Options.AutoSpace {
 					ret := 0
@@ -1039,6 +1105,9 @@ func (r *ProtyleExportMdRenderer) renderLinkText(node *ast.Node, entering bool)
 		} else {
 			tokens = node.Tokens
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		r.Write(tokens)
 	}
 	return ast.WalkContinue
@@ -1239,6 +1308,9 @@ func (r *ProtyleExportMdRenderer) renderText(node *ast.Node, entering bool) ast.
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 		if (nil == node.Previous || ast.NodeTaskListItemMarker == node.Previous.Type) &&
 			nil != node.Parent.Parent && nil != node.Parent.Parent.ListData && 3 == node.Parent.Parent.ListData.Typ {
 			if ' ' == r.LastOut {
@@ -1428,6 +1500,10 @@ func (r *ProtyleExportMdRenderer) renderCodeBlockCode(node *ast.Node, entering b
 func (r *ProtyleExportMdRenderer) renderCodeBlockInfoMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Write(node.CodeBlockInfo)
+		if 0 < len(node.CodeBlockInfoMeta) {
+			r.WriteByte(lex.ItemSpace)
+			r.Write(node.CodeBlockInfoMeta)
+		}
 		r.WriteByte(lex.ItemNewline)
 	}
 	return ast.WalkContinue
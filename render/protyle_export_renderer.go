@@ -259,12 +259,52 @@ func (r *ProtyleExportRenderer) renderBlockQueryEmbed(node *ast.Node, entering b
 		attrs = append(attrs, []string{"data-content", util.BytesToStr(tokens)})
 		r.blockNodeAttrs(node, &attrs, "render-node")
 		r.Tag("div", attrs, false)
+		if nil != r.Options.BlockProvider {
+			r.renderEmbeddedBlocks(node)
+		}
 		r.renderIAL(node)
 		r.Tag("/div", nil, false)
 	}
 	return ast.WalkContinue
 }
 
+func (r *ProtyleExportRenderer) renderEmbeddedBlocks(node *ast.Node) {
+	blocks, err := r.embeddedBlocks(node)
+	if nil != err {
+		r.Tag("div", [][]string{{"class", "protyle-wysiwyg__embed protyle-wysiwyg__embed--error"}}, false)
+		r.WriteString(html.EscapeHTMLStr(err.Error()))
+		r.Tag("/div", nil, false)
+		return
+	}
+
+	for _, block := range blocks {
+		r.Tag("div", [][]string{{"class", "protyle-wysiwyg__embed"}, {"data-id", block.id}}, false)
+		if block.embed {
+			embedded := NewProtyleExportRenderer(block.tree, r.Options)
+			embedded.embedStack = append(append([]string{}, r.embedStack...), block.id)
+			r.Write(embedded.renderNodes(block.nodes()...))
+		} else {
+			// 超过最大嵌套深度或者循环嵌入时渲染为引用
+			r.renderBlockRefLink(block, "s", blockRefAnchor(node, block))
+		}
+		r.Tag("/div", nil, false)
+	}
+}
+
+func (r *ProtyleExportRenderer) renderBlockRefLink(block *providedBlock, subtype, anchor string) {
+	href := block.url
+	if "" == href {
+		href = "#" + block.id
+	}
+	attrs := [][]string{{"data-type", "block-ref"}, {"data-subtype", subtype}, {"data-id", block.id}, {"href", html.EscapeHTMLStr(href)}}
+	if title := blockRefTitle(block); "" != title {
+		attrs = append(attrs, []string{"title", r.escapeRefText(title)})
+	}
+	r.Tag("a", attrs, false)
+	r.WriteString(r.escapeRefText(anchor))
+	r.Tag("/a", nil, false)
+}
+
 func (r *ProtyleExportRenderer) renderBlockQueryEmbedScript(node *ast.Node, entering bool) ast.WalkStatus {
 	return ast.WalkContinue
 }
@@ -391,6 +431,12 @@ func (r *ProtyleExportRenderer) renderBlockRef(node *ast.Node, entering bool) as
 		if nil != refTextNode {
 			refText = refTextNode.Text()
 		}
+		if nil != r.Options.BlockProvider {
+			if block := r.block(idNode.TokensStr()); nil != block {
+				r.renderBlockRefLink(block, subtype, blockRefAnchor(node, block))
+				return ast.WalkSkipChildren
+			}
+		}
 		refText = r.escapeRefText(refText)
 		attrs := [][]string{{"data-type", "block-ref"}, {"data-subtype", subtype}, {"data-id", idNode.TokensStr()}}
 		r.Tag("span", attrs, false)
@@ -780,6 +826,18 @@ func (r *ProtyleExportRenderer) renderFootnotesRef(node *ast.Node, entering bool
 }
 
 func (r *ProtyleExportRenderer) renderCodeBlock(node *ast.Node, entering bool) ast.WalkStatus {
+	if rendered, ok := r.renderCodeBlockByLanguage(node); ok {
+		if entering {
+			var attrs [][]string
+			r.blockNodeAttrs(node, &attrs, "code-block")
+			r.Tag("div", attrs, false)
+			r.WriteString(rendered)
+			r.renderIAL(node)
+			r.Tag("/div", nil, false)
+		}
+		return ast.WalkSkipChildren
+	}
+
 	noHighlight := false
 	var language string
 	if nil != node.FirstChild && nil != node.FirstChild.Next && 0 < len(node.FirstChild.Next.CodeBlockInfo) {
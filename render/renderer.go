@@ -57,6 +57,10 @@ type Options struct {
 	CodeSyntaxHighlightLineNum bool
 	// CodeSyntaxHighlightStyleName 指定语法高亮样式名，默认为 "github"。
 	CodeSyntaxHighlightStyleName string
+	// CodeSyntaxHighlightClassPrefix 指定语法高亮使用 class 时的类名前缀，默认为 "highlight-"，需要和 ChromaStyleCSS 生成样式时使用的前缀一致。
+	CodeSyntaxHighlightClassPrefix string
+	// CodeBlockRenderers 设置按语言（代码块信息字符串的第一个单词）注册的代码块渲染器，对 HTML、Vditor 预览和 Protyle 导出预览渲染生效。
+	CodeBlockRenderers map[string]CodeBlockRenderer
 	// Vditor 所见即所得支持。
 	VditorWYSIWYG bool
 	// Vditor 即时渲染支持。
@@ -71,6 +75,10 @@ type Options struct {
 	KramdownSpanIAL bool
 	// SuperBlock 设置是否支持超级块。 https://github.com/88250/lute/issues/111
 	SuperBlock bool
+	// SuperBlockClass 设置打开 SuperBlock 后 HTML 渲染超级块容器时使用的 class，横向布局渲染为 class="sb sb--row"，纵向布局渲染为 class="sb sb--col"，默认为 "sb"，为空时不输出 class。
+	SuperBlockClass string
+	// SuperBlockInlineStyle 设置 HTML 渲染超级块容器时是否输出布局内联样式，横向布局使用 grid 等分列，纵向布局使用 flex 堆叠，用于没有配套样式表的场景。
+	SuperBlockInlineStyle bool
 	// ImageLazyLoading 设置图片懒加载时使用的图片路径，配置该字段后将启用图片懒加载。
 	// 图片 src 的值会复制给新属性 data-src，然后使用该参数值作为 src 的值 https://github.com/88250/lute/issues/55
 	ImageLazyLoading string
@@ -82,17 +90,45 @@ type Options struct {
 	// https://github.com/sparanoid/chinese-copywriting-guidelines
 	// 注意：开启术语修正的话会默认在中西文之间插入空格。
 	FixTermTypo bool
+	// SmartTypography 设置是否对普通文本进行印刷排版美化，包括弯引号、破折号和省略号。
+	SmartTypography bool
+	// SmartTypographyLang 设置 SmartTypography 使用的引号语言，可选 "en"、"de"、"fr" 和 "cjk"（直角引号，"ja"、"zh-TW" 等也使用直角引号），默认为 "en"。
+	SmartTypographyLang string
 	// Terms 将传入的 terms 合并覆盖到已有的 Terms 字典。
 	Terms map[string]string
 	// ToC 设置是否打开“目录”支持。
 	ToC bool
+	// ToCMinLevel 设置目录中包含的最小标题级别，默认为 1。
+	ToCMinLevel int
+	// ToCMaxLevel 设置目录中包含的最大标题级别，默认为 6。
+	ToCMaxLevel int
+	// ToCExcludeClass 设置不包含在目录中的标题的 class，比如 kramdown 使用的 no_toc，标题通过内联属性列表 {: class="no_toc"} 设置 class。
+	ToCExcludeClass string
+	// ToCOrdered 设置目录是否使用有序列表。
+	ToCOrdered bool
+	// ToCTitle 设置目录上方插入的标题，为空时不插入。
+	ToCTitle string
+	// ToCTitleLevel 设置目录上方插入的标题级别，默认为 2。
+	ToCTitleLevel int
+	// ToCMarkdown 设置格式化时是否将目录写为 Markdown 列表。打开后 FormatRenderer 会将 [toc] 以及 <!-- toc --> 和 <!-- tocstop --> 注释之间的内容
+	// 替换为由标题链接组成的列表，这样目录在不支持 [toc] 的 Markdown 平台上也能正常显示，再次格式化时会刷新该列表。
+	ToCMarkdown bool
 	// HeadingID 设置是否打开“自定义标题 ID”支持。
 	HeadingID bool
 	// KramdownIALIDRenderName 设置 kramdown 内联属性列表中出现 id 属性时渲染 id 属性用的 name(key) 名称，默认为 "id"。
 	// 仅在 HTML 渲染器 HtmlRenderer 中支持。
 	KramdownIALIDRenderName string
+	// Slugger 设置标题 ID 的生成算法，对 HeadingID、ToC 和 HeadingAnchor 生效，为 nil 时使用 Lute 原有的算法。
+	Slugger Slugger
 	// HeadingAnchor 设置是否对标题生成链接锚点。
 	HeadingAnchor bool
+	// BlockProvider 设置内容块提供者，渲染内容块引用和内容块查询嵌入时通过它获取被引用的内容块，为 nil 时保持原有渲染。
+	// 仅在 HtmlRenderer、ProtyleExportRenderer 和 ProtyleExportMdRenderer 中支持。
+	BlockProvider BlockProvider
+	// BlockEmbedMaxDepth 设置内容块查询嵌入的最大嵌套深度，超过后渲染为内容块引用，小于 1 时使用 BlockEmbedDefaultMaxDepth。
+	BlockEmbedMaxDepth int
+	// FrontMatterRender 设置 HTML 渲染 Front Matter 的方式："code" 渲染为代码块，"none" 不渲染，"meta" 渲染为 <meta> 标签（解析失败时不渲染），默认为 "code"。
+	FrontMatterRender string
 	// GFMTaskListItemClass 作为 GFM 任务列表项类名，默认为 "vditor-task"。
 	GFMTaskListItemClass string
 	// VditorCodeBlockPreview 设置 Vditor 代码块是否需要渲染预览部分
@@ -116,6 +152,30 @@ type Options struct {
 	KeepParagraphBeginningSpace bool
 	// NetImgMarker 设置 Protyle 是否标记网络图片
 	ProtyleMarkNetImg bool
+	// FormatBulletMarker 设置格式化时无序列表使用的标记符，可选 "*"、"-" 和 "+"，为空时保留原文标记符。
+	// 相邻的两个无序列表会交替使用另一种标记符，避免合并为一个列表。
+	FormatBulletMarker string
+	// FormatEmphasisMarker 设置格式化时强调使用的标记符，可选 "*" 和 "_"，为空时保留原文标记符。
+	FormatEmphasisMarker string
+	// FormatStrongMarker 设置格式化时加粗使用的标记符，可选 "**" 和 "__"，为空时保留原文标记符。
+	FormatStrongMarker string
+	// FormatOrderedListNumbering 设置格式化时有序列表的编号方式，"sequential" 为递增编号，"one" 为所有列表项都使用列表起始序号。
+	FormatOrderedListNumbering string
+	// FormatCodeFenceChar 设置格式化时代码块围栏使用的字符，可选 "`" 和 "~"，为空时保留原文围栏。
+	FormatCodeFenceChar string
+	// FormatCodeFenceLen 设置格式化时代码块围栏的长度，最小为 3，为 0 时保留原文长度。代码块内容中出现同样的围栏时会自动加长。
+	FormatCodeFenceLen int
+	// FormatThematicBreak 设置格式化时分隔线的写法，比如 "---"、"***" 或者 "___"。
+	FormatThematicBreak string
+	// FormatHeadingStyle 设置格式化时标题的写法，"atx" 为 # 标题，"setext" 为下划线标题（仅支持一二级标题），为空时保留原文写法。
+	FormatHeadingStyle string
+	// FormatTablePadding 设置格式化时是否使用空格对齐表格单元格。
+	FormatTablePadding bool
+	// FormatProseWrap 设置格式化时段落的换行方式，"preserve" 为保留原文换行，"column" 为在 FormatProseWrapColumn 列处折行，
+	// "sentence" 为每个句子一行。折行仅发生在空格处，所以没有空格的中文段落不会被折行。
+	FormatProseWrap string
+	// FormatProseWrapColumn 设置 FormatProseWrap 为 "column" 时的折行列宽。
+	FormatProseWrapColumn int
 }
 
 func NewOptions() *Options {
@@ -127,6 +187,7 @@ func NewOptions() *Options {
 		CodeSyntaxHighlightInlineStyle: false,
 		CodeSyntaxHighlightLineNum:     false,
 		CodeSyntaxHighlightStyleName:   "github",
+		CodeSyntaxHighlightClassPrefix: "highlight-",
 		VditorWYSIWYG:                  false,
 		VditorIR:                       false,
 		VditorSV:                       false,
@@ -134,9 +195,16 @@ func NewOptions() *Options {
 		KramdownBlockIAL:               false,
 		ChineseParagraphBeginningSpace: false,
 		FixTermTypo:                    false,
+		SmartTypography:                false,
+		SmartTypographyLang:            "en",
+		SuperBlockClass:                "sb",
 		ToC:                            false,
+		ToCMinLevel:                    1,
+		ToCMaxLevel:                    6,
+		ToCTitleLevel:                  2,
 		HeadingID:                      false,
 		KramdownIALIDRenderName:        "id",
+		FrontMatterRender:              FrontMatterRenderCode,
 		GFMTaskListItemClass:           "vditor-task",
 		VditorCodeBlockPreview:         true,
 		VditorMathBlockPreview:         true,
@@ -146,6 +214,11 @@ func NewOptions() *Options {
 		NodeIndexStart:                 1,
 		ProtyleContenteditable:         true,
 		ProtyleMarkNetImg:              true,
+		FormatOrderedListNumbering:     "sequential",
+		FormatThematicBreak:            "---",
+		FormatTablePadding:             true,
+		FormatProseWrap:                "preserve",
+		FormatProseWrapColumn:          80,
 	}
 }
 
@@ -161,6 +234,9 @@ type BaseRenderer struct {
 	DisableTags         int                              // 标签嵌套计数器，用于判断不可能出现标签嵌套的情况，比如语法树允许图片节点包含链接节点，但是 HTML <img> 不能包含 <a>
 	FootnotesDefs       []*ast.Node                      // 脚注定义集
 	RenderingFootnotes  bool                             // 是否正在渲染脚注定义
+	headingIDs          map[*ast.Node]string             // 使用 Options.Slugger 生成的标题 ID
+	embedStack          []string                         // 内容块嵌入链，用于检测循环嵌入和限制嵌入深度
+	renderedCodeBlocks  map[*ast.Node]*renderedCodeBlock // 使用 Options.CodeBlockRenderers 渲染过的代码块
 }
 
 // NewBaseRenderer 构造一个 BaseRenderer。
@@ -173,11 +249,24 @@ func NewBaseRenderer(tree *parse.Tree, options *Options) *BaseRenderer {
 
 // Render 从根节点开始遍历并渲染。
 func (r *BaseRenderer) Render() (output []byte) {
+	return r.renderNodes(r.Tree.Root)
+}
+
+// renderNodes 依次从 nodes 开始遍历并渲染，nodes 需要在 r.Tree 上。
+func (r *BaseRenderer) renderNodes(nodes ...*ast.Node) (output []byte) {
 	r.LastOut = lex.ItemNewline
 	r.Writer = &bytes.Buffer{}
 	r.Writer.Grow(4096)
 
-	ast.Walk(r.Tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+	for _, node := range nodes {
+		r.walk(node)
+	}
+	output = r.Writer.Bytes()
+	return
+}
+
+func (r *BaseRenderer) walk(node *ast.Node) {
+	ast.Walk(node, func(n *ast.Node, entering bool) ast.WalkStatus {
 		extRender := r.ExtRendererFuncs[n.Type]
 		if nil != extRender {
 			output, status := extRender(n, entering)
@@ -194,9 +283,6 @@ func (r *BaseRenderer) Render() (output []byte) {
 		}
 		return render(n, entering)
 	})
-
-	output = r.Writer.Bytes()
-	return
 }
 
 func (r *BaseRenderer) renderDefault(n *ast.Node, entering bool) ast.WalkStatus {
@@ -401,6 +487,7 @@ type Heading struct {
 	Content  string     `json:"content"`
 	Level    int        `json:"level"`
 	Children []*Heading `json:"children"`
+	Node     *ast.Node  `json:"-"`
 	parent   *Heading
 }
 
@@ -409,12 +496,18 @@ func (r *BaseRenderer) renderToC(node *ast.Node, entering bool) ast.WalkStatus {
 		headings := r.headings()
 		length := len(headings)
 		r.WriteString("<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\">")
+		if "" != r.Options.ToCTitle {
+			level := headingLevel[r.tocTitleLevel() : r.tocTitleLevel()+1]
+			r.WriteString("<h" + level + ">")
+			r.Write(html.EscapeHTML([]byte(r.Options.ToCTitle)))
+			r.WriteString("</h" + level + ">")
+		}
 		if 0 < length {
-			r.WriteString("<ul>")
+			r.WriteString("<" + r.tocListTag() + ">")
 			for _, child := range headings {
 				r.renderToC0(child)
 			}
-			r.WriteString("</ul>")
+			r.WriteString("</" + r.tocListTag() + ">")
 		} else {
 			r.WriteString("[toc]<br>")
 		}
@@ -429,11 +522,11 @@ func (r *BaseRenderer) renderToC0(heading *Heading) {
 	r.WriteString(heading.Content)
 	r.Tag("/span", nil, false)
 	if 0 < len(heading.Children) {
-		r.WriteString("<ul>")
+		r.WriteString("<" + r.tocListTag() + ">")
 		for _, child := range heading.Children {
 			r.renderToC0(child)
 		}
-		r.WriteString("</ul>")
+		r.WriteString("</" + r.tocListTag() + ">")
 	}
 	r.WriteString("</li>")
 }
@@ -458,13 +551,14 @@ func (r *BaseRenderer) Tag(name string, attrs [][]string, selfclosing bool) {
 
 func (r *BaseRenderer) headings() (ret []*Heading) {
 	headings := r.Tree.Root.ChildrenByType(ast.NodeHeading)
+	marked := tocMarked(r.Tree.Root)
 	var tip *Heading
 	for _, heading := range headings {
-		if r.Tree.Root != heading.Parent {
+		if r.Tree.Root != heading.Parent || marked[heading] || r.tocExcluded(heading) {
 			continue
 		}
 
-		id := HeadingID(heading)
+		id := r.HeadingID(heading)
 		if r.Options.VditorWYSIWYG {
 			id = "wysiwyg-" + id
 		} else if r.Options.VditorIR {
@@ -487,6 +581,7 @@ func (r *BaseRenderer) headings() (ret []*Heading) {
 			HPath:   r.Tree.HPath,
 			Content: headingText(heading),
 			Level:   heading.HeadingLevel,
+			Node:    heading,
 		}
 
 		if nil == tip {
@@ -580,19 +675,36 @@ func (r *BaseRenderer) setextHeadingLen(node *ast.Node) (ret int) {
 	content = strings.ReplaceAll(content, editor.Caret, "")
 	lines := strings.Split(content, "\n")
 	lastLine := lines[len(lines)-1]
-	for _, r := range lastLine {
-		if utf8.RuneSelf <= r {
-			ret += 2
-		} else {
-			ret++
-		}
-	}
+	ret = util.DisplayWidth(lastLine)
 	if 0 == ret {
 		ret = 3
 	}
 	return
 }
 
+// tableCellWidth 返回表格单元格 cell 内容的显示宽度。单元格节点本身的 tokens 不会被渲染（比如 HTML 转换时的标签名 td），不计入宽度，
+// 单元格中的换行按照渲染后的 <br/> 和 <br /> 计算。
+func tableCellWidth(cell *ast.Node) int {
+	buf := &bytes.Buffer{}
+	for c := cell.FirstChild; nil != c; c = c.Next {
+		ast.Walk(c, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering {
+				return ast.WalkContinue
+			}
+			switch n.Type {
+			case ast.NodeHardBreak:
+				buf.WriteString("<br/>")
+			case ast.NodeBr:
+				buf.WriteString("<br />")
+			default:
+				buf.Write(n.Tokens)
+			}
+			return ast.WalkContinue
+		})
+	}
+	return util.BytesDisplayWidth(buf.Bytes())
+}
+
 func (r *BaseRenderer) renderListStyle(node *ast.Node, attrs *[][]string) {
 	if r.Options.RenderListStyle {
 		switch node.ListData.Typ {
@@ -0,0 +1,201 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/editor"
+	"github.com/88250/lute/util"
+)
+
+// Slugger 描述了标题 ID 的生成算法，通过 Options.Slugger 配置。
+//
+// 重复的 ID 会依次加上 -1、-2 等后缀。Options.Slugger 为 nil 时使用 Lute 原有的算法：非字母和数字的字符替换为 -，重复时追加 -。
+type Slugger interface {
+	// Slug 返回标题文本 text 对应的 ID。
+	Slug(text string) string
+}
+
+// NewSlugger 返回 name 对应的内置 Slugger，可选 "github"、"gitlab"、"hugo" 和 "ascii"，其他值返回 nil。
+func NewSlugger(name string) Slugger {
+	switch strings.ToLower(name) {
+	case "github":
+		return GitHubSlugger{}
+	case "gitlab":
+		return GitLabSlugger{}
+	case "hugo", "goldmark":
+		return HugoSlugger{}
+	case "ascii":
+		return ASCIISlugger{}
+	}
+	return nil
+}
+
+// GitHubSlugger 与 GitHub 渲染 Markdown 时生成的标题 ID 一致：转为小写，去掉字母、数字、下划线、连字符和空格以外的字符，空格替换为 -。
+type GitHubSlugger struct{}
+
+func (GitHubSlugger) Slug(text string) string {
+	buf := &strings.Builder{}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case ' ' == r:
+			buf.WriteByte('-')
+		case '-' == r || unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r) || unicode.Is(unicode.Pc, r):
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// GitLabSlugger 与 GitLab 生成的标题 ID 一致：在 GitHubSlugger 的基础上去掉首尾空白，并将连续的 - 合并为一个。
+type GitLabSlugger struct{}
+
+func (GitLabSlugger) Slug(text string) string {
+	return collapseHyphens(GitHubSlugger{}.Slug(strings.TrimSpace(text)), false)
+}
+
+// HugoSlugger 与 Hugo 使用的 Goldmark 默认生成的标题 ID 一致：只保留 ASCII 字母和数字并转为小写，空白、- 和 _ 替换为 -，结果为空时使用 heading。
+type HugoSlugger struct{}
+
+func (HugoSlugger) Slug(text string) string {
+	buf := &strings.Builder{}
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case 'A' <= r && 'Z' >= r:
+			buf.WriteRune(unicode.ToLower(r))
+		case 'a' <= r && 'z' >= r, '0' <= r && '9' >= r:
+			buf.WriteRune(r)
+		case ' ' == r, '\t' == r, '\n' == r, '-' == r, '_' == r:
+			buf.WriteByte('-')
+		}
+	}
+	if 0 == buf.Len() {
+		return "heading"
+	}
+	return buf.String()
+}
+
+// ASCIISlugger 将标题文本转写为 ASCII 后生成 ID：去掉拉丁字母的变音符号，汉字转为不带声调的拼音，
+// 其他字符替换为 -，连续的 - 合并为一个并去掉首尾的 -，结果为空时使用 heading。
+type ASCIISlugger struct{}
+
+func (ASCIISlugger) Slug(text string) string {
+	buf := &strings.Builder{}
+	for _, r := range strings.ToLower(transliterate(text)) {
+		if ('a' <= r && 'z' >= r) || ('0' <= r && '9' >= r) {
+			buf.WriteRune(r)
+		} else {
+			buf.WriteByte('-')
+		}
+	}
+	if ret := collapseHyphens(buf.String(), true); "" != ret {
+		return ret
+	}
+	return "heading"
+}
+
+// collapseHyphens 将 s 中连续的 - 合并为一个，trim 为 true 时去掉首尾的 -。
+func collapseHyphens(s string, trim bool) string {
+	for strings.Contains(s, "--") {
+		s = strings.ReplaceAll(s, "--", "-")
+	}
+	if trim {
+		s = strings.Trim(s, "-")
+	}
+	return s
+}
+
+// HeadingID 返回标题节点 heading 的 ID。设置了 Options.Slugger 时使用 Slugger 生成，否则与包级函数 HeadingID 一致。
+func (r *BaseRenderer) HeadingID(heading *ast.Node) string {
+	if nil == r.Options.Slugger {
+		return HeadingID(heading)
+	}
+
+	if nil == r.headingIDs {
+		r.headingIDs = SlugHeadings(r.Tree.Root, r.Options.Slugger)
+	}
+	if id, ok := r.headingIDs[heading]; ok {
+		return id
+	}
+	// 不在渲染树上的标题，比如 Protyle 中嵌入的块
+	return r.Options.Slugger.Slug(headingSlugText(heading))
+}
+
+// HeadingIDs 返回 root 下所有标题的 ID，与使用 options 渲染时生成的标题 ID 一致：设置了 options.Slugger 时使用 SlugHeadings 生成，
+// 否则使用包级函数 HeadingID 生成。options 为 nil 时使用默认渲染选项。
+func HeadingIDs(root *ast.Node, options *Options) (ret map[*ast.Node]string) {
+	if nil != options && nil != options.Slugger {
+		return SlugHeadings(root, options.Slugger)
+	}
+
+	ret = map[*ast.Node]string{}
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeHeading == n.Type {
+			ret[n] = HeadingID(n)
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// SlugHeadings 使用 slugger 为 root 下的所有标题生成 ID，重复的 ID 依次加上 -1、-2 等后缀。
+//
+// 使用 {#id} 自定义的 ID 保持原样，生成的 ID 去重时会避开文档中所有的自定义 ID。
+func SlugHeadings(root *ast.Node, slugger Slugger) (ret map[*ast.Node]string) {
+	ret = map[*ast.Node]string{}
+	occurs := map[string]bool{}
+	var headings []*ast.Node
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering || ast.NodeHeading != n.Type {
+			return ast.WalkContinue
+		}
+
+		headings = append(headings, n)
+		if id := customHeadingID(n); "" != id {
+			occurs[id] = true
+			ret[n] = id
+		}
+		return ast.WalkContinue
+	})
+
+	for _, heading := range headings {
+		if _, ok := ret[heading]; ok {
+			continue
+		}
+
+		slug := slugger.Slug(headingSlugText(heading))
+		id := slug
+		for i := 1; occurs[id]; i++ {
+			id = slug + "-" + strconv.Itoa(i)
+		}
+		occurs[id] = true
+		ret[heading] = id
+	}
+	return
+}
+
+// customHeadingID 返回标题 heading 使用 {#id} 自定义的 ID，没有自定义时返回空字符串。
+func customHeadingID(heading *ast.Node) string {
+	headingID := heading.ChildByType(ast.NodeHeadingID)
+	if nil == headingID {
+		return ""
+	}
+	return strings.TrimLeft(strings.ReplaceAll(util.BytesToStr(headingID.Tokens), editor.Caret, ""), "#")
+}
+
+// headingSlugText 返回用于生成 ID 的标题文本，包括代码和链接文本。
+func headingSlugText(heading *ast.Node) string {
+	return strings.ReplaceAll(heading.Content(), editor.Caret, "")
+}
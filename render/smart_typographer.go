@@ -0,0 +1,206 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/editor"
+)
+
+// smartQuotes 描述了一种语言使用的引号。
+type smartQuotes struct {
+	doubleOpen, doubleClose string // 双引号
+	singleOpen, singleClose string // 单引号
+	apostrophe              string // 撇号
+}
+
+var (
+	englishQuotes = &smartQuotes{"“", "”", "‘", "’", "’"}
+	germanQuotes  = &smartQuotes{"„", "“", "‚", "‘", "’"}
+	frenchQuotes  = &smartQuotes{"« ", " »", "‹ ", " ›", "’"}
+	cjkQuotes     = &smartQuotes{"「", "」", "『", "』", "’"}
+)
+
+// quotesOf 返回语言 lang 使用的引号，未知语言使用英文引号。
+func quotesOf(lang string) *smartQuotes {
+	switch strings.ToLower(lang) {
+	case "de", "de-de", "de-at", "de-ch":
+		return germanQuotes
+	case "fr", "fr-fr", "fr-ca", "fr-ch":
+		return frenchQuotes
+	case "cjk", "ja", "ja-jp", "zh-tw", "zh-hk", "zh-hant":
+		return cjkQuotes
+	}
+	return englishQuotes
+}
+
+// SmartTypography 对文本节点 node 的 tokens 进行印刷排版美化：直引号转换为 Options.SmartTypographyLang 对应语言的弯引号，
+// -- 和 --- 分别转换为短破折号 – 和长破折号 —，... 转换为省略号 …。
+//
+// 引号的开闭根据前后字符判断，文本节点开头和结尾的前后字符取自相邻的行级节点，前面是 HTML 开始标签时按照新词开始处理。
+// 代码、公式、HTML 和链接地址等不是文本节点，所以不会被转换，使用 \" 和 \' 转义的引号也会保留原样。
+// 自动链接以及文本和地址相同的链接，其文本就是链接地址，也不会被转换。
+func (r *BaseRenderer) SmartTypography(node *ast.Node, tokens []byte) []byte {
+	if !bytes.ContainsAny(tokens, "\"'-.") || isURLLinkText(node) {
+		return tokens
+	}
+
+	quotes := quotesOf(r.Options.SmartTypographyLang)
+	runes := []rune(string(tokens))
+	length := len(runes)
+	prev, _ := utf8.DecodeLastRuneInString(adjacentText(node, true))
+	unclosed := map[rune]int{}
+	buf := &bytes.Buffer{}
+	for i := 0; i < length; i++ {
+		c := runes[i]
+		switch c {
+		case '.':
+			if i+2 < length && '.' == runes[i+1] && '.' == runes[i+2] {
+				buf.WriteRune('…')
+				prev = '…'
+				i += 2
+				continue
+			}
+		case '-':
+			if i+2 < length && '-' == runes[i+1] && '-' == runes[i+2] {
+				buf.WriteRune('—')
+				prev = '—'
+				i += 2
+				continue
+			}
+			if i+1 < length && '-' == runes[i+1] {
+				buf.WriteRune('–')
+				prev = '–'
+				i++
+				continue
+			}
+		case '"', '\'':
+			next, after := utf8.RuneError, utf8.RuneError
+			if i+1 < length {
+				next = runes[i+1]
+				if i+2 < length {
+					after = runes[i+2]
+				}
+			} else {
+				next, _ = utf8.DecodeRuneInString(adjacentText(node, false))
+			}
+			quote, depth := quotes.of(c, prev, next, after, wordAt(runes, i+1), 0 < unclosed[c])
+			unclosed[c] += depth
+			buf.WriteString(quote)
+			prev, _ = utf8.DecodeLastRuneInString(quote)
+			continue
+		}
+		if editor.CaretRune != c {
+			prev = c
+		}
+		buf.WriteRune(c)
+	}
+	return buf.Bytes()
+}
+
+// of 根据引号 c 前一个字符 prev、后一个字符 next、再后一个字符 after 以及紧跟的单词 word 返回转换后的引号，unclosed 表示前面是否有未闭合的同类引号。
+// depth 为 1 表示开引号，为 -1 表示闭引号，撇号和保留原样的直引号为 0。
+func (quotes *smartQuotes) of(c, prev, next, after rune, word string, unclosed bool) (ret string, depth int) {
+	opening := utf8.RuneError == prev || unicode.IsSpace(prev) || strings.ContainsRune("([{<-–—/"+quotes.doubleOpen+quotes.singleOpen, prev)
+	if isCJK(prev) {
+		// 中日韩文字之间没有空格，只能根据是否有未闭合的引号判断
+		opening = !unclosed
+	}
+	if '"' == c {
+		if opening {
+			if utf8.RuneError == next || unicode.IsSpace(next) {
+				return "\"", 0
+			}
+			return quotes.doubleOpen, 1
+		}
+		return quotes.doubleClose, -1
+	}
+
+	if opening {
+		if unicode.IsDigit(next) && unicode.IsDigit(after) {
+			return quotes.apostrophe, 0 // '90s
+		}
+		if leadingElisions[strings.ToLower(word)] {
+			return quotes.apostrophe, 0 // 'tis
+		}
+		if utf8.RuneError == next || unicode.IsSpace(next) {
+			return "'", 0
+		}
+		return quotes.singleOpen, 1
+	}
+	if !isCJK(prev) && !isCJK(next) && (unicode.IsLetter(prev) || unicode.IsDigit(prev)) && unicode.IsLetter(next) {
+		return quotes.apostrophe, 0 // don't
+	}
+	return quotes.singleClose, -1
+}
+
+// leadingElisions 是以撇号开头的省略词（不含撇号），它们开头的单引号是撇号而不是开引号。
+var leadingElisions = map[string]bool{
+	"tis": true, "twas": true, "twere": true, "twill": true, "em": true, "cause": true, "til": true, "bout": true,
+}
+
+// wordAt 返回 runes 从 i 开始的由字母组成的单词。
+func wordAt(runes []rune, i int) string {
+	j := i
+	for j < len(runes) && unicode.IsLetter(runes[j]) {
+		j++
+	}
+	return string(runes[i:j])
+}
+
+// isURLLinkText 判断 node 是否是自动链接的文本或者文本和地址相同的链接文本。
+func isURLLinkText(node *ast.Node) bool {
+	if ast.NodeLinkText != node.Type || nil == node.Parent || ast.NodeLink != node.Parent.Type {
+		return false
+	}
+	if 2 == node.Parent.LinkType {
+		return true
+	}
+	dest := node.Parent.ChildByType(ast.NodeLinkDest)
+	return nil != dest && bytes.Equal(node.Tokens, dest.Tokens)
+}
+
+// adjacentText 返回与行级节点 node 相邻的前一个（previous 为 true）或者后一个节点的文本，遇到块级节点时停止查找。
+func adjacentText(node *ast.Node, previous bool) string {
+	for n := node; nil != n && !n.IsBlock(); n = n.Parent {
+		for s := adjacent(n, previous); nil != s; s = adjacent(s, previous) {
+			switch s.Type {
+			case ast.NodeSoftBreak, ast.NodeHardBreak, ast.NodeBr:
+				return " "
+			case ast.NodeKramdownSpanIAL, ast.NodeOpenBracket, ast.NodeCloseBracket, ast.NodeOpenParen, ast.NodeCloseParen, ast.NodeLinkDest,
+				ast.NodeLinkSpace, ast.NodeLinkTitle, ast.NodeBang:
+				continue // 链接标记符不是可见文本，链接文本的前后字符取自链接外
+			case ast.NodeInlineHTML:
+				if previous && !bytes.HasPrefix(s.Tokens, []byte("</")) {
+					return " " // 开始标签之后按照新词开始处理
+				}
+				continue
+			}
+			if text := strings.ReplaceAll(s.Content(), editor.Caret, ""); "" != text {
+				return text
+			}
+			return "a" // 图片等没有文本的节点按照单词处理
+		}
+	}
+	return ""
+}
+
+func adjacent(n *ast.Node, previous bool) *ast.Node {
+	if previous {
+		return n.Previous
+	}
+	return n.Next
+}
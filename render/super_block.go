@@ -0,0 +1,75 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/88250/lute/ast"
+)
+
+const (
+	// SuperBlockLayoutRow 为超级块横向布局，子块并排为多列。
+	SuperBlockLayoutRow = "row"
+	// SuperBlockLayoutCol 为超级块纵向布局，子块上下堆叠。
+	SuperBlockLayoutCol = "col"
+)
+
+// SuperBlockLayout 返回超级块 superBlock 的布局，布局标记为空或者无法识别时返回 SuperBlockLayoutRow。
+func SuperBlockLayout(superBlock *ast.Node) string {
+	if layout := superBlock.ChildByType(ast.NodeSuperBlockLayoutMarker); nil != layout && SuperBlockLayoutCol == layout.TokensStr() {
+		return SuperBlockLayoutCol
+	}
+	return SuperBlockLayoutRow
+}
+
+// superBlockChildren 返回超级块 superBlock 下除标记符以外的子块。
+func superBlockChildren(superBlock *ast.Node) (ret []*ast.Node) {
+	for c := superBlock.FirstChild; nil != c; c = c.Next {
+		switch c.Type {
+		case ast.NodeSuperBlockOpenMarker, ast.NodeSuperBlockLayoutMarker, ast.NodeSuperBlockCloseMarker, ast.NodeKramdownBlockIAL:
+			continue
+		}
+		ret = append(ret, c)
+	}
+	return
+}
+
+// superBlockAttrs 返回超级块容器标签的属性，包括 class、data-sb-layout 以及启用 Options.SuperBlockInlineStyle 时的布局样式。
+//
+// 横向布局使用 grid 将子块等分为多列，纵向布局使用 flex 上下堆叠，嵌套的超级块作为子块自然参与父容器的布局。
+func (r *BaseRenderer) superBlockAttrs(layout string) (ret [][]string) {
+	class := r.Options.SuperBlockClass
+	if "" != class {
+		ret = append(ret, []string{"class", class + " " + class + "--" + layout})
+	}
+	ret = append(ret, []string{"data-sb-layout", layout})
+	if r.Options.SuperBlockInlineStyle {
+		ret = append(ret, []string{"style", superBlockStyle(layout)})
+	}
+	return
+}
+
+func superBlockStyle(layout string) string {
+	if SuperBlockLayoutCol == layout {
+		return "display: flex; flex-direction: column; gap: 1em"
+	}
+	return "display: grid; grid-auto-flow: column; grid-auto-columns: minmax(0, 1fr); gap: 1em"
+}
+
+// superBlockCellWidth 返回横向布局的超级块导出为表格时每个单元格的宽度百分比。
+func superBlockCellWidth(count int) string {
+	if 1 > count {
+		count = 1
+	}
+	return strconv.FormatFloat(math.Floor(10000/float64(count))/100, 'f', -1, 64) + "%"
+}
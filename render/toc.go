@@ -0,0 +1,117 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package render
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// Markdown 目录列表的起止标记，ToCMarkdown 打开时 FormatRenderer 会刷新这两个注释之间的目录列表。
+const (
+	ToCMarkdownStart = "<!-- toc -->"
+	ToCMarkdownEnd   = "<!-- tocstop -->"
+)
+
+// Outline 返回 tree 的大纲，即目录使用的标题树。标题的过滤规则（ToCMinLevel、ToCMaxLevel 和 ToCExcludeClass）和 ID 生成算法（Slugger）由 options 决定。
+func Outline(tree *parse.Tree, options *Options) []*Heading {
+	return NewBaseRenderer(tree, options).headings()
+}
+
+// tocExcluded 判断标题 heading 是否不包含在目录中。
+func (r *BaseRenderer) tocExcluded(heading *ast.Node) bool {
+	if heading.HeadingLevel < r.Options.ToCMinLevel {
+		return true
+	}
+	if 0 < r.Options.ToCMaxLevel && heading.HeadingLevel > r.Options.ToCMaxLevel {
+		return true
+	}
+	if "" != r.Options.ToCExcludeClass {
+		for _, class := range strings.Fields(heading.IALAttr("class")) {
+			if r.Options.ToCExcludeClass == class {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *BaseRenderer) tocListTag() string {
+	if r.Options.ToCOrdered {
+		return "ol"
+	}
+	return "ul"
+}
+
+func (r *BaseRenderer) tocTitleLevel() int {
+	if 1 > r.Options.ToCTitleLevel || 6 < r.Options.ToCTitleLevel {
+		return 2
+	}
+	return r.Options.ToCTitleLevel
+}
+
+// markdownToC 返回包括起止标记在内的 Markdown 目录。
+func (r *BaseRenderer) markdownToC() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(ToCMarkdownStart + "\n\n")
+	if "" != r.Options.ToCTitle {
+		buf.WriteString(strings.Repeat("#", r.tocTitleLevel()) + " " + r.Options.ToCTitle + "\n\n")
+	}
+	if headings := r.headings(); 0 < len(headings) {
+		r.markdownToC0(buf, headings, "")
+		buf.WriteString("\n")
+	}
+	buf.WriteString(ToCMarkdownEnd + "\n")
+	return buf.String()
+}
+
+func (r *BaseRenderer) markdownToC0(buf *bytes.Buffer, headings []*Heading, indent string) {
+	for i, heading := range headings {
+		marker := "- "
+		if r.Options.ToCOrdered {
+			marker = strconv.Itoa(i+1) + ". "
+		}
+		buf.WriteString(indent + marker + "[" + escapeLinkText(headingSlugText(heading.Node)) + "](#" + heading.ID + ")\n")
+		r.markdownToC0(buf, heading.Children, indent+strings.Repeat(" ", len(marker)))
+	}
+}
+
+func escapeLinkText(text string) string {
+	return strings.NewReplacer("\\", "\\\\", "[", "\\[", "]", "\\]").Replace(strings.TrimSpace(text))
+}
+
+// tocMarked 返回 root 中 Markdown 目录起止标记之间的块，这些块是生成的目录，不参与目录生成。没有结束标记时不标记。
+func tocMarked(root *ast.Node) (ret map[*ast.Node]bool) {
+	ret = map[*ast.Node]bool{}
+	var blocks []*ast.Node
+	start := false
+	for n := root.FirstChild; nil != n; n = n.Next {
+		if isToCMarkdownMarker(n, ToCMarkdownStart) {
+			start, blocks = true, nil
+		} else if start && isToCMarkdownMarker(n, ToCMarkdownEnd) {
+			for _, block := range blocks {
+				ret[block] = true
+			}
+			start = false
+		} else if start {
+			blocks = append(blocks, n)
+		}
+	}
+	return
+}
+
+func isToCMarkdownMarker(n *ast.Node, marker string) bool {
+	return ast.NodeHTMLBlock == n.Type && marker == strings.TrimSpace(string(n.Tokens))
+}
@@ -0,0 +1,56 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build !javascript
+// +build !javascript
+
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterate 将 text 转写为 ASCII：去掉变音符号，汉字转为拼音并使用空格分隔，无法转写的字符保持原样。
+func transliterate(text string) string {
+	buf := &strings.Builder{}
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case 0x4E00 <= r && 0x9FFF >= r:
+			i := int(r-0x4E00) * 2
+			syllable := strings.IndexByte(pinyinAlphabet, pinyinTable[i])<<6 | strings.IndexByte(pinyinAlphabet, pinyinTable[i+1])
+			if 0 < syllable {
+				buf.WriteString(" " + pinyinSyllables[syllable] + " ")
+				continue
+			}
+		case 'ß' == r:
+			buf.WriteString("ss")
+			continue
+		case 'æ' == r:
+			buf.WriteString("ae")
+			continue
+		case 'ø' == r:
+			buf.WriteString("o")
+			continue
+		case 'đ' == r:
+			buf.WriteString("d")
+			continue
+		case 'ł' == r:
+			buf.WriteString("l")
+			continue
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
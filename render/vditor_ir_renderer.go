@@ -266,7 +266,7 @@ func (r *VditorIRRenderer) renderSubCloseMarker(node *ast.Node, entering bool) a
 func (r *VditorIRRenderer) renderYamlFrontMatterCloseMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Tag("span", [][]string{{"data-type", "yaml-front-matter-close-marker"}}, false)
-		r.Write(parse.YamlFrontMatterMarker)
+		r.Write(parse.FrontMatterMarker(node))
 		r.Tag("/span", nil, false)
 	}
 	return ast.WalkContinue
@@ -292,7 +292,7 @@ func (r *VditorIRRenderer) renderYamlFrontMatterContent(node *ast.Node, entering
 func (r *VditorIRRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Tag("span", [][]string{{"data-type", "yaml-front-matter-open-marker"}}, false)
-		r.Write(parse.YamlFrontMatterMarker)
+		r.Write(parse.FrontMatterMarker(node))
 		r.Tag("/span", nil, false)
 	}
 	return ast.WalkContinue
@@ -495,6 +495,11 @@ func (r *VditorIRRenderer) renderCodeBlockCode(node *ast.Node, entering bool) as
 
 	if r.Options.VditorCodeBlockPreview {
 		r.Tag("pre", [][]string{{"class", "vditor-ir__preview"}, {"data-render", "2"}}, false)
+		if rendered, ok := r.renderCodeBlockByLanguage(node.Parent); ok {
+			r.WriteString(rendered)
+			r.WriteString("</pre>")
+			return ast.WalkContinue
+		}
 		preDiv := NoHighlight(language)
 		if preDiv {
 			r.Tag("div", attrs, false)
@@ -827,7 +832,11 @@ func (r *VditorIRRenderer) renderLinkText(node *ast.Node, entering bool) ast.Wal
 				r.Tag("span", [][]string{{"class", "vditor-ir__link"}}, false)
 			}
 		}
-		r.Write(node.Tokens)
+		tokens := node.Tokens
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
+		r.Write(tokens)
 		r.Tag("/span", nil, false)
 	}
 	return ast.WalkContinue
@@ -1090,6 +1099,9 @@ func (r *VditorIRRenderer) renderText(node *ast.Node, entering bool) ast.WalkSta
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 
 		// 有的场景需要零宽空格撑起，但如果有其他文本内容的话需要把零宽空格删掉
 		if !bytes.EqualFold(tokens, []byte(editor.Caret+editor.Zwsp)) {
@@ -1292,7 +1304,7 @@ func (r *VditorIRRenderer) renderHeading(node *ast.Node, entering bool) ast.Walk
 			id = string(headingID.Tokens)
 		}
 		if "" == id {
-			id = HeadingID(node)
+			id = r.HeadingID(node)
 		}
 		r.WriteString(" id=\"ir-" + id + "\"")
 		if !node.HeadingSetext {
@@ -1305,7 +1317,7 @@ func (r *VditorIRRenderer) renderHeading(node *ast.Node, entering bool) ast.Walk
 			}
 		}
 		if r.Options.HeadingAnchor {
-			id := HeadingID(node)
+			id := r.HeadingID(node)
 			r.Tag("a", [][]string{{"id", "vditorAnchor-" + id}, {"class", "vditor-anchor"}, {"href", "#" + id}}, false)
 			r.WriteString(`<svg viewBox="0 0 16 16" version="1.1" width="16" height="16"><path fill-rule="evenodd" d="M4 9h1v1H4c-1.5 0-3-1.69-3-3.5S2.55 3 4 3h4c1.45 0 3 1.69 3 3.5 0 1.41-.91 2.72-2 3.25V8.59c.58-.45 1-1.27 1-2.09C10 5.22 8.98 4 8 4H4c-.98 0-2 1.22-2 2.5S3 9 4 9zm9-3h-1v1h1c1 0 2 1.22 2 2.5S13.98 12 13 12H9c-.98 0-2-1.22-2-2.5 0-.83.42-1.64 1-2.09V6.25c-1.09.53-2 1.84-2 3.25C6 11.31 7.55 13 9 13h4c1.45 0 3-1.69 3-3.5S14.5 6 13 6z"></path></svg>`)
 			r.Tag("/a", nil, false)
@@ -315,7 +315,7 @@ func (r *VditorSVRenderer) renderYamlFrontMatterCloseMarker(node *ast.Node, ente
 	if entering {
 		r.Newline()
 		r.Tag("span", [][]string{{"data-type", "yaml-front-matter-close-marker"}, {"class", "vditor-sv__marker"}}, false)
-		r.Write(parse.YamlFrontMatterMarker)
+		r.Write(parse.FrontMatterMarker(node))
 		r.Tag("/span", nil, false)
 		r.Newline()
 		r.Write(NewlineSV)
@@ -338,7 +338,7 @@ func (r *VditorSVRenderer) renderYamlFrontMatterContent(node *ast.Node, entering
 func (r *VditorSVRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
 		r.Tag("span", [][]string{{"data-type", "yaml-front-matter-open-marker"}, {"class", "vditor-sv__marker"}}, false)
-		r.Write(parse.YamlFrontMatterMarker)
+		r.Write(parse.FrontMatterMarker(node))
 		r.Tag("/span", nil, false)
 		r.Newline()
 	}
@@ -729,7 +729,11 @@ func (r *VditorSVRenderer) renderLinkText(node *ast.Node, entering bool) ast.Wal
 				r.Tag("span", [][]string{{"class", "vditor-sv__marker--bracket"}, {"data-type", "link-text"}}, false)
 			}
 		}
-		r.Write(node.Tokens)
+		tokens := node.Tokens
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
+		r.Write(tokens)
 		r.Tag("/span", nil, false)
 	}
 	return ast.WalkContinue
@@ -910,6 +914,9 @@ func (r *VditorSVRenderer) renderText(node *ast.Node, entering bool) ast.WalkSta
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 
 		r.Tag("span", [][]string{{"data-type", "text"}}, false)
 		tokens = bytes.TrimRight(tokens, "\n")
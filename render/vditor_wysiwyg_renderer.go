@@ -263,7 +263,11 @@ func (r *VditorRenderer) renderYamlFrontMatterOpenMarker(node *ast.Node, enterin
 
 func (r *VditorRenderer) renderYamlFrontMatter(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.WriteString(`<div class="vditor-wysiwyg__block" data-type="yaml-front-matter" data-block="0">`)
+		attrs := [][]string{{"class", "vditor-wysiwyg__block"}, {"data-type", "yaml-front-matter"}, {"data-block", "0"}}
+		if marker := parse.FrontMatterMarker(node); 0 < len(marker) && !bytes.Equal(marker, parse.YamlFrontMatterMarker) {
+			attrs = append(attrs, []string{"data-marker", string(marker)})
+		}
+		r.Tag("div", attrs, false)
 	} else {
 		r.WriteString("</div>")
 	}
@@ -608,7 +612,11 @@ func (r *VditorRenderer) renderLinkSpace(node *ast.Node, entering bool) ast.Walk
 
 func (r *VditorRenderer) renderLinkText(node *ast.Node, entering bool) ast.WalkStatus {
 	if entering {
-		r.Write(node.Tokens)
+		tokens := node.Tokens
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
+		r.Write(tokens)
 	}
 	return ast.WalkContinue
 }
@@ -869,6 +877,9 @@ func (r *VditorRenderer) renderText(node *ast.Node, entering bool) ast.WalkStatu
 		if r.Options.FixTermTypo {
 			tokens = r.FixTermTypo(tokens)
 		}
+		if r.Options.SmartTypography {
+			tokens = r.SmartTypography(node, tokens)
+		}
 
 		tokens = bytes.TrimRight(tokens, "\n")
 		// 有的场景需要零宽空格撑起，但如果有其他文本内容的话需要把零宽空格删掉
@@ -1012,7 +1023,7 @@ func (r *VditorRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkSt
 			r.WriteString(" data-id=\"" + id + "\"")
 		}
 		if "" == id {
-			id = HeadingID(node)
+			id = r.HeadingID(node)
 		}
 		r.WriteString(" id=\"wysiwyg-" + id + "\"")
 		if !node.HeadingSetext {
@@ -1025,7 +1036,7 @@ func (r *VditorRenderer) renderHeading(node *ast.Node, entering bool) ast.WalkSt
 			}
 		}
 		if r.Options.HeadingAnchor {
-			id := HeadingID(node)
+			id := r.HeadingID(node)
 			r.Tag("a", [][]string{{"id", "vditorAnchor-" + id}, {"class", "vditor-anchor"}, {"href", "#" + id}}, false)
 			r.WriteString(`<svg viewBox="0 0 16 16" version="1.1" width="16" height="16"><path fill-rule="evenodd" d="M4 9h1v1H4c-1.5 0-3-1.69-3-3.5S2.55 3 4 3h4c1.45 0 3 1.69 3 3.5 0 1.41-.91 2.72-2 3.25V8.59c.58-.45 1-1.27 1-2.09C10 5.22 8.98 4 8 4H4c-.98 0-2 1.22-2 2.5S3 9 4 9zm9-3h-1v1h1c1 0 2 1.22 2 2.5S13.98 12 13 12H9c-.98 0-2-1.22-2-2.5 0-.83.42-1.64 1-2.09V6.25c-1.09.53-2 1.84-2 3.25C6 11.31 7.55 13 9 13h4c1.45 0 3-1.69 3-3.5S14.5 6 13 6z"></path></svg>`)
 			r.Tag("/a", nil, false)
@@ -1206,6 +1217,11 @@ func (r *VditorRenderer) renderCodeBlockCode(node *ast.Node, entering bool) ast.
 
 	if r.Options.VditorCodeBlockPreview {
 		r.Tag("pre", [][]string{{"class", "vditor-wysiwyg__preview"}, {"data-render", "2"}}, false)
+		if rendered, ok := r.renderCodeBlockByLanguage(node.Parent); ok {
+			r.WriteString(rendered)
+			r.WriteString("</pre>")
+			return ast.WalkContinue
+		}
 		preDiv := NoHighlight(language)
 		if preDiv {
 			r.Tag("div", attrs, false)
@@ -0,0 +1,30 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build !javascript
+// +build !javascript
+
+package tableop
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func localeCompare(lang string) func(a, b string) int {
+	tag := language.Und
+	if "" != lang {
+		if t, err := language.Parse(lang); nil == err {
+			tag = t
+		}
+	}
+	collator := collate.New(tag, collate.Loose, collate.Numeric)
+	return collator.CompareString
+}
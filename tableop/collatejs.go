@@ -0,0 +1,27 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build javascript
+// +build javascript
+
+package tableop
+
+import "strings"
+
+// 因为引入 golang.org/x/text/collate 后打包体积太大，所以这里仅做忽略大小写的比较
+
+func localeCompare(lang string) func(a, b string) int {
+	return func(a, b string) int {
+		if ret := strings.Compare(strings.ToLower(a), strings.ToLower(b)); 0 != ret {
+			return ret
+		}
+		return strings.Compare(a, b)
+	}
+}
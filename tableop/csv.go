@@ -0,0 +1,126 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package tableop
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// ToCSV 将表格转换为以 comma 分隔的 CSV 文本，comma 为 '\t' 时即为 TSV。单元格内容使用 Markdown 表示，表头行为第一条记录。
+func ToCSV(table *ast.Node, comma rune) (ret string, err error) {
+	rows, err := checkTable(table)
+	if nil != err {
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	writer.Comma = comma
+	for _, row := range rows {
+		var record []string
+		for _, cell := range Cells(row) {
+			record = append(record, cellMarkdown(cell))
+		}
+		if err = writer.Write(record); nil != err {
+			return
+		}
+	}
+	writer.Flush()
+	if err = writer.Error(); nil != err {
+		return
+	}
+	ret = buf.String()
+	return
+}
+
+// FromCSV 将以 comma 分隔的 CSV 文本转换为表格节点，第一条记录作为表头行，记录字段数不一致时使用空单元格补齐。
+// 单元格内容按 Markdown 行级元素解析，options 为 nil 时使用默认解析选项。
+func FromCSV(data string, comma rune, options *parse.Options) (ret *ast.Node, err error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = '\t' == comma
+	records, err := reader.ReadAll()
+	if nil != err {
+		return
+	}
+	if 1 > len(records) {
+		return nil, errors.New("empty csv")
+	}
+	if nil == options {
+		options = parse.NewOptions()
+	}
+
+	cols := 0
+	for _, record := range records {
+		if cols < len(record) {
+			cols = len(record)
+		}
+	}
+
+	ret = &ast.Node{Type: ast.NodeTable}
+	head := &ast.Node{Type: ast.NodeTableHead}
+	ret.AppendChild(head)
+	for i, record := range records {
+		row := &ast.Node{Type: ast.NodeTableRow}
+		for j := 0; j < cols; j++ {
+			cell := &ast.Node{Type: ast.NodeTableCell}
+			if j < len(record) {
+				appendCellContent(cell, record[j], options)
+			}
+			row.AppendChild(cell)
+		}
+		if 0 == i {
+			head.AppendChild(row)
+		} else {
+			ret.AppendChild(row)
+		}
+	}
+	syncAligns(ret, make([]int, cols))
+	return
+}
+
+// cellMarkdown 返回单元格内容的 Markdown，单元格中转义的 \| 会还原为 |。
+func cellMarkdown(cell *ast.Node) string {
+	tree := &parse.Tree{Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: parse.NewOptions()}}
+	tree.Context.Tree = tree
+	paragraph := &ast.Node{Type: ast.NodeParagraph}
+	for c := cell.FirstChild; nil != c; c = c.Next {
+		paragraph.AppendChild(c.Clone())
+	}
+	tree.Root.AppendChild(paragraph)
+	renderer := render.NewFormatRenderer(tree, render.NewOptions())
+	return strings.ReplaceAll(strings.TrimSpace(string(renderer.Render())), "\\|", "|")
+}
+
+func appendCellContent(cell *ast.Node, markdown string, options *parse.Options) {
+	markdown = strings.TrimSpace(strings.ReplaceAll(markdown, "\n", " "))
+	if "" == markdown {
+		return
+	}
+
+	// 表格单元格中的 | 需要转义，否则会被解析为单元格分隔符
+	markdown = strings.ReplaceAll(markdown, "|", "\\|")
+	tree := parse.Inline("", []byte(markdown), options)
+	paragraph := tree.Root.FirstChild
+	for c := paragraph.FirstChild; nil != c; {
+		next := c.Next
+		cell.AppendChild(c)
+		c = next
+	}
+}
@@ -0,0 +1,113 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package tableop
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/88250/lute/ast"
+)
+
+// Collation 描述了排序时单元格内容的比较方式。
+type Collation int
+
+const (
+	CollationLexical Collation = iota // 按字节序比较
+	CollationNumeric                  // 按数值比较，不能解析为数值的单元格排在数值之后并按字节序比较
+	CollationLocale                   // 按 SortOptions.Language 指定语言的排序规则比较
+)
+
+// SortOptions 描述了表格排序选项。
+type SortOptions struct {
+	Collation Collation // 比较方式
+	Desc      bool      // 是否降序
+	Language  string    // CollationLocale 使用的 BCP 47 语言标签，比如 zh、en-US，为空时使用 und
+}
+
+// Sort 按下标为 index 的列对表格数据行进行稳定排序，表头行不参与排序。
+func Sort(table *ast.Node, index int, options *SortOptions) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+	if err = checkColumn(table, index); nil != err {
+		return err
+	}
+	if nil == options {
+		options = &SortOptions{}
+	}
+
+	body := rows[1:]
+	if 2 > len(body) {
+		return nil
+	}
+	keys := map[*ast.Node]string{}
+	for _, row := range body {
+		keys[row] = strings.TrimSpace(Cells(row)[index].Content())
+	}
+
+	var compare func(a, b string) int
+	switch options.Collation {
+	case CollationNumeric:
+		compare = compareNumeric
+	case CollationLocale:
+		compare = localeCompare(options.Language)
+	default:
+		compare = strings.Compare
+	}
+
+	sorted := append([]*ast.Node{}, body...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ret := compare(keys[sorted[i]], keys[sorted[j]])
+		if options.Desc {
+			return 0 < ret
+		}
+		return 0 > ret
+	})
+	for _, row := range sorted {
+		table.AppendChild(row)
+	}
+	return nil
+}
+
+// compareNumeric 按数值比较 a 和 b，数值可以包含千分位逗号以及货币符号、百分号等前后缀。
+func compareNumeric(a, b string) int {
+	x, xok := parseNumber(a)
+	y, yok := parseNumber(b)
+	switch {
+	case xok && yok:
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	case xok:
+		return -1
+	case yok:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func parseNumber(s string) (ret float64, ok bool) {
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.TrimLeft(s, "$¥€£")
+	s = strings.TrimRight(s, "%")
+	s = strings.TrimSpace(s)
+	if "" == s {
+		return
+	}
+	ret, err := strconv.ParseFloat(s, 64)
+	return ret, nil == err
+}
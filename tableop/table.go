@@ -0,0 +1,342 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package tableop 提供了表格节点（ast.NodeTable）的编辑操作，包括行列的插入、删除和移动，列对齐设置，排序，转置以及与 CSV、TSV 的互相转换。
+//
+// 行下标从表头行开始计算，表头行的下标为 0。所有操作都会保持表格的 TableAligns、每一行的 TableAligns 以及每个单元格的 TableCellAlign 一致，
+// 操作后的表格可以直接使用 render.FormatRenderer 格式化输出。
+package tableop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// 列对齐方式，与 TableAligns 和 TableCellAlign 的取值一致。
+const (
+	AlignNone   = 0 // 未指定
+	AlignLeft   = 1 // 左对齐 :--
+	AlignCenter = 2 // 居中 :-:
+	AlignRight  = 3 // 右对齐 --:
+)
+
+// Tables 返回 tree 中的所有表格节点。
+func Tables(tree *parse.Tree) (ret []*ast.Node) {
+	ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeTable == n.Type {
+			ret = append(ret, n)
+			return ast.WalkSkipChildren
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// Rows 返回表格的所有行，第一行为表头行。
+func Rows(table *ast.Node) (ret []*ast.Node) {
+	for c := table.FirstChild; nil != c; c = c.Next {
+		switch c.Type {
+		case ast.NodeTableHead:
+			if row := c.ChildByType(ast.NodeTableRow); nil != row {
+				ret = append(ret, row)
+			}
+		case ast.NodeTableRow:
+			ret = append(ret, c)
+		}
+	}
+	return
+}
+
+// Cells 返回表格行 row 的所有单元格。
+func Cells(row *ast.Node) (ret []*ast.Node) {
+	for c := row.FirstChild; nil != c; c = c.Next {
+		if ast.NodeTableCell == c.Type {
+			ret = append(ret, c)
+		}
+	}
+	return
+}
+
+// InsertRow 在下标 index 处插入一个空行，返回插入的行。index 为 0 时插入的行成为新的表头行，原表头行成为第一个数据行。
+func InsertRow(table *ast.Node, index int) (*ast.Node, error) {
+	rows, err := checkTable(table)
+	if nil != err {
+		return nil, err
+	}
+	if 0 > index || len(rows) < index {
+		return nil, fmt.Errorf("row index [%d] out of range [0, %d]", index, len(rows))
+	}
+
+	row := &ast.Node{Type: ast.NodeTableRow}
+	for range table.TableAligns {
+		row.AppendChild(&ast.Node{Type: ast.NodeTableCell})
+	}
+	if 0 == index {
+		head := rows[0].Parent
+		head.InsertAfter(rows[0])
+		head.AppendChild(row)
+	} else {
+		rowAnchor(rows, index-1).InsertAfter(row)
+	}
+	syncAligns(table, table.TableAligns)
+	return row, nil
+}
+
+// DeleteRow 删除下标为 index 的行。删除表头行时第一个数据行成为新的表头行，表格只有一行时不能删除。
+func DeleteRow(table *ast.Node, index int) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+	if 0 > index || len(rows) <= index {
+		return fmt.Errorf("row index [%d] out of range [0, %d)", index, len(rows))
+	}
+	if 2 > len(rows) {
+		return errors.New("can not delete the only row")
+	}
+
+	if 0 == index {
+		head := rows[0].Parent
+		rows[0].Unlink()
+		head.AppendChild(rows[1])
+	} else {
+		rows[index].Unlink()
+	}
+	syncAligns(table, table.TableAligns)
+	return nil
+}
+
+// InsertColumn 在下标 index 处插入对齐方式为 align 的空列。
+func InsertColumn(table *ast.Node, index, align int) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+	if 0 > index || len(table.TableAligns) < index {
+		return fmt.Errorf("column index [%d] out of range [0, %d]", index, len(table.TableAligns))
+	}
+	if err = checkAlign(align); nil != err {
+		return err
+	}
+
+	for _, row := range rows {
+		cell := &ast.Node{Type: ast.NodeTableCell}
+		if cells := Cells(row); index < len(cells) {
+			cells[index].InsertBefore(cell)
+		} else {
+			row.AppendChild(cell)
+		}
+	}
+	editColgroup(table, func(cols []string) []string {
+		return append(cols[:index], append([]string{""}, cols[index:]...)...)
+	})
+	aligns := append(append(append([]int{}, table.TableAligns[:index]...), align), table.TableAligns[index:]...)
+	syncAligns(table, aligns)
+	return nil
+}
+
+// DeleteColumn 删除下标为 index 的列，表格只有一列时不能删除。
+func DeleteColumn(table *ast.Node, index int) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+	if err = checkColumn(table, index); nil != err {
+		return err
+	}
+	if 2 > len(table.TableAligns) {
+		return errors.New("can not delete the only column")
+	}
+
+	for _, row := range rows {
+		Cells(row)[index].Unlink()
+	}
+	editColgroup(table, func(cols []string) []string {
+		return append(cols[:index], cols[index+1:]...)
+	})
+	aligns := append(append([]int{}, table.TableAligns[:index]...), table.TableAligns[index+1:]...)
+	syncAligns(table, aligns)
+	return nil
+}
+
+// MoveColumn 将下标为 from 的列移动到下标 to 处。
+func MoveColumn(table *ast.Node, from, to int) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+	if err = checkColumn(table, from); nil != err {
+		return err
+	}
+	if err = checkColumn(table, to); nil != err {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+
+	for _, row := range rows {
+		cells := Cells(row)
+		cell := cells[from]
+		cell.Unlink()
+		if from < to {
+			cells[to].InsertAfter(cell)
+		} else {
+			cells[to].InsertBefore(cell)
+		}
+	}
+	order := moveOrder(len(table.TableAligns), from, to)
+	editColgroup(table, func(cols []string) (ret []string) {
+		for _, i := range order {
+			ret = append(ret, cols[i])
+		}
+		return
+	})
+	var aligns []int
+	for _, i := range order {
+		aligns = append(aligns, table.TableAligns[i])
+	}
+	syncAligns(table, aligns)
+	return nil
+}
+
+// SetAlign 设置下标为 index 的列的对齐方式。
+func SetAlign(table *ast.Node, index, align int) error {
+	if _, err := checkTable(table); nil != err {
+		return err
+	}
+	if err := checkColumn(table, index); nil != err {
+		return err
+	}
+	if err := checkAlign(align); nil != err {
+		return err
+	}
+
+	aligns := append([]int{}, table.TableAligns...)
+	aligns[index] = align
+	syncAligns(table, aligns)
+	return nil
+}
+
+// Transpose 转置表格，原表头行成为第一列，转置后所有列的对齐方式为 AlignNone。
+func Transpose(table *ast.Node) error {
+	rows, err := checkTable(table)
+	if nil != err {
+		return err
+	}
+
+	var matrix [][]*ast.Node
+	for _, row := range rows {
+		matrix = append(matrix, Cells(row))
+		row.Unlink()
+	}
+
+	table.RemoveIALAttr("colgroup")
+	head := table.ChildByType(ast.NodeTableHead)
+	for col := range table.TableAligns {
+		row := &ast.Node{Type: ast.NodeTableRow}
+		for _, cells := range matrix {
+			row.AppendChild(cells[col])
+		}
+		if 0 == col {
+			head.AppendChild(row)
+		} else {
+			table.AppendChild(row)
+		}
+	}
+	syncAligns(table, make([]int, len(matrix)))
+	return nil
+}
+
+// syncAligns 将表格、每一行以及每个单元格的对齐方式设置为 aligns。
+func syncAligns(table *ast.Node, aligns []int) {
+	table.TableAligns = aligns
+	for _, row := range Rows(table) {
+		if ast.NodeTableHead != row.Parent.Type {
+			row.TableAligns = aligns
+		} else {
+			row.TableAligns = nil
+		}
+		for i, cell := range Cells(row) {
+			if i < len(aligns) {
+				cell.TableCellAlign = aligns[i]
+			}
+		}
+	}
+}
+
+// editColgroup 使用 edit 编辑表格块级内联属性中 colgroup 的列宽样式，列数不一致时移除 colgroup。
+func editColgroup(table *ast.Node, edit func(cols []string) []string) {
+	colgroup := table.IALAttr("colgroup")
+	if "" == colgroup {
+		return
+	}
+
+	cols := strings.Split(colgroup, "|")
+	if len(cols) != len(table.TableAligns) {
+		table.RemoveIALAttr("colgroup")
+		return
+	}
+	table.SetIALAttr("colgroup", strings.Join(edit(cols), "|"))
+}
+
+// moveOrder 返回将下标 from 移动到下标 to 后各列原来的下标。
+func moveOrder(n, from, to int) (ret []int) {
+	for i := 0; i < n; i++ {
+		if i != from {
+			ret = append(ret, i)
+		}
+	}
+	return append(ret[:to], append([]int{from}, ret[to:]...)...)
+}
+
+// checkTable 检查 table 是否为结构完整的表格，并返回表格的所有行。
+func checkTable(table *ast.Node) (rows []*ast.Node, err error) {
+	if ast.NodeTable != table.Type {
+		return nil, errors.New("not a table node")
+	}
+	if head := table.ChildByType(ast.NodeTableHead); nil == head || nil == head.ChildByType(ast.NodeTableRow) {
+		return nil, errors.New("table head not found")
+	}
+
+	rows = Rows(table)
+	for _, row := range rows {
+		if len(Cells(row)) != len(table.TableAligns) {
+			return nil, errors.New("table row cells mismatch column count")
+		}
+	}
+	return
+}
+
+func checkColumn(table *ast.Node, index int) error {
+	if 0 > index || len(table.TableAligns) <= index {
+		return fmt.Errorf("column index [%d] out of range [0, %d)", index, len(table.TableAligns))
+	}
+	return nil
+}
+
+func checkAlign(align int) error {
+	if AlignNone > align || AlignRight < align {
+		return fmt.Errorf("invalid align [%d]", align)
+	}
+	return nil
+}
+
+// rowAnchor 返回在第 index 行之后插入行时使用的参照节点，表头行的参照节点为表头节点。
+func rowAnchor(rows []*ast.Node, index int) *ast.Node {
+	if 0 == index {
+		return rows[0].Parent
+	}
+	return rows[index]
+}
@@ -0,0 +1,70 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/diff"
+	"github.com/88250/lute/parse"
+)
+
+type blockDiffTest struct {
+	name string
+	old  string
+	new  string
+	html string
+}
+
+var blockDiffTests = []*blockDiffTest{
+
+	{"9", "> foo\n>\n> - a\n> - b\n", "> foo\n>\n> - a\n> - b c\n", "<blockquote>\n<p>foo</p>\n<ul>\n<li>a</li>\n<li>b<ins> c</ins></li>\n</ul>\n</blockquote>\n"},
+	{"8", "- [x] a\n- [ ] b\n", "- [x] a\n- [ ] d\n", "<ul>\n<li class=\"vditor-task vditor-task--done\"><input checked=\"\" disabled=\"\" type=\"checkbox\" /> a</li>\n<li class=\"vditor-task\"><input disabled=\"\" type=\"checkbox\" /> <del>b</del><ins>d</ins></li>\n</ul>\n"},
+	{"7", "foo *bar* &amp; `baz`\n", "foo bar &lt; `baz qux`\n", "<p>foo bar <del>&amp;</del><ins>&lt;</ins> <code>baz<ins> qux</ins></code></p>\n"},
+	{"6", "1. one two\n2. three\n", "1. one 2\n2. three\n", "<ol>\n<li>one <del>two</del><ins>2</ins></li>\n<li>three</li>\n</ol>\n"},
+
+	{"5", "# Title\n\nfoo\n", "# Title\n\nfoo\n", "<h1>Title</h1>\n<p>foo</p>\n"},
+	{"4", "## Old heading\n", "## New heading\n", "<h2><del>Old</del><ins>New</ins> heading</h2>\n"},
+	{"3", "- a\n- b\n", "- a\n- c\n", "<ul>\n<li>a</li>\n<li><del>b</del></li>\n<li><ins>c</ins></li>\n</ul>\n"},
+	{"2", "foo\n\nbar\n\nbaz\n", "baz\n\nfoo\n\nbar\n", "<ins class=\"diff-move\" data-old-index=\"2\"><p>baz</p></ins>\n<p>foo</p>\n<p>bar</p>\n"},
+	{"1", "foo bar baz\n\nsecond\n", "foo **bar** qux baz\n\nsecond\n", "<p>foo <strong>bar</strong> <ins>qux </ins>baz</p>\n<p>second</p>\n"},
+	{"0", "foo\n\ngone\n\nbar\n", "foo\n\nbar\n\nnew\n", "<p>foo</p>\n<del><p>gone</p></del>\n<p>bar</p>\n<ins><p>new</p></ins>\n"},
+}
+
+func TestBlockDiff(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range blockDiffTests {
+		oldTree := parse.Parse("", []byte(test.old), luteEngine.ParseOptions)
+		newTree := parse.Parse("", []byte(test.new), luteEngine.ParseOptions)
+		html := string(diff.HTML(diff.Diff(oldTree, newTree), nil))
+		if test.html != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q\n\t%q", test.name, test.html, html, test.old, test.new)
+		}
+	}
+}
+
+func TestBlockDiffByID(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+
+	oldTree := parse.Parse("", []byte("one\n{: id=\"20210101000000-aaaaaaa\"}\n\ntwo\n{: id=\"20210101000000-bbbbbbb\"}\n"), luteEngine.ParseOptions)
+	newTree := parse.Parse("", []byte("totally different\n{: id=\"20210101000000-bbbbbbb\"}\n\none\n{: id=\"20210101000000-aaaaaaa\"}\n"), luteEngine.ParseOptions)
+	data, err := diff.JSON(diff.Diff(oldTree, newTree))
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := `[{"op":"move","id":"20210101000000-bbbbbbb","type":"NodeParagraph","oldIndex":1,"newIndex":0,"old":"two","new":"totally different","words":[{"op":"delete","text":"two"},{"op":"insert","text":"totally different"}]},{"op":"equal","id":"20210101000000-aaaaaaa","type":"NodeParagraph","oldIndex":0,"newIndex":1,"old":"one","new":"one"}]`
+	if expected != string(data) {
+		t.Fatalf("block diff by id failed\nexpected\n\t%s\ngot\n\t%s", expected, data)
+	}
+}
@@ -0,0 +1,145 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+// testBlockProvider 从一篇文档中提供内容块，查询脚本直接映射到内容块 ID 列表。
+type testBlockProvider struct {
+	tree    *parse.Tree
+	queries map[string][]string
+}
+
+func newTestBlockProvider(luteEngine *lute.Lute) *testBlockProvider {
+	markdown := "# Heading A\n{: id=\"20200101000000-aaaaaaa\"}\n\nContent under \"A\" with **bold**\n{: id=\"20200101000000-bbbbbbb\"}\n\n# Next\n{: id=\"20200101000000-ddddddd\"}\n\n{{self}}\n{: id=\"20200101000000-eeeeeee\"}\n\n{{heading}}\n{: id=\"20200101000000-fffffff\"}\n"
+	return &testBlockProvider{
+		tree: parse.Parse("Doc", []byte(markdown), luteEngine.ParseOptions),
+		queries: map[string][]string{
+			"heading": {"20200101000000-aaaaaaa"},
+			"self":    {"20200101000000-eeeeeee"},
+			"nested":  {"20200101000000-fffffff"},
+			"missing": {"20200101000000-zzzzzzz", "20200101000000-bbbbbbb"},
+		},
+	}
+}
+
+func (p *testBlockProvider) Block(id string) (tree *parse.Tree, url string) {
+	if "20200101000000-zzzzzzz" == id {
+		return
+	}
+	return p.tree, "/blocks/" + id
+}
+
+func (p *testBlockProvider) Query(stmt string) (ids []string, err error) {
+	ids, ok := p.queries[stmt]
+	if !ok {
+		return nil, errors.New("invalid query [" + stmt + "]")
+	}
+	return
+}
+
+var blockProviderTests = []parseTest{
+
+	{"5", "{{bad}}\n", "<div class=\"block-embed block-embed--error\">invalid query [bad]</div>\n"},
+	{"4", "{{nested}}\n", "<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-fffffff\">\n<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-aaaaaaa\">\n<h1 id=\"Heading-A\">Heading A</h1>\n<p id=\"20200101000000-bbbbbbb\">Content under &quot;A&quot; with <strong>bold</strong></p>\n</div>\n</div>\n</div>\n</div>\n"},
+	{"3", "{{self}}\n", "<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-eeeeeee\">\n<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-eeeeeee\">\n<p><a href=\"/blocks/20200101000000-eeeeeee\" class=\"block-ref\" data-id=\"20200101000000-eeeeeee\">20200101000000-eeeeeee</a></p>\n</div>\n</div>\n</div>\n</div>\n"},
+	{"2", "{{missing}}\n", "<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-bbbbbbb\">\n<p id=\"20200101000000-bbbbbbb\">Content under &quot;A&quot; with <strong>bold</strong></p>\n</div>\n</div>\n"},
+	{"1", "{{heading}}\n", "<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-aaaaaaa\">\n<h1 id=\"Heading-A\">Heading A</h1>\n<p id=\"20200101000000-bbbbbbb\">Content under &quot;A&quot; with <strong>bold</strong></p>\n</div>\n</div>\n"},
+	{"0", "See ((20200101000000-bbbbbbb \"static\")), ((20200101000000-aaaaaaa 'dynamic')), ((20200101000000-ddddddd)) and ((20200101000000-zzzzzzz \"gone\")).\n", "<p>See <a href=\"/blocks/20200101000000-bbbbbbb\" class=\"block-ref\" data-id=\"20200101000000-bbbbbbb\" title=\"Content under &quot;A&quot; with bold\">static</a>, <a href=\"/blocks/20200101000000-aaaaaaa\" class=\"block-ref\" data-id=\"20200101000000-aaaaaaa\" title=\"Heading A\">Heading A</a>, <a href=\"/blocks/20200101000000-ddddddd\" class=\"block-ref\" data-id=\"20200101000000-ddddddd\" title=\"Next\">Next</a> and <span class=\"block-ref block-ref--missing\" data-id=\"20200101000000-zzzzzzz\">gone</span>.</p>\n"},
+}
+
+func TestBlockProvider(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetBlockProvider(newTestBlockProvider(luteEngine))
+	luteEngine.SetBlockEmbedMaxDepth(2)
+
+	for _, test := range blockProviderTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var blockProviderExportTests = []parseTest{
+
+	{"2", "{{bad}}\n{: id=\"20200101000000-ggggggg\"}\n", "<div data-content=\"bad\" data-node-id=\"20200101000000-ggggggg\" data-type=\"NodeBlockQueryEmbed\" class=\"render-node\" id=\"20200101000000-ggggggg\"><div class=\"protyle-wysiwyg__embed protyle-wysiwyg__embed--error\">invalid query [bad]</div><div class=\"protyle-attr\" contenteditable=\"false\"></div></div>"},
+	{"1", "{{missing}}\n{: id=\"20200101000000-ggggggg\"}\n", "<div data-content=\"missing\" data-node-id=\"20200101000000-ggggggg\" data-type=\"NodeBlockQueryEmbed\" class=\"render-node\" id=\"20200101000000-ggggggg\"><div class=\"protyle-wysiwyg__embed\" data-id=\"20200101000000-bbbbbbb\"><div data-node-id=\"20200101000000-bbbbbbb\" data-type=\"NodeParagraph\" class=\"p\" id=\"20200101000000-bbbbbbb\"><div contenteditable=\"true\" spellcheck=\"false\">Content under &quot;A&quot; with <strong>bold</strong></div><div class=\"protyle-attr\" contenteditable=\"false\"></div></div></div><div class=\"protyle-attr\" contenteditable=\"false\"></div></div>"},
+	{"0", "See ((20200101000000-bbbbbbb \"static\")) and ((20200101000000-zzzzzzz \"gone\")).\n{: id=\"20200101000000-ggggggg\"}\n", "<div data-node-id=\"20200101000000-ggggggg\" data-type=\"NodeParagraph\" class=\"p\" id=\"20200101000000-ggggggg\"><div contenteditable=\"true\" spellcheck=\"false\">See <a data-type=\"block-ref\" data-subtype=\"s\" data-id=\"20200101000000-bbbbbbb\" href=\"/blocks/20200101000000-bbbbbbb\" title=\"Content under &quot;A&quot; with bold\">static</a> and <span data-type=\"block-ref\" data-subtype=\"s\" data-id=\"20200101000000-zzzzzzz\">gone</span>.</div><div class=\"protyle-attr\" contenteditable=\"false\"></div></div>"},
+}
+
+func TestBlockProviderExport(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetBlockProvider(newTestBlockProvider(luteEngine))
+
+	for _, test := range blockProviderExportTests {
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink() // 去掉文档块 IAL
+		html := string(render.NewProtyleExportRenderer(tree, luteEngine.RenderOptions).Render())
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var blockProviderExportMdTests = []parseTest{
+
+	{"3", "{{bad}}\n", "{{bad}}\n"},
+	{"2", "{{self}}\n", "[20200101000000-eeeeeee](/blocks/20200101000000-eeeeeee)\n"},
+	{"1", "{{heading}}\n\nfoo\n", "# Heading A\n\nContent under \"A\" with **bold**\n\nfoo\n"},
+	{"0", "See ((20200101000000-bbbbbbb \"static\")), ((20200101000000-aaaaaaa 'dynamic')) and ((20200101000000-zzzzzzz \"gone\")).\n", "See [static](/blocks/20200101000000-bbbbbbb \"Content under \\\"A\\\" with bold\"), [Heading A](/blocks/20200101000000-aaaaaaa \"Heading A\") and gone.\n"},
+}
+
+func TestBlockProviderExportMd(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetAutoSpace(false)
+
+	for _, test := range blockProviderExportMdTests {
+		luteEngine.SetBlockProvider(newTestBlockProvider(luteEngine))
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink() // 去掉文档块 IAL
+		options := render.NewOptions()
+		options.BlockProvider = luteEngine.RenderOptions.BlockProvider
+		markdown := string(render.NewProtyleExportMdRenderer(tree, options).Render())
+		if test.to != markdown {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, markdown, test.from)
+		}
+	}
+}
+
+func TestBlockEmbedMaxDepth(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetBlockProvider(newTestBlockProvider(luteEngine))
+	luteEngine.SetBlockEmbedMaxDepth(1)
+
+	html := luteEngine.MarkdownStr("", "{{nested}}\n")
+	expected := "<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-fffffff\">\n<div class=\"block-embed\">\n<div class=\"block-embed__item\" data-id=\"20200101000000-aaaaaaa\">\n<p><a href=\"/blocks/20200101000000-aaaaaaa\" class=\"block-ref\" data-id=\"20200101000000-aaaaaaa\" title=\"Heading A\">Heading A</a></p>\n</div>\n</div>\n</div>\n</div>\n"
+	if expected != html {
+		t.Fatalf("block embed max depth failed\nexpected\n\t%q\ngot\n\t%q", expected, html)
+	}
+}
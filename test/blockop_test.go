@@ -0,0 +1,193 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/blockop"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+type blockopTest struct {
+	name string
+	from string
+	op   func(root *ast.Node) bool
+	to   string
+}
+
+var blockopTests = []*blockopTest{
+
+	{"14", "- a\n- b\n", func(root *ast.Node) bool {
+		li := root.FirstChild.FirstChild
+		return nil == blockop.WrapBlockquote(li) && nil == blockop.WrapSuperBlock("row", li, li.Next)
+	}, "- a\n- b\n"},
+	{"13", "foobar\n", func(root *ast.Node) bool {
+		p := root.FirstChild
+		if nil == blockop.SplitParagraph(p, 3) {
+			return false
+		}
+		inserted := &ast.Node{Type: ast.NodeParagraph}
+		inserted.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: []byte("XYZ")})
+		p.InsertAfter(inserted)
+		return blockop.MergeParagraphs(p, inserted)
+	}, "fooXYZ\n\nbar\n"},
+	{"12", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n", func(root *ast.Node) bool {
+		return nil != blockop.WrapSuperBlock("row", root.FirstChild, root.FirstChild.Next.Next)
+	}, "{{{row\na\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n\n}}}\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\"}\n"},
+	{"11", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n", func(root *ast.Node) bool {
+		return nil != blockop.WrapBlockquote(root.FirstChild)
+	}, "> a\n> {: id=\"20210101000000-aaaaaaa\"}\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+	{"10", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n", func(root *ast.Node) bool {
+		return blockop.MoveUp(root.FirstChild.Next.Next) && !blockop.MoveUp(root.FirstChild)
+	}, "b\n{: id=\"20210101000000-bbbbbbb\"}\n\na\n{: id=\"20210101000000-aaaaaaa\"}\n"},
+	{"9", "# A\n\n## B\n\ntext\n\n# C\n", func(root *ast.Node) bool {
+		return !blockop.PromoteSection(root.FirstChild) && blockop.DemoteSection(root.FirstChild)
+	}, "## A\n\n### B\n\ntext\n\n# C\n"},
+	{"8", "foo bar\n{: id=\"20210101000000-aaaaaaa\"}\n\nbaz\n{: id=\"20210101000000-bbbbbbb\"}\n", func(root *ast.Node) bool {
+		return blockop.MergeParagraphs(root.FirstChild, root.FirstChild.Next.Next)
+	}, "foo barbaz\n{: id=\"20210101000000-aaaaaaa\"}\n"},
+	{"7", "foo **bar** baz\n{: id=\"20210101000000-aaaaaaa\"}\n", func(root *ast.Node) bool {
+		return nil != blockop.SplitParagraph(root.FirstChild, 2) && nil == blockop.SplitParagraph(root.FirstChild, 2)
+	}, "fo\n{: id=\"20210101000000-aaaaaaa\"}\n\no **bar** baz\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\"}\n"},
+	{"6", "- [x] a\n- [ ] b\n", func(root *ast.Node) bool {
+		return blockop.CancelList(root.FirstChild)
+	}, "a\n\nb\n"},
+	{"5", "- [x] a\n- [ ] b\n", func(root *ast.Node) bool {
+		return blockop.ConvertList(root.FirstChild, 1)
+	}, "1. a\n2. b\n"},
+	{"4", "1. a\n2. b\n", func(root *ast.Node) bool {
+		return blockop.ConvertList(root.FirstChild, 3) && blockop.MoveDown(root.FirstChild.FirstChild)
+	}, "1. [ ] b\n2. [ ] a\n"},
+	{"3", "- a\n  - b\n  - c\n", func(root *ast.Node) bool {
+		return blockop.Outdent(root.FirstChild.FirstChild)
+	}, "a\n\n- b\n- c\n"},
+	{"2", "- a\n  - b\n  - c\n- d\n", func(root *ast.Node) bool {
+		return blockop.Outdent(root.FirstChild.FirstChild.LastChild.FirstChild)
+	}, "- a\n- b\n  - c\n- d\n"},
+	{"1", "1. a\n2. b\n3. c\n", func(root *ast.Node) bool {
+		b := root.FirstChild.FirstChild.Next
+		return blockop.Indent(b) && blockop.Indent(b.Parent.Parent.Next) && !blockop.Indent(root.FirstChild.FirstChild)
+	}, "1. a\n   1. b\n   2. c\n"},
+	{"0", "- a\n- b\n", func(root *ast.Node) bool {
+		return blockop.Indent(root.FirstChild.FirstChild.Next)
+	}, "- a\n  - b\n"},
+}
+
+func TestBlockop(t *testing.T) {
+	testBlockop(t, blockopTests, false)
+}
+
+var blockopDocIALTests = []*blockopTest{
+
+	{"3", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n", func(root *ast.Node) bool {
+		return blockop.MoveDown(root.FirstChild) && !blockop.MoveDown(root.FirstChild.Next.Next)
+	}, "b\n{: id=\"20210101000000-bbbbbbb\"}\n\na\n{: id=\"20210101000000-aaaaaaa\"}\n\n\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\" type=\"doc\"}\n"},
+	{"2", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nfoo bar\n", func(root *ast.Node) bool {
+		return nil != blockop.SplitParagraph(root.FirstChild.Next.Next, 3)
+	}, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nfoo\n\n bar\n\n\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\" type=\"doc\"}\n"},
+	{"1", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n- b\n  - c\n", func(root *ast.Node) bool {
+		return blockop.Outdent(root.LastChild.Previous.FirstChild)
+	}, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n\n- {: id=\"20060102150405-1a2b3c4\"}c\n\n\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\" type=\"doc\"}\n"},
+	{"0", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n- b\n- c\n", func(root *ast.Node) bool {
+		return blockop.Indent(root.LastChild.Previous.LastChild.Previous)
+	}, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n- {: id=\"20060102150405-1a2b3c4\"}b\n  - {: id=\"20060102150405-1a2b3c4\"}c\n  {: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\"}\n\n\n{: id=\"20060102150405-1a2b3c4\" updated=\"20060102150405\" type=\"doc\"}\n"},
+}
+
+// TestBlockopDocIAL 测试在保留文档块级内联属性列表的树上操作最后一个块。
+func TestBlockopDocIAL(t *testing.T) {
+	testBlockop(t, blockopDocIALTests, true)
+}
+
+var blockopProtyleTests = []*blockopTest{
+
+	{"4", "1. a\n2. b\n3. c\n", func(root *ast.Node) bool {
+		return blockop.MoveDown(listItem(root, 0)) && "1." == string(listItem(root, 0).ListData.Marker) && "2." == string(listItem(root, 1).ListData.Marker)
+	}, "1. b\n2. a\n3. c\n"},
+	{"3", "1. a\n2. b\n3. c\n", func(root *ast.Node) bool {
+		return blockop.Indent(listItem(root, 1)) && blockop.Indent(listItem(root, 2))
+	}, "1. a\n\n    1. b\n    2. c\n"},
+	{"2", "- a\n  - b\n  - c\n", func(root *ast.Node) bool {
+		return blockop.Outdent(listItem(root, 1))
+	}, "* a\n* b\n\n  * c\n"},
+	{"1", "1. a\n2. b\n\n   - c\n   - d\n", func(root *ast.Node) bool {
+		return blockop.Outdent(listItem(root, 3))
+	}, "1. a\n2. b\n\n    * c\n3. d\n"},
+	{"0", "- a\n- b\n", func(root *ast.Node) bool {
+		return blockop.Indent(listItem(root, 1))
+	}, "* a\n\n  * b\n"},
+}
+
+// listItem 按文档顺序返回 root 中第 i 个列表项。
+func listItem(root *ast.Node, i int) (ret *ast.Node) {
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if entering && ast.NodeListItem == n.Type {
+			if 0 == i {
+				ret = n
+				return ast.WalkStop
+			}
+			i--
+		}
+		return ast.WalkContinue
+	})
+	return
+}
+
+// TestBlockopProtyle 测试在 Protyle DOM 转换得到的树上操作列表，这类树的列表节点上没有列表标识符。
+func TestBlockopProtyle(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetProtyleWYSIWYG(true)
+	luteEngine.SetKramdownIAL(true)
+	options := render.NewOptions()
+
+	for _, test := range blockopProtyleTests {
+		tree := luteEngine.BlockDOM2Tree(luteEngine.Md2BlockDOM(test.from))
+		if !test.op(tree.Root) {
+			t.Fatalf("test case [%s] failed: unexpected operation result", test.name)
+		}
+		formatted := string(render.NewFormatRenderer(tree, options).Render())
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+func testBlockop(t *testing.T, tests []*blockopTest, keepDocIAL bool) {
+	ast.Testing = true
+	defer func() { ast.Testing = false }()
+
+	for _, test := range tests {
+		// 包含块级内联属性列表的用例打开 kramdown 支持
+		ial := strings.Contains(test.from, "{:")
+		luteEngine := lute.New()
+		luteEngine.SetKramdownIAL(ial)
+		luteEngine.ParseOptions.SuperBlock = true
+		options := render.NewOptions()
+		options.KramdownBlockIAL = ial
+		options.SuperBlock = true
+
+		tree := parse.Parse("", []byte(test.from), luteEngine.ParseOptions)
+		if ial && !keepDocIAL {
+			tree.Root.LastChild.Unlink() // 剔除文档内联属性列表
+		}
+		if !test.op(tree.Root) {
+			t.Fatalf("test case [%s] failed: unexpected operation result", test.name)
+		}
+		formatted := string(render.NewFormatRenderer(tree, options).Render())
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/builder"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+type builderTest struct {
+	name     string
+	nodes    []*ast.Node
+	markdown string
+}
+
+var builderTests = []builderTest{
+
+	{"12", []*ast.Node{builder.Paragraph(builder.Link("a", "b", "x \"y\" z\\"), builder.Text(" "), builder.Image("c", "d e.png", "\\"))}, "[a](b \"x &quot;y&quot; z\\\\\") ![c](d%20e.png \"\\\\\")\n"},
+	{"11", []*ast.Node{builder.Paragraph(builder.CodeSpan("a``b"), builder.Text(" "), builder.CodeSpan("`a"), builder.Text(" "), builder.CodeSpan("`b "), builder.Text(" "), builder.CodeSpan("```"))}, "```a``b``` `` `a `` `` `b  `` ```` ``` ````\n"},
+	{"10", []*ast.Node{builder.TextTable([][]string{{"a|b", "c"}, {"`x`", "<y>"}}, []int{0, 0})}, "| a\\|b | c   |\n| --- | --- |\n| \\`x\\` | \\<y> |\n"},
+	{"9", []*ast.Node{builder.Paragraph(builder.Link("a]b", "my file (1).md", ""), builder.Text(" "), builder.Image("c", "d(e).png", "f \"g\""))}, "[a\\]b](my%20file%20(1).md) ![c](d(e).png \"f &quot;g&quot;\")\n"},
+	{"8", []*ast.Node{builder.Paragraph(builder.Text("1. not a list *x*"), builder.SoftBreak(), builder.Text("- # :smile: &amp; == 2024.")), builder.Paragraph(builder.Text("> quote\n+ ===\n3) x"))}, "1\\. not a list \\*x\\*\n\\- \\# \\:smile: \\&amp; \\=\\= 2024.\n\n\\> quote\n\\+ \\=\\=\\=\n3\\) x\n"},
+
+	{"7", []*ast.Node{builder.Paragraph(builder.Text("foo"), builder.HardBreak(), builder.Text("bar"), builder.SoftBreak(), builder.InlineMath("x^2"))}, "foo\\\nbar\n$x^2$\n"},
+	{"6", []*ast.Node{builder.TextTable([][]string{{"a", "b", "c"}, {"1", "", "3"}}, []int{1, 2, 3})}, "| a | b | c |\n| :- | :-: | -: |\n| 1 |  | 3 |\n"},
+	{"5", []*ast.Node{builder.CodeBlock("go", "fmt.Println(\"```\")\n```"), builder.MathBlock("a+b")}, "````go\nfmt.Println(\"```\")\n```\n````\n\n$$\na+b\n$$\n"},
+	{"4", []*ast.Node{builder.BulletList(builder.TaskListItem(true, builder.Paragraph(builder.Text("done"))), builder.TaskListItem(false, builder.Paragraph(builder.Strong(builder.Text("todo")))))}, "- [X] done\n- [ ] **todo**\n"},
+	{"3", []*ast.Node{builder.OrderedList(9, builder.ListItem(builder.Paragraph(builder.Text("nine"))), builder.ListItem(builder.Paragraph(builder.Text("ten")), builder.BulletList(builder.ListItem(builder.Paragraph(builder.Text("sub"))))))}, "9. nine\n10. ten\n    - sub\n"},
+	{"2", []*ast.Node{builder.Blockquote(builder.Paragraph(builder.Link("Lute", "https://b3log.org/lute", "Lute title"), builder.Text(" "), builder.Image("logo", "logo.png", ""))), builder.ThematicBreak()}, "> [Lute](https://b3log.org/lute \"Lute title\") ![logo](logo.png)\n\n---\n"},
+	{"1", []*ast.Node{builder.Heading(2, builder.Text("foo "), builder.Emphasis(builder.Text("bar")), builder.Text(" "), builder.Strikethrough(builder.Text("baz")))}, "## foo *bar* ~~baz~~\n"},
+	{"0", []*ast.Node{builder.Paragraph(builder.Text("foo "), builder.CodeSpan("a`b"))}, "foo ``a`b``\n"},
+}
+
+func TestBuilder(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetFixTermTypo(false)
+	luteEngine.SetSoftBreak2HardBreak(false)
+
+	for _, test := range builderTests {
+		tree := builder.Tree(test.name, luteEngine.ParseOptions, test.nodes...)
+		html := luteEngine.Tree2HTML(tree, luteEngine.RenderOptions)
+		expected := luteEngine.MarkdownStr(test.name, test.markdown)
+		if expected != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q", test.name, expected, html)
+		}
+
+		formatted := string(render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render())
+		if test.markdown != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q", test.name, test.markdown, formatted)
+		}
+
+		// 其他渲染器也需要能够接受构造出的节点
+		render.NewProtyleRenderer(tree, luteEngine.RenderOptions).Render()
+		render.NewVditorIRRenderer(tree, luteEngine.RenderOptions).Render()
+		render.NewJSONRenderer(tree, luteEngine.RenderOptions).Render()
+
+		parsed := parse.Parse(test.name, []byte(test.markdown), luteEngine.ParseOptions)
+		if formattedParsed := string(render.NewFormatRenderer(parsed, luteEngine.RenderOptions).Render()); formatted != formattedParsed {
+			t.Fatalf("test case [%s] failed\nbuilt\n\t%q\nparsed\n\t%q", test.name, formatted, formattedParsed)
+		}
+	}
+}
+
+func TestBuilderTreeCopies(t *testing.T) {
+	luteEngine := lute.New()
+	p := builder.Paragraph(builder.Link("a", "b c.md", "x \\\"y\\\""))
+	dest := p.FirstChild.ChildByType(ast.NodeLinkDest)
+
+	expected := luteEngine.Tree2HTML(builder.Tree("", luteEngine.ParseOptions, p), luteEngine.RenderOptions)
+	if html := luteEngine.Tree2HTML(builder.Tree("", luteEngine.ParseOptions, p), luteEngine.RenderOptions); expected != html {
+		t.Fatalf("tree should not change the children\nexpected\n\t%q\ngot\n\t%q", expected, html)
+	}
+	if nil != p.Parent || "b c.md" != string(dest.Tokens) || nil == dest.Previous || ast.NodeLess != dest.Previous.Type {
+		t.Fatalf("tree should not change the children")
+	}
+}
+
+var builderRoundTripTests = []builderTest{
+
+	{"2", []*ast.Node{builder.TextTable([][]string{{"a|b"}, {"c|d"}}, []int{0})}, "a|bc|d"},
+	{"1", []*ast.Node{builder.Paragraph(builder.Link("a", "my file (1).md", ""), builder.Link("b", "x(y", "t"))}, "amy file (1).mdbx(y"},
+	{"0", []*ast.Node{builder.Paragraph(builder.Text("1. not a list *x*"))}, "1. not a list *x*"},
+}
+
+func TestBuilderRoundTrip(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetProtyleWYSIWYG(true)
+
+	for _, test := range builderRoundTripTests {
+		tree := builder.Tree(test.name, luteEngine.ParseOptions, test.nodes...)
+		expected := roundTripText(tree.Root)
+		if test.markdown != expected {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q", test.name, test.markdown, expected)
+		}
+
+		formatted := render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render()
+		parsed := parse.Parse(test.name, formatted, luteEngine.ParseOptions)
+		if got := roundTripText(parsed.Root); expected != got {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\nformatted\n\t%q", test.name, expected, got, formatted)
+		}
+	}
+}
+
+// roundTripText 返回块级节点类型以外的文本内容和链接地址，用于比较构造出的语法树和重新解析的语法树。
+func roundTripText(root *ast.Node) string {
+	var ret []string
+	ast.Walk(root, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+		switch n.Type {
+		case ast.NodeParagraph, ast.NodeTable:
+			if ast.NodeDocument != n.Parent.Type {
+				ret = append(ret, "!nested "+n.Type.String())
+			}
+		case ast.NodeText, ast.NodeLinkText, ast.NodeBackslashContent, ast.NodeLinkDest:
+			ret = append(ret, string(n.Tokens))
+		}
+		return ast.WalkContinue
+	})
+	return strings.Join(ret, "")
+}
@@ -0,0 +1,176 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/render"
+)
+
+const (
+	chromaLightStyleXML = `<style name="lute-test-light">
+  <entry type="Background" style="bg:#ffffff #000000"/>
+  <entry type="Keyword" style="bold #0000ff"/>
+</style>`
+	chromaDarkStyleJSON = `{"name": "lute-test-dark", "entries": {"Background": "bg:#000000 #ffffff", "Keyword": "#ff8800", "GenericInserted": "bg:#003300"}}`
+)
+
+func registerTestChromaStyles(t *testing.T) {
+	if name, err := render.RegisterChromaStyle([]byte(chromaLightStyleXML), true); nil != err || "lute-test-light" != name {
+		t.Fatalf("register XML style failed: %s, %v", name, err)
+	}
+	if name, err := render.RegisterChromaStyle([]byte(chromaDarkStyleJSON), true); nil != err || "lute-test-dark" != name {
+		t.Fatalf("register JSON style failed: %s, %v", name, err)
+	}
+}
+
+func TestChromaStyleCSS(t *testing.T) {
+	registerTestChromaStyles(t)
+
+	css, err := render.ChromaStyleCSS("lute-test-light", "")
+	if nil != err {
+		t.Fatalf("generate style css failed: %s", err)
+	}
+	for _, rule := range []string{
+		"/* PreWrapper */ .highlight-chroma { color: #000000; background-color: #ffffff; }\n",
+		"/* Keyword */ .highlight-chroma .highlight-k { color: #0000ff; font-weight: bold }\n",
+		"/* DiffAdd */ .highlight-chroma .highlight-diff-add { background-color: #e6ffed }\n",
+		"/* DiffDel */ .highlight-chroma .highlight-diff-del { background-color: #ffeef0 }\n",
+	} {
+		if !strings.Contains(css, rule) {
+			t.Fatalf("style css should contain [%s], got\n%s", rule, css)
+		}
+	}
+
+	css, err = render.ChromaStyleCSS("github", "hl-")
+	if nil != err {
+		t.Fatalf("generate style css failed: %s", err)
+	}
+	if !strings.Contains(css, "/* Keyword */ .hl-chroma .hl-k { color: #000000; font-weight: bold }\n") || strings.Contains(css, "highlight-") {
+		t.Fatalf("unexpected prefixed style css\n%s", css)
+	}
+
+	if _, err = render.ChromaStyleCSS("not-exist", ""); nil == err {
+		t.Fatalf("generate not exist style css should fail")
+	}
+}
+
+func TestChromaDualStyleCSS(t *testing.T) {
+	registerTestChromaStyles(t)
+
+	css, err := render.ChromaDualStyleCSS("lute-test-light", "lute-test-dark", "", render.ChromaDarkModeMedia)
+	if nil != err {
+		t.Fatalf("generate dual style css failed: %s", err)
+	}
+	light, dark := strings.Index(css, "@media not all and (prefers-color-scheme: dark) {\n"), strings.Index(css, "}\n@media (prefers-color-scheme: dark) {\n")
+	if 0 != light || light > dark || !strings.HasSuffix(css, "}\n") {
+		t.Fatalf("unexpected media dual style css\n%s", css)
+	}
+	if !strings.Contains(css[dark:], "/* Keyword */ .highlight-chroma .highlight-k { color: #ff8800 }\n") {
+		t.Fatalf("dark media query should contain dark keyword rule\n%s", css)
+	}
+
+	css, err = render.ChromaDualStyleCSS("lute-test-light", "lute-test-dark", "hl-", render.ChromaDarkModeAttr)
+	if nil != err {
+		t.Fatalf("generate dual style css failed: %s", err)
+	}
+	for _, rule := range []string{
+		"/* Keyword */ :root:not([data-theme=\"dark\"]) .hl-chroma .hl-k { color: #0000ff; font-weight: bold }\n",
+		"/* Keyword */ [data-theme=\"dark\"] .hl-chroma .hl-k { color: #ff8800 }\n",
+		"/* DiffAdd */ [data-theme=\"dark\"] .hl-chroma .hl-diff-add { background-color: #003300 }\n",
+		"/* DiffDel */ [data-theme=\"dark\"] .hl-chroma .hl-diff-del { background-color: #67060c }\n",
+	} {
+		if !strings.Contains(css, rule) {
+			t.Fatalf("dual style css should contain [%s], got\n%s", rule, css)
+		}
+	}
+
+	if _, err = render.ChromaDualStyleCSS("lute-test-light", "lute-test-dark", "", "class"); nil == err {
+		t.Fatalf("generate dual style css with unknown mode should fail")
+	}
+}
+
+func TestRegisterChromaStyleErr(t *testing.T) {
+	for _, definition := range []string{
+		`<style name="lute-test-err"><entry type="NotAToken" style="bold"/></style>`,
+		`{"entries": {"Keyword": "bold"}}`,
+		`{"name": "lute-test-err", "entries": {"Keyword": "bold #zzz"}}`,
+		`<style name="lute-test-err"`,
+	} {
+		if name, err := render.RegisterChromaStyle([]byte(definition), true); nil == err {
+			t.Fatalf("register style [%s] should fail, got [%s]", definition, name)
+		}
+	}
+}
+
+func TestRegisterChromaStyleOverride(t *testing.T) {
+	registerTestChromaStyles(t)
+
+	for _, definition := range []string{
+		`{"name": "github", "entries": {"Keyword": "#ff0000"}}`,
+		chromaLightStyleXML,
+	} {
+		if name, err := render.RegisterChromaStyle([]byte(definition), false); nil == err {
+			t.Fatalf("register existing style [%s] should fail", name)
+		}
+	}
+	css, err := render.ChromaStyleCSS("github", "")
+	if nil != err || !strings.Contains(css, "/* Keyword */ .highlight-chroma .highlight-k { color: #000000; font-weight: bold }\n") {
+		t.Fatalf("built-in style should not be replaced, got\n%s", css)
+	}
+
+	if _, err = render.RegisterChromaStyle([]byte(`<style name="lute-test-light"><entry type="Keyword" style="#ff0000"/></style>`), true); nil != err {
+		t.Fatalf("override style failed: %s", err)
+	}
+	if css, err = render.ChromaStyleCSS("lute-test-light", ""); nil != err || !strings.Contains(css, "/* Keyword */ .highlight-chroma .highlight-k { color: #ff0000 }\n") {
+		t.Fatalf("style should be overridden, got\n%s", css)
+	}
+	registerTestChromaStyles(t)
+}
+
+var chromaStyleRenderTests = []parseTest{
+
+	{"1", "```diff-go\n+break\n```\n", "<pre style=\"color: #ffffff; background-color: #000000\"><code class=\"language-diff-go\"><span style=\"display:flex; background-color:#003300;\"><span><span style=\"background-color:#030\">+</span><span style=\"color:#f80\">break</span>\n</span></span></code></pre>\n"},
+	{"0", "```go\nbreak\n```\n", "<pre style=\"color: #ffffff; background-color: #000000\"><code class=\"language-go\"><span style=\"display:flex;\"><span><span style=\"color:#f80\">break</span>\n</span></span></code></pre>\n"},
+}
+
+func TestChromaStyleRender(t *testing.T) {
+	registerTestChromaStyles(t)
+
+	luteEngine := lute.New()
+	luteEngine.SetCodeSyntaxHighlightInlineStyle(true)
+	luteEngine.SetCodeSyntaxHighlightStyleName("lute-test-dark")
+	for _, test := range chromaStyleRenderTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var chromaClassPrefixTests = []parseTest{
+
+	{"0", "```diff-go\n+break\n```\n", "<pre><code class=\"language-diff-go hl-chroma\"><span class=\"hl-line hl-diff-add\"><span class=\"hl-cl\"><span class=\"hl-gi\">+</span><span class=\"hl-k\">break</span>\n</span></span></code></pre>\n"},
+}
+
+func TestChromaClassPrefix(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCodeSyntaxHighlightClassPrefix("hl-")
+	for _, test := range chromaClassPrefixTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
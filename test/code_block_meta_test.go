@@ -0,0 +1,116 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/render"
+)
+
+var codeBlockMetaTests = []parseTest{
+
+	{"7", "```go linenos=false\nfoo\n```\n", "<pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">foo</span>\n</span></span></code></pre>\n"},
+	{"6", "```diff-go {2}\n a := 1\n-b := 2\n+b := 3\n```\n", "<pre><code class=\"language-diff-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\"> <span class=\"highlight-nx\">a</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">1</span>\n</span></span><span class=\"highlight-line highlight-diff-del highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-gd\">-</span><span class=\"highlight-nx\">b</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">2</span>\n</span></span><span class=\"highlight-line highlight-diff-add\"><span class=\"highlight-cl\"><span class=\"highlight-gi\">+</span><span class=\"highlight-nx\">b</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">3</span>\n</span></span></code></pre>\n"},
+	{"5", "```diff-go\n a := 1\n-b := 2\n+b := 3\n```\n", "<pre><code class=\"language-diff-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\"> <span class=\"highlight-nx\">a</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">1</span>\n</span></span><span class=\"highlight-line highlight-diff-del\"><span class=\"highlight-cl\"><span class=\"highlight-gd\">-</span><span class=\"highlight-nx\">b</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">2</span>\n</span></span><span class=\"highlight-line highlight-diff-add\"><span class=\"highlight-cl\"><span class=\"highlight-gi\">+</span><span class=\"highlight-nx\">b</span> <span class=\"highlight-o\">:=</span> <span class=\"highlight-mi\">3</span>\n</span></span></code></pre>\n"},
+	{"4", "```go hl_lines=\"1 3\" linenostart=5\na\nb\nc\n```\n", "<pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">a</span>\n</span></span><span class=\"highlight-line\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">b</span>\n</span></span><span class=\"highlight-line highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">c</span>\n</span></span></code></pre>\n"},
+	{"3", "```go {2} linenos=10\na\nb\n```\n", "<pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-ln\">10</span><span class=\"highlight-cl\"><span class=\"highlight-nx\">a</span>\n</span></span><span class=\"highlight-line highlight-hl\"><span class=\"highlight-ln\">11</span><span class=\"highlight-cl\"><span class=\"highlight-nx\">b</span>\n</span></span></code></pre>\n"},
+	{"2", "```go {1,3-4}\na\nb\nc\nd\n```\n", "<pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">a</span>\n</span></span><span class=\"highlight-line\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">b</span>\n</span></span><span class=\"highlight-line highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">c</span>\n</span></span><span class=\"highlight-line highlight-hl\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">d</span>\n</span></span></code></pre>\n"},
+	{"1", "```go title=\"main.go\"\nfoo\n```\n", "<figure class=\"code-block\"><figcaption>main.go</figcaption><pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">foo</span>\n</span></span></code></pre></figure>\n"},
+	{"0", "```go title=\"a <b>.go\"\nfoo\n```\n", "<figure class=\"code-block\"><figcaption>a &lt;b&gt;.go</figcaption><pre><code class=\"language-go highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\"><span class=\"highlight-nx\">foo</span>\n</span></span></code></pre></figure>\n"},
+}
+
+func TestCodeBlockMeta(t *testing.T) {
+	luteEngine := lute.New()
+	for _, test := range codeBlockMetaTests {
+		if "7" == test.name {
+			luteEngine.SetCodeSyntaxHighlightLineNum(true)
+		}
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		luteEngine.SetCodeSyntaxHighlightLineNum(false)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var codeBlockMetaInlineStyleTests = []parseTest{
+
+	{"0", "```diff-go\n a := 1\n-b := 2\n+b := 3\n```\n", "<pre style=\"background-color: #ffffff\"><code class=\"language-diff-go\"><span style=\"display:flex;\"><span> a <span style=\"color:#000;font-weight:bold\">:=</span> <span style=\"color:#099\">1</span>\n</span></span><span style=\"display:flex; background-color:#ffdddd;\"><span><span style=\"color:#000;background-color:#fdd\">-</span>b <span style=\"color:#000;font-weight:bold\">:=</span> <span style=\"color:#099\">2</span>\n</span></span><span style=\"display:flex; background-color:#ddffdd;\"><span><span style=\"color:#000;background-color:#dfd\">+</span>b <span style=\"color:#000;font-weight:bold\">:=</span> <span style=\"color:#099\">3</span>\n</span></span></code></pre>\n"},
+}
+
+func TestCodeBlockMetaInlineStyle(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCodeSyntaxHighlightInlineStyle(true)
+	for _, test := range codeBlockMetaInlineStyleTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var codeBlockMetaNoHighlightTests = []parseTest{
+
+	{"2", "```go title=\"a &amp; b.go\"\nfoo\n```\n", "<figure class=\"code-block\"><figcaption>a &amp; b.go</figcaption><pre><code class=\"language-go\">foo\n</code></pre></figure>\n"},
+	{"1", "```mermaid title=\"Flow\"\ngraph TD;\n```\n", "<figure class=\"code-block\"><figcaption>Flow</figcaption><div class=\"language-mermaid\">graph TD;\n</div></figure>\n"},
+	{"0", "```go title=\"main.go\" {1}\nfoo\n```\n", "<figure class=\"code-block\"><figcaption>main.go</figcaption><pre><code class=\"language-go\">foo\n</code></pre></figure>\n"},
+}
+
+func TestCodeBlockMetaNoHighlight(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCodeSyntaxHighlight(false)
+	for _, test := range codeBlockMetaNoHighlightTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var codeBlockMetaFormatTests = []parseTest{
+
+	{"4", "```go title=\"a &amp; b.go\" x=\\*\nfoo\n```\n", "```go title=\"a &amp; b.go\" x=\\*\nfoo\n```\n"},
+	{"3", "~~~~ diff-go  {1}\n+foo\n~~~~\n", "~~~~diff-go {1}\n+foo\n~~~~\n"},
+	{"2", "```go {3,5-7} title=\"main.go\" linenos=10\nfoo\n```\n", "```go {3,5-7} title=\"main.go\" linenos=10\nfoo\n```\n"},
+	{"1", "```ruby startline=3 $%@#$\nfoo\n```\n", "```ruby startline=3 $%@#$\nfoo\n```\n"},
+	{"0", "```go\nfoo\n```\n", "```go\nfoo\n```\n"},
+}
+
+func TestCodeBlockMetaFormat(t *testing.T) {
+	luteEngine := lute.New()
+	for _, test := range codeBlockMetaFormatTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+func TestParseCodeBlockInfoMeta(t *testing.T) {
+	info := render.ParseCodeBlockInfo([]byte("diff-go {3, 5-7} title=\"main.go\" linenos=10 hl_lines=\"9 x 12-11\""))
+	if !info.Diff || "go" != info.DiffLanguage || "diff-go" != info.Language {
+		t.Fatalf("unexpected diff mode %+v", info)
+	}
+	if expected := [][2]int{{3, 3}, {5, 7}, {9, 9}}; !reflect.DeepEqual(expected, info.HighlightLines) {
+		t.Fatalf("unexpected highlight lines %v", info.HighlightLines)
+	}
+	if "main.go" != info.Title || 1 != info.LineNumbers || 10 != info.LineNumberStart {
+		t.Fatalf("unexpected info %+v", info)
+	}
+
+	info = render.ParseCodeBlockInfo([]byte("go filename=a.go linenos=false"))
+	if info.Diff || "a.go" != info.Title || -1 != info.LineNumbers || 1 != info.LineNumberStart || 0 != len(info.HighlightLines) {
+		t.Fatalf("unexpected info %+v", info)
+	}
+}
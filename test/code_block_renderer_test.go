@@ -0,0 +1,150 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+func csvCodeBlockRenderer(code []byte, info *render.CodeBlockInfo) (html string, ok bool) {
+	lines := strings.Split(strings.TrimSpace(string(code)), "\n")
+	if 2 > len(lines) {
+		return "", false
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteString("<table class=\"language-" + info.Language + "\">")
+	if "" != info.Title {
+		buf.WriteString("<caption>" + info.Title + "</caption>")
+	}
+	for _, line := range lines {
+		buf.WriteString("<tr>")
+		for _, cell := range strings.Split(line, ",") {
+			buf.WriteString("<td>" + cell + "</td>")
+		}
+		buf.WriteString("</tr>")
+	}
+	buf.WriteString("</table>")
+	return buf.String(), true
+}
+
+var codeBlockRendererTests = []parseTest{
+
+	{"4", "```csv title=\"Foo Bar\"\na,b\n1,2\n```\n", "<table class=\"language-csv\"><caption>Foo Bar</caption><tr><td>a</td><td>b</td></tr><tr><td>1</td><td>2</td></tr></table>\n"},
+	{"3", "    a,b\n    1,2\n", "<pre><code>a,b\n1,2\n</code></pre>\n"},
+	{"2", "```csv\na,b\n```\n", "<pre><code class=\"language-csv\">a,b\n</code></pre>\n"},
+	{"1", "```go\nfoo\n```\n", "<pre><code class=\"language-go\">foo\n</code></pre>\n"},
+	{"0", "```csv\na,b\n1,2\n```\n", "<table class=\"language-csv\"><tr><td>a</td><td>b</td></tr><tr><td>1</td><td>2</td></tr></table>\n"},
+}
+
+func TestCodeBlockRenderer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCodeSyntaxHighlight(false)
+	luteEngine.SetCodeBlockRenderer("csv", csvCodeBlockRenderer)
+	for _, test := range codeBlockRendererTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+
+	luteEngine.SetCodeBlockRenderer("csv", nil)
+	test := codeBlockRendererTests[len(codeBlockRendererTests)-1]
+	if html := luteEngine.MarkdownStr(test.name, test.from); "<pre><code class=\"language-csv\">a,b\n1,2\n</code></pre>\n" != html {
+		t.Fatalf("unregister code block renderer failed, got [%s]", html)
+	}
+}
+
+var codeBlockRendererVditorIRTests = []parseTest{
+
+	{"0", "```csv\na,b\n1,2\n```\n", "<div data-block=\"0\" data-type=\"code-block\" class=\"vditor-ir__node\"><span data-type=\"code-block-open-marker\">```</span><span class=\"vditor-ir__marker vditor-ir__marker--info\" data-type=\"code-block-info\">\u200bcsv</span><pre class=\"vditor-ir__marker--pre vditor-ir__marker\"><code class=\"language-csv\">a,b\n1,2\n</code></pre><pre class=\"vditor-ir__preview\" data-render=\"2\"><table class=\"language-csv\"><tr><td>a</td><td>b</td></tr><tr><td>1</td><td>2</td></tr></table></pre><span data-type=\"code-block-close-marker\">```</span></div>"},
+}
+
+func TestCodeBlockRendererVditorIR(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetCodeBlockRenderer("csv", csvCodeBlockRenderer)
+	for _, test := range codeBlockRendererVditorIRTests {
+		html := luteEngine.Md2VditorIRDOM(test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var codeBlockRendererProtyleExportTests = []parseTest{
+
+	{"1", "```go\nfoo\n```\n{: id=\"20060102150405-1a2b3c4\"}\n", "<div data-node-id=\"20060102150405-1a2b3c4\" data-type=\"NodeCodeBlock\" class=\"code-block\" id=\"20060102150405-1a2b3c4\"><div class=\"protyle-action\"><span class=\"protyle-action--first protyle-action__language\" contenteditable=\"false\">go</span><span class=\"fn__flex-1\"></span><span class=\"protyle-icon protyle-icon--only protyle-action__copy\"><svg><use xlink:href=\"#iconCopy\"></use></svg></span></div><div class=\"hljs\" contenteditable=\"true\" spellcheck=\"false\">foo\n</div><div class=\"protyle-attr\" contenteditable=\"false\"></div></div>"},
+	{"0", "```csv\na,b\n1,2\n```\n{: id=\"20060102150405-1a2b3c4\"}\n", "<div data-node-id=\"20060102150405-1a2b3c4\" data-type=\"NodeCodeBlock\" class=\"code-block\" id=\"20060102150405-1a2b3c4\"><table class=\"language-csv\"><tr><td>a</td><td>b</td></tr><tr><td>1</td><td>2</td></tr></table><div class=\"protyle-attr\" contenteditable=\"false\"></div></div>"},
+}
+
+func TestCodeBlockRendererProtyleExport(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetCodeBlockRenderer("csv", csvCodeBlockRenderer)
+	for _, test := range codeBlockRendererProtyleExportTests {
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		html := string(render.NewProtyleExportRenderer(tree, luteEngine.RenderOptions).Render())
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+func TestParseCodeBlockInfo(t *testing.T) {
+	info := render.ParseCodeBlockInfo([]byte(" go title=\"main file.go\" linenos=10 nowrap name='x y' "))
+	if "go" != info.Language {
+		t.Fatalf("unexpected language [%s]", info.Language)
+	}
+	expected := map[string]string{"title": "main file.go", "linenos": "10", "nowrap": "", "name": "x y"}
+	if len(expected) != len(info.Attrs) {
+		t.Fatalf("unexpected attrs %v", info.Attrs)
+	}
+	for k, v := range expected {
+		if got, ok := info.Attrs[k]; !ok || v != got {
+			t.Fatalf("unexpected attr [%s]: expected [%s], got [%s]", k, v, got)
+		}
+	}
+
+	info = render.ParseCodeBlockInfo(nil)
+	if "" != info.Language || 0 != len(info.Attrs) {
+		t.Fatalf("unexpected empty info %+v", info)
+	}
+}
+
+func TestCodeBlockRendererInfo(t *testing.T) {
+	luteEngine := lute.New()
+	var got *render.CodeBlockInfo
+	luteEngine.SetCodeBlockRenderer("go", func(code []byte, info *render.CodeBlockInfo) (html string, ok bool) {
+		got = info
+		return "<pre>" + string(code) + "</pre>", true
+	})
+	luteEngine.MarkdownStr("", "```go {3} title=\"main file.go\" linenos=10 nowrap\nfoo\n```\n")
+	if nil == got {
+		t.Fatalf("code block renderer not called")
+	}
+	if "go" != got.Language || "main file.go" != got.Title || 10 != got.LineNumberStart {
+		t.Fatalf("unexpected info %+v", got)
+	}
+	if 1 != len(got.HighlightLines) || [2]int{3, 3} != got.HighlightLines[0] {
+		t.Fatalf("unexpected highlight lines %v", got.HighlightLines)
+	}
+	expected := map[string]string{"title": "main file.go", "linenos": "10", "nowrap": ""}
+	for k, v := range expected {
+		if attr, ok := got.Attrs[k]; !ok || v != attr {
+			t.Fatalf("unexpected attr [%s]: expected [%s], got [%s]", k, v, attr)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+)
+
+var formatStyleTests = []formatTest{
+
+	{"7", "| a | bbbb |\n| :-: | --: |\n| c | d |\n", "| a | bbbb |\n| :-: | --: |\n| c | d |\n"},
+	{"6", "foo\n\n---\n\nbar\n", "foo\n\n***\n\nbar\n"},
+	{"5", "# T\n\n### U\n", "T\n=\n\n### U\n"},
+	{"4", "```go\nx\n~~~~~\n```\n", "~~~~~~go\nx\n~~~~~\n~~~~~~\n"},
+	{"3", "3. a\n4. b\n", "3. a\n3. b\n"},
+	{"2", "*foo* a*b*c **bar**\n", "_foo_ a*b*c __bar__\n"},
+	{"1", "* a\n* b\n\n+ c\n", "- a\n- b\n\n* c\n"},
+	{"0", "foo *bar*\n", "foo _bar_\n"},
+}
+
+func TestFormatStyle(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetFormatBulletMarker("-")
+	luteEngine.SetFormatEmphasisMarker("_")
+	luteEngine.SetFormatStrongMarker("__")
+	luteEngine.SetFormatOrderedListNumbering("one")
+	luteEngine.SetFormatCodeFence("~", 4)
+	luteEngine.SetFormatThematicBreak("***")
+	luteEngine.SetFormatHeadingStyle("setext")
+	luteEngine.SetFormatTablePadding(false)
+	for _, test := range formatStyleTests {
+		formatted := luteEngine.FormatStr(test.name, test.original)
+		if test.formatted != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.formatted, formatted, test.original)
+		}
+	}
+}
+
+var formatProseWrapTests = []formatTest{
+
+	{"3", "First one. Second *one!* Third? yes\nstill. 1. no\n", "First one.\nSecond *one!*\nThird?\nyes still. 1.\nno\n"},
+	{"2", "- list item with many words in it ok\n", "- list item with\n  many words in it\n  ok\n"},
+	{"1", "one `code with spaces here` two - three\nfour\n", "one\n`code with spaces here`\ntwo - three four\n"},
+	{"0", "one two three four five six\n", "one two three four\nfive six\n"},
+}
+
+func TestFormatProseWrap(t *testing.T) {
+	luteEngine := lute.New()
+	for _, test := range formatProseWrapTests {
+		if "3" == test.name {
+			luteEngine.SetFormatProseWrap("sentence", 0)
+		} else {
+			luteEngine.SetFormatProseWrap("column", 20)
+		}
+		formatted := luteEngine.FormatStr(test.name, test.original)
+		if test.formatted != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.formatted, formatted, test.original)
+		}
+	}
+}
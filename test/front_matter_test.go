@@ -0,0 +1,221 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/frontmatter"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+var frontMatterTests = []parseTest{
+
+	{"8", "---\nlist:\n  - name: a\n    tags: [x, 'y z']\n  - name: b\ndesc: |\n  line1\n  line2\nfolded: >-\n  a\n  b\n---\n", "map[desc:line1\nline2\n folded:a b list:[map[name:a tags:[x y z]] map[name:b]]]"},
+	{"7", "---\ntitle: \"Hello: World\" # comment\ndate: 2024-01-02\ndraft: false\nweight: 10\nratio: 0.5\nempty: ~\n---\n", "map[date:2024-01-02 00:00:00 +0000 UTC draft:false empty:<nil> ratio:0.5 title:Hello: World weight:10]"},
+	{"6", "+++\n[params]\nauthor = { name = \"Tom\" }\n\n[[menu]]\nname = \"a\"\n\n[[menu]]\nname = \"b\"\n+++\n", "map[menu:[map[name:a] map[name:b]] params:map[author:map[name:Tom]]]"},
+	{"5", "+++\ntitle = 'Literal \\n'\ndate = 1979-05-27T07:32:00Z\ncount = 1_000\nhex = 0xff\ntags = [\n  \"a\",\n  \"b\", # comment\n]\nsite.name = \"Lute\"\n+++\n", "map[count:1000 date:1979-05-27 07:32:00 +0000 UTC hex:255 site:map[name:Lute] tags:[a b] title:Literal \\n]"},
+	{"4", ";;;\n\"title\": \"Hello\",\n\"tags\": [\"a\", \"b\"]\n;;;\n", "map[tags:[a b] title:Hello]"},
+	{"3", ";;;\n{\"title\": \"Hello\", \"weight\": 1.5}\n;;;\n", "map[title:Hello weight:1.5]"},
+	{"2", "{\n  \"title\": \"Hello\",\n  \"weight\": 10\n}\n\nfoo\n", "map[title:Hello weight:10]"},
+	{"1", "---\n---\n", "map[]"},
+	{"0", "# Hello\n", "map[]"},
+}
+
+func TestFrontMatter(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterTests {
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		if nil != tree.FrontMatterErr {
+			t.Fatalf("test case [%s] failed: %s", test.name, tree.FrontMatterErr)
+		}
+		metadata := fmt.Sprintf("%v", tree.FrontMatter)
+		if test.to != metadata {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, metadata, test.from)
+		}
+	}
+}
+
+func TestFrontMatterErr(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, markdown := range []string{"---\n- a\n- b\n---\n", "---\ntitle: [a, b\n---\n", "+++\ntitle = \"a\"\ntitle = \"b\"\n+++\n", ";;;\n\"title\": \n;;;\n", ";;;\n{\"a\": 1} garbage\n;;;\n"} {
+		tree := parse.Parse("", []byte(markdown), luteEngine.ParseOptions)
+		if nil == tree.FrontMatterErr {
+			t.Fatalf("front matter [%q] should be invalid", markdown)
+		}
+		if tree.FrontMatterModified() {
+			t.Fatalf("invalid front matter [%q] should not be modified", markdown)
+		}
+	}
+}
+
+var frontMatterHTMLTests = []parseTest{
+
+	{"6", "code:{\nnot json\n}\n\nhello\n", "<p>{<br />\nnot json<br />\n}</p>\n<p>hello</p>\n"},
+	{"5", "code:+++\ntitle = \"Hello\"\n\n{\nfoo\n", "<p>+++<br />\ntitle = &quot;Hello&quot;</p>\n<p>{<br />\nfoo</p>\n"},
+	{"4", "meta:---\ntitle: Hello\n- invalid\n---\n\nfoo\n", "<p>foo</p>\n"},
+	{"3", "meta:---\ntitle: A <b>\ntags: [a, b]\nauthor:\n  name: Tom\ndate: 2024-01-02\n---\n\nfoo\n", "<meta name=\"author.name\" content=\"Tom\" />\n<meta name=\"date\" content=\"2024-01-02\" />\n<meta name=\"tags\" content=\"a, b\" />\n<meta name=\"title\" content=\"A &lt;b&gt;\" />\n<p>foo</p>\n"},
+	{"2", "none:+++\ntitle = \"Hello\"\n+++\n\nfoo\n", "<p>foo</p>\n"},
+	{"1", "code:{\n  \"title\": \"Hello\"\n}\n", "<pre class=\"vditor-yml-front-matter\"><code class=\"language-json\">{\n  &quot;title&quot;: &quot;Hello&quot;\n}</code></pre>\n"},
+	{"0", "code:+++\ntitle = \"Hello\"\n+++\n", "<pre class=\"vditor-yml-front-matter\"><code class=\"language-toml\">title = &quot;Hello&quot;</code></pre>\n"},
+}
+
+func TestFrontMatterHTML(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterHTMLTests {
+		mode, markdown := test.from[:strings.Index(test.from, ":")], test.from[strings.Index(test.from, ":")+1:]
+		luteEngine.SetFrontMatterRender(mode)
+		html := luteEngine.MarkdownStr(test.name, markdown)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, markdown)
+		}
+	}
+}
+
+var frontMatterInvalidJSONTests = []parseTest{
+
+	{"1", "{\n\"title\": \"Hello\",\n}\n\nhello\n", "{\n\"title\": \"Hello\",\n}\n\nhello\n"},
+	{"0", "{\nnot json\n}\n\nhello\n", "{\nnot json\n}\n\nhello\n"},
+}
+
+// TestFrontMatterInvalidJSON 测试不是合法 JSON 对象的裸 { 行不作为 Front Matter。
+func TestFrontMatterInvalidJSON(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterInvalidJSONTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+var frontMatterFormatTests = []parseTest{
+
+	{"5", "# Hello\n", "---\ndate: 2024-01-02\ntags:\n  - lute\n  - markdown\n---\n# Hello\n"},
+	{"4", "---\ntitle: Hello\n---\n\nfoo\n", "foo\n"},
+	{"3", "{\n\"title\": \"Hello\"\n}\n\nfoo\n", "{\n  \"date\": \"2024-01-02\",\n  \"tags\": [\n    \"lute\",\n    \"markdown\"\n  ],\n  \"title\": \"Hello\"\n}\nfoo\n"},
+	{"2", ";;;\n\"title\": \"Hello\"\n;;;\n\nfoo\n", ";;;\n{\n  \"date\": \"2024-01-02\",\n  \"tags\": [\n    \"lute\",\n    \"markdown\"\n  ],\n  \"title\": \"Hello\"\n}\n;;;\nfoo\n"},
+	{"1", "+++\ntitle = \"Hello\" # comment\n+++\n\nfoo\n", "+++\ndate = 2024-01-02\ntags = [\"lute\", \"markdown\"]\ntitle = \"Hello\"\n+++\nfoo\n"},
+	{"0", "---\ntitle: Hello # comment\ntags: [a]\n---\n\nfoo\n", "---\ndate: 2024-01-02\ntags:\n  - lute\n  - markdown\ntitle: Hello\n---\nfoo\n"},
+}
+
+func TestFrontMatterFormat(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterFormatTests {
+		// 未修改时保持原样
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		if formatted != string(render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render()) {
+			t.Fatalf("test case [%s] failed: unmodified front matter should not be rewritten", test.name)
+		}
+
+		if "4" == test.name {
+			tree.FrontMatter = nil
+		} else {
+			if nil == tree.FrontMatter {
+				tree.FrontMatter = map[string]interface{}{}
+			}
+			tree.FrontMatter["date"] = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			tree.FrontMatter["tags"] = []interface{}{"lute", "markdown"}
+		}
+		if !tree.FrontMatterModified() {
+			t.Fatalf("test case [%s] failed: front matter should be modified", test.name)
+		}
+		formatted = string(render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render())
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
+
+func TestFrontMatterMarshal(t *testing.T) {
+	metadata := map[string]interface{}{
+		"title":  "Hello: \"World\"",
+		"date":   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"draft":  true,
+		"weight": int64(10),
+		"ratio":  1.0,
+		"tags":   []interface{}{"a", "b c", "true"},
+		"params": map[string]interface{}{"author": "Tom", "nested key": map[string]interface{}{"x": int64(1)}},
+		"menu":   []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}},
+	}
+
+	for _, format := range []string{frontmatter.YAML, frontmatter.TOML, frontmatter.JSON} {
+		data, err := frontmatter.Marshal(format, metadata)
+		if nil != err {
+			t.Fatalf("marshal [%s] failed: %s", format, err)
+		}
+		parsed, err := frontmatter.Unmarshal(format, data)
+		if nil != err {
+			t.Fatalf("unmarshal [%s] failed: %s\n%s", format, err, data)
+		}
+		if _, ok := parsed["ratio"].(float64); !ok {
+			t.Fatalf("format [%s] changed the type of float [ratio] to %T\n%s", format, parsed["ratio"], data)
+		}
+		again, _ := frontmatter.Marshal(format, parsed)
+		if string(data) != string(again) {
+			t.Fatalf("format [%s] is not stable\nexpected\n\t%q\ngot\n\t%q", format, data, again)
+		}
+	}
+}
+
+func TestFrontMatterYAMLInt(t *testing.T) {
+	metadata, err := frontmatter.Unmarshal(frontmatter.YAML, []byte("num: 010\nneg: -007\n"))
+	if nil != err {
+		t.Fatalf("unmarshal failed: %s", err)
+	}
+	if int64(10) != metadata["num"] || int64(-7) != metadata["neg"] {
+		t.Fatalf("unexpected metadata %#v", metadata)
+	}
+	data, err := frontmatter.Marshal(frontmatter.YAML, metadata)
+	if nil != err || "neg: -7\nnum: 10\n" != string(data) {
+		t.Fatalf("unexpected marshalled data %q: %v", data, err)
+	}
+}
+
+var frontMatterVditorTests = []parseTest{
+
+	{"2", ";;;\n\"a\": 1\n;;;\n\nfoo\n", ";;;\n\"a\": 1\n;;;\nfoo\n"},
+	{"1", "{\n\"a\": 1\n}\n\nfoo\n", "{\n\"a\": 1\n}\nfoo\n"},
+	{"0", "+++\ntitle = \"x\"\n+++\n\nfoo\n", "+++\ntitle = \"x\"\n+++\nfoo\n"},
+}
+
+func TestFrontMatterVditorIR(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterVditorTests {
+		md := luteEngine.VditorIRDOM2Md(luteEngine.Md2VditorIRDOM(test.from))
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
+
+func TestFrontMatterVditorWYSIWYG(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range frontMatterVditorTests {
+		md := luteEngine.VditorDOM2Md(luteEngine.Md2VditorDOM(test.from))
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/graph"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+func graphTree(luteEngine *lute.Lute, p, markdown string) *parse.Tree {
+	tree := parse.Parse("", []byte(markdown), luteEngine.ParseOptions)
+	tree.ID = ""
+	tree.Path = p
+	return tree
+}
+
+func TestGraph(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetFileAnnotationRef(true)
+
+	g := graph.New(nil)
+	g.Add(graphTree(luteEngine, "notes/a.md", "# Intro\n\nsee [[b]] and [b intro](b.md#intro)\n"))
+	g.Add(graphTree(luteEngine, "notes/b.md", "# Intro\n\n[[missing]]\n\n<<assets/foo-20211115212742-80mbhnk.pdf/20211115213157-zifdhvu \"anno\">>\n"))
+	g.Add(graphTree(luteEngine, "notes/c.md", "lonely\n"))
+
+	backlinks := g.Backlinks("notes/b.md")
+	if 2 != len(backlinks) || "notes/b.md" != backlinks[0].Target || "notes/b.md#Intro" != backlinks[1].Target {
+		t.Fatalf("unexpected backlinks %+v", backlinks)
+	}
+	if n := g.Node("notes/b.md#Intro"); nil == n || graph.KindHeading != n.Kind || 1 != n.Level {
+		t.Fatalf("unexpected heading node %+v", n)
+	}
+	if links := g.Links("notes/a.md"); 2 != len(links) || "notes/a.md" != links[0].Source {
+		t.Fatalf("unexpected links %+v", links)
+	}
+
+	unresolved := g.Unresolved()
+	if 1 != len(unresolved) || "missing" != unresolved[0].Raw {
+		t.Fatalf("unexpected unresolved links %+v", unresolved)
+	}
+	if annotation := g.Backlinks("assets/foo-20211115212742-80mbhnk.pdf/20211115213157-zifdhvu"); 1 != len(annotation) {
+		t.Fatalf("unexpected annotation backlinks %+v", annotation)
+	}
+
+	orphans := g.Orphans()
+	if 2 != len(orphans) || "notes/a.md" != orphans[0].ID || "notes/c.md" != orphans[1].ID {
+		t.Fatalf("unexpected orphans %+v", orphans)
+	}
+
+	// 增量更新：新增被引用的文档后未解析链接消失，删除文档后链接重新变为未解析
+	g.Add(graphTree(luteEngine, "missing.md", "now exists\n"))
+	if unresolved = g.Unresolved(); 0 != len(unresolved) {
+		t.Fatalf("unexpected unresolved links %+v", unresolved)
+	}
+	g.Add(graphTree(luteEngine, "notes/a.md", "no links anymore\n"))
+	if backlinks = g.Backlinks("notes/b.md"); 0 != len(backlinks) {
+		t.Fatalf("unexpected backlinks %+v", backlinks)
+	}
+	g.Remove("missing.md")
+	if unresolved = g.Unresolved(); 1 != len(unresolved) {
+		t.Fatalf("unexpected unresolved links %+v", unresolved)
+	}
+
+	data, err := json.Marshal(g)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\"links\":[{\"kind\":\"wikilink\",\"source\":\"notes/b.md\",\"sourceDoc\":\"notes/b.md\",\"raw\":\"missing\"}") {
+		t.Fatalf("unexpected json %s", data)
+	}
+}
+
+func TestGraphBlockRef(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetBlockRef(true)
+	luteEngine.SetKramdownIAL(true)
+
+	target := parse.Parse("", []byte("foo\n{: id=\"20210101000000-aaaaaaa\"}\n"), luteEngine.ParseOptions)
+	target.ID = "20210101000000-doc0001"
+	source := parse.Parse("", []byte("((20210101000000-aaaaaaa \"foo\"))\n{: id=\"20210101000000-bbbbbbb\"}\n"), luteEngine.ParseOptions)
+	source.ID = "20210101000000-doc0002"
+
+	g := graph.New(nil)
+	g.Add(target)
+	g.Add(source)
+	backlinks := g.Backlinks("20210101000000-aaaaaaa")
+	if 1 != len(backlinks) || "20210101000000-bbbbbbb" != backlinks[0].Source || graph.LinkBlockRef != backlinks[0].Kind {
+		t.Fatalf("unexpected backlinks %+v", backlinks)
+	}
+	if backlinks = g.Backlinks("20210101000000-doc0001"); 1 != len(backlinks) {
+		t.Fatalf("unexpected doc backlinks %+v", backlinks)
+	}
+}
+
+func TestGraphIncremental(t *testing.T) {
+	luteEngine := lute.New()
+
+	g := graph.New(nil)
+	g.Add(graphTree(luteEngine, "x.md", "[[b]]\n\n[c](c.md)\n"))
+	g.Add(graphTree(luteEngine, "z/b.md", "# Intro\n"))
+	g.Add(graphTree(luteEngine, "c.md", "c\n"))
+	if links := g.Links("x.md"); 2 != len(links) || "z/b.md" != links[0].Target || "c.md" != links[1].Target {
+		t.Fatalf("unexpected links %+v", links)
+	}
+
+	// 同名文档中 ID 较小的优先，移除后重新解析到另一个同名文档
+	g.Add(graphTree(luteEngine, "a/b.md", "b\n"))
+	if links := g.Links("x.md"); "a/b.md" != links[0].Target || "c.md" != links[1].Target {
+		t.Fatalf("unexpected links %+v", links)
+	}
+	g.Remove("a/b.md")
+	if backlinks := g.Backlinks("z/b.md"); 1 != len(backlinks) || "x.md" != backlinks[0].Source {
+		t.Fatalf("unexpected backlinks %+v", backlinks)
+	}
+	g.Remove("c.md")
+	if unresolved := g.Unresolved(); 1 != len(unresolved) || "c.md" != unresolved[0].Raw {
+		t.Fatalf("unexpected unresolved links %+v", unresolved)
+	}
+
+	// 返回的链接是副本
+	g.Links("x.md")[0].Target = "changed"
+	g.Backlinks("z/b.md")[0].Target = "changed"
+	if links := g.Links("x.md"); "z/b.md" != links[0].Target {
+		t.Fatalf("returned links should be copies %+v", links)
+	}
+}
+
+func TestGraphSlugger(t *testing.T) {
+	luteEngine := lute.New()
+	options := render.NewOptions()
+	options.Slugger = render.GitHubSlugger{}
+
+	g := graph.New(options)
+	g.Add(graphTree(luteEngine, "a.md", "[b](b.md#foo-bar)\n"))
+	g.Add(graphTree(luteEngine, "b.md", "# Foo Bar\n"))
+	if n := g.Node("b.md#foo-bar"); nil == n || graph.KindHeading != n.Kind {
+		t.Fatalf("unexpected heading node %+v", n)
+	}
+	if backlinks := g.Backlinks("b.md#foo-bar"); 1 != len(backlinks) {
+		t.Fatalf("unexpected backlinks %+v", backlinks)
+	}
+}
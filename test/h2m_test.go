@@ -40,28 +40,28 @@ var html2MdTests = []parseTest{
 	{"57", "a<strong>  b: </strong>c", "a**\u200b  b: \u200b**c\n"},
 	{"56", "<a href=\"https://b3log.org\">foo'bar</a>", "[foo'bar](https://b3log.org)\n"},
 	{"55", "foo'bar", "foo'bar\n"},
-	{"54", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"198\">\n <colgroup><col width=\"198\">\n </colgroup><tbody><tr height=\"45\">\n\n  <td height=\"45\" class=\"xl65\" width=\"198\">foobar\n  baz</td>\n\n </tr>\n</tbody></table>", "| foobar  baz |\n| ------------- |\n"},
+	{"54", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"198\">\n <colgroup><col width=\"198\">\n </colgroup><tbody><tr height=\"45\">\n\n  <td height=\"45\" class=\"xl65\" width=\"198\">foobar\n  baz</td>\n\n </tr>\n</tbody></table>", "| foobar  baz |\n| ----------- |\n"},
 	{"53", "<figure data-size=\"normal\"><noscript><img src=\"https://foo.jpg\" data-caption=\"\" data-size=\"normal\" data-rawwidth=\"642\" data-rawheight=\"643\" class=\"origin_image zh-lightbox-thumb\" width=\"642\" data-original=\"https://foo.jpg\"/></noscript><img src=\"https://foo.jpg\" data-caption=\"\" data-size=\"normal\" data-rawwidth=\"642\" data-rawheight=\"643\" class=\"origin_image zh-lightbox-thumb lazy\" width=\"642\" data-original=\"https://foo.jpg\" data-actualsrc=\"https://foo.jpg\" data-lazy-status=\"ok\"></figure><h2 id=\"h_399935581_6\" data-into-catalog-status=\"\">bar</h2>", "![](https://foo.jpg)\n\n## bar\n"},
 	{"52", "<p><span style=\"font-size:18px\">关键代码如下：</span></p> \n<p><span style=\"font-size:18px\"></span></p>\n<pre><code class=\"language-csharp hljs\"><ol class=\"hljs-ln\" style=\"width:930px\"><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"1\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">        <span class=\"hljs-function\"><span class=\"hljs-keyword\">public</span> <span class=\"hljs-keyword\">static</span> <span class=\"hljs-keyword\">string</span> <span class=\"hljs-title\">getFileHash</span>(<span class=\"hljs-params\"><span class=\"hljs-keyword\">string</span> filePath</span>)</span></div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"2\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">        {           </div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"3\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            <span class=\"hljs-keyword\">try</span></div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"4\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            {</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"5\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                FileStream fs = <span class=\"hljs-keyword\">new</span> FileStream(filePath, FileMode.Open);</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"6\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">int</span> len = (<span class=\"hljs-keyword\">int</span>)fs.Length;</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"7\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">byte</span>[] data = <span class=\"hljs-keyword\">new</span> <span class=\"hljs-keyword\">byte</span>[len];</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"8\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                fs.Read(data, <span class=\"hljs-number\">0</span>, len);</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"9\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                fs.Close();</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"10\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                MD5 md5 = <span class=\"hljs-keyword\">new</span> MD5CryptoServiceProvider();</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"11\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">byte</span>[] result = md5.ComputeHash(data);</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"12\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">string</span> fileMD5 = <span class=\"hljs-string\">\"\"</span>;</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"13\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">foreach</span> (<span class=\"hljs-keyword\">byte</span> b <span class=\"hljs-keyword\">in</span> result)</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"14\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                {</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"15\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                    fileMD5 += Convert.ToString(b, <span class=\"hljs-number\">16</span>);</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"16\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                }</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"17\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">return</span> fileMD5;   </div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"18\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            }</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"19\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            <span class=\"hljs-keyword\">catch</span> (FileNotFoundException e)</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"20\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            {</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"21\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                Console.WriteLine(e.Message);</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"22\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">                <span class=\"hljs-keyword\">return</span> <span class=\"hljs-string\">\"\"</span>;</div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"23\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">            }                                 </div></div></li><li><div class=\"hljs-ln-numbers\"><div class=\"hljs-ln-line hljs-ln-n\" data-line-number=\"24\"></div></div><div class=\"hljs-ln-code\"><div class=\"hljs-ln-line\">        }</div></div></li></ol></code><div class=\"hljs-button signin\" data-title=\"登录后复制\" data-report-click=\"{&quot;spm&quot;:&quot;1001.2101.3001.4334&quot;}\" onclick=\"hljs.signin(event)\"></div></pre>&nbsp; &nbsp; &nbsp; &nbsp; 调用的时候通过填写制定文件的完整目录，即可获得对应文件的MD5码：", "关键代码如下：\n\n\n```csharp\n        public static string getFileHash(string filePath)\n        {           \n            try\n            {\n                FileStream fs = new FileStream(filePath, FileMode.Open);\n                int len = (int)fs.Length;\n                byte[] data = new byte[len];\n                fs.Read(data, 0, len);\n                fs.Close();\n                MD5 md5 = new MD5CryptoServiceProvider();\n                byte[] result = md5.ComputeHash(data);\n                string fileMD5 = \"\";\n                foreach (byte b in result)\n                {\n                    fileMD5 += Convert.ToString(b, 16);\n                }\n                return fileMD5;   \n            }\n            catch (FileNotFoundException e)\n            {\n                Console.WriteLine(e.Message);\n                return \"\";\n            }                                 \n        }\n```\n\n        调用的时候通过填写制定文件的完整目录，即可获得对应文件的 MD5 码：\n"},
 	{"51", "<div class=\"language-js line-numbers-mode\"><pre class=\"language-js\"><code><span class=\"token keyword\">const</span> count <span class=\"token operator\">=</span> <span class=\"token function\">ref</span><span class=\"token punctuation\">(</span><span class=\"token number\">0</span><span class=\"token punctuation\">)</span>\nconsole<span class=\"token punctuation\">.</span><span class=\"token function\">log</span><span class=\"token punctuation\">(</span>count<span class=\"token punctuation\">.</span>value<span class=\"token punctuation\">)</span> <span class=\"token comment\">// 0</span>\n\ncount<span class=\"token punctuation\">.</span>value<span class=\"token operator\">++</span>\nconsole<span class=\"token punctuation\">.</span><span class=\"token function\">log</span><span class=\"token punctuation\">(</span>count<span class=\"token punctuation\">.</span>value<span class=\"token punctuation\">)</span> <span class=\"token comment\">// 1</span>\n</code></pre> <div class=\"line-numbers-wrapper\"><span class=\"line-number\">1</span><br><span class=\"line-number\">2</span><br><span class=\"line-number\">3</span><br><span class=\"line-number\">4</span><br><span class=\"line-number\">5</span><br></div></div>", "```js\nconst count = ref(0)\nconsole.log(count.value) // 0\n\ncount.value++\nconsole.log(count.value) // 1\n```\n"},
-	{"50", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"144\">  <colgroup><col width=\"72\" span=\"2\">  </colgroup><tbody><tr height=\"36\">    <td height=\"36\" class=\"xl65\" width=\"72\">1<br>     2</td>   <td class=\"xl65\" width=\"72\">3<br>     4</td>   </tr> </tbody></table>", "| 1<br/>2 | 3<br/>4 |\n| ----- | ----- |\n"},
+	{"50", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"144\">  <colgroup><col width=\"72\" span=\"2\">  </colgroup><tbody><tr height=\"36\">    <td height=\"36\" class=\"xl65\" width=\"72\">1<br>     2</td>   <td class=\"xl65\" width=\"72\">3<br>     4</td>   </tr> </tbody></table>", "| 1<br/>2 | 3<br/>4 |\n| ------- | ------- |\n"},
 	{"49", "<a href=\"https://b3log.org/siyuan\" data-ved=\"2ahUKEwiE1_PRiP3xAhXQtp4KHXw1AcMQFjAAegQIBhAD\" ping=\"/url?sa=t&amp;source=web&amp;rct=j&amp;url=https://b3log.org/siyuan&amp;ved=2ahUKEwiE1_PRiP3xAhXQtp4KHXw1AcMQFjAAegQIBhAD\"><h3 class=\"LC20lb DKV0Md\">思源笔记- 本地优先的个人知识管理系统，支持Markdown 排版 ...</h3></a>", "[思源笔记- 本地优先的个人知识管理系统，支持 Markdown 排版 ...](https://b3log.org/siyuan)\n"},
 	{"48", "<figure data-size=\"normal\"><img src=\"foo.png\"></figure><h2>bar</h2>", "![](foo.png)\n\n## bar\n"},
-	{"47", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"207\">\n <colgroup><col width=\"207\">\n </colgroup><tbody><tr height=\"126\">\n\n  <td height=\"126\" class=\"xl66\" width=\"207\">foo<font class=\"font7\">bar</font><font class=\"font6\">；<br>\n    baz</font></td>\n\n </tr>\n</tbody></table>", "| foobar；    baz |\n| ----------------- |\n"},
-	{"46", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"207\">\n <colgroup><col width=\"207\">\n </colgroup><tbody><tr height=\"180\">\n\n  <td height=\"180\" class=\"xl66\" width=\"207\"><font class=\"font6\">foo<br>\n    </font><font class=\"font7\">bar</font></td>\n\n </tr>\n</tbody></table>", "| foo    bar |\n| ------------ |\n"},
+	{"47", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"207\">\n <colgroup><col width=\"207\">\n </colgroup><tbody><tr height=\"126\">\n\n  <td height=\"126\" class=\"xl66\" width=\"207\">foo<font class=\"font7\">bar</font><font class=\"font6\">；<br>\n    baz</font></td>\n\n </tr>\n</tbody></table>", "| foobar；    baz |\n| --------------- |\n"},
+	{"46", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"207\">\n <colgroup><col width=\"207\">\n </colgroup><tbody><tr height=\"180\">\n\n  <td height=\"180\" class=\"xl66\" width=\"207\"><font class=\"font6\">foo<br>\n    </font><font class=\"font7\">bar</font></td>\n\n </tr>\n</tbody></table>", "| foo    bar |\n| ---------- |\n"},
 	{"45", "<ul class=\"dictBing-Cdef\"><li class=\"dictBing-CdefItem\"><span class=\"dictBing-CdefItem_Pos\">adj.</span><span class=\"dictBing-CdefItem_Def\">完全正确；对极了</span></li><li class=\"dictBing-CdefItem\"></li></ul><br class=\"Apple-interchange-newline\">", "* **adj.**完全正确；对极了\n*\n"},
-	{"44", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"72\">\n <colgroup><col width=\"72\">\n </colgroup><tbody><tr height=\"36\">\n\n  <td height=\"36\" class=\"xl65\" width=\"72\">foo<br>\n    bar</td>\n\n </tr>\n</tbody></table>", "| foo<br/>bar |\n| --------- |\n"},
+	{"44", "<table border=\"0\" cellpadding=\"0\" cellspacing=\"0\" width=\"72\">\n <colgroup><col width=\"72\">\n </colgroup><tbody><tr height=\"36\">\n\n  <td height=\"36\" class=\"xl65\" width=\"72\">foo<br>\n    bar</td>\n\n </tr>\n</tbody></table>", "| foo<br/>bar |\n| ----------- |\n"},
 	{"43", "<!--StartFragment-->foo<strong>bar.</strong><span>baz</span><!--EndFragment-->", "foo**\u200bbar.\u200b**baz\n"},
 	{"42", "\n<!--StartFragment--><img class=\"rich_pages img_loading\" data-ratio=\"0.5625\" data-s=\"300,640\" data-src=\"https://foo\" data-type=\"jpeg\" data-w=\"1280\" data-backw=\"578\" data-backh=\"326\" _width=\"100%\" src=\"data:image/gif;base64,dataimge\" crossorigin=\"anonymous\" alt=\"图片\">", "![图片](https://foo)\n"},
 	{"41", "<section class=\"code-snippet__fix code-snippet__js\"><pre class=\"code-snippet__js\" data-lang=\"makefile\"><code><span class=\"code-snippet_outer\">foo</span></code><code><span class=\"code-snippet_outer\">bar</span></code></pre></section>", "```\nfoo\nbar\n```\n"},
 	{"40", "<!--StartFragment--><strong>foo.</strong><span>bar</span><!--EndFragment-->", "**​foo.​**bar\n"},
 	{"39", "<!--StartFragment--><p><strong>Js版</strong></p><pre>&lt;script&gt;\n&nbsp;&nbsp;&nbsp;&nbsp; test = \"你好abc\"\n&nbsp;&nbsp;&nbsp;&nbsp; str = \"\"\n&nbsp;&nbsp;&nbsp;&nbsp; for( i=0;&nbsp;&nbsp;&nbsp; i&lt;test.length; i++ )\n&nbsp;&nbsp;&nbsp;&nbsp; {\n&nbsp;&nbsp;&nbsp;&nbsp;  temp = test.charCodeAt(i).toString(16);\n&nbsp;&nbsp;&nbsp;&nbsp;  str&nbsp;&nbsp;&nbsp; += \"\\\\u\"+ new Array(5-String(temp).length).join(\"0\") +temp;\n&nbsp;&nbsp;&nbsp;&nbsp; }\n&nbsp;&nbsp;&nbsp;&nbsp; document.write (str)\n&lt;/script&gt;</pre><br><!--EndFragment-->", "**Js 版**\n\n```\n<script>\n\u00a0\u00a0\u00a0\u00a0 test = \"你好abc\"\n\u00a0\u00a0\u00a0\u00a0 str = \"\"\n\u00a0\u00a0\u00a0\u00a0 for( i=0;\u00a0\u00a0\u00a0 i<test.length; i++ )\n\u00a0\u00a0\u00a0\u00a0 {\n\u00a0\u00a0\u00a0\u00a0  temp = test.charCodeAt(i).toString(16);\n\u00a0\u00a0\u00a0\u00a0  str\u00a0\u00a0\u00a0 += \"\\\\u\"+ new Array(5-String(temp).length).join(\"0\") +temp;\n\u00a0\u00a0\u00a0\u00a0 }\n\u00a0\u00a0\u00a0\u00a0 document.write (str)\n</script>\n```\n"},
-	{"38", "<!--StartFragment--><table width=\"778\"><tbody><tr><td class=\"key\">ú</td><td>&amp;uacute;</td><td>&amp;#250;</td><td class=\"key\">û</td><td>&amp;ucirc;</td><td>&amp;#251;</td><td class=\"key\">ü</td><td>&amp;uuml;</td><td>&amp;#252;</td><td class=\"key\">ý</td><td>&amp;yacute;</td><td>&amp;#253;</td><td class=\"key\">þ</td><td>&amp;thorn;</td><td>&amp;#254;</td></tr><tr><td class=\"key\">ÿ</td><td>&amp;yuml;</td></tr></tbody></table><!--EndFragment-->\n", "| ú | &uacute; | &#250; | û | &ucirc; | &#251; | ü | &uuml; | &#252; | ý | &yacute; | &#253; | þ | &thorn; | &#254; |\n| ---- | ---------- | -------- | ---- | --------- | -------- | ---- | -------- | -------- | ---- | ---------- | -------- | ---- | --------- | -------- |\n| ÿ | &yuml;   |\n"},
-	{"37", "<!--StartFragment--><table width=\"400\"><tbody><tr><th>显示</th><th>说明</th><th>实体名称</th><th>实体编号</th></tr><tr><td class=\"key\"></td><td>半方大的空白</td><td>&amp;ensp;</td><td>&amp;#8194;</td></tr><tr></tr><tr><td class=\"key\"></td><td>全方大的空白</td><td>&amp;emsp;</td><td>&amp;#8195;</td></tr><tr></tr><tr><td class=\"key\"></td><td>不断行的空白格</td><td>&amp;nbsp;</td><td>&amp;#160;</td></tr><tr><td class=\"key\">&lt;</td><td>小于</td><td>&amp;lt;</td><td>&amp;#60;</td></tr><tr><td class=\"key\">&gt;</td><td>大于</td><td>&amp;gt;</td><td>&amp;#62;</td></tr><tr><td class=\"key\">&amp;</td><td>&amp;符号</td><td>&amp;amp;</td><td>&amp;#38;</td></tr><tr><td class=\"key\">\"</td><td>双引号</td><td>&amp;quot;</td><td>&amp;#34;</td></tr><tr><td class=\"key\">©</td><td>版权</td><td>&amp;copy;</td><td>&amp;#169;</td></tr><tr><td class=\"key\">®</td><td>已注册商标</td><td>&amp;reg;</td><td>&amp;#174;</td></tr><tr><td class=\"key\">™</td><td>商标（美国）</td><td>™</td><td>&amp;#8482;</td></tr><tr></tr><tr><td class=\"key\">×</td><td>乘号</td><td>&amp;times;</td><td>&amp;#215;</td></tr><tr><td class=\"key\">÷</td><td>除号</td><td>&amp;divide;</td><td>&amp;#247;</td></tr></tbody></table><!--EndFragment-->\n", "| 显示 | 说明           | 实体名称 | 实体编号 |\n| ------ | ---------------- | ---------- | ---------- |\n|      | 半方大的空白   | &ensp;   | &#8194;  |\n|      | 全方大的空白   | &emsp;   | &#8195;  |\n|      | 不断行的空白格 | &nbsp;   | &#160;   |\n| <    | 小于           | &lt;     | &#60;    |\n| >    | 大于           | &gt;     | &#62;    |\n| &    | &符号          | &amp;    | &#38;    |\n| \"    | 双引号         | &quot;   | &#34;    |\n| ©   | 版权           | &copy;   | &#169;   |\n| ®   | 已注册商标     | &reg;    | &#174;   |\n| ™   | 商标（美国）   | ™       | &#8482;  |\n| ×   | 乘号           | &times;  | &#215;   |\n| ÷   | 除号           | &divide; | &#247;   |\n"},
+	{"38", "<!--StartFragment--><table width=\"778\"><tbody><tr><td class=\"key\">ú</td><td>&amp;uacute;</td><td>&amp;#250;</td><td class=\"key\">û</td><td>&amp;ucirc;</td><td>&amp;#251;</td><td class=\"key\">ü</td><td>&amp;uuml;</td><td>&amp;#252;</td><td class=\"key\">ý</td><td>&amp;yacute;</td><td>&amp;#253;</td><td class=\"key\">þ</td><td>&amp;thorn;</td><td>&amp;#254;</td></tr><tr><td class=\"key\">ÿ</td><td>&amp;yuml;</td></tr></tbody></table><!--EndFragment-->\n", "| ú | &uacute; | &#250; | û | &ucirc; | &#251; | ü | &uuml; | &#252; | ý | &yacute; | &#253; | þ | &thorn; | &#254; |\n| - | -------- | ------ | - | ------- | ------ | - | ------ | ------ | - | -------- | ------ | - | ------- | ------ |\n| ÿ | &yuml;   |\n"},
+	{"37", "<!--StartFragment--><table width=\"400\"><tbody><tr><th>显示</th><th>说明</th><th>实体名称</th><th>实体编号</th></tr><tr><td class=\"key\"></td><td>半方大的空白</td><td>&amp;ensp;</td><td>&amp;#8194;</td></tr><tr></tr><tr><td class=\"key\"></td><td>全方大的空白</td><td>&amp;emsp;</td><td>&amp;#8195;</td></tr><tr></tr><tr><td class=\"key\"></td><td>不断行的空白格</td><td>&amp;nbsp;</td><td>&amp;#160;</td></tr><tr><td class=\"key\">&lt;</td><td>小于</td><td>&amp;lt;</td><td>&amp;#60;</td></tr><tr><td class=\"key\">&gt;</td><td>大于</td><td>&amp;gt;</td><td>&amp;#62;</td></tr><tr><td class=\"key\">&amp;</td><td>&amp;符号</td><td>&amp;amp;</td><td>&amp;#38;</td></tr><tr><td class=\"key\">\"</td><td>双引号</td><td>&amp;quot;</td><td>&amp;#34;</td></tr><tr><td class=\"key\">©</td><td>版权</td><td>&amp;copy;</td><td>&amp;#169;</td></tr><tr><td class=\"key\">®</td><td>已注册商标</td><td>&amp;reg;</td><td>&amp;#174;</td></tr><tr><td class=\"key\">™</td><td>商标（美国）</td><td>™</td><td>&amp;#8482;</td></tr><tr></tr><tr><td class=\"key\">×</td><td>乘号</td><td>&amp;times;</td><td>&amp;#215;</td></tr><tr><td class=\"key\">÷</td><td>除号</td><td>&amp;divide;</td><td>&amp;#247;</td></tr></tbody></table><!--EndFragment-->\n", "| 显示 | 说明           | 实体名称 | 实体编号 |\n| ---- | -------------- | -------- | -------- |\n|      | 半方大的空白   | &ensp;   | &#8194;  |\n|      | 全方大的空白   | &emsp;   | &#8195;  |\n|      | 不断行的空白格 | &nbsp;   | &#160;   |\n| <    | 小于           | &lt;     | &#60;    |\n| >    | 大于           | &gt;     | &#62;    |\n| &    | &符号          | &amp;    | &#38;    |\n| \"    | 双引号         | &quot;   | &#34;    |\n| ©    | 版权           | &copy;   | &#169;   |\n| ®    | 已注册商标     | &reg;    | &#174;   |\n| ™    | 商标（美国）   | ™        | &#8482;  |\n| ×    | 乘号           | &times;  | &#215;   |\n| ÷    | 除号           | &divide; | &#247;   |\n"},
 	{"36", "<!--StartFragment--><h1><b><span>foo</span></b><b><span><o:p></o:p></span></b></h1><p class=\"MsoNormal\"><img width=\"554\" height=\"337\" src=\"file:///C:\\WINDOWS\\TEMP\\ksohtml15220\\wps4.jpg\"><span><o:p>&nbsp;</o:p></span></p><!--EndFragment-->", "# **foo**\n\n![](file:///C:\\WINDOWS\\TEMP\\ksohtml15220\\wps4.jpg)\n"},
 	{"35", "<a href=\"bar\">&lt;foo&gt;</a>", "[<foo>](bar)\n"},
 	{"34", "<div class=\"gatsby-highlight\" data-language=\"js\"><pre class=\"blog-code language-js\"><span class=\"token keyword\">const</span></pre></div>", "```js\nconst\n```\n"},
-	{"33", "<table><tr><td><p>事件编号</p></td><td><p>事件类别(category)</p></td><td><p>事件操作(action)</p></td><td><p>事件标签(label)</p></td><td><p>事件值(value)</p></td></tr><tr><td><p>1</p></td><td><p>合作行业标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>2</p></td><td><p>营销目的标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>3</p></td><td><p>合作资源标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>4</p></td><td><p>合作平台标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>5</p></td><td><p>卡片</p></td><td><p>查看详情</p></td><td><p>案例名称</p></td><td></td></tr><tr><td><p>6</p></td><td><p>卡片</p></td><td><p>点赞</p></td><td><p>案例名称</p></td><td><p>点赞数</p></td></tr><tr><td><p>7</p></td><td><p>卡片</p></td><td><p>取消点赞</p></td><td><p>案例名称</p></td><td><p>点赞数</p></td></tr><tr><td><p>8</p></td><td><p>卡片</p></td><td><p>下载分享图</p></td><td><p>案例名称</p></td><td></td></tr></table>", "| 事件编号 | 事件类别(category) | 事件操作(action) | 事件标签(label) | 事件值(value) |\n| ---------- | -------------------- | ------------------ | ----------------- | --------------- |\n| 1        | 合作行业标签       | 点击             | 选择条件        |               |\n| 2        | 营销目的标签       | 点击             | 选择条件        |               |\n| 3        | 合作资源标签       | 点击             | 选择条件        |               |\n| 4        | 合作平台标签       | 点击             | 选择条件        |               |\n| 5        | 卡片               | 查看详情         | 案例名称        |               |\n| 6        | 卡片               | 点赞             | 案例名称        | 点赞数        |\n| 7        | 卡片               | 取消点赞         | 案例名称        | 点赞数        |\n| 8        | 卡片               | 下载分享图       | 案例名称        |               |\n"},
+	{"33", "<table><tr><td><p>事件编号</p></td><td><p>事件类别(category)</p></td><td><p>事件操作(action)</p></td><td><p>事件标签(label)</p></td><td><p>事件值(value)</p></td></tr><tr><td><p>1</p></td><td><p>合作行业标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>2</p></td><td><p>营销目的标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>3</p></td><td><p>合作资源标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>4</p></td><td><p>合作平台标签</p></td><td><p>点击</p></td><td><p>选择条件</p></td><td></td></tr><tr><td><p>5</p></td><td><p>卡片</p></td><td><p>查看详情</p></td><td><p>案例名称</p></td><td></td></tr><tr><td><p>6</p></td><td><p>卡片</p></td><td><p>点赞</p></td><td><p>案例名称</p></td><td><p>点赞数</p></td></tr><tr><td><p>7</p></td><td><p>卡片</p></td><td><p>取消点赞</p></td><td><p>案例名称</p></td><td><p>点赞数</p></td></tr><tr><td><p>8</p></td><td><p>卡片</p></td><td><p>下载分享图</p></td><td><p>案例名称</p></td><td></td></tr></table>", "| 事件编号 | 事件类别(category) | 事件操作(action) | 事件标签(label) | 事件值(value) |\n| -------- | ------------------ | ---------------- | --------------- | ------------- |\n| 1        | 合作行业标签       | 点击             | 选择条件        |               |\n| 2        | 营销目的标签       | 点击             | 选择条件        |               |\n| 3        | 合作资源标签       | 点击             | 选择条件        |               |\n| 4        | 合作平台标签       | 点击             | 选择条件        |               |\n| 5        | 卡片               | 查看详情         | 案例名称        |               |\n| 6        | 卡片               | 点赞             | 案例名称        | 点赞数        |\n| 7        | 卡片               | 取消点赞         | 案例名称        | 点赞数        |\n| 8        | 卡片               | 下载分享图       | 案例名称        |               |\n"},
 	{"32", "<ul>\n  <li>咖啡</li>\n  <li>茶\n    <ul>\n    <li>红茶</li>\n    <li>绿茶</li>\n    </ul>\n  </li>\n  <li>牛奶</li>\n</ul>", "* 咖啡\n* 茶\n  * 红茶\n  * 绿茶\n* 牛奶\n"},
 	{"32", "<ul>\n<li>foo</li>\n<li>bar\n<ul>\n<li>baz</li>\n<li>baz</li>\n</ul>\n</li>\n<li>bar</li>\n</ul>", "* foo\n* bar\n  * baz\n  * baz\n* bar\n"},
 	{"31", "<ul>\n<li>foo\n<ul>\n<li>bar</li>\n</ul>\n</li>\n</ul>", "* foo\n  * bar\n"},
@@ -84,7 +84,7 @@ var html2MdTests = []parseTest{
 		</tr>
 	</table>
 </body>
-</html>`, "| Month    | Savings |\n| ---------- | --------- |\n| January  | \\$100   |\n| February | \\$80    |\n"},
+</html>`, "| Month    | Savings |\n| -------- | ------- |\n| January  | \\$100   |\n| February | \\$80    |\n"},
 	{"27", `<html>
 <body>
     <table>
@@ -106,10 +106,10 @@ var html2MdTests = []parseTest{
             </tbody>
     </table>
 </body>
-</html>`, "| Month    | Savings |\n| ---------- | --------- |\n| January  | \\$100   |\n| February | \\$80    |\n"},
-	{"26", "<table class=\"markdown-reference\"><thead><tr><th>Type</th><th class=\"second-example\">Or</th><th>… to Get</th></tr></thead><tbody><tr><td class=\"preformatted\">*Italic*</td><td class=\"preformatted second-example\">_Italic_</td><td><em>Italic</em></td></tr><tr><td class=\"preformatted\">**Bold**</td><td class=\"preformatted second-example\">__Bold__</td><td><strong>Bold</strong></td></tr><tr><td class=\"preformatted\"># Heading 1</td><td class=\"preformatted second-example\">Heading 1<br>=========</td><td><h1 class=\"smaller-h1\">Heading 1</h1></td></tr><tr><td class=\"preformatted\">## Heading 2</td><td class=\"preformatted second-example\">Heading 2<br>---------</td><td><h2 class=\"smaller-h2\">Heading 2</h2></td></tr><tr><td class=\"preformatted\">[Link](http://a.com)</td><td class=\"preformatted second-example\">[Link][1]<br>⋮<br>[1]: http://b.org</td><td><a href=\"https://commonmark.org/\">Link</a></td></tr><tr><td class=\"preformatted\">![Image](http://url/a.png)</td><td class=\"preformatted second-example\">![Image][1]<br>⋮<br>[1]: http://url/b.jpg</td><td><img src=\"https://commonmark.org/help/images/favicon.png\" width=\"36\" height=\"36\" alt=\"Markdown\"></td></tr><tr><td class=\"preformatted\">&gt; Blockquote</td><td class=\"preformatted second-example\">&nbsp;</td><td><blockquote>Blockquote</blockquote></td></tr><tr><td class=\"preformatted\"><p>* List<br>* List<br>* List</p></td><td class=\"preformatted second-example\"><p>- List<br>- List<br>- List<br></p></td><td><ul><li>List</li><li>List</li><li>List</li></ul></td></tr></tbody></table>", "| Type                       | Or                                   | … to Get                                                 |\n| ---------------------------- | -------------------------------------- | ----------------------------------------------------------- |\n| \\*Italic\\*                 | \\_Italic\\_                           | *Italic*                                                |\n| \\*\\*Bold\\*\\*               | \\_\\_Bold\\_\\_                         | **Bold**                                            |\n| # Heading 1                | Heading 1<br/>=========                  | # Heading 1                                              |\n| ## Heading 2               | Heading 2<br/>---------                  | ## Heading 2                                             |\n| [Link](http://a.com)       | [Link][1]<br/>⋮<br/>[1]: http://b.org       | [Link](https://commonmark.org/)                              |\n| ![Image](http://url/a.png) | ![Image][1]<br/>⋮<br/>[1]: http://url/b.jpg | ![Markdown](https://commonmark.org/help/images/favicon.png) |\n| > Blockquote               |                                      | > Blockquote                                     |\n| \\* List<br/>\\* List<br/>\\* List    | - List<br/>- List<br/>- List<br/>                | * List* List* List                                      |\n"},
-	{"25", "<table class=\"table table-bordered\"><thead class=\"thead-light\"><tr><th>Element</th><th>Markdown Syntax</th></tr></thead><tbody><tr><td><a href=\"https://www.markdownguide.org/extended-syntax/#tables\">Table</a></td><td><code>| Syntax | Description |<br>| ----------- | ----------- |<br>| Header | Title |<br>| Paragraph | Text |</code></td></tr><tr><td><a href=\"https://www.markdownguide.org/extended-syntax/#fenced-code-blocks\">Fenced Code Block</a></td><td><code>```<br>{<br>&nbsp;&nbsp;\"firstName\": \"John\",<br>&nbsp;&nbsp;\"lastName\": \"Smith\",<br>&nbsp;&nbsp;\"age\": 25<br>}<br>```</code></td></tr></tbody></table>", "| Element                                                                             | Markdown Syntax                                                                                                  |\n| ------------------------------------------------------------------------------------- | ------------------------------------------------------------------------------------------------------------------ |\n| [Table](https://www.markdownguide.org/extended-syntax/#tables)                         | `\\| Syntax \\| Description \\|\\| ----------- \\| ----------- \\|\\| Header \\| Title \\|\\| Paragraph \\| Text \\|` |\n| [Fenced Code Block](https://www.markdownguide.org/extended-syntax/#fenced-code-blocks) | ````{\u00a0\u00a0\"firstName\": \"John\",\u00a0\u00a0\"lastName\": \"Smith\",\u00a0\u00a0\"age\": 25}````        |\n"},
-	{"24", "<table><thead><tr><th>Element</th><th>Markdown Syntax</th></tr></thead><tbody><tr><td>Table</td><td><code>| Syntax | Description |<br>| ----------- | ----------- |<br>| Header | Title |<br>| Paragraph | Text |</code></td></tr></tbody></table>", "| Element | Markdown Syntax                                                                                                  |\n| --------- | ------------------------------------------------------------------------------------------------------------------ |\n| Table   | `\\| Syntax \\| Description \\|\\| ----------- \\| ----------- \\|\\| Header \\| Title \\|\\| Paragraph \\| Text \\|` |\n"},
+</html>`, "| Month    | Savings |\n| -------- | ------- |\n| January  | \\$100   |\n| February | \\$80    |\n"},
+	{"26", "<table class=\"markdown-reference\"><thead><tr><th>Type</th><th class=\"second-example\">Or</th><th>… to Get</th></tr></thead><tbody><tr><td class=\"preformatted\">*Italic*</td><td class=\"preformatted second-example\">_Italic_</td><td><em>Italic</em></td></tr><tr><td class=\"preformatted\">**Bold**</td><td class=\"preformatted second-example\">__Bold__</td><td><strong>Bold</strong></td></tr><tr><td class=\"preformatted\"># Heading 1</td><td class=\"preformatted second-example\">Heading 1<br>=========</td><td><h1 class=\"smaller-h1\">Heading 1</h1></td></tr><tr><td class=\"preformatted\">## Heading 2</td><td class=\"preformatted second-example\">Heading 2<br>---------</td><td><h2 class=\"smaller-h2\">Heading 2</h2></td></tr><tr><td class=\"preformatted\">[Link](http://a.com)</td><td class=\"preformatted second-example\">[Link][1]<br>⋮<br>[1]: http://b.org</td><td><a href=\"https://commonmark.org/\">Link</a></td></tr><tr><td class=\"preformatted\">![Image](http://url/a.png)</td><td class=\"preformatted second-example\">![Image][1]<br>⋮<br>[1]: http://url/b.jpg</td><td><img src=\"https://commonmark.org/help/images/favicon.png\" width=\"36\" height=\"36\" alt=\"Markdown\"></td></tr><tr><td class=\"preformatted\">&gt; Blockquote</td><td class=\"preformatted second-example\">&nbsp;</td><td><blockquote>Blockquote</blockquote></td></tr><tr><td class=\"preformatted\"><p>* List<br>* List<br>* List</p></td><td class=\"preformatted second-example\"><p>- List<br>- List<br>- List<br></p></td><td><ul><li>List</li><li>List</li><li>List</li></ul></td></tr></tbody></table>", "| Type                            | Or                                          | … to Get                                                  |\n| ------------------------------- | ------------------------------------------- | --------------------------------------------------------- |\n| \\*Italic\\*                      | \\_Italic\\_                                  | *Italic*                                                |\n| \\*\\*Bold\\*\\*                    | \\_\\_Bold\\_\\_                                | **Bold**                                            |\n| # Heading 1                     | Heading 1<br/>=========                     | # Heading 1                                              |\n| ## Heading 2                    | Heading 2<br/>---------                     | ## Heading 2                                             |\n| [Link](http://a.com)            | [Link][1]<br/>⋮<br/>[1]: http://b.org       | [Link](https://commonmark.org/)                              |\n| ![Image](http://url/a.png)      | ![Image][1]<br/>⋮<br/>[1]: http://url/b.jpg | ![Markdown](https://commonmark.org/help/images/favicon.png) |\n| > Blockquote                    |                                             | > Blockquote                                     |\n| \\* List<br/>\\* List<br/>\\* List | - List<br/>- List<br/>- List<br/>           | * List* List* List                                      |\n"},
+	{"25", "<table class=\"table table-bordered\"><thead class=\"thead-light\"><tr><th>Element</th><th>Markdown Syntax</th></tr></thead><tbody><tr><td><a href=\"https://www.markdownguide.org/extended-syntax/#tables\">Table</a></td><td><code>| Syntax | Description |<br>| ----------- | ----------- |<br>| Header | Title |<br>| Paragraph | Text |</code></td></tr><tr><td><a href=\"https://www.markdownguide.org/extended-syntax/#fenced-code-blocks\">Fenced Code Block</a></td><td><code>```<br>{<br>&nbsp;&nbsp;\"firstName\": \"John\",<br>&nbsp;&nbsp;\"lastName\": \"Smith\",<br>&nbsp;&nbsp;\"age\": 25<br>}<br>```</code></td></tr></tbody></table>", "| Element                                                                             | Markdown Syntax                                                                                                  |\n| ----------------------------------------------------------------------------------- | ---------------------------------------------------------------------------------------------------------------- |\n| [Table](https://www.markdownguide.org/extended-syntax/#tables)                         | `\\| Syntax \\| Description \\|\\| ----------- \\| ----------- \\|\\| Header \\| Title \\|\\| Paragraph \\| Text \\|` |\n| [Fenced Code Block](https://www.markdownguide.org/extended-syntax/#fenced-code-blocks) | ```` ```{\u00a0\u00a0\"firstName\": \"John\",\u00a0\u00a0\"lastName\": \"Smith\",\u00a0\u00a0\"age\": 25}``` ````              |\n"},
+	{"24", "<table><thead><tr><th>Element</th><th>Markdown Syntax</th></tr></thead><tbody><tr><td>Table</td><td><code>| Syntax | Description |<br>| ----------- | ----------- |<br>| Header | Title |<br>| Paragraph | Text |</code></td></tr></tbody></table>", "| Element | Markdown Syntax                                                                                                  |\n| ------- | ---------------------------------------------------------------------------------------------------------------- |\n| Table   | `\\| Syntax \\| Description \\|\\| ----------- \\| ----------- \\|\\| Header \\| Title \\|\\| Paragraph \\| Text \\|` |\n"},
 	{"23", "<h2 style=\"box-sizing: border-box; margin-top: 24px; margin-bottom: 16px; font-weight: 600; font-size: 1.5em; line-height: 1.25; padding-bottom: 0.3em; border-bottom: 1px solid rgb(234, 236, 239); color: rgb(36, 41, 46); font-family: -apple-system, BlinkMacSystemFont, &quot;Segoe UI&quot;, Helvetica, Arial, sans-serif, &quot;Apple Color Emoji&quot;, &quot;Segoe UI Emoji&quot;; font-style: normal; font-variant-ligatures: normal; font-variant-caps: normal; letter-spacing: normal; orphans: 2; text-align: start; text-indent: 0px; text-transform: none; white-space: normal; widows: 2; word-spacing: 0px; -webkit-text-stroke-width: 0px; background-color: rgb(255, 255, 255); text-decoration-style: initial; text-decoration-color: initial;\"><g-emoji class=\"g-emoji\" alias=\"m\" fallback-src=\"https://github.githubassets.com/images/icons/emoji/unicode/24c2.png\" style=\"box-sizing: border-box; font-family: &quot;Apple Color Emoji&quot;, &quot;Segoe UI&quot;, &quot;Segoe UI Emoji&quot;, &quot;Segoe UI Symbol&quot;; font-size: 1.2em; font-weight: 400; line-height: 20px; vertical-align: middle; font-style: normal !important;\">Ⓜ️</g-emoji><span> </span>Markdown User Guide</h2>", "## Ⓜ️ Markdown User Guide\n"},
 	{"22", "<div class=\"highlight highlight-source-shell\"><pre>npm install vditor --save</pre></div>", "```shell\nnpm install vditor --save\n```\n"},
 	{"21", "<h4><a id=\"user-content-id\" class=\"anchor\" aria-hidden=\"true\" href=\"https://github.com/Vanessa219/vditor/blob/master/README.md#id\"><svg class=\"octicon octicon-link\" viewBox=\"0 0 16 16\" version=\"1.1\" width=\"16\" height=\"16\" aria-hidden=\"true\"><path fill-rule=\"evenodd\" d=\"M4 9h1v1H4c-1.5 0-3-1.69-3-3.5S2.55 3 4 3h4c1.45 0 3 1.69 3 3.5 0 1.41-.91 2.72-2 3.25V8.59c.58-.45 1-1.27 1-2.09C10 5.22 8.98 4 8 4H4c-.98 0-2 1.22-2 2.5S3 9 4 9zm9-3h-1v1h1c1 0 2 1.22 2 2.5S13.98 12 13 12H9c-.98 0-2-1.22-2-2.5 0-.83.42-1.64 1-2.09V6.25c-1.09.53-2 1.84-2 3.25C6 11.31 7.55 13 9 13h4c1.45 0 3-1.69 3-3.5S14.5 6 13 6z\"></path></svg></a>id</h4>", "#### id\n"},
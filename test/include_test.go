@@ -0,0 +1,79 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/parse"
+)
+
+var includeFS = fstest.MapFS{
+	"intro.md":             {Data: []byte("intro **text**\n")},
+	"chapters/a.md":        {Data: []byte("# A\n\n![img](img/a.png)\n\n!include sub/b.md\n")},
+	"chapters/sub/b.md":    {Data: []byte("[b](b.html) [top](#top)\n")},
+	"sections.md":          {Data: []byte("# One\n\none\n\n## One.1\n\nsub\n\n# Two\n\ntwo\n")},
+	"lines.md":             {Data: []byte("line1\n\nline3\n\nline5\n")},
+	"cycle1.md":            {Data: []byte("!include cycle2.md\n")},
+	"cycle2.md":            {Data: []byte("!include cycle1.md\n")},
+	"chapters/absolute.md": {Data: []byte("{{include \"/intro.md\"}}\n")},
+	"chapters/schemes.md":  {Data: []byte("[mail](mailto:x@y.z) [tel](tel:+123) [git](git+ssh:repo) [doc](doc.md)\n")},
+	"chapters/refs.md":     {Data: []byte("[lute] [self]\n\n!include sub/refs.md\n\n[b]: sub/b.md\n[self]: self.md\n")},
+	"chapters/sub/refs.md": {Data: []byte("[lute] [self] [c]\n\n[c]: c.md\n")},
+}
+
+var includeTests = []parseTest{
+
+	{"9", "[b] [c]\n\n!include chapters/refs.md\n\n[lute]: https://lute.b3log.org\n", "<p><a href=\"chapters/sub/b.md\">b</a> <a href=\"chapters/sub/c.md\">c</a></p>\n<p><a href=\"https://lute.b3log.org\">lute</a> <a href=\"chapters/self.md\">self</a></p>\n<p><a href=\"https://lute.b3log.org\">lute</a> <a href=\"chapters/self.md\">self</a> <a href=\"chapters/sub/c.md\">c</a></p>\n"},
+
+	{"8", "!include chapters/schemes.md\n", "<p><a href=\"mailto:x@y.z\">mail</a> <a href=\"tel:+123\">tel</a> <a href=\"git+ssh:repo\">git</a> <a href=\"chapters/doc.md\">doc</a></p>\n"},
+	{"7", "!include chapters/absolute.md\n", "<p>intro <strong>text</strong></p>\n"},
+	{"6", "!include missing.md\n", "<p>!include missing.md</p>\n"},
+	{"5", "!include cycle1.md\n", "<p>!include cycle1.md</p>\n"},
+	{"4", "{{include \"lines.md\" lines=\"3-5\"}}\n", "<p>line3</p>\n<p>line5</p>\n"},
+	{"3", "!include lines.md:1\n", "<p>line1</p>\n"},
+	{"2", "!include sections.md#One\n", "<h1 id=\"One\">One</h1>\n<p>one</p>\n<h2 id=\"One-1\">One.1</h2>\n<p>sub</p>\n"},
+	{"1", "!include chapters/a.md\n", "<h1 id=\"A\">A</h1>\n<p><img src=\"chapters/img/a.png\" alt=\"img\" /></p>\n<p><a href=\"chapters/sub/b.html\">b</a> <a href=\"#top\">top</a></p>\n"},
+	{"0", "foo\n\n- !include intro.md\n", "<p>foo</p>\n<ul>\n<li>intro <strong>text</strong></li>\n</ul>\n"},
+}
+
+func TestInclude(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingID(true)
+	luteEngine.SetInclude(true)
+	luteEngine.SetIncludeFS(includeFS)
+
+	for _, test := range includeTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+func TestIncludeErrs(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetInclude(true)
+	luteEngine.SetIncludeFS(includeFS)
+
+	tree := parse.Parse("", []byte("!include cycle1.md\n"), luteEngine.ParseOptions)
+	if 1 != len(tree.IncludeErrs) {
+		t.Fatalf("expected 1 include error, got %v", tree.IncludeErrs)
+	}
+
+	luteEngine.SetIncludeMaxDepth(1)
+	tree = parse.Parse("", []byte("!include chapters/a.md\n"), luteEngine.ParseOptions)
+	if 1 != len(tree.IncludeErrs) {
+		t.Fatalf("expected 1 include error, got %v", tree.IncludeErrs)
+	}
+}
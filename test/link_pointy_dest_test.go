@@ -0,0 +1,41 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+)
+
+// CommonMark 中 <> 包裹的链接地址后面可以跟空白和链接标题 https://spec.commonmark.org/0.30/#links
+var linkPointyDestTests = []parseTest{
+
+	{"5", "[a](<b>c)\n", "<p>[a](<b>c)</p>\n"},
+	{"4", "[a](<b> )\n", "<p><a href=\"b\">a</a></p>\n"},
+	{"3", "[a](<b>\n\"t\")\n", "<p><a href=\"b\" title=\"t\">a</a></p>\n"},
+	{"2", "[a](<b c> 't')\n", "<p><a href=\"b%20c\" title=\"t\">a</a></p>\n"},
+	{"1", "[link](</my uri> \"title\")\n", "<p><a href=\"/my%20uri\" title=\"title\">link</a></p>\n"},
+	{"0", "[link](</my uri>)\n", "<p><a href=\"/my%20uri\">link</a></p>\n"},
+}
+
+func TestLinkPointyDest(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAutoSpace(false)
+	luteEngine.SetFixTermTypo(false)
+
+	for _, test := range linkPointyDestTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/linkcheck"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+type linkCheckTest struct {
+	name     string
+	markdown string
+	kinds    []linkcheck.Kind
+	targets  []string
+}
+
+var linkCheckTests = []linkCheckTest{
+
+	{"6", "[a](docs/other.md#Intro) [b](docs/other.md#Nope)\n", []linkcheck.Kind{linkcheck.BrokenDocumentAnchor}, []string{"docs/other.md#Nope"}},
+	{"5", "![img](img/ok.png) ![img](img/missing.png) [ext](https://ld246.com/x)\n", []linkcheck.Kind{linkcheck.MissingFile}, []string{"img/missing.png"}},
+	{"4", "[foo][bar] [baz][] [ok][def]\n\n[def]: /url\n", []linkcheck.Kind{linkcheck.UndefinedLinkRef, linkcheck.UndefinedLinkRef}, []string{"bar", "baz"}},
+	{"3", "a[^1] b[^2][^4]\n\n[^1]: def\n\n[^3]: unused\n", []linkcheck.Kind{linkcheck.UndefinedFootnote, linkcheck.UndefinedFootnote, linkcheck.UnusedFootnote}, []string{"^2", "^4", "^3"}},
+	{"2", "# 标题 一\n\n[x](#标题-一) [y](#%E6%A0%87%E9%A2%98-%E4%B8%80)\n", nil, nil},
+	{"1", "# Foo\n\n[x](#Foo) [y](#Bar)\n", []linkcheck.Kind{linkcheck.BrokenAnchor}, []string{"#Bar"}},
+	{"0", "# Same\n\n# Same\n\n[x](#Same-)\n", nil, nil},
+}
+
+func TestLinkCheck(t *testing.T) {
+	luteEngine := lute.New()
+	fsys := fstest.MapFS{"img/ok.png": {}}
+	other := parse.Parse("", []byte("# Intro\n"), luteEngine.ParseOptions)
+	other.Path = "docs/other.md"
+
+	for _, test := range linkCheckTests {
+		tree := parse.Parse(test.name, []byte(test.markdown), luteEngine.ParseOptions)
+		diagnostics := linkcheck.CheckAll([]*parse.Tree{tree, other}, fsys, nil)
+		if len(test.kinds) != len(diagnostics) {
+			t.Fatalf("test case [%s] failed\nexpected %d diagnostics, got %d", test.name, len(test.kinds), len(diagnostics))
+		}
+		for i, d := range diagnostics {
+			if test.kinds[i] != d.Kind || test.targets[i] != d.Target {
+				t.Fatalf("test case [%s] failed\nexpected [%s %s]\ngot [%s %s]", test.name, test.kinds[i], test.targets[i], d.Kind, d.Target)
+			}
+		}
+	}
+}
+
+func TestLinkCheckSlugger(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.RenderOptions.Slugger = render.GitHubSlugger{}
+
+	tree := parse.Parse("", []byte("# Foo Bar\n\n[x](#foo-bar) [y](#Foo-Bar)\n"), luteEngine.ParseOptions)
+	diagnostics := linkcheck.Check(tree, nil, luteEngine.RenderOptions)
+	if 1 != len(diagnostics) || linkcheck.BrokenAnchor != diagnostics[0].Kind || "#Foo-Bar" != diagnostics[0].Target {
+		t.Fatalf("unexpected diagnostics %+v", diagnostics)
+	}
+}
+
+func TestLinkCheckPosition(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.ParseOptions.SourceSpan = true
+
+	tree := parse.Parse("", []byte("# Foo\n\n> text\n> [x](#Foo) [y](#Bar)\n"), luteEngine.ParseOptions)
+	diagnostics := linkcheck.Check(tree, nil, nil)
+	if 1 != len(diagnostics) {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diagnostics))
+	}
+	if 4 != diagnostics[0].Line || 17 != diagnostics[0].Column {
+		t.Fatalf("expected line 4 column 17, got line %d column %d", diagnostics[0].Line, diagnostics[0].Column)
+	}
+}
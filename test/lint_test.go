@@ -0,0 +1,123 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/lint"
+)
+
+type lintTest struct {
+	name     string
+	markdown string
+	rules    []string // 检查出的问题对应的规则
+	fixed    string   // 自动修复后的 Markdown
+}
+
+var lintTests = []lintTest{
+
+	{"12", "<https://a.com> and https://c.com/d\n\n- www.bb.com\n", []string{"no-bare-urls", "no-bare-urls"}, "[https://a.com](https://a.com) and [https://c.com/d](https://c.com/d)\n\n- [www.bb.com](http://www.bb.com)\n"},
+	{"11", "<https://a.com> [b](https://b.com)\n", nil, "[https://a.com](https://a.com) [b](https://b.com)\n"},
+	{"10", "# a\n\n## b\n\n#### c\n\n## d\n\n#### e\n\n##### f\n", []string{"heading-increment", "heading-increment"}, "# a\n\n## b\n\n### c\n\n## d\n\n### e\n\n#### f\n"},
+	{"9", "# a\n\n#### b\n\n##### c\n", []string{"heading-increment"}, "# a\n\n## b\n\n### c\n"},
+	{"8", "foo \nbar\n", []string{"no-trailing-spaces"}, "foo\nbar\n"},
+	{"7", "<div>  \nx</div>  \n", []string{"no-trailing-spaces", "no-trailing-spaces"}, "<div>\nx</div>\n"},
+	{"6", "# A\n\n# A\n", []string{"no-duplicate-heading"}, "# A\n\n# A\n"},
+	{"5", "![](foo.png)\n", []string{"image-alt"}, "![](foo.png)\n"},
+	{"4", "see https://ld246.com/x.\n", []string{"no-bare-urls"}, "see [https://ld246.com/x](https://ld246.com/x).\n"},
+	{"3", "**Title**\n\ntext\n\n**Not a title.**\n", []string{"no-emphasis-as-heading"}, "## Title\n\ntext\n\n**Not a title.**\n"},
+	{"2", "- a\n- b\n\n* c\n* d\n", []string{"list-marker-style"}, "- a\n- b\n\n- c\n- d\n"},
+	{"1", "# a\n\n### b\n\n##### c\n", []string{"heading-increment", "heading-increment"}, "# a\n\n## b\n\n### c\n"},
+	{"0", "# a\n\n## b\n", nil, "# a\n\n## b\n"},
+}
+
+func TestLint(t *testing.T) {
+	luteEngine := lute.New()
+
+	for _, test := range lintTests {
+		problems := luteEngine.Lint(test.name, []byte(test.markdown), nil)
+		if len(test.rules) != len(problems) {
+			t.Fatalf("test case [%s] failed\nexpected %d problems, got %d", test.name, len(test.rules), len(problems))
+		}
+		for i, problem := range problems {
+			if test.rules[i] != problem.Rule {
+				t.Fatalf("test case [%s] failed\nexpected rule [%s]\ngot [%s]", test.name, test.rules[i], problem.Rule)
+			}
+		}
+
+		fixed, _ := luteEngine.LintFix(test.name, []byte(test.markdown), nil)
+		if test.fixed != string(fixed) {
+			t.Fatalf("test case [%s] failed\nmarkdown\n  %q\nexpected\n  %q\ngot\n  %q", test.name, test.markdown, test.fixed, string(fixed))
+		}
+	}
+}
+
+func TestLintNoGFMAutoLink(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetGFMAutoLink(false)
+
+	fixed, problems := luteEngine.LintFix("", []byte("see https://ld246.com/x.\n"), nil)
+	if 0 != len(problems) {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+	if expected := "see [https://ld246.com/x](https://ld246.com/x).\n"; expected != string(fixed) {
+		t.Fatalf("expected\n  %q\ngot\n  %q", expected, string(fixed))
+	}
+}
+
+func TestLintFixable(t *testing.T) {
+	luteEngine := lute.New()
+
+	problems := luteEngine.Lint("", []byte("foo \nbar\n\n<div>  \nx</div>\n"), nil)
+	if 2 != len(problems) {
+		t.Fatalf("expected 2 problems, got %d", len(problems))
+	}
+	if problems[0].Fixable || !problems[1].Fixable {
+		t.Fatalf("only trailing spaces in HTML blocks should be fixable")
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	luteEngine := lute.New()
+
+	config := lint.NewConfig().Disable("heading-increment").Set("list-marker-style", "style", "asterisk").Set("no-duplicate-heading", "siblings_only", "true")
+	markdown := "# a\n\n### b\n\n## c\n\n### b\n\n- x\n"
+	fixed, problems := luteEngine.LintFix("", []byte(markdown), config)
+	if 0 != len(problems) {
+		t.Fatalf("unexpected problems %+v", problems)
+	}
+	if expected := "# a\n\n### b\n\n## c\n\n### b\n\n* x\n"; expected != string(fixed) {
+		t.Fatalf("expected\n  %q\ngot\n  %q", expected, string(fixed))
+	}
+}
+
+func TestLintPosition(t *testing.T) {
+	luteEngine := lute.New()
+
+	problems := luteEngine.Lint("", []byte("# a\n\ntext\n\n- x\n\n  ### b\n"), nil)
+	if 1 != len(problems) {
+		t.Fatalf("expected 1 problem, got %d", len(problems))
+	}
+	if 7 != problems[0].Line || 3 != problems[0].Column {
+		t.Fatalf("expected line 7 column 3, got line %d column %d", problems[0].Line, problems[0].Column)
+	}
+
+	// 源码保留了 \r\n 和 \r 换行符
+	problems = luteEngine.Lint("", []byte("# a\r\n\r\ntext\r\rx\n\n- x\r\n\r\n  ### b\r\n"), nil)
+	if 1 != len(problems) {
+		t.Fatalf("expected 1 problem, got %d", len(problems))
+	}
+	if 9 != problems[0].Line || 3 != problems[0].Column {
+		t.Fatalf("expected line 9 column 3, got line %d column %d", problems[0].Line, problems[0].Column)
+	}
+}
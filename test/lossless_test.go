@@ -0,0 +1,107 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+type losslessTest struct {
+	name     string
+	original string
+	edit     func(tree *parse.Tree)
+	expected string
+}
+
+func editText(from, to string) func(tree *parse.Tree) {
+	return func(tree *parse.Tree) {
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if entering && ast.NodeText == n.Type && from == string(n.Tokens) {
+				n.Tokens = []byte(to)
+			}
+			return ast.WalkContinue
+		})
+	}
+}
+
+var losslessTests = []losslessTest{
+
+	{"17", "a\x00b\r\n\r\nc\r\n", editText("c", "d"), "a\x00b\r\n\r\nd\r\n"},
+	{"16", "foo\rbar\r\r- a\r- b\r", editText("b", "x\ny"), "foo\rbar\r\r- a\r- x\r  y\r"},
+	{"15", "# T\r\n\r\n> a\r\n>\r\n> b\r\n\r\nc\r\n", func(tree *parse.Tree) {
+		p := &ast.Node{Type: ast.NodeParagraph}
+		p.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: []byte("new\nlines")})
+		tree.Root.FirstChild.InsertAfter(p)
+	}, "# T\r\n\r\nnew\r\nlines\r\n\r\n> a\r\n>\r\n> b\r\n\r\nc\r\n"},
+	{"14", "- a\r\n- b\r\n\r\n> x\r\n> y\r\n", editText("y", "z"), "- a\r\n- b\r\n\r\n> x\r\n> z\r\n"},
+
+	{"13", "+   one\n+   two\n    - nested  \n+   three\n", editText("three", "THREE"), "+   one\n+   two\n    - nested  \n+   THREE\n"},
+	{"12", "a\n\nb", editText("a", "c"), "c\n\nb"},
+	{"11", "> foo\n>bar *x*\n>\n>  baz\n", editText("bar ", "qux "), "> foo\n> qux *x*\n>\n>  baz\n"},
+	{"10", "1.  a\n\n    para  two\n\n2.  b\n", editText("para  two", "p2\nline"), "1.  a\n\n    p2\n    line\n\n2.  b\n"},
+	{"9", "> - a\n>   - x\n>   - y\n> - b\n", func(tree *parse.Tree) {
+		tree.Root.FirstChild.FirstChild.Next.FirstChild.LastChild.FirstChild.Unlink()
+	}, "> - a\n>   - y\n> - b\n"},
+	{"8", "*   a\n*   b\n", func(tree *parse.Tree) {
+		first := tree.Root.FirstChild.FirstChild
+		li := &ast.Node{Type: ast.NodeListItem, ListData: first.ListData}
+		p := &ast.Node{Type: ast.NodeParagraph}
+		p.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: []byte("new")})
+		li.AppendChild(p)
+		first.InsertAfter(li)
+	}, "*   a\n* new\n*   b\n"},
+	{"7", "!include intro.md\n\nfoo\n", editText("foo", "bar"), "!include intro.md\n\nbar\n"},
+	{"6", "[foo]:  /url\n[bar]: /bar\nsee [foo]\nand [bar]\n", editText("see ", "look "), "[foo]:  /url\n[bar]: /bar\nlook [foo]\nand [bar]\n"},
+	{"5", "|a|b|\n|-|-|\n|1|2|\n\n\n* one\n* two\n", editText("two", "three"), "|a|b|\n|-|-|\n|1|2|\n\n\n* one\n* three\n"},
+	{"4", "# Title\n\n\n\npara  one\n\nremoved\n\n+ item\n", func(tree *parse.Tree) {
+		tree.Root.FirstChild.Next.Next.Unlink()
+	}, "# Title\n\n\n\npara  one\n\n+ item\n"},
+	{"3", "Title\n=====\n\n*  a\n*  b\n", func(tree *parse.Tree) {
+		p := &ast.Node{Type: ast.NodeParagraph}
+		p.AppendChild(&ast.Node{Type: ast.NodeText, Tokens: []byte("new *paragraph*")})
+		tree.Root.FirstChild.InsertAfter(p)
+	}, "Title\n=====\n\nnew *paragraph*\n\n*  a\n*  b\n"},
+	{"2", "foo __bar__\n\n1) baz\n", editText("bar", "qux"), "foo __qux__\n\n1) baz\n"},
+	{"1", "\n\nfoo\r\n***\r\nbar\n\n\n", editText("bar", "baz"), "\n\nfoo\r\n***\r\nbaz\n\n\n"},
+	{"0", "   foo   *bar*\n\n\n| a |\n|---|\n", nil, "   foo   *bar*\n\n\n| a |\n|---|\n"},
+}
+
+func TestLossless(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSourceSpan(true)
+	luteEngine.SetInclude(true)
+	luteEngine.SetIncludeFS(includeFS)
+
+	for _, test := range losslessTests {
+		tree := parse.Parse(test.name, []byte(test.original), luteEngine.ParseOptions)
+		if nil != test.edit {
+			test.edit(tree)
+		}
+		markdown := luteEngine.Tree2Markdown(tree, luteEngine.RenderOptions)
+		if test.expected != markdown {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.expected, markdown, test.original)
+		}
+	}
+}
+
+func TestLosslessWithoutSourceSpan(t *testing.T) {
+	luteEngine := lute.New()
+	original := "foo __bar__\n\n\n* baz\n"
+	tree := parse.Parse("", []byte(original), luteEngine.ParseOptions)
+	markdown := luteEngine.Tree2Markdown(tree, luteEngine.RenderOptions)
+	if expected := luteEngine.FormatStr("", original); expected != markdown {
+		t.Fatalf("lossless render without source span should be the same as format, expected [%q], got [%q]", expected, markdown)
+	}
+}
@@ -0,0 +1,195 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/merge"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+type mergeTest struct {
+	name      string
+	base      string
+	ours      string
+	theirs    string
+	conflicts int
+	merged    string
+}
+
+var mergeTests = []*mergeTest{
+
+	{"6", "* {: id=\"20210101000000-1111111\"}a\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		"* {: id=\"20210101000000-1111111\"}a ours\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		"* {: id=\"20210101000000-1111111\"}a theirs\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		1, "* {: id=\"20210101000000-1111111\"}\n  <<<<<<< ours\n  a ours\n  {: id=\"20210101000000-aaaaaaa\"}\n  =======\n  a theirs\n  {: id=\"20210101000000-aaaaaaa\"}\n  >>>>>>> theirs\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n"},
+	{"5", "* {: id=\"20210101000000-1111111\"}a\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		"* {: id=\"20210101000000-1111111\"}a ours\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		"* {: id=\"20210101000000-1111111\"}a\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b theirs\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n",
+		0, "* {: id=\"20210101000000-1111111\"}a ours\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-2222222\"}b theirs\n  {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-lllllll\"}\n"},
+
+	{"4", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb theirs\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		1, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n<<<<<<< ours\n=======\nb theirs\n{: id=\"20210101000000-bbbbbbb\"}\n>>>>>>> theirs\n"},
+	{"3", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\"}\n",
+		"a theirs\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		0, "a theirs\n{: id=\"20210101000000-aaaaaaa\"}\n"},
+	{"2", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"a ours\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"b\n{: id=\"20210101000000-bbbbbbb\"}\n\na\n{: id=\"20210101000000-aaaaaaa\"}\n",
+		0, "b\n{: id=\"20210101000000-bbbbbbb\"}\n\na ours\n{: id=\"20210101000000-aaaaaaa\"}\n"},
+	{"1", "a\n{: id=\"20210101000000-aaaaaaa\" x=\"1\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\" x=\"2\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\" x=\"3\"}\n",
+		1, "<<<<<<< ours\na\n{: id=\"20210101000000-aaaaaaa\" x=\"2\"}\n=======\na\n{: id=\"20210101000000-aaaaaaa\" x=\"3\"}\n>>>>>>> theirs\n"},
+	{"0", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\" x=\"1\"}\n",
+		"a ours\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\" x=\"1\" y=\"2\" updated=\"20210102000000\"}\n",
+		"a\n{: id=\"20210101000000-aaaaaaa\"}\n\nnew\n{: id=\"20210101000000-ccccccc\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\" x=\"3\" updated=\"20210103000000\"}\n",
+		0, "a ours\n{: id=\"20210101000000-aaaaaaa\"}\n\nnew\n{: id=\"20210101000000-ccccccc\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\" x=\"3\" y=\"2\" updated=\"20210103000000\"}\n"},
+}
+
+func newMergeEngine() *lute.Lute {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetGitConflict(true)
+	return luteEngine
+}
+
+func formatMerged(tree *parse.Tree) string {
+	options := render.NewOptions()
+	options.KramdownBlockIAL = true
+	return string(render.NewFormatRenderer(tree, options).Render())
+}
+
+func TestMerge(t *testing.T) {
+	luteEngine := newMergeEngine()
+	// 测试用例中不包含文档内联属性列表
+	parseBlocks := func(markdown string) *parse.Tree {
+		tree := parse.Parse("", []byte(markdown), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink()
+		return tree
+	}
+
+	for _, test := range mergeTests {
+		merged, conflicts := merge.Merge(parseBlocks(test.base), parseBlocks(test.ours), parseBlocks(test.theirs))
+		if test.conflicts != len(conflicts) {
+			t.Fatalf("test case [%s] failed: expected %d conflicts, got %d", test.name, test.conflicts, len(conflicts))
+		}
+		if actual := formatMerged(merged); test.merged != actual {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q", test.name, test.merged, actual)
+		}
+	}
+}
+
+var mergeWithoutIDTests = []*mergeTest{
+
+	{"6", "- a\n- b\n", "- a\n- b\n- c\n", "- a0\n- b\n", 0, "- a0\n- b\n- c\n"},
+	{"5", "- a\n- b\n", "- a1\n- b\n", "- a2\n- b\n", 1, "- <<<<<<< ours\n  a1\n  =======\n  a2\n  >>>>>>> theirs\n- b\n"},
+	{"4", "A\n", "A\n\nB\n", "A\n\nC\n", 0, "A\n\nB\n\nC\n"},
+	{"3", "", "X\n", "Y\n", 0, "X\n\nY\n"},
+
+	{"2", "a\n\nb\n\nc\n", "a\n\nb ours\n\nc\n", "a\n\nb\n\nnew\n\nc theirs\n", 0, "a\n\nb ours\n\nnew\n\nc theirs\n"},
+	{"1", "x\n\ny\n", "x1\n\ny\n", "x\n\ny2\n", 0, "x1\n\ny2\n"},
+	{"0", "x\n", "x1\n", "x2\n", 1, "<<<<<<< ours\nx1\n=======\nx2\n>>>>>>> theirs\n"},
+}
+
+func TestMergeWithoutID(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetGitConflict(true)
+
+	for _, test := range mergeWithoutIDTests {
+		base := parse.Parse("", []byte(test.base), luteEngine.ParseOptions)
+		ours := parse.Parse("", []byte(test.ours), luteEngine.ParseOptions)
+		theirs := parse.Parse("", []byte(test.theirs), luteEngine.ParseOptions)
+		merged, conflicts := merge.Merge(base, ours, theirs)
+		if test.conflicts != len(conflicts) {
+			t.Fatalf("test case [%s] failed: expected %d conflicts, got %d", test.name, test.conflicts, len(conflicts))
+		}
+		if actual := formatMerged(merged); test.merged != actual {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q", test.name, test.merged, actual)
+		}
+	}
+}
+
+func TestMergeResolve(t *testing.T) {
+	luteEngine := newMergeEngine()
+	conflicted := "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n<<<<<<< ours\nb ours\n{: id=\"20210101000000-bbbbbbb\"}\n=======\nb theirs\n{: id=\"20210101000000-bbbbbbb\"}\n>>>>>>> theirs\n"
+
+	tests := []struct {
+		resolution merge.Resolution
+		expected   string
+	}{
+		{merge.ResolveOurs, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb ours\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+		{merge.ResolveTheirs, "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb theirs\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+	}
+	for _, test := range tests {
+		tree := parse.Parse("", []byte(conflicted), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink()
+		if 1 != len(merge.Conflicts(tree)) {
+			t.Fatalf("expected 1 conflict")
+		}
+		if err := merge.ResolveAll(tree, test.resolution); nil != err {
+			t.Fatal(err)
+		}
+		if actual := formatMerged(tree); test.expected != actual {
+			t.Fatalf("resolve [%d] failed\nexpected\n\t%q\ngot\n\t%q", test.resolution, test.expected, actual)
+		}
+	}
+
+	tree := parse.Parse("", []byte(conflicted), luteEngine.ParseOptions)
+	if err := merge.ResolveAll(tree, merge.ResolveBoth); nil != err {
+		t.Fatal(err)
+	}
+	ours, theirs := tree.Root.FirstChild.Next.Next, tree.Root.FirstChild.Next.Next.Next.Next
+	if "b ours" != ours.Text() || "b theirs" != theirs.Text() {
+		t.Fatalf("resolve both failed: %s", formatMerged(tree))
+	}
+	if "20210101000000-bbbbbbb" != ours.IALAttr("id") || "20210101000000-bbbbbbb" == theirs.IALAttr("id") || theirs.IALAttr("id") != theirs.ID {
+		t.Fatalf("resolve both should regenerate duplicated id: %s", formatMerged(tree))
+	}
+}
+
+func TestMergeResolveSeparatorInContent(t *testing.T) {
+	luteEngine := newMergeEngine()
+	parseBlocks := func(markdown string) *parse.Tree {
+		tree := parse.Parse("", []byte(markdown), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink()
+		return tree
+	}
+
+	base := "```\nx\n```\n{: id=\"20210101000000-aaaaaaa\"}\n"
+	ours := "```\nx\n=======\ny\n```\n{: id=\"20210101000000-aaaaaaa\"}\n"
+	theirs := "```\nz\n=======\n```\n{: id=\"20210101000000-aaaaaaa\"}\n"
+	tests := []struct {
+		resolution merge.Resolution
+		expected   string
+	}{
+		{merge.ResolveOurs, ours},
+		{merge.ResolveTheirs, theirs},
+	}
+	for _, test := range tests {
+		merged, conflicts := merge.Merge(parseBlocks(base), parseBlocks(ours), parseBlocks(theirs))
+		if 1 != len(conflicts) {
+			t.Fatalf("expected 1 conflict, got %d", len(conflicts))
+		}
+		if err := merge.Resolve(merged, conflicts[0], test.resolution); nil != err {
+			t.Fatal(err)
+		}
+		if actual := formatMerged(merged); test.expected != actual {
+			t.Fatalf("resolve [%d] failed\nexpected\n\t%q\ngot\n\t%q", test.resolution, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+const cloneMarkdown = "# foo\n{: id=\"20210101000000-aaaaaaa\" updated=\"20210101000000\"}\n\n* [bar](/bar)\n  {: id=\"20210101000000-bbbbbbb\"}\n\n  baz[^1]\n  {: id=\"20210101000000-ccccccc\"}\n{: id=\"20210101000000-ddddddd\"}\n\n[^1]: note\n    {: id=\"20210101000000-eeeeeee\"}\n{: id=\"20210101000000-fffffff\"}\n"
+
+func TestClone(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetKramdownBlockIAL(true)
+	tree := parse.Parse("", []byte(cloneMarkdown), luteEngine.ParseOptions)
+	original := string(render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render())
+
+	clone := tree.Root.Clone()
+	if !ast.Equal(tree.Root, clone) {
+		t.Fatalf("clone is not equal to the original: %v", ast.Diff(tree.Root, clone))
+	}
+	if clone.FirstChild.ID != tree.Root.FirstChild.ID {
+		t.Fatalf("clone should keep IDs")
+	}
+
+	// 修改复制出的节点不能影响原节点
+	clone.FirstChild.ChildByType(ast.NodeText).Tokens[0] = 'F'
+	clone.FirstChild.SetIALAttr("style", "color: red")
+	clone.FirstChild.Next.Next.ListData.Marker[0] = '+'
+	if got := string(render.NewFormatRenderer(tree, luteEngine.RenderOptions).Render()); original != got {
+		t.Fatalf("modifying clone changed the original\nexpected\n\t%q\ngot\n\t%q", original, got)
+	}
+
+	diffs := ast.Diff(tree.Root, clone)
+	if 3 != len(diffs) {
+		t.Fatalf("expected 3 diffs, got %v", diffs)
+	}
+	if `/0/1 Tokens: "foo" != "Foo"` != diffs[1].String() {
+		t.Fatalf("unexpected diff [%s]", diffs[1])
+	}
+
+	// 脚注引用在子树内时指向复制出的节点
+	cloneTree := &parse.Tree{Root: clone, Context: tree.Context}
+	render.NewHtmlRenderer(cloneTree, luteEngine.RenderOptions).Render()
+}
+
+func TestCloneWithNewIDs(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	luteEngine.SetKramdownBlockIAL(true)
+	tree := parse.Parse("", []byte(cloneMarkdown), luteEngine.ParseOptions)
+
+	clone := tree.Root.FirstChild.Next.Next.CloneWithNewIDs()
+	if nil != clone.Parent || nil != clone.Next {
+		t.Fatalf("clone should be detached")
+	}
+	if !ast.Equal(tree.Root.FirstChild.Next.Next, clone) {
+		t.Fatalf("clone with new IDs should be equal to the original: %v", ast.Diff(tree.Root.FirstChild.Next.Next, clone))
+	}
+
+	ast.Walk(clone, func(n *ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.WalkContinue
+		}
+		if strings.HasPrefix(n.ID, "20210101000000-") || strings.HasPrefix(n.IALAttr("id"), "20210101000000-") {
+			t.Fatalf("node [%s] ID is not regenerated", n.Type)
+		}
+		if ast.NodeKramdownBlockIAL == n.Type && strings.Contains(string(n.Tokens), "20210101000000-") {
+			t.Fatalf("IAL tokens [%s] is not updated", n.Tokens)
+		}
+		return ast.WalkContinue
+	})
+}
+
+func TestDiff(t *testing.T) {
+	luteEngine := lute.New()
+	a := parse.Parse("", []byte("# foo\n\n- bar\n- baz\n"), luteEngine.ParseOptions)
+	b := parse.Parse("", []byte("## foo\n\n- bar\n"), luteEngine.ParseOptions)
+
+	var got []string
+	for _, diff := range ast.Diff(a.Root, b.Root) {
+		got = append(got, diff.String())
+	}
+	expected := `/0 HeadingLevel: "1" != "2"|/0/0 Tokens: "# " != "## "|/1 Children: "2" != "1"`
+	if strings.Join(got, "|") != expected {
+		t.Fatalf("expected diffs\n\t%q\ngot\n\t%q", expected, strings.Join(got, "|"))
+	}
+}
+
+func TestCodeBlockInfoMeta(t *testing.T) {
+	luteEngine := lute.New()
+	a := parse.Parse("", []byte("```go {3} title=\"main.go\"\nfoo\n```\n"), luteEngine.ParseOptions)
+	b := parse.Parse("", []byte("```go {4} title=\"main.go\"\nfoo\n```\n"), luteEngine.ParseOptions)
+
+	clone := a.Root.Clone()
+	if !ast.Equal(a.Root, clone) {
+		t.Fatalf("clone is not equal to the original: %v", ast.Diff(a.Root, clone))
+	}
+	clone.FirstChild.CodeBlockInfoMeta[1] = '5'
+	if "{3} title=\"main.go\"" != string(a.Root.FirstChild.CodeBlockInfoMeta) {
+		t.Fatalf("modifying clone changed the original info meta [%s]", a.Root.FirstChild.CodeBlockInfoMeta)
+	}
+
+	diffs := ast.Diff(a.Root, b.Root)
+	if 2 != len(diffs) || `/0 CodeBlockInfoMeta: "{3} title=\"main.go\"" != "{4} title=\"main.go\""` != diffs[0].String() {
+		t.Fatalf("unexpected diffs %v", diffs)
+	}
+	if a.Root.FirstChild.Fingerprint() == b.Root.FirstChild.Fingerprint() {
+		t.Fatalf("fingerprint should include info meta")
+	}
+}
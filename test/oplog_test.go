@@ -0,0 +1,149 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/blockop"
+	"github.com/88250/lute/oplog"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+var oplogTests = []parseTest{
+
+	{"7", "* {: id=\"20210101000000-lllllll\"}a\n  {: id=\"20210101000000-aaaaaaa\"}\n{: id=\"20210101000000-ttttttt\"}\n",
+		"> * {: id=\"20210101000000-lllllll\"}a\n>   {: id=\"20210101000000-aaaaaaa\"}\n> {: id=\"20210101000000-uuuuuuu\"}\n{: id=\"20210101000000-qqqqqqq\"}\n"},
+	{"6", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"{{{row\na\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n\n}}}\n{: id=\"20210101000000-sssssss\"}\n"},
+	{"5", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"* {: id=\"20210101000000-lllllll\"}a\n  {: id=\"20210101000000-aaaaaaa\"}\n* {: id=\"20210101000000-mmmmmmm\"}c\n  {: id=\"20210101000000-ccccccc\"}\n{: id=\"20210101000000-ttttttt\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+	{"4", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"> x\n> {: id=\"20210101000000-xxxxxxx\"}\n>\n> a\n> {: id=\"20210101000000-aaaaaaa\"}\n{: id=\"20210101000000-qqqqqqq\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+	{"3", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"> a\n> {: id=\"20210101000000-aaaaaaa\"}\n{: id=\"20210101000000-qqqqqqq\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n"},
+	{"2", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\n> b\n> {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-qqqqqqq\"}\n",
+		"> a\n> {: id=\"20210101000000-aaaaaaa\"}\n>\n> b\n> {: id=\"20210101000000-bbbbbbb\"}\n{: id=\"20210101000000-qqqqqqq\"}\n"},
+	{"1", "* {: id=\"20210101000000-lllllll\"}x\n  {: id=\"20210101000000-xxxxxxx\"}\n* {: id=\"20210101000000-mmmmmmm\"}y\n  {: id=\"20210101000000-yyyyyyy\"}\n{: id=\"20210101000000-ttttttt\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n",
+		"* {: id=\"20210101000000-mmmmmmm\"}y\n  {: id=\"20210101000000-yyyyyyy\"}\n* {: id=\"20210101000000-lllllll\"}x2\n  {: id=\"20210101000000-xxxxxxx\"}\n* {: id=\"20210101000000-nnnnnnn\"}new\n  {: id=\"20210101000000-zzzzzzz\"}\n{: id=\"20210101000000-ttttttt\"}\n"},
+	{"0", "a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n\nc\n{: id=\"20210101000000-ccccccc\"}\n",
+		"c\n{: id=\"20210101000000-ccccccc\"}\n\na2\n{: id=\"20210101000000-aaaaaaa\"}\n\nd\n{: id=\"20210101000000-ddddddd\"}\n"},
+}
+
+func TestOplog(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	options := render.NewOptions()
+	options.KramdownBlockIAL = true
+	parseBlocks := func(markdown string) *parse.Tree {
+		tree := parse.Parse("", []byte(markdown), luteEngine.ParseOptions)
+		tree.Root.LastChild.Unlink() // 剔除文档内联属性列表
+		return tree
+	}
+
+	for _, test := range oplogTests {
+		oldTree, newTree := parseBlocks(test.from), parseBlocks(test.to)
+		ops := oplog.Derive(oldTree, newTree)
+		if err := oplog.Apply(oldTree, ops); nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+
+		expected := string(render.NewFormatRenderer(newTree, options).Render())
+		actual := string(render.NewFormatRenderer(oldTree, options).Render())
+		if expected != actual {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, expected, actual, test.from)
+		}
+	}
+}
+
+// TestOplogBlockop 测试在 Protyle DOM 转换得到的树上随机进行块级操作后，Derive 得到的操作能将原树变为操作后的树。
+func TestOplogBlockop(t *testing.T) {
+	// 操作中新建的块需要不重复的 ID
+	defer func(testing bool) { ast.Testing = testing }(ast.Testing)
+	ast.Testing = false
+
+	luteEngine := lute.New()
+	luteEngine.SetProtyleWYSIWYG(true)
+	luteEngine.SetKramdownIAL(true)
+	options := render.NewOptions()
+	options.KramdownBlockIAL = true
+
+	dom := luteEngine.Md2BlockDOM("- a\n- b\n  - c\n- d\n\n1. e\n2. f\n   1. x\n   2. y\n\n> g\n\n- [ ] t\n- [x] u\n\nh\n\ni\n")
+	ops := []func(n *ast.Node){
+		func(n *ast.Node) { blockop.MoveUp(n) },
+		func(n *ast.Node) { blockop.MoveDown(n) },
+		func(n *ast.Node) { blockop.Indent(n) },
+		func(n *ast.Node) { blockop.Outdent(n) },
+		func(n *ast.Node) { blockop.WrapBlockquote(n) },
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		oldTree, newTree := luteEngine.BlockDOM2Tree(dom), luteEngine.BlockDOM2Tree(dom)
+		for j := 0; j < 8; j++ {
+			var blocks []*ast.Node
+			ast.Walk(newTree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+				if entering && n.IsBlock() && ast.NodeDocument != n.Type && ast.NodeKramdownBlockIAL != n.Type && "" != n.ID {
+					blocks = append(blocks, n)
+				}
+				return ast.WalkContinue
+			})
+			ops[random.Intn(len(ops))](blocks[random.Intn(len(blocks))])
+		}
+
+		if err := oplog.Apply(oldTree, oplog.Derive(oldTree, newTree)); nil != err {
+			t.Fatalf("test case [%d] failed: %s", i, err)
+		}
+		expected := string(render.NewFormatRenderer(newTree, options).Render())
+		actual := string(render.NewFormatRenderer(oldTree, options).Render())
+		if expected != actual {
+			t.Fatalf("test case [%d] failed\nexpected\n\t%q\ngot\n\t%q", i, expected, actual)
+		}
+	}
+}
+
+func TestBlockDOMOperations(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetProtyleWYSIWYG(true)
+	luteEngine.SetKramdownIAL(true)
+
+	oldDOM := luteEngine.Md2BlockDOM("a\n{: id=\"20210101000000-aaaaaaa\"}\n\nb\n{: id=\"20210101000000-bbbbbbb\"}\n\nc\n{: id=\"20210101000000-ccccccc\"}\n")
+	newDOM := luteEngine.Md2BlockDOM("b\n{: id=\"20210101000000-bbbbbbb\"}\n\na2\n{: id=\"20210101000000-aaaaaaa\"}\n")
+	data, err := json.Marshal(luteEngine.BlockDOMOperations(oldDOM, newDOM))
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := `[{"action":"move","id":"20210101000000-bbbbbbb","parentID":"","previousID":""},{"action":"update","id":"20210101000000-aaaaaaa","parentID":"","previousID":"20210101000000-bbbbbbb","data":"a2\n{: id=\"20210101000000-aaaaaaa\" updated=\"20210101000000\"}"},{"action":"delete","id":"20210101000000-ccccccc","parentID":"","previousID":"20210101000000-bbbbbbb"}]`
+	if expected != string(data) {
+		t.Fatalf("block DOM operations failed\nexpected\n\t%s\ngot\n\t%s", expected, data)
+	}
+}
+
+func TestOplogApplyErrs(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	tree := parse.Parse("", []byte("a\n{: id=\"20210101000000-aaaaaaa\"}\n"), luteEngine.ParseOptions)
+
+	ops := []*oplog.Operation{
+		{Action: oplog.ActionDelete, ID: "20210101000000-bbbbbbb"},
+		{Action: oplog.ActionMove, ID: "20210101000000-aaaaaaa", PreviousID: "20210101000000-bbbbbbb"},
+		{Action: oplog.ActionInsert, ID: "20210101000000-aaaaaaa", Data: "a\n{: id=\"20210101000000-aaaaaaa\"}"},
+	}
+	for _, op := range ops {
+		if err := oplog.Apply(tree, []*oplog.Operation{op}); nil == err {
+			t.Fatalf("operation %s [%s] should fail", op.Action, op.ID)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+)
+
+var withTests = []parseTest{
+
+	{"1", "foo ==bar==<script>alert(1)</script>\nbaz\n", "<p>foo <mark>bar</mark> alert(1) \nbaz</p>\n"},
+	{"0", "foo ==bar==<script>alert(1)</script>\nbaz\n", "<p>foo ==bar==<script>alert(1)</script><br />\nbaz</p>\n"},
+}
+
+func TestWith(t *testing.T) {
+	luteEngine := lute.New()
+	for _, test := range withTests {
+		engine := luteEngine
+		if "1" == test.name {
+			var preset lute.ParseOption = lute.PresetGFM
+			engine = luteEngine.With(lute.WithEngine(preset), lute.WithRender(render.WithSanitize(true), render.WithSoftBreak2HardBreak(false)), lute.WithParse(parse.WithMark(true)))
+		}
+		html := engine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+
+	if luteEngine.RenderOptions.Sanitize || !luteEngine.RenderOptions.SoftBreak2HardBreak || luteEngine.ParseOptions.Mark {
+		t.Fatalf("engine options should not be changed by snapshot")
+	}
+}
+
+func TestWithSetter(t *testing.T) {
+	luteEngine := lute.New()
+	engine := luteEngine.With(func(l *lute.Lute) {
+		l.SetToC(true)
+		l.SetCodeBlockRenderer("csv", csvCodeBlockRenderer)
+	})
+	engine.SetHeadingAnchor(true)
+
+	if !engine.ParseOptions.ToC || !engine.RenderOptions.ToC || !engine.RenderOptions.HeadingAnchor || nil == engine.RenderOptions.CodeBlockRenderers["csv"] {
+		t.Fatalf("snapshot options should be changed by setter")
+	}
+	if luteEngine.ParseOptions.ToC || luteEngine.RenderOptions.ToC || luteEngine.RenderOptions.HeadingAnchor || nil != luteEngine.RenderOptions.CodeBlockRenderers["csv"] {
+		t.Fatalf("engine options should not be changed by snapshot")
+	}
+
+	other := engine.With(lute.WithRender(render.WithCodeBlockRenderer("csv", nil)))
+	if nil != other.RenderOptions.CodeBlockRenderers["csv"] || nil == engine.RenderOptions.CodeBlockRenderers["csv"] {
+		t.Fatalf("code block renderers should be copied on write")
+	}
+}
+
+var presetTests = []parseTest{
+
+	{"3", "foo\nbar ~~baz~~ ==mark== :smile: https://b3log.org\n\n| a |\n| - |\n| b |\n", "<p>foo<br />\nbar <del>baz</del> <mark>mark</mark> :smile: <a href=\"https://b3log.org\">https://b3log.org</a></p>\n<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"2", "foo\nbar ~~baz~~ ==mark== :smile: https://b3log.org\n\n| a |\n| - |\n| b |\n", "<p>foo<br />\nbar <del>baz</del> ==mark== 😄 https://b3log.org</p>\n<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"1", "foo\nbar ~~baz~~ ==mark== :smile: https://b3log.org\n\n| a |\n| - |\n| b |\n", "<p>foo\nbar <del>baz</del> ==mark== 😄 <a href=\"https://b3log.org\">https://b3log.org</a></p>\n<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+	{"0", "foo\nbar ~~baz~~ ==mark== :smile: https://b3log.org\n\n| a |\n| - |\n| b |\n", "<p>foo\nbar ~~baz~~ ==mark== :smile: https://b3log.org</p>\n<p>| a |\n| - |\n| b |</p>\n"},
+}
+
+var presets = []lute.Option{lute.PresetCommonMark, lute.PresetGFM, lute.PresetVditorIR, lute.PresetObsidian}
+
+func TestPreset(t *testing.T) {
+	luteEngine := lute.New()
+	for i, test := range presetTests {
+		engine := luteEngine.With(presets[len(presetTests)-1-i])
+		html := engine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+func TestPresetNew(t *testing.T) {
+	luteEngine := lute.New(lute.PresetProtyle)
+	if !luteEngine.ParseOptions.ProtyleWYSIWYG || !luteEngine.RenderOptions.ProtyleWYSIWYG || !luteEngine.RenderOptions.KramdownBlockIAL || !luteEngine.ParseOptions.BlockRef || luteEngine.ParseOptions.Setext {
+		t.Fatalf("preset Protyle options are not applied")
+	}
+
+	engine := luteEngine.With(lute.PresetVditorIR, lute.WithRender(render.WithSanitize(true)))
+	if engine.ParseOptions.ProtyleWYSIWYG || !engine.ParseOptions.VditorIR || !engine.RenderOptions.VditorIR || engine.ParseOptions.BlockRef || !engine.RenderOptions.Sanitize {
+		t.Fatalf("preset Vditor IR options are not applied")
+	}
+	if !luteEngine.ParseOptions.ProtyleWYSIWYG || luteEngine.ParseOptions.VditorIR {
+		t.Fatalf("engine options should not be changed by snapshot")
+	}
+}
+
+func TestWithMaps(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.PutTerms(map[string]string{"github": "GitHub"})
+	engine := luteEngine.With()
+	engine.PutTerms(map[string]string{"lute": "Lute"})
+	engine.PutEmojis(map[string]string{"lute-logo": "🎸"})
+
+	if "Lute" != engine.RenderOptions.Terms["lute"] || "GitHub" != engine.RenderOptions.Terms["github"] || "🎸" != engine.GetEmojis()["lute-logo"] {
+		t.Fatalf("snapshot maps should be changed")
+	}
+	if _, ok := luteEngine.RenderOptions.Terms["lute"]; ok {
+		t.Fatalf("engine terms should not be changed by snapshot")
+	}
+	if _, ok := luteEngine.GetEmojis()["lute-logo"]; ok {
+		t.Fatalf("engine emojis should not be changed by snapshot")
+	}
+}
+
+func TestPresetCommonMarkWikilink(t *testing.T) {
+	luteEngine := lute.New(lute.PresetCommonMark)
+	if expected, html := "<p>[[foo]]</p>\n", luteEngine.MarkdownStr("", "[[foo]]\n"); expected != html {
+		t.Fatalf("expected\n\t%q\ngot\n\t%q", expected, html)
+	}
+	if !luteEngine.With(lute.PresetObsidian).ParseOptions.Wikilink {
+		t.Fatalf("preset Obsidian should parse wikilinks")
+	}
+}
@@ -0,0 +1,76 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/query"
+)
+
+const queryMarkdown = "# A\n\n![a](a.png)\n\n## B\n\n![b](b.png)\n\n- x\n- y\n\n```go\ncode\n```\n{: custom-priority=\"high\"}\n\n### C\n\n![c](c.jpg)\n\n## D\n\n```js title=\"app.js\"\nj\n```\n"
+
+var queryTests = []parseTest{
+
+	{"11", "codeblock[info*=title]", "NodeCodeBlock j\n"},
+	{"10", "codeblock[lang=js][info!=js]", "NodeCodeBlock j\n"},
+	{"9", "*[dest$=\".png\"]", "NodeImage a|NodeImage b"},
+	{"8", "paragraph:not(:has(image))", "NodeParagraph x|NodeParagraph y"},
+	{"7", "list:has(listitem:last-child)", "NodeList xy"},
+	{"6", "heading:has(+ codeblock)", "NodeHeading D"},
+	{"5", "NodeHeading ~ NodeCodeBlock[lang=js]", "NodeCodeBlock j\n"},
+	{"4", "heading + paragraph", "NodeParagraph a|NodeParagraph b|NodeParagraph c"},
+	{"3", "list > listitem:first-child, listitem:last-child", "NodeListItem x|NodeListItem y"},
+	{"2", "[custom-priority=high]", "NodeCodeBlock code\n"},
+	{"1", "codeblock[info^=g]", "NodeCodeBlock code\n"},
+	{"0", "NodeHeading[level=2] NodeImage", "NodeImage b|NodeImage c"},
+}
+
+func TestQuery(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(true)
+	tree := parse.Parse("", []byte(queryMarkdown), luteEngine.ParseOptions)
+
+	for _, test := range queryTests {
+		nodes, err := query.Query(tree, test.from)
+		if nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+		var got []string
+		for _, n := range nodes {
+			text := n.Text()
+			if code := n.ChildByType(ast.NodeCodeBlockCode); nil != code {
+				text = string(code.Tokens)
+			}
+			got = append(got, n.Type.String()+" "+text)
+		}
+		if actual := strings.Join(got, "|"); test.to != actual {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal selector\n\t%q", test.name, test.to, actual, test.from)
+		}
+	}
+
+	first, err := query.QueryFirst(tree, "codeblock")
+	if nil != err || "go" != string(first.CodeBlockInfo) {
+		t.Fatalf("query first failed: %v", err)
+	}
+}
+
+func TestQueryErrs(t *testing.T) {
+	for _, selector := range []string{"", "foo", "[level", ":bogus", "heading >", "heading,,image", ":not()"} {
+		if _, err := query.Compile(selector); nil == err {
+			t.Fatalf("selector [%s] should be invalid", selector)
+		}
+	}
+}
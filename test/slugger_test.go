@@ -0,0 +1,58 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/render"
+)
+
+var sluggerTests = []parseTest{
+
+	{"9", "github:# Foo\n\n## Bar {#foo}\n\n### Baz {#foo}\n", "<h1 id=\"foo-1\">Foo</h1>\n<h2 id=\"foo\">Bar</h2>\n<h3 id=\"foo\">Baz</h3>\n"},
+	{"8", "github:[toc]\n\n# Foo\n\n## Foo\n", "<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\"><ul><li><span data-target-id=\"foo\">Foo</span><ul><li><span data-target-id=\"foo-1\">Foo</span></li></ul></li></ul></div>\n<h1 id=\"foo\">Foo</h1>\n<h2 id=\"foo-1\">Foo</h2>\n"},
+	{"7", "ascii:# Ärger über `Größe` -- 中文标题!\n", "<h1 id=\"arger-uber-grosse-zhong-wen-biao-ti\">Ärger über <code>Größe</code> -- 中文标题!</h1>\n"},
+	{"6", "ascii:# 你好，世界\n\n# 你好 世界\n", "<h1 id=\"ni-hao-shi-jie\">你好，世界</h1>\n<h1 id=\"ni-hao-shi-jie-1\">你好 世界</h1>\n"},
+	{"5", "hugo:# 中文\n\n# Hello_World  Again!\n\n# Hello World Again\n", "<h1 id=\"heading\">中文</h1>\n<h1 id=\"hello-world--again\">Hello_World  Again!</h1>\n<h1 id=\"hello-world-again\">Hello World Again</h1>\n"},
+	{"4", "gitlab:#  Hello -- World!  \n\n# Hello - World\n", "<h1 id=\"hello-world\">Hello -- World!</h1>\n<h1 id=\"hello-world-1\">Hello - World</h1>\n"},
+	{"3", "github:### Heading {#custom-id}\n\n# custom-id\n", "<h3 id=\"custom-id\">Heading</h3>\n<h1 id=\"custom-id-1\">custom-id</h1>\n"},
+	{"2", "github:# Foo\n\n# Foo\n\n# Foo-1\n\n# Foo\n", "<h1 id=\"foo\">Foo</h1>\n<h1 id=\"foo-1\">Foo</h1>\n<h1 id=\"foo-1-1\">Foo-1</h1>\n<h1 id=\"foo-2\">Foo</h1>\n"},
+	{"1", "github:# 中文 `Code` & **Bold**!\n", "<h1 id=\"中文-code--bold\">中文 <code>Code</code> &amp; <strong>Bold</strong>!</h1>\n"},
+	{"0", "github:# Hello, World -- Again\n", "<h1 id=\"hello-world----again\">Hello, World -- Again</h1>\n"},
+}
+
+func TestSlugger(t *testing.T) {
+	for _, test := range sluggerTests {
+		luteEngine := lute.New()
+		luteEngine.SetHeadingID(true)
+		luteEngine.SetToC(true)
+		from := test.from[strings.Index(test.from, ":")+1:]
+		luteEngine.SetSlugger(test.from[:strings.Index(test.from, ":")])
+		html := luteEngine.MarkdownStr(test.name, from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+func TestSluggerHeadingAnchor(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingID(true)
+	luteEngine.RenderOptions.HeadingAnchor = true
+	luteEngine.RenderOptions.Slugger = render.GitHubSlugger{}
+	html := luteEngine.MarkdownStr("", "# Foo Bar\n")
+	if !strings.Contains(html, "<h1 id=\"foo-bar\">") || !strings.Contains(html, "href=\"#foo-bar\"") {
+		t.Fatalf("heading anchor failed\n\t%q", html)
+	}
+}
@@ -0,0 +1,74 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+)
+
+var smartTypographyTests = []parseTest{
+
+	{"15", "see <http://x.com/a--b...> and https://y.com/c--d or www.y.com/e--f \"g\"\n", "<p>see <a href=\"http://x.com/a--b...\">http://x.com/a--b...</a> and <a href=\"https://y.com/c--d\">https://y.com/c--d</a> or <a href=\"http://www.y.com/e--f\">www.y.com/e--f</a> “g”</p>\n"},
+	{"14", "'tis the season, 'twas fun\n", "<p>’tis the season, ’twas fun</p>\n"},
+	{"13", "<span title=\"x\">\"y\"</span> and <b>'z'</b>\n", "<p><span title=\"x\">“y”</span> and <b>‘z’</b></p>\n"},
+	{"12", "see [\"a\" -- b...](url)\n", "<p>see <a href=\"url\">“a” – b…</a></p>\n"},
+	{"11", "cjk:\"引用'嵌套'内容\"\n", "<p>「引用『嵌套』内容」</p>\n"},
+	{"10", "fr:\"Bonjour\", l'ami\n", "<p>«\u00a0Bonjour\u00a0», l’ami</p>\n"},
+	{"9", "de:\"Hallo 'Welt'\", sagte er\n", "<p>„Hallo ‚Welt‘“, sagte er</p>\n"},
+	{"8", "\"*emphasis*\" and '[link](https://example.com/'a'--b)'\n", "<p>“<em>emphasis</em>” and ‘<a href=\"https://example.com/'a'--b\">link</a>’</p>\n"},
+	{"7", "He said \"hi\"  \nthen \"bye\"\n", "<p>He said “hi”<br />\nthen “bye”</p>\n"},
+	{"6", "\\\"escaped\\\" \"quoted\"\n", "<p>&quot;escaped&quot; “quoted”</p>\n"},
+	{"5", "`\"code\"` \"text\" $\"math\"$ <span title=\"a--b\">x</span>\n", "<p><code>&quot;code&quot;</code> “text” <span class=\"language-math\">&quot;math&quot;</span> <span title=\"a--b\">x</span></p>\n"},
+	{"4", "```\n\"code\" -- ...\n```\n", "<pre><code class=\"highlight-chroma\"><span class=\"highlight-line\"><span class=\"highlight-cl\">&#34;code&#34; -- ...\n</span></span></code></pre>\n"},
+	{"3", "wait... -- and --- done\n", "<p>wait… – and — done</p>\n"},
+	{"2", "rock 'n' roll in the '90s, it's James' \"book\"\n", "<p>rock ‘n’ roll in the ’90s, it’s James’ “book”</p>\n"},
+	{"1", "'single' and \"double\"\n", "<p>‘single’ and “double”</p>\n"},
+	{"0", "\"Hello,\" she said.\n", "<p>“Hello,” she said.</p>\n"},
+}
+
+func TestSmartTypography(t *testing.T) {
+	for _, test := range smartTypographyTests {
+		luteEngine := lute.New()
+		luteEngine.SetSmartTypography(true)
+		luteEngine.SetInlineMathAllowDigitAfterOpenMarker(true)
+		from := test.from
+		for _, lang := range []string{"cjk", "fr", "de"} {
+			if strings.HasPrefix(from, lang+":") {
+				luteEngine.SetSmartTypographyLang(lang)
+				from = strings.TrimPrefix(from, lang+":")
+			}
+		}
+		html := luteEngine.MarkdownStr(test.name, from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var smartTypographyFormatTests = []parseTest{
+
+	{"1", "see <http://x.com/a--b...> and https://y.com/c--d or www.y.com/e--f \"g\"\n", "see [http://x.com/a--b...](http://x.com/a--b...) and [https://y.com/c--d](https://y.com/c--d) or [www.y.com/e--f](http://www.y.com/e--f) “g”\n"},
+	{"0", "\"a\" -- `\"b\"` [c](d--e \"f\")\n", "“a” – `\"b\"` [c](d--e \"f\")\n"},
+}
+
+func TestSmartTypographyFormat(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSmartTypography(true)
+	for _, test := range smartTypographyFormatTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"testing"
 
 	"github.com/88250/lute"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
 )
 
 var superBlockTests = []parseTest{
@@ -40,3 +42,81 @@ func TestSuperBlock(t *testing.T) {
 		}
 	}
 }
+
+var superBlockLayoutTests = []parseTest{
+
+	{"3", "{{{col\nfoo\n\n{{{row\nbar\n\nbaz\n}}}\n}}}\n", "<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<p>foo</p>\n<div class=\"sb sb--row\" data-sb-layout=\"row\">\n<p>bar</p>\n<p>baz</p>\n</div>\n</div>\n"},
+	{"2", "{{{row\nfoo\n\n{{{col\n## bar\n\nbaz\n}}}\n}}}\n\nqux\n", "<div class=\"sb sb--row\" data-sb-layout=\"row\">\n<p>foo</p>\n<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<h2>bar</h2>\n<p>baz</p>\n</div>\n</div>\n<p>qux</p>\n"},
+	{"1", "{{{col\nfoo\n\nbar\n}}}\n", "<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<p>foo</p>\n<p>bar</p>\n</div>\n"},
+	{"0", "{{{row\nfoo\n\nbar\n}}}\n", "<div class=\"sb sb--row\" data-sb-layout=\"row\">\n<p>foo</p>\n<p>bar</p>\n</div>\n"},
+}
+
+func TestSuperBlockLayout(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSuperBlock(true)
+	for _, test := range superBlockLayoutTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var superBlockLayoutStyleTests = []parseTest{
+
+	{"1", "{{{row\nfoo\n\n{{{col\nbar\n\nbaz\n}}}\n}}}\n", "<div data-sb-layout=\"row\" style=\"display: grid; grid-auto-flow: column; grid-auto-columns: minmax(0, 1fr); gap: 1em\">\n<p>foo</p>\n<div data-sb-layout=\"col\" style=\"display: flex; flex-direction: column; gap: 1em\">\n<p>bar</p>\n<p>baz</p>\n</div>\n</div>\n"},
+	{"0", "{{{row\nfoo\n\nbar\n}}}\n", "<div data-sb-layout=\"row\" style=\"display: grid; grid-auto-flow: column; grid-auto-columns: minmax(0, 1fr); gap: 1em\">\n<p>foo</p>\n<p>bar</p>\n</div>\n"},
+}
+
+func TestSuperBlockLayoutStyle(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSuperBlock(true)
+	luteEngine.SetSuperBlockClass("")
+	luteEngine.SetSuperBlockInlineStyle(true)
+	for _, test := range superBlockLayoutStyleTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var superBlockLayoutDocxTests = []parseTest{
+
+	{"2", "{{{row\nfoo\n\n{{{col\nbar\n\nbaz\n}}}\n\nqux\n}}}\n", "<table class=\"sb sb--row\" data-sb-layout=\"row\" style=\"width: 100%; table-layout: fixed; border-collapse: collapse; border: none\"><tr>\n<td style=\"width: 33.33%; vertical-align: top\">\n<p>foo</p>\n</td>\n<td style=\"width: 33.33%; vertical-align: top\">\n<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<p>bar</p>\n<p>baz</p>\n</div>\n</td>\n<td style=\"width: 33.33%; vertical-align: top\">\n<p>qux</p>\n</td>\n</tr></table>\n"},
+	{"1", "{{{col\nfoo\n\nbar\n}}}\n", "<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<p>foo</p>\n<p>bar</p>\n</div>\n"},
+	{"0", "{{{row\nfoo\n\nbar\n}}}\n", "<table class=\"sb sb--row\" data-sb-layout=\"row\" style=\"width: 100%; table-layout: fixed; border-collapse: collapse; border: none\"><tr>\n<td style=\"width: 50%; vertical-align: top\">\n<p>foo</p>\n</td>\n<td style=\"width: 50%; vertical-align: top\">\n<p>bar</p>\n</td>\n</tr></table>\n"},
+}
+
+func TestSuperBlockLayoutDocx(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSuperBlock(true)
+	for _, test := range superBlockLayoutDocxTests {
+		tree := parse.Parse(test.name, []byte(test.from), luteEngine.ParseOptions)
+		html := string(render.NewProtyleExportDocxRenderer(tree, luteEngine.RenderOptions).Render())
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var superBlockLayoutHTML2MdTests = []parseTest{
+
+	{"5", "<div class=\"columns\"><p>foo</p><p>bar</p></div>", "foo\n\nbar\n"},
+	{"4", "<table class=\"sb sb--row\" data-sb-layout=\"row\"><tr><td><p>foo</p></td><td><p>bar</p><p>baz</p></td><td>qux</td></tr></table>", "{{{row\nfoo\n\n{{{col\nbar\n\nbaz\n\n}}}\nqux\n\n}}}\n"},
+	{"3", "<div class=\"sb sb--col\"><p>foo</p><div class=\"sb sb--row\"><p>bar</p><p>baz</p></div></div>", "{{{col\nfoo\n\n{{{row\nbar\n\nbaz\n\n}}}\n}}}\n"},
+	{"2", "<div data-sb-layout=\"col\"><h2>foo</h2><p>bar</p></div>", "{{{col\n## foo\n\nbar\n\n}}}\n"},
+	{"1", "<div class=\"sb sb--row\" data-sb-layout=\"row\">\n<p>foo</p>\n<div class=\"sb sb--col\" data-sb-layout=\"col\">\n<h2>bar</h2>\n<p>baz</p>\n</div>\n</div>\n<p>qux</p>\n", "{{{row\nfoo\n\n{{{col\n## bar\n\nbaz\n\n}}}\n\n}}}\n\nqux\n"},
+	{"0", "<div class=\"sb sb--row\" data-sb-layout=\"row\">\n<p>foo</p>\n<p>bar</p>\n</div>\n", "{{{row\nfoo\n\nbar\n\n}}}\n"},
+}
+
+func TestSuperBlockLayoutHTML2Md(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSuperBlock(true)
+	for _, test := range superBlockLayoutHTML2MdTests {
+		md := luteEngine.HTML2Md(test.from)
+		if test.to != md {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal html\n\t%q", test.name, test.to, md, test.from)
+		}
+	}
+}
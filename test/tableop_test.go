@@ -0,0 +1,166 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/render"
+	"github.com/88250/lute/tableop"
+)
+
+type tableopTest struct {
+	name string
+	from string
+	op   func(table *ast.Node) error
+	to   string
+}
+
+var tableopTests = []*tableopTest{
+
+	{"9", "| a | b |\n| - | - |\n| 1 | 2 |\n", func(table *ast.Node) error {
+		return tableop.Transpose(table)
+	}, "| a | 1 |\n| - | - |\n| b | 2 |\n"},
+	{"8", "| name |\n| - |\n| b |\n| 中 |\n| A |\n| a |\n", func(table *ast.Node) error {
+		return tableop.Sort(table, 0, &tableop.SortOptions{Collation: tableop.CollationLocale, Language: "en"})
+	}, "| name |\n| ---- |\n| A    |\n| a    |\n| b    |\n| 中   |\n"},
+	{"7", "| n |\n| - |\n| 10 |\n| x |\n| 9 |\n| 1,000 |\n", func(table *ast.Node) error {
+		return tableop.Sort(table, 0, &tableop.SortOptions{Collation: tableop.CollationNumeric})
+	}, "| n     |\n| ----- |\n| 9     |\n| 10    |\n| 1,000 |\n| x     |\n"},
+	{"6", "| n | v |\n| - | - |\n| 10 | a |\n| 9 | b |\n| 100 | c |\n", func(table *ast.Node) error {
+		return tableop.Sort(table, 0, &tableop.SortOptions{Desc: true})
+	}, "| n   | v |\n| --- | - |\n| 9   | b |\n| 100 | c |\n| 10  | a |\n"},
+	{"5", "| a | b | c |\n| :- | :-: | -: |\n| 1 | 2 | 3 |\n", func(table *ast.Node) error {
+		return tableop.MoveColumn(table, 2, 0)
+	}, "| c | a | b |\n| -: | :- | :-: |\n| 3 | 1 | 2 |\n"},
+	{"4", "| a | b |\n| - | - |\n| 1 | 2 |\n", func(table *ast.Node) error {
+		return tableop.SetAlign(table, 1, tableop.AlignRight)
+	}, "| a | b |\n| - | -: |\n| 1 | 2 |\n"},
+	{"3", "| a | b | c |\n| - | :-: | - |\n| 1 | 2 | 3 |\n", func(table *ast.Node) error {
+		return tableop.DeleteColumn(table, 1)
+	}, "| a | c |\n| - | - |\n| 1 | 3 |\n"},
+	{"2", "| a | b |\n| - | - |\n| 1 | 2 |\n", func(table *ast.Node) error {
+		return tableop.InsertColumn(table, 1, tableop.AlignCenter)
+	}, "| a |  | b |\n| - | :-: | - |\n| 1 |  | 2 |\n"},
+	{"1", "| a | b |\n| - | - |\n| 1 | 2 |\n| 3 | 4 |\n", func(table *ast.Node) error {
+		if err := tableop.DeleteRow(table, 0); nil != err {
+			return err
+		}
+		return tableop.DeleteRow(table, 1)
+	}, "| 1 | 2 |\n| - | - |\n"},
+	{"0", "| a | b |\n| - | - |\n| 1 | 2 |\n", func(table *ast.Node) (err error) {
+		if _, err = tableop.InsertRow(table, 0); nil != err {
+			return
+		}
+		_, err = tableop.InsertRow(table, 3)
+		return
+	}, "|   |   |\n| - | - |\n| a | b |\n| 1 | 2 |\n|   |   |\n"},
+}
+
+func TestTableop(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(false)
+
+	for _, test := range tableopTests {
+		tree := parse.Parse("", []byte(test.from), luteEngine.ParseOptions)
+		if err := test.op(tableop.Tables(tree)[0]); nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+		formatted := string(render.NewFormatRenderer(tree, render.NewOptions()).Render())
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+
+		// 操作后的表格应该能够稳定地格式化
+		tree = parse.Parse("", []byte(formatted), luteEngine.ParseOptions)
+		if reformatted := string(render.NewFormatRenderer(tree, render.NewOptions()).Render()); formatted != reformatted {
+			t.Fatalf("test case [%s] failed: unstable format\n\t%q\n\t%q", test.name, formatted, reformatted)
+		}
+	}
+}
+
+func TestTableopErrs(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetKramdownIAL(false)
+
+	tree := parse.Parse("", []byte("| a |\n| - |\n"), luteEngine.ParseOptions)
+	table := tableop.Tables(tree)[0]
+	if nil == tableop.DeleteRow(table, 0) || nil == tableop.DeleteColumn(table, 0) || nil == tableop.SetAlign(table, 0, 4) || nil == tableop.MoveColumn(table, 0, 1) {
+		t.Fatalf("expected errors")
+	}
+	if _, err := tableop.InsertRow(table, 2); nil == err {
+		t.Fatalf("expected error")
+	}
+}
+
+var tableCSVTests = []*parseTest{
+
+	{"2", "a\tb\tc\nx|y\t\"q\"\n1\n", "| a   | b | c |\n| --- | - | - |\n| x\\|y | q |   |\n| 1   |   |   |\n"},
+	{"1", "a,b\n\"1,000\",**b**\n", "| a     | b     |\n| ----- | ----- |\n| 1,000 | **b** |\n"},
+	{"0", "a,b\n1,2\n", "| a | b |\n| - | - |\n| 1 | 2 |\n"},
+}
+
+func TestTableCSV(t *testing.T) {
+	for _, test := range tableCSVTests {
+		comma := ','
+		if strings.Contains(test.from, "\t") {
+			comma = '\t'
+		}
+		table, err := tableop.FromCSV(test.from, comma, nil)
+		if nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+		tree := &parse.Tree{Root: &ast.Node{Type: ast.NodeDocument}, Context: &parse.Context{ParseOption: parse.NewOptions()}}
+		tree.Context.Tree = tree
+		tree.Root.AppendChild(table)
+		formatted := string(render.NewFormatRenderer(tree, render.NewOptions()).Render())
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+
+		tree = parse.Parse("", []byte(formatted), parse.NewOptions())
+		csv, err := tableop.ToCSV(tableop.Tables(tree)[0], comma)
+		if nil != err {
+			t.Fatalf("test case [%s] failed: %s", test.name, err)
+		}
+		if table, _ = tableop.FromCSV(csv, comma, nil); len(tableop.Rows(table)) != len(tableop.Rows(tableop.Tables(tree)[0])) {
+			t.Fatalf("test case [%s] failed: csv round trip\n\t%q", test.name, csv)
+		}
+	}
+}
+
+func TestEditTableDOM(t *testing.T) {
+	ast.Testing = true
+	defer func() { ast.Testing = false }()
+
+	luteEngine := lute.New()
+	ivHTML := luteEngine.Md2BlockDOM("| a | b |\n| - | - |\n| 2 | x |\n| 1 | y |\n")
+	ovHTML, err := luteEngine.EditTableDOM(ivHTML, func(table *ast.Node) error {
+		if err := tableop.Sort(table, 0, &tableop.SortOptions{Collation: tableop.CollationNumeric}); nil != err {
+			return err
+		}
+		return tableop.InsertColumn(table, 1, tableop.AlignCenter)
+	})
+	if nil != err {
+		t.Fatalf("edit table DOM failed: %s", err)
+	}
+	expected := "<div data-node-id=\"20060102150405-1a2b3c4\" data-node-index=\"1\" data-type=\"NodeTable\" class=\"table\" colgroup=\"||\"><div contenteditable=\"false\"><table contenteditable=\"true\" spellcheck=\"false\"><colgroup><col /><col /><col /></colgroup><thead><tr><th>a</th><th align=\"center\"></th><th>b</th></tr></thead><tbody><tr><td>1</td><td align=\"center\"></td><td>y</td></tr><tr><td>2</td><td align=\"center\"></td><td>x</td></tr></tbody></table><div class=\"protyle-action__table\"><div class=\"table__resize\"></div><div class=\"table__select\"></div></div></div><div class=\"protyle-attr\" contenteditable=\"false\">\u200b</div></div>"
+	if expected != ovHTML {
+		t.Fatalf("edit table DOM failed\nexpected\n\t%q\ngot\n\t%q", expected, ovHTML)
+	}
+	if _, err = luteEngine.EditTableDOM(luteEngine.Md2BlockDOM("foo"), nil); nil == err {
+		t.Fatalf("expected error")
+	}
+}
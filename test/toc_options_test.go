@@ -0,0 +1,79 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/88250/lute"
+)
+
+var tocOptionsTests = []parseTest{
+
+	{"1", "[toc]\n\n# 1\n\n## 1.1\n\n### 1.1.1\n\n## 1.2\n{: class=\"foo no_toc\"}\n", "<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\"><h2>Contents</h2><ol><li><span data-target-id=\"1-1\">1.1</span><ol><li><span data-target-id=\"1-1-1\">1.1.1</span></li></ol></li></ol></div>\n<h1 id=\"1\">1</h1>\n<h2 id=\"1-1\">1.1</h2>\n<h3 id=\"1-1-1\">1.1.1</h3>\n<h2 id=\"1-2\">1.2</h2>\n"},
+	{"0", "[toc]\n\n# A & B\n\n## C\n", "<div class=\"vditor-toc\" data-block=\"0\" data-type=\"toc-block\" contenteditable=\"false\"><h2>Contents</h2><ol><li><span data-target-id=\"C\">C</span></li></ol></div>\n<h1 id=\"A---B\">A &amp; B</h1>\n<h2 id=\"C\">C</h2>\n"},
+}
+
+func TestToCOptions(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetHeadingID(true)
+	luteEngine.SetToC(true)
+	luteEngine.SetToCOrdered(true)
+	luteEngine.SetToCTitle("Contents")
+	luteEngine.SetToCLevel(2, 3)
+	luteEngine.SetToCExcludeClass("no_toc")
+	luteEngine.SetKramdownIAL(true)
+
+	for _, test := range tocOptionsTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+}
+
+var tocMarkdownTests = []parseTest{
+
+	{"3", "<!-- toc -->\n\n# A\n", "<!-- toc -->\n\n- [A](#a)\n\n<!-- tocstop -->\n\n# A\n"},
+	{"2", "# Title\n\n<!-- toc -->\n\n- [Stale](#stale)\n\n<!-- tocstop -->\n\n## A `b` [c]\n\n### D\n\n## A `b` [c]\n", "# Title\n\n<!-- toc -->\n\n- [Title](#title)\n  - [A b \\[c\\]](#a-b-c)\n    - [D](#d)\n  - [A b \\[c\\]](#a-b-c-1)\n\n<!-- tocstop -->\n\n## A `b` [c]\n\n### D\n\n## A `b` [c]\n"},
+	{"1", "[toc]\n\n# A\n\n## B\n", "<!-- toc -->\n\n- [A](#a)\n  - [B](#b)\n\n<!-- tocstop -->\n\n# A\n\n## B\n"},
+	{"0", "<!-- toc -->\n<!-- tocstop -->\n\n# A\n\n## B\n\n# C\n", "<!-- toc -->\n\n- [A](#a)\n  - [B](#b)\n- [C](#c)\n\n<!-- tocstop -->\n\n# A\n\n## B\n\n# C\n"},
+}
+
+func TestToCMarkdown(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetToC(true)
+	luteEngine.SetToCMarkdown(true)
+	luteEngine.SetSlugger("github")
+
+	for _, test := range tocMarkdownTests {
+		formatted := luteEngine.FormatStr(test.name, test.from)
+		if test.to != formatted {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, formatted, test.from)
+		}
+		if refreshed := luteEngine.FormatStr(test.name, formatted); formatted != refreshed {
+			t.Fatalf("test case [%s] failed: unstable toc\n\t%q\n\t%q", test.name, formatted, refreshed)
+		}
+	}
+}
+
+func TestOutline(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetSlugger("github")
+	luteEngine.SetToCLevel(1, 2)
+	outline := luteEngine.Outline("", []byte("# A\n\n## B\n\n### C\n\n## D\n\n# E\n"))
+	if 2 != len(outline) || "a" != outline[0].ID || 2 != len(outline[0].Children) || "d" != outline[0].Children[1].ID || "E" != outline[1].Content {
+		t.Fatalf("unexpected outline")
+	}
+	if 2 != outline[0].Children[0].Node.HeadingLevel || 0 != len(outline[0].Children[0].Children) {
+		t.Fatalf("unexpected outline")
+	}
+}
@@ -0,0 +1,86 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/88250/lute"
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+	"github.com/88250/lute/transform"
+)
+
+func mdLinkRewrite(link *ast.Node, dest string) string {
+	if ast.NodeLink == link.Type && strings.HasSuffix(dest, ".md") {
+		return strings.TrimSuffix(dest, ".md") + ".html"
+	}
+	return dest
+}
+
+var transformerTests = []parseTest{
+
+	{"3", "# foo\n\n###### bar\n", "<h2>foo</h2>\n<h6>bar</h6>\n"},
+	{"2", "[a](a.md) ![b](b.md) <https://b3log.org/c.md>\n", "<p><a href=\"a.html\">a</a> <img src=\"b.md\" alt=\"b\" /> <a href=\"https://b3log.org/c.md\">https://b3log.org/c.md</a></p>\n"},
+	{"1", "[a][ref]\n\n[ref]: docs/ref.md\n", "<p><a href=\"docs/ref.html\">a</a></p>\n"},
+	{"0", "foo\n===\n", "<h2>foo</h2>\n"},
+}
+
+func TestTransformer(t *testing.T) {
+	luteEngine := lute.New()
+	luteEngine.SetAutoSpace(false)
+	luteEngine.AddTransformer(0, transform.HeadingShift(1))
+	luteEngine.AddTransformer(0, transform.LinkRewrite(mdLinkRewrite))
+
+	for _, test := range transformerTests {
+		html := luteEngine.MarkdownStr(test.name, test.from)
+		if test.to != html {
+			t.Fatalf("test case [%s] failed\nexpected\n\t%q\ngot\n\t%q\noriginal markdown text\n\t%q", test.name, test.to, html, test.from)
+		}
+	}
+
+	formatted := luteEngine.FormatStr("", "foo\n---\n\n[a](a.md)\n")
+	if expected := "### foo\n\n[a](a.html)\n"; expected != formatted {
+		t.Fatalf("format with transformers failed, expected [%q], got [%q]", expected, formatted)
+	}
+
+	markdown, err := luteEngine.HTML2Markdown("<h1>foo</h1><p><a href=\"a.md\">a</a></p>")
+	if nil != err {
+		t.Fatal(err)
+	}
+	if expected := "## foo\n\n[a](a.html)\n"; expected != markdown {
+		t.Fatalf("html2markdown with transformers failed, expected [%q], got [%q]", expected, markdown)
+	}
+
+	if vHTML := luteEngine.Md2VditorIRDOM("# foo\n"); !strings.Contains(vHTML, "<h2") {
+		t.Fatalf("md2vditorirdom with transformers failed, got [%s]", vHTML)
+	}
+	if vHTML := luteEngine.Md2BlockDOM("# foo\n"); !strings.Contains(vHTML, "data-subtype=\"h2\"") {
+		t.Fatalf("md2blockdom with transformers failed, got [%s]", vHTML)
+	}
+}
+
+func TestTransformerPriority(t *testing.T) {
+	luteEngine := lute.New()
+	var order []string
+	record := func(name string) func(*parse.Tree) {
+		return func(*parse.Tree) { order = append(order, name) }
+	}
+	luteEngine.AddTransformer(10, record("c"))
+	luteEngine.AddTransformer(-1, record("a"))
+	luteEngine.AddTransformer(10, record("d"))
+	luteEngine.AddTransformer(0, record("b"))
+	luteEngine.MarkdownStr("", "foo")
+	if got := strings.Join(order, ""); "abcd" != got {
+		t.Fatalf("transformers run in wrong order [%s]", got)
+	}
+}
@@ -0,0 +1,62 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+// Package transform 提供了常用的语法树变换器，可以通过 Lute.AddTransformer 注册到引擎上，在解析完成后、渲染之前执行。
+package transform
+
+import (
+	"github.com/88250/lute/ast"
+	"github.com/88250/lute/parse"
+)
+
+// HeadingShift 返回一个将所有标题级别调整 offset 级的变换器，调整后的级别限制在 1 到 6 之间。
+//
+// 比如将文档嵌入到其他文档的二级标题下时可以使用 HeadingShift(2)。
+func HeadingShift(offset int) func(tree *parse.Tree) {
+	return func(tree *parse.Tree) {
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || ast.NodeHeading != n.Type {
+				return ast.WalkContinue
+			}
+
+			n.HeadingLevel += offset
+			if 1 > n.HeadingLevel {
+				n.HeadingLevel = 1
+			} else if 6 < n.HeadingLevel {
+				n.HeadingLevel = 6
+			}
+			if 2 < n.HeadingLevel {
+				n.HeadingSetext = false // Setext 标题只有一级和二级
+			}
+			return ast.WalkContinue
+		})
+	}
+}
+
+// LinkRewrite 返回一个改写链接和图片地址的变换器，rewrite 的参数 link 为链接或者图片节点，dest 为原地址，返回新地址。
+//
+// 链接引用定义中的地址也会被改写，自动链接的链接文本就是地址，所以不会被改写。
+func LinkRewrite(rewrite func(link *ast.Node, dest string) string) func(tree *parse.Tree) {
+	return func(tree *parse.Tree) {
+		ast.Walk(tree.Root, func(n *ast.Node, entering bool) ast.WalkStatus {
+			if !entering || (ast.NodeLink != n.Type && ast.NodeImage != n.Type) {
+				return ast.WalkContinue
+			}
+			if 2 == n.LinkType {
+				return ast.WalkSkipChildren
+			}
+
+			if dest := n.ChildByType(ast.NodeLinkDest); nil != dest {
+				dest.Tokens = []byte(rewrite(n, string(dest.Tokens)))
+			}
+			return ast.WalkContinue
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package util
+
+import "bytes"
+
+// IsRelativePath 判断链接地址 dest 是否为相对路径（没有协议前缀且不以 / 开头）。
+func IsRelativePath(dest []byte) bool {
+	if 1 > len(dest) {
+		return true
+	}
+
+	if '/' == dest[0] {
+		return false
+	}
+	return !bytes.Contains(dest, []byte(":/")) && !bytes.Contains(dest, []byte(":\\")) && !bytes.Contains(dest, []byte(":%5C"))
+}
+
+// RelativePath 使用 base 作为相对路径 dest 的前缀，dest 不是相对路径时原样返回。
+func RelativePath(base string, dest []byte) []byte {
+	if "" == base {
+		return dest
+	}
+
+	if !IsRelativePath(dest) {
+		return dest
+	}
+
+	dest = bytes.ReplaceAll(dest, []byte("%5C"), []byte("\\"))
+	linkBase := []byte(base)
+	if !bytes.HasSuffix(linkBase, []byte("/")) {
+		linkBase = append(linkBase, []byte("/")...)
+	}
+	ret := append(linkBase, dest...)
+	if bytes.Equal(linkBase, ret) {
+		return []byte("")
+	}
+	return ret
+}
@@ -0,0 +1,89 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+package util
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// DisplayWidth 返回 str 在等宽字体下的显示宽度。
+//
+// 宽度按字素簇计算：东亚宽字符（Unicode East Asian Width 为 W 或者 F）和表情符号占两列，组合字符、零宽字符以及 ZWJ 连接的表情序列不额外占列，
+// 其他字符（包括 East Asian Width 为 A 的字符以及 ASCII 控制字符）占一列。
+func DisplayWidth(str string) (ret int) {
+	clusterWidth := -1 // 当前字素簇宽度，-1 表示还没有字素簇
+	joined := false    // 前一个字符是否是 ZWJ
+	regional := false  // 当前字素簇是否是单个区域指示符
+	for _, r := range str {
+		switch {
+		case 0x200D == r: // ZWJ
+			joined = true
+			continue
+		case joined && 0 <= clusterWidth:
+			joined = false
+			continue
+		case 0xFE0F == r: // VS16 要求表情符号样式显示
+			if 0 <= clusterWidth {
+				clusterWidth = 2
+			}
+			continue
+		case isGraphemeExtend(r):
+			if 0 > clusterWidth {
+				clusterWidth = 0
+			}
+			continue
+		case isRegionalIndicator(r) && regional:
+			regional = false // 两个区域指示符组成一面旗帜
+			continue
+		}
+
+		if 0 < clusterWidth {
+			ret += clusterWidth
+		}
+		joined = false
+		regional = isRegionalIndicator(r)
+		clusterWidth = runeWidth(r)
+	}
+	if 0 < clusterWidth {
+		ret += clusterWidth
+	}
+	return
+}
+
+// BytesDisplayWidth 返回 bytes 在等宽字体下的显示宽度。
+func BytesDisplayWidth(bytes []byte) int {
+	return DisplayWidth(BytesToStr(bytes))
+}
+
+// isGraphemeExtend 判断 r 是否会扩展前一个字素簇，包括组合字符、变体选择符、表情修饰符以及格式控制字符。
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Variation_Selector) ||
+		(0x1F3FB <= r && 0x1F3FF >= r) // 肤色修饰符
+}
+
+func isRegionalIndicator(r rune) bool {
+	return 0x1F1E6 <= r && 0x1F1FF >= r
+}
+
+// runeWidth 返回字素簇首字符 r 的显示宽度。
+func runeWidth(r rune) int {
+	if utf8.RuneSelf > r {
+		return 1 // ASCII 控制字符也按一列计算，和 lex.BytesShowLength 保持一致
+	}
+	if unicode.IsControl(r) {
+		return 0
+	}
+	if isRegionalIndicator(r) || isEastAsianWide(r) {
+		return 2
+	}
+	return 1
+}
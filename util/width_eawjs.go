@@ -0,0 +1,92 @@
+// Lute - 一款结构化的 Markdown 引擎，支持 Go 和 JavaScript
+// Copyright (c) 2019-present, b3log.org
+//
+// Lute is licensed under Mulan PSL v2.
+// You can use this software according to the terms and conditions of the Mulan PSL v2.
+// You may obtain a copy of Mulan PSL v2 at:
+//         http://license.coscl.org.cn/MulanPSL2
+// THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+// See the Mulan PSL v2 for more details.
+
+//go:build javascript
+// +build javascript
+
+package util
+
+// 因为引入 golang.org/x/text/width 后打包体积太大，所以这里仅列出常用的宽字符区间
+
+var eastAsianWideRanges = [][2]rune{
+	{0x1100, 0x115F},   // 谚文字母
+	{0x231A, 0x231B},   // ⌚⌛
+	{0x2329, 0x232A},   // 〈〉
+	{0x23E9, 0x23EC},   // ⏩⏪⏫⏬
+	{0x23F0, 0x23F0},   // ⏰
+	{0x23F3, 0x23F3},   // ⏳
+	{0x25FD, 0x25FE},   // ◽◾
+	{0x2614, 0x2615},   // ☔☕
+	{0x2648, 0x2653},   // 星座
+	{0x267F, 0x267F},   // ♿
+	{0x2693, 0x2693},   // ⚓
+	{0x26A1, 0x26A1},   // ⚡
+	{0x26AA, 0x26AB},   // ⚪⚫
+	{0x26BD, 0x26BE},   // ⚽⚾
+	{0x26C4, 0x26C5},   // ⛄⛅
+	{0x26CE, 0x26CE},   // ⛎
+	{0x26D4, 0x26D4},   // ⛔
+	{0x26EA, 0x26EA},   // ⛪
+	{0x26F2, 0x26F3},   // ⛲⛳
+	{0x26F5, 0x26F5},   // ⛵
+	{0x26FA, 0x26FA},   // ⛺
+	{0x26FD, 0x26FD},   // ⛽
+	{0x2705, 0x2705},   // ✅
+	{0x270A, 0x270B},   // ✊✋
+	{0x2728, 0x2728},   // ✨
+	{0x274C, 0x274C},   // ❌
+	{0x274E, 0x274E},   // ❎
+	{0x2753, 0x2755},   // ❓❔❕
+	{0x2757, 0x2757},   // ❗
+	{0x2795, 0x2797},   // ➕➖➗
+	{0x27B0, 0x27B0},   // ➰
+	{0x27BF, 0x27BF},   // ➿
+	{0x2B1B, 0x2B1C},   // ⬛⬜
+	{0x2B50, 0x2B50},   // ⭐
+	{0x2B55, 0x2B55},   // ⭕
+	{0x2E80, 0x303E},   // CJK 部首、符号和标点
+	{0x3041, 0x33FF},   // 假名、注音、CJK 兼容
+	{0x3400, 0x4DBF},   // CJK 扩展 A
+	{0x4E00, 0x9FFF},   // CJK 统一汉字
+	{0xA000, 0xA4CF},   // 彝文
+	{0xA960, 0xA97F},   // 谚文扩展 A
+	{0xAC00, 0xD7A3},   // 谚文音节
+	{0xF900, 0xFAFF},   // CJK 兼容汉字
+	{0xFE10, 0xFE19},   // 竖排标点
+	{0xFE30, 0xFE6F},   // CJK 兼容形式、小写变体
+	{0xFF00, 0xFF60},   // 全角字符
+	{0xFFE0, 0xFFE6},   // 全角符号
+	{0x16FE0, 0x18CFF}, // 西夏文等
+	{0x1B000, 0x1B2FF}, // 假名补充
+	{0x1F004, 0x1F004}, // 🀄
+	{0x1F0CF, 0x1F0CF}, // 🃏
+	{0x1F18E, 0x1F18E}, // 🆎
+	{0x1F191, 0x1F19A}, // 🆑-🆚
+	{0x1F200, 0x1F251}, // 带圈 CJK
+	{0x1F300, 0x1F64F}, // 表情符号
+	{0x1F680, 0x1F6FF}, // 交通和地图符号
+	{0x1F7E0, 0x1F7EB}, // 彩色圆形和方形
+	{0x1F90C, 0x1F9FF}, // 补充表情符号
+	{0x1FA70, 0x1FAFF}, // 扩展表情符号
+	{0x20000, 0x3FFFD}, // CJK 扩展 B 及以后
+}
+
+// isEastAsianWide 判断 r 的 Unicode East Asian Width 是否为 W 或者 F。
+func isEastAsianWide(r rune) bool {
+	for _, rng := range eastAsianWideRanges {
+		if r < rng[0] {
+			return false
+		}
+		if r <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
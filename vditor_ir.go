@@ -65,6 +65,7 @@ func (lute *Lute) VditorIRDOM2HTML(vhtml string) (sHTML string) {
 // Md2VditorIRDOM 将 markdown 转换为 Vditor Instant-Rendering DOM，用于从源码模式切换至即时渲染模式。
 func (lute *Lute) Md2VditorIRDOM(markdown string) (vHTML string) {
 	tree := parse.Parse("", []byte(markdown), lute.ParseOptions)
+	lute.transform(tree)
 	renderer := render.NewVditorIRRenderer(tree, lute.RenderOptions)
 	for nodeType, rendererFunc := range lute.Md2VditorIRDOMRendererFuncs {
 		renderer.ExtRendererFuncs[nodeType] = rendererFunc
@@ -400,8 +401,14 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 				node.Tokens = codeTokens
 				tree.Context.Tip.AppendChild(node)
 			case "yaml-front-matter":
+				content := &ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: codeTokens}
+				if ast.NodeYamlFrontMatter == tree.Context.Tip.Type {
+					// 内容直接挂在标记符所在的 Front Matter 节点下，以便根据内容推断裸 JSON 的标记符
+					tree.Context.Tip.AppendChild(content)
+					break
+				}
 				node.Type = ast.NodeYamlFrontMatter
-				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: codeTokens})
+				node.AppendChild(content)
 				tree.Context.Tip.AppendChild(node)
 			default:
 				node.Type = ast.NodeCodeBlockCode
@@ -664,12 +671,12 @@ func (lute *Lute) genASTByVditorIRDOM(n *html.Node, tree *parse.Tree) {
 			tree.Context.Tip = node
 			return
 		case "yaml-front-matter-close-marker":
-			tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker, Tokens: parse.YamlFrontMatterMarker})
+			tree.Context.Tip.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker, Tokens: parse.FrontMatterDOMMarker(util.DomText(n))})
 			defer tree.Context.ParentTip()
 			return
 		case "yaml-front-matter-open-marker":
 			node.Type = ast.NodeYamlFrontMatter
-			node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker, Tokens: parse.YamlFrontMatterMarker})
+			node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker, Tokens: parse.FrontMatterDOMMarker(util.DomText(n))})
 			tree.Context.Tip.AppendChild(node)
 			tree.Context.Tip = node
 			return
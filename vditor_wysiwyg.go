@@ -742,9 +742,10 @@ func (lute *Lute) genASTByVditorDOM(n *html.Node, tree *parse.Tree) {
 				tree.Context.Tip.AppendChild(node)
 			case "yaml-front-matter":
 				node.Type = ast.NodeYamlFrontMatter
-				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker})
+				fmMarker := parse.FrontMatterDOMMarker(util.DomAttrValue(n.Parent, "data-marker"))
+				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterOpenMarker, Tokens: fmMarker})
 				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterContent, Tokens: codeTokens})
-				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker})
+				node.AppendChild(&ast.Node{Type: ast.NodeYamlFrontMatterCloseMarker, Tokens: fmMarker})
 				tree.Context.Tip.AppendChild(node)
 			case "html-block":
 				node.Type = ast.NodeHTMLBlock